@@ -0,0 +1,4 @@
+// Package loyalsysv1 contains the gRPC API for internal services.
+package loyalsysv1
+
+//go:generate buf generate ../.. --template ../../buf.gen.yaml --output ../..
@@ -0,0 +1,609 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: loyalsys/v1/loyalsys.proto
+
+package loyalsysv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetBalanceRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Current       string                 `protobuf:"bytes,1,opt,name=current,proto3" json:"current,omitempty"`
+	Withdrawn     string                 `protobuf:"bytes,2,opt,name=withdrawn,proto3" json:"withdrawn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceResponse) Reset() {
+	*x = GetBalanceResponse{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceResponse) ProtoMessage() {}
+
+func (x *GetBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBalanceResponse) GetCurrent() string {
+	if x != nil {
+		return x.Current
+	}
+	return ""
+}
+
+func (x *GetBalanceResponse) GetWithdrawn() string {
+	if x != nil {
+		return x.Withdrawn
+	}
+	return ""
+}
+
+type WithdrawRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Order          string                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	Sum            string                 `protobuf:"bytes,3,opt,name=sum,proto3" json:"sum,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WithdrawRequest) Reset() {
+	*x = WithdrawRequest{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawRequest) ProtoMessage() {}
+
+func (x *WithdrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawRequest.ProtoReflect.Descriptor instead.
+func (*WithdrawRequest) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WithdrawRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WithdrawRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *WithdrawRequest) GetSum() string {
+	if x != nil {
+		return x.Sum
+	}
+	return ""
+}
+
+func (x *WithdrawRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type WithdrawResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawResponse) Reset() {
+	*x = WithdrawResponse{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawResponse) ProtoMessage() {}
+
+func (x *WithdrawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawResponse.ProtoReflect.Descriptor instead.
+func (*WithdrawResponse) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{3}
+}
+
+type GetOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// limit defaults to 50 and may not exceed 100.
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersRequest) Reset() {
+	*x = GetOrdersRequest{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersRequest) ProtoMessage() {}
+
+func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrdersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type Order struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Number string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Status string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// accrual is empty until the order is processed.
+	Accrual       string `protobuf:"bytes,3,opt,name=accrual,proto3" json:"accrual,omitempty"`
+	UploadedAt    string `protobuf:"bytes,4,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Order) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetAccrual() string {
+	if x != nil {
+		return x.Accrual
+	}
+	return ""
+}
+
+func (x *Order) GetUploadedAt() string {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return ""
+}
+
+type GetOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// next_offset is zero on the last page.
+	NextOffset    int32 `protobuf:"varint,2,opt,name=next_offset,json=nextOffset,proto3" json:"next_offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersResponse) Reset() {
+	*x = GetOrdersResponse{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersResponse) ProtoMessage() {}
+
+func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetOrdersResponse) GetNextOffset() int32 {
+	if x != nil {
+		return x.NextOffset
+	}
+	return 0
+}
+
+type RegisterOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterOrderRequest) Reset() {
+	*x = RegisterOrderRequest{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterOrderRequest) ProtoMessage() {}
+
+func (x *RegisterOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterOrderRequest.ProtoReflect.Descriptor instead.
+func (*RegisterOrderRequest) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RegisterOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RegisterOrderRequest) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+type RegisterOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// already_registered is set when the user uploaded the order before.
+	AlreadyRegistered bool `protobuf:"varint,1,opt,name=already_registered,json=alreadyRegistered,proto3" json:"already_registered,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RegisterOrderResponse) Reset() {
+	*x = RegisterOrderResponse{}
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterOrderResponse) ProtoMessage() {}
+
+func (x *RegisterOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_loyalsys_v1_loyalsys_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterOrderResponse.ProtoReflect.Descriptor instead.
+func (*RegisterOrderResponse) Descriptor() ([]byte, []int) {
+	return file_loyalsys_v1_loyalsys_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RegisterOrderResponse) GetAlreadyRegistered() bool {
+	if x != nil {
+		return x.AlreadyRegistered
+	}
+	return false
+}
+
+var File_loyalsys_v1_loyalsys_proto protoreflect.FileDescriptor
+
+const file_loyalsys_v1_loyalsys_proto_rawDesc = "" +
+	"\n" +
+	"\x1aloyalsys/v1/loyalsys.proto\x12\vloyalsys.v1\",\n" +
+	"\x11GetBalanceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"L\n" +
+	"\x12GetBalanceResponse\x12\x18\n" +
+	"\acurrent\x18\x01 \x01(\tR\acurrent\x12\x1c\n" +
+	"\twithdrawn\x18\x02 \x01(\tR\twithdrawn\"{\n" +
+	"\x0fWithdrawRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05order\x18\x02 \x01(\tR\x05order\x12\x10\n" +
+	"\x03sum\x18\x03 \x01(\tR\x03sum\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"\x12\n" +
+	"\x10WithdrawResponse\"Y\n" +
+	"\x10GetOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"r\n" +
+	"\x05Order\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\tR\x06number\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\aaccrual\x18\x03 \x01(\tR\aaccrual\x12\x1f\n" +
+	"\vuploaded_at\x18\x04 \x01(\tR\n" +
+	"uploadedAt\"`\n" +
+	"\x11GetOrdersResponse\x12*\n" +
+	"\x06orders\x18\x01 \x03(\v2\x12.loyalsys.v1.OrderR\x06orders\x12\x1f\n" +
+	"\vnext_offset\x18\x02 \x01(\x05R\n" +
+	"nextOffset\"G\n" +
+	"\x14RegisterOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\"F\n" +
+	"\x15RegisterOrderResponse\x12-\n" +
+	"\x12already_registered\x18\x01 \x01(\bR\x11alreadyRegistered2\xcc\x02\n" +
+	"\x0eLoyaltyService\x12M\n" +
+	"\n" +
+	"GetBalance\x12\x1e.loyalsys.v1.GetBalanceRequest\x1a\x1f.loyalsys.v1.GetBalanceResponse\x12G\n" +
+	"\bWithdraw\x12\x1c.loyalsys.v1.WithdrawRequest\x1a\x1d.loyalsys.v1.WithdrawResponse\x12J\n" +
+	"\tGetOrders\x12\x1d.loyalsys.v1.GetOrdersRequest\x1a\x1e.loyalsys.v1.GetOrdersResponse\x12V\n" +
+	"\rRegisterOrder\x12!.loyalsys.v1.RegisterOrderRequest\x1a\".loyalsys.v1.RegisterOrderResponseB6Z4github.com/rycln/loyalsys/api/loyalsys/v1;loyalsysv1b\x06proto3"
+
+var (
+	file_loyalsys_v1_loyalsys_proto_rawDescOnce sync.Once
+	file_loyalsys_v1_loyalsys_proto_rawDescData []byte
+)
+
+func file_loyalsys_v1_loyalsys_proto_rawDescGZIP() []byte {
+	file_loyalsys_v1_loyalsys_proto_rawDescOnce.Do(func() {
+		file_loyalsys_v1_loyalsys_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_loyalsys_v1_loyalsys_proto_rawDesc), len(file_loyalsys_v1_loyalsys_proto_rawDesc)))
+	})
+	return file_loyalsys_v1_loyalsys_proto_rawDescData
+}
+
+var file_loyalsys_v1_loyalsys_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_loyalsys_v1_loyalsys_proto_goTypes = []any{
+	(*GetBalanceRequest)(nil),     // 0: loyalsys.v1.GetBalanceRequest
+	(*GetBalanceResponse)(nil),    // 1: loyalsys.v1.GetBalanceResponse
+	(*WithdrawRequest)(nil),       // 2: loyalsys.v1.WithdrawRequest
+	(*WithdrawResponse)(nil),      // 3: loyalsys.v1.WithdrawResponse
+	(*GetOrdersRequest)(nil),      // 4: loyalsys.v1.GetOrdersRequest
+	(*Order)(nil),                 // 5: loyalsys.v1.Order
+	(*GetOrdersResponse)(nil),     // 6: loyalsys.v1.GetOrdersResponse
+	(*RegisterOrderRequest)(nil),  // 7: loyalsys.v1.RegisterOrderRequest
+	(*RegisterOrderResponse)(nil), // 8: loyalsys.v1.RegisterOrderResponse
+}
+var file_loyalsys_v1_loyalsys_proto_depIdxs = []int32{
+	5, // 0: loyalsys.v1.GetOrdersResponse.orders:type_name -> loyalsys.v1.Order
+	0, // 1: loyalsys.v1.LoyaltyService.GetBalance:input_type -> loyalsys.v1.GetBalanceRequest
+	2, // 2: loyalsys.v1.LoyaltyService.Withdraw:input_type -> loyalsys.v1.WithdrawRequest
+	4, // 3: loyalsys.v1.LoyaltyService.GetOrders:input_type -> loyalsys.v1.GetOrdersRequest
+	7, // 4: loyalsys.v1.LoyaltyService.RegisterOrder:input_type -> loyalsys.v1.RegisterOrderRequest
+	1, // 5: loyalsys.v1.LoyaltyService.GetBalance:output_type -> loyalsys.v1.GetBalanceResponse
+	3, // 6: loyalsys.v1.LoyaltyService.Withdraw:output_type -> loyalsys.v1.WithdrawResponse
+	6, // 7: loyalsys.v1.LoyaltyService.GetOrders:output_type -> loyalsys.v1.GetOrdersResponse
+	8, // 8: loyalsys.v1.LoyaltyService.RegisterOrder:output_type -> loyalsys.v1.RegisterOrderResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_loyalsys_v1_loyalsys_proto_init() }
+func file_loyalsys_v1_loyalsys_proto_init() {
+	if File_loyalsys_v1_loyalsys_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_loyalsys_v1_loyalsys_proto_rawDesc), len(file_loyalsys_v1_loyalsys_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_loyalsys_v1_loyalsys_proto_goTypes,
+		DependencyIndexes: file_loyalsys_v1_loyalsys_proto_depIdxs,
+		MessageInfos:      file_loyalsys_v1_loyalsys_proto_msgTypes,
+	}.Build()
+	File_loyalsys_v1_loyalsys_proto = out.File
+	file_loyalsys_v1_loyalsys_proto_goTypes = nil
+	file_loyalsys_v1_loyalsys_proto_depIdxs = nil
+}
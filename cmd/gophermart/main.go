@@ -1,13 +1,24 @@
 package main
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/rycln/loyalsys/internal/app"
 )
 
 func main() {
-	app, err := app.New()
+	cfg, err := app.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.PrintConfig {
+		fmt.Print(cfg)
+		return
+	}
+
+	app, err := app.New(cfg)
 	if err != nil {
 		log.Fatal(err)
 	}
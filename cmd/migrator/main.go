@@ -1,39 +1,239 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
 
+	"github.com/caarlos0/env/v11"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
-	"github.com/rycln/loyalsys/internal/db"
+	"github.com/rycln/loyalsys/internal/migrator"
 )
 
+const defaultMigrationsDir = "internal/db/migrations"
+
+const usage = `Usage: migrator [flags] <command> [args]
+
+Commands:
+  up                 apply all pending migrations
+  up-to VERSION      apply pending migrations up to VERSION
+  down               roll back the latest migration
+  down-to VERSION    roll back migrations down to VERSION
+  redo               roll back and reapply the latest migration
+  status             print the status of all migrations
+  version            print the current database version
+  create NAME        create a new SQL migration in -dir
+  validate           check embedded migrations without a database
+
+Flags:
+`
+
+type cfg struct {
+	DatabaseURI string `env:"DATABASE_URI"`
+}
+
 func main() {
-	uri := flag.String("d", "", "Database connection address")
+	var c cfg
+	flag.StringVar(&c.DatabaseURI, "d", "", "Database connection address")
+	dryRun := flag.Bool("dry-run", false, "Print SQL that would be applied without running it")
+	dir := flag.String("dir", defaultMigrationsDir, "Directory for new migrations")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
-	if *uri == "" {
-		log.Fatal("dsn required")
+	if err := env.Parse(&c); err != nil {
+		log.Fatal(err)
 	}
 
-	database, err := sql.Open("pgx", *uri)
-	if err != nil {
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(c, args[0], args[1:], *dryRun, *dir); err != nil {
 		log.Fatal(err)
 	}
+}
+
+func run(c cfg, cmd string, args []string, dryRun bool, dir string) error {
+	switch cmd {
+	case "create":
+		if len(args) != 1 {
+			return errors.New("create requires a migration name")
+		}
+		return migrator.Create(dir, args[0])
+	case "validate":
+		fsys, err := migrator.MigrationsFS()
+		if err != nil {
+			return err
+		}
+		if err := migrator.Validate(fsys); err != nil {
+			return err
+		}
+		log.Print("Migrations are valid")
+		return nil
+	}
+
+	if c.DatabaseURI == "" {
+		return errors.New("dsn required")
+	}
+
+	database, err := sql.Open("pgx", c.DatabaseURI)
+	if err != nil {
+		return err
+	}
 	defer database.Close()
 
-	goose.SetBaseFS(db.MigrationsFS)
+	m, err := migrator.New(database)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
 
-	if err := goose.SetDialect("postgres"); err != nil {
-		log.Fatal(err)
+	ctx := context.Background()
+
+	if dryRun {
+		return plan(ctx, m, cmd, args)
 	}
+	return migrate(ctx, m, cmd, args)
+}
 
-	if err := goose.Up(database, "migrations"); err != nil && !errors.Is(err, goose.ErrNoNextVersion) {
-		log.Fatal(err)
+func migrate(ctx context.Context, m *migrator.Migrator, cmd string, args []string) error {
+	switch cmd {
+	case "up":
+		results, err := m.Up(ctx)
+		return report(results, err)
+	case "up-to":
+		version, err := parseVersion(args)
+		if err != nil {
+			return err
+		}
+		results, err := m.UpTo(ctx, version)
+		return report(results, err)
+	case "down":
+		result, err := m.Down(ctx)
+		if err != nil {
+			return report(nil, err)
+		}
+		return report([]*goose.MigrationResult{result}, nil)
+	case "down-to":
+		version, err := parseVersion(args)
+		if err != nil {
+			return err
+		}
+		results, err := m.DownTo(ctx, version)
+		return report(results, err)
+	case "redo":
+		results, err := m.Redo(ctx)
+		return report(results, err)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatus(statuses)
+		return nil
+	case "version":
+		version, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println(version)
+		return nil
+	}
+	return fmt.Errorf("unknown command %q", cmd)
+}
+
+func plan(ctx context.Context, m *migrator.Migrator, cmd string, args []string) error {
+	var steps []*migrator.Step
+	var err error
+	switch cmd {
+	case "up":
+		steps, err = m.PlanUp(ctx, goose.MaxVersion)
+	case "up-to":
+		var version int64
+		version, err = parseVersion(args)
+		if err != nil {
+			return err
+		}
+		steps, err = m.PlanUp(ctx, version)
+	case "down":
+		steps, err = m.PlanDown(ctx, 0, 1)
+	case "down-to":
+		var version int64
+		version, err = parseVersion(args)
+		if err != nil {
+			return err
+		}
+		steps, err = m.PlanDown(ctx, version, 0)
+	case "redo":
+		steps, err = m.PlanRedo(ctx)
+	default:
+		return fmt.Errorf("dry-run is not supported for %q", cmd)
+	}
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		log.Print("No migrations to apply")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		log.Print("No migrations to apply")
+		return nil
+	}
+	for _, step := range steps {
+		direction := "down"
+		if step.Up {
+			direction = "up"
+		}
+		fmt.Printf("-- %s %s\n%s\n\n", direction, step.Path, step.SQL)
 	}
+	return nil
+}
 
+func report(results []*goose.MigrationResult, err error) error {
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		log.Print("No migrations to apply")
+		return nil
+	}
+	for _, result := range results {
+		log.Print(result)
+	}
+	if err != nil {
+		return err
+	}
 	log.Print("Migrations applied successfully")
+	return nil
+}
+
+func printStatus(statuses []*goose.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Applied At\tMigration")
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Source.Path)
+	}
+	w.Flush()
+}
+
+func parseVersion(args []string) (int64, error) {
+	if len(args) != 1 {
+		return 0, errors.New("version argument required")
+	}
+	return strconv.ParseInt(args[0], 10, 64)
 }
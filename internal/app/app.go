@@ -5,115 +5,606 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/getkin/kin-openapi/routers/gorillamux"
 	"github.com/go-resty/resty/v2"
 	"github.com/gofiber/contrib/fiberzap/v2"
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/timeout"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
 	"github.com/rycln/loyalsys/internal/client"
 	"github.com/rycln/loyalsys/internal/config"
 	"github.com/rycln/loyalsys/internal/handlers"
 	"github.com/rycln/loyalsys/internal/logger"
 	"github.com/rycln/loyalsys/internal/middleware"
+	"github.com/rycln/loyalsys/internal/migrator"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/notifier"
+	"github.com/rycln/loyalsys/internal/openapi"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/rycln/loyalsys/internal/ratelimit"
+	"github.com/rycln/loyalsys/internal/replay"
+	"github.com/rycln/loyalsys/internal/rpc"
 	"github.com/rycln/loyalsys/internal/services"
 	"github.com/rycln/loyalsys/internal/storage"
+	"github.com/rycln/loyalsys/internal/storage/memory"
 	"github.com/rycln/loyalsys/internal/strategies/password"
+	"github.com/rycln/loyalsys/internal/strategies/totp"
+	"github.com/rycln/loyalsys/internal/throttle"
 	"github.com/rycln/loyalsys/internal/worker"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
-const shutdownTimeout = 5 * time.Second
+const (
+	shutdownTimeout  = 5 * time.Second
+	flushTimeout     = 4 * time.Second
+	ipThrottleFactor = 4
+)
 
 type App struct {
 	*fiber.App
-	cfg    *config.Cfg
-	db     *sql.DB
-	worker *worker.OrderSyncWorker
+	cfg        *config.Cfg
+	pool       *pgxpool.Pool
+	migrateDB  *sql.DB
+	dsn        *storage.DSN
+	jwtKey     *services.JWTKey
+	tenants    []*tenantApp
+	grpc       *grpc.Server
+	grpcHealth *health.Server
 }
 
-func New() (*App, error) {
-	cfg, err := config.NewConfigBuilder().
-		WithFlagParsing().
-		WithEnvParsing().
-		WithDefaultJWTKey().
-		Build()
+// tenantApp is what each tenant gets to itself: routes and services bound to
+// its data, an accrual sync worker talking to its accrual system and the
+// gRPC service acting for its users.
+type tenantApp struct {
+	*fiber.App
+	id       models.TenantID
+	hosts    []string
+	worker   *worker.OrderSyncWorker
+	rpc      *rpc.LoyaltyServer
+	password *services.PasswordService
+}
+
+func New(cfg *config.Cfg) (*App, error) {
+	err := logger.LogInit(cfg.LogLevel)
 	if err != nil {
-		return nil, fmt.Errorf("can't initialize the configuration: %v", err)
+		return nil, fmt.Errorf("can't initialize the logger: %v", err)
+	}
+	logger.Log.Debug("Configuration loaded", zap.Stringer("config", cfg))
+
+	dsn := storage.NewDSN(cfg.DatabaseURI)
+	jwtKey := services.NewJWTKey(cfg.Key)
+	var pool *pgxpool.Pool
+	var migrateDB *sql.DB
+	var schema schemaVersioner
+	var newRepos func(models.TenantID) *repositories
+	switch cfg.Storage {
+	case config.StorageMemory:
+		memDB := memory.NewDB()
+		schema = memDB
+		newRepos = func(tenant models.TenantID) *repositories {
+			return newMemoryRepositories(memDB, tenant)
+		}
+	default:
+		pool, migrateDB, schema, err = openPostgres(cfg, dsn)
+		if err != nil {
+			return nil, err
+		}
+		newRepos = func(tenant models.TenantID) *repositories {
+			return newPostgresRepositories(pool, tenant)
+		}
 	}
 
-	err = logger.LogInit(cfg.LogLevel)
+	validateRequest, checkContentType, err := newRequestValidation(cfg.OpenAPIValidation)
 	if err != nil {
-		return nil, fmt.Errorf("can't initialize the logger: %v", err)
+		return nil, fmt.Errorf("can't load the OpenAPI document: %v", err)
+	}
+
+	restyClient := resty.New()
+	var tenants []*tenantApp
+	var routes []middleware.TenantRoute
+	rpcServers := make(map[models.TenantID]loyalsysv1.LoyaltyServiceServer)
+	for _, tenant := range cfg.TenantList() {
+		ta, err := newTenantApp(cfg, tenant, newRepos(models.TenantID(tenant.ID)), pool, jwtKey, restyClient, checkContentType)
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize tenant %q: %v", tenant.ID, err)
+		}
+		tenants = append(tenants, ta)
+		routes = append(routes, middleware.TenantRoute{ID: ta.id, Hosts: ta.hosts, Handler: ta.Handler()})
+		rpcServers[ta.id] = ta.rpc
+	}
+
+	healthHandler := handlers.NewHealthHandler(schema)
+	openAPIHandler := handlers.NewOpenAPIHandler(openapi.Spec)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Use(fiberzap.New(fiberzap.Config{
+		Logger: logger.Log,
+		Fields: []string{"url", "method", "latency", "status", "bytesSent"},
+		Levels: []zapcore.Level{zapcore.InfoLevel},
+	}), validateRequest)
+	app.Get("/api/health", timeout.NewWithContext(healthHandler, cfg.Timeout))
+	app.Get("/openapi.json", openAPIHandler)
+	app.Use(middleware.TenantRouter(cfg.TenantHeader, routes))
+
+	a := &App{
+		App:       app,
+		cfg:       cfg,
+		pool:      pool,
+		migrateDB: migrateDB,
+		dsn:       dsn,
+		jwtKey:    jwtKey,
+		tenants:   tenants,
+	}
+	if cfg.GRPCAddr != "" {
+		a.grpc, a.grpcHealth, err = newGRPCServer(cfg, rpc.NewTenantRouter(rpcServers))
+		if err != nil {
+			return nil, fmt.Errorf("can't initialize the gRPC server: %v", err)
+		}
+	}
+	return a, nil
+}
+
+type schemaVersioner interface {
+	SchemaVersion(context.Context) (int64, error)
+}
+
+type nonceStore interface {
+	Use(context.Context, string, time.Time) (bool, error)
+}
+
+// repositories are the storages of a tenant on the configured backend.
+type repositories struct {
+	users       storage.UserRepository
+	orders      storage.OrderSyncRepository
+	withdrawals storage.WithdrawalRepository
+	balance     storage.BalanceRepository
+	referrals   storage.ReferralRepository
+	transfers   storage.TransferRepository
+	statements  storage.StatementRepository
+	twoFactor   storage.TwoFactorRepository
+}
+
+// newPostgresRepositories builds the storages on the shared pool.
+func newPostgresRepositories(pool *pgxpool.Pool, tenant models.TenantID) *repositories {
+	return &repositories{
+		users:       storage.NewUserStorage(pool, tenant),
+		orders:      storage.NewOrderSyncStorage(storage.NewOrderStorage(pool, tenant)),
+		withdrawals: storage.NewWithdrawalStorage(pool, tenant),
+		balance:     storage.NewBalanceStorage(pool, tenant),
+		referrals:   storage.NewReferralStorage(pool, tenant),
+		transfers:   storage.NewTransferStorage(pool, tenant),
+		statements:  storage.NewStatementStorage(pool, tenant),
+		twoFactor:   storage.NewTwoFactorStorage(pool, tenant),
+	}
+}
+
+func newMemoryRepositories(memDB *memory.DB, tenant models.TenantID) *repositories {
+	return &repositories{
+		users:       memory.NewUserStorage(memDB, tenant),
+		orders:      memory.NewOrderStorage(memDB, tenant),
+		withdrawals: memory.NewWithdrawalStorage(memDB, tenant),
+		balance:     memory.NewBalanceStorage(memDB, tenant),
+		referrals:   memory.NewReferralStorage(memDB, tenant),
+		transfers:   memory.NewTransferStorage(memDB, tenant),
+		statements:  memory.NewStatementStorage(memDB, tenant),
+		twoFactor:   memory.NewTwoFactorStorage(memDB, tenant),
 	}
+}
 
-	database, err := storage.NewDB(cfg.DatabaseURI)
+// openPostgres connects to the database and prepares its schema as the
+// migrate mode says. goose only works on database/sql, so the migrator gets
+// the pool through stdlib; the storages use the pool directly.
+func openPostgres(cfg *config.Cfg, dsn *storage.DSN) (*pgxpool.Pool, *sql.DB, *migrator.Migrator, error) {
+	pool, err := storage.NewPool(context.Background(), dsn, storage.PoolConfig{
+		MaxConns:        int32(cfg.DBMaxConns),
+		MinConns:        int32(cfg.DBMinConns),
+		MaxConnIdleTime: cfg.DBMaxConnIdle,
+		MaxConnLifetime: cfg.DBMaxConnLife,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("can't open database: %v", err)
+		return nil, nil, nil, fmt.Errorf("can't open database: %v", err)
 	}
+	migrateDB := stdlib.OpenDBFromPool(pool)
 
-	userStrg := storage.NewUserStorage(database)
-	orderStrg := storage.NewOrderStorage(database)
-	withdrawalStrg := storage.NewWithdrawalStorage(database)
-	balanceStrg := storage.NewBalanceStorage(database)
+	schema, err := migrator.New(migrateDB)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't initialize migrations: %v", err)
+	}
+	err = prepareSchema(context.Background(), schema, cfg.MigrateMode)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("can't prepare database schema: %v", err)
+	}
+	return pool, migrateDB, schema, nil
+}
 
-	restyClient := resty.New()
-	client := client.NewOrderUpdateClient(restyClient, cfg.AccrualAddr, cfg.Timeout)
-	workerCfg := worker.NewSyncWorkerConfigBuilder().
-		WithTimeout(cfg.Timeout).
-		Build()
-	orderUpdater := worker.NewOrderSyncWorker(client, orderStrg, workerCfg)
+// newTenantApp builds the services and routes of a tenant. Every storage is
+// bound to the tenant, so its queries never reach another tenant's data.
+// The pool is only used by the postgres login throttle and nonce stores.
+func newTenantApp(cfg *config.Cfg, tenant config.Tenant, repos *repositories, pool *pgxpool.Pool, jwtKey *services.JWTKey, restyClient *resty.Client, checkContentType func(...string) fiber.Handler) (*tenantApp, error) {
+	id := models.TenantID(tenant.ID)
+
+	var orderUpdater *worker.OrderSyncWorker
+	var pushSource *worker.PushSource
+	switch tenant.AccrualMode {
+	case config.AccrualBatch:
+		client := client.NewOrderBatchClient(restyClient, tenant.AccrualAddr, cfg.Timeout)
+		orderUpdater = worker.NewOrderBatchSyncWorker(client, repos.orders, newWorkerConfig(cfg))
+	case config.AccrualPush:
+		client := client.NewOrderUpdateClient(restyClient, tenant.AccrualAddr, cfg.Timeout)
+		pushSource = worker.NewPushSource()
+		orderUpdater = worker.NewOrderPushSyncWorker(pushSource, client, repos.orders, newWorkerConfig(cfg))
+	default:
+		client := client.NewOrderUpdateClient(restyClient, tenant.AccrualAddr, cfg.Timeout)
+		orderUpdater = worker.NewOrderSyncWorker(client, repos.orders, newWorkerConfig(cfg))
+	}
 
-	passwordStrategy := password.NewBCryptHasher()
-	userService := services.NewUserService(userStrg, passwordStrategy)
-	orderService := services.NewOrderService(orderStrg)
-	balanceService := services.NewBalanceService(balanceStrg)
-	withdrawalService := services.NewWithdrawalService(withdrawalStrg, balanceService)
-	jwtService := services.NewJWTService(cfg.Key)
+	passwordStrategy := newPasswordHasher(cfg)
+	passwordPolicy := password.NewPolicy(cfg.PasswordMinLen, cfg.PasswordMinBits)
+	if cfg.BreachedList != "" {
+		err := passwordPolicy.LoadBreachedList(cfg.BreachedList)
+		if err != nil {
+			return nil, fmt.Errorf("can't load breached password list: %v", err)
+		}
+	}
+	userService, err := services.NewUserService(repos.users, passwordStrategy, passwordPolicy, services.ReferralBonus{
+		Referrer: cfg.ReferrerBonus,
+		Referee:  cfg.RefereeBonus,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't create user service: %v", err)
+	}
+	referralService := services.NewReferralService(repos.referrals)
+	transferService := services.NewTransferService(repos.transfers, repos.users, cfg.TransferLimit)
+	statementService := services.NewStatementService(repos.statements)
+	orderService := services.NewOrderService(repos.orders, cfg.PendingOrderLimit)
+	balanceService := services.NewBalanceService(repos.balance)
+	withdrawalService := services.NewWithdrawalService(repos.withdrawals)
+	jwtService := services.NewJWTService(jwtKey, tenant.Audience)
+	sessionService := services.NewSessionService(repos.users, jwtService)
+	passwordService := services.NewPasswordService(repos.users, passwordStrategy, passwordPolicy, newResetNotifier(cfg), cfg.ResetTTL, cfg.Timeout)
+	loginThrottleService := newLoginThrottleService(cfg, pool, id)
+	twoFactorService := services.NewTwoFactorService(repos.twoFactor, repos.users, totp.NewGenerator(cfg.TOTPIssuer), cfg.TOTPWithdrawLimit)
 
 	registerHandler := handlers.NewRegisterHandler(userService, jwtService)
-	loginHandler := handlers.NewLoginHandler(userService, jwtService)
+	loginHandler := handlers.NewLoginHandler(userService, jwtService, loginThrottleService, twoFactorService)
+	postLogin2FAHandler := handlers.NewPostLogin2FAHandler(twoFactorService, jwtService, loginThrottleService)
 	postOrderHandler := handlers.NewPostOrderHandler(orderService, jwtService)
+	postOrdersBatchHandler := handlers.NewPostOrdersBatchHandler(orderService, jwtService, cfg.BatchLimit)
 	getOrdersHandler := handlers.NewGetOrdersHandler(orderService, jwtService)
+	getOrderDetailHandler := handlers.NewGetOrderDetailHandler(orderService, jwtService)
 	getBalanceHandler := handlers.NewGetBalanceHandler(balanceService, jwtService)
-	postWithdrawalHandler := handlers.NewPostWithdrawalHandler(withdrawalService, jwtService)
+	postWithdrawalHandler := handlers.NewPostWithdrawalHandler(withdrawalService, jwtService, twoFactorService)
 	getWithdrawalsHandler := handlers.NewGetWithdrawalsHandler(withdrawalService, jwtService)
+	getReferralsHandler := handlers.NewGetReferralsHandler(referralService, jwtService)
+	postTransferHandler := handlers.NewPostTransferHandler(transferService, jwtService, twoFactorService)
+	getTransfersHandler := handlers.NewGetTransfersHandler(transferService, jwtService)
+	getStatementHandler := handlers.NewGetStatementHandler(statementService, jwtService, cfg.Timeout)
+	postPasswordHandler := handlers.NewPostPasswordHandler(passwordService, jwtService, loginThrottleService)
+	postPasswordResetHandler := handlers.NewPostPasswordResetHandler(passwordService)
+	postPasswordResetConfirmHandler := handlers.NewPostPasswordResetConfirmHandler(passwordService)
+	postTOTPHandler := handlers.NewPostTOTPHandler(twoFactorService, jwtService)
+	postTOTPConfirmHandler := handlers.NewPostTOTPConfirmHandler(twoFactorService, jwtService)
+	postOrderV2Handler := handlers.NewPostOrderV2Handler(orderService, jwtService)
+	getOrdersV2Handler := handlers.NewGetOrdersV2Handler(orderService, jwtService)
+	getOrderDetailV2Handler := handlers.NewGetOrderDetailV2Handler(orderService, jwtService)
+	getBalanceV2Handler := handlers.NewGetBalanceV2Handler(balanceService, jwtService)
+	postWithdrawalV2Handler := handlers.NewPostWithdrawalV2Handler(withdrawalService, jwtService, twoFactorService)
+	getWithdrawalsV2Handler := handlers.NewGetWithdrawalsV2Handler(withdrawalService, jwtService)
+	getReferralsV2Handler := handlers.NewGetReferralsV2Handler(referralService, jwtService)
+	postTransferV2Handler := handlers.NewPostTransferV2Handler(transferService, jwtService, twoFactorService)
+	getTransfersV2Handler := handlers.NewGetTransfersV2Handler(transferService, jwtService)
+	getStatementV2Handler := handlers.NewGetStatementV2Handler(statementService, jwtService, cfg.Timeout)
 
-	app := fiber.New()
-	app.Use(fiberzap.New(fiberzap.Config{
-		Logger: logger.Log,
-		Fields: []string{"url", "method", "latency", "status", "bytesSent"},
-		Levels: []zapcore.Level{zapcore.InfoLevel},
-	}))
-	app.Post("/api/user/register", middleware.ContentTypeChecker("application/json"), timeout.NewWithContext(registerHandler, cfg.Timeout))
-	app.Post("/api/user/login", middleware.ContentTypeChecker("application/json"), timeout.NewWithContext(loginHandler, cfg.Timeout))
+	publicLimit := newRateLimit("public", cfg.RateLimitPublic, cfg.RateLimitWindow, middleware.ByIP())
+	userLimit := newRateLimit("user", cfg.RateLimitUser, cfg.RateLimitWindow, middleware.ByUser(jwtService))
+	ordersLimit := newRateLimit("orders", cfg.RateLimitOrders, cfg.RateLimitWindow, middleware.ByUser(jwtService))
+	withdrawLimit := newRateLimit("withdrawals", cfg.RateLimitWithdraw, cfg.RateLimitWindow, middleware.ByUser(jwtService))
+	transferLimit := newRateLimit("transfers", cfg.RateLimitTransfer, cfg.RateLimitWindow, middleware.ByUser(jwtService))
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Use("/api/user", middleware.Deprecation("/api/v2/user"))
+	app.Post("/api/user/register", publicLimit, checkContentType("application/json"), timeout.NewWithContext(registerHandler, cfg.Timeout))
+	app.Post("/api/user/login", publicLimit, checkContentType("application/json"), timeout.NewWithContext(loginHandler, cfg.Timeout))
+	app.Post("/api/user/login/2fa", publicLimit, checkContentType("application/json"), timeout.NewWithContext(postLogin2FAHandler, cfg.Timeout))
+	app.Post("/api/user/password/reset", publicLimit, checkContentType("application/json"), timeout.NewWithContext(postPasswordResetHandler, cfg.Timeout))
+	app.Post("/api/user/password/reset/confirm", publicLimit, checkContentType("application/json"), timeout.NewWithContext(postPasswordResetConfirmHandler, cfg.Timeout))
+	v2 := app.Group("/api/v2/user")
+	v2.Post("/register", publicLimit, checkContentType("application/json"), timeout.NewWithContext(registerHandler, cfg.Timeout))
+	v2.Post("/login", publicLimit, checkContentType("application/json"), timeout.NewWithContext(loginHandler, cfg.Timeout))
+	v2.Post("/login/2fa", publicLimit, checkContentType("application/json"), timeout.NewWithContext(postLogin2FAHandler, cfg.Timeout))
+	v2.Post("/password/reset", publicLimit, checkContentType("application/json"), timeout.NewWithContext(postPasswordResetHandler, cfg.Timeout))
+	v2.Post("/password/reset/confirm", publicLimit, checkContentType("application/json"), timeout.NewWithContext(postPasswordResetConfirmHandler, cfg.Timeout))
+	if pushSource != nil {
+		postAccrualCallbackHandler := handlers.NewPostAccrualCallbackHandler(pushSource)
+		app.Post("/internal/accrual/callback", middleware.ContentTypeChecker("application/json"), middleware.SignatureChecker([]byte(tenant.AccrualKey), cfg.ReplayWindow, newNonceStore(cfg, pool, id)), timeout.NewWithContext(postAccrualCallbackHandler, cfg.Timeout))
+	}
 	app.Use(middleware.NoTokenChecker(), jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte(cfg.Key)},
-	}))
-	app.Post("/api/user/orders", middleware.ContentTypeChecker("text/plain"), timeout.NewWithContext(postOrderHandler, cfg.Timeout))
+		KeyFunc:      jwtService.Keyfunc,
+		ErrorHandler: jwtErrorHandler,
+	}), middleware.SessionChecker(sessionService), userLimit)
+	app.Post("/api/user/password", checkContentType("application/json"), timeout.NewWithContext(postPasswordHandler, cfg.Timeout))
+	app.Post("/api/user/2fa/totp", timeout.NewWithContext(postTOTPHandler, cfg.Timeout))
+	app.Post("/api/user/2fa/totp/confirm", checkContentType("application/json"), timeout.NewWithContext(postTOTPConfirmHandler, cfg.Timeout))
+	app.Post("/api/user/orders", ordersLimit, checkContentType("text/plain"), timeout.NewWithContext(postOrderHandler, cfg.Timeout))
+	app.Post("/api/user/orders/batch", ordersLimit, checkContentType("application/json", "text/plain"), timeout.NewWithContext(postOrdersBatchHandler, cfg.Timeout))
 	app.Get("/api/user/orders", timeout.NewWithContext(getOrdersHandler, cfg.Timeout))
+	app.Get("/api/user/orders/:number", timeout.NewWithContext(getOrderDetailHandler, cfg.Timeout))
 	app.Get("/api/user/balance", timeout.NewWithContext(getBalanceHandler, cfg.Timeout))
-	app.Post("/api/user/balance/withdraw", timeout.NewWithContext(postWithdrawalHandler, cfg.Timeout))
+	app.Post("/api/user/balance/withdraw", withdrawLimit, timeout.NewWithContext(postWithdrawalHandler, cfg.Timeout))
 	app.Get("/api/user/withdrawals", timeout.NewWithContext(getWithdrawalsHandler, cfg.Timeout))
+	app.Get("/api/user/referrals", timeout.NewWithContext(getReferralsHandler, cfg.Timeout))
+	app.Post("/api/user/balance/transfer", transferLimit, checkContentType("application/json"), timeout.NewWithContext(postTransferHandler, cfg.Timeout))
+	app.Get("/api/user/transfers", timeout.NewWithContext(getTransfersHandler, cfg.Timeout))
+	app.Get("/api/user/statement", timeout.NewWithContext(getStatementHandler, cfg.Timeout))
+	v2.Post("/password", checkContentType("application/json"), timeout.NewWithContext(postPasswordHandler, cfg.Timeout))
+	v2.Post("/2fa/totp", timeout.NewWithContext(postTOTPHandler, cfg.Timeout))
+	v2.Post("/2fa/totp/confirm", checkContentType("application/json"), timeout.NewWithContext(postTOTPConfirmHandler, cfg.Timeout))
+	v2.Post("/orders", ordersLimit, checkContentType("application/json"), timeout.NewWithContext(postOrderV2Handler, cfg.Timeout))
+	v2.Post("/orders/batch", ordersLimit, checkContentType("application/json"), timeout.NewWithContext(postOrdersBatchHandler, cfg.Timeout))
+	v2.Get("/orders", timeout.NewWithContext(getOrdersV2Handler, cfg.Timeout))
+	v2.Get("/orders/:number", timeout.NewWithContext(getOrderDetailV2Handler, cfg.Timeout))
+	v2.Get("/balance", timeout.NewWithContext(getBalanceV2Handler, cfg.Timeout))
+	v2.Post("/balance/withdraw", withdrawLimit, checkContentType("application/json"), timeout.NewWithContext(postWithdrawalV2Handler, cfg.Timeout))
+	v2.Get("/withdrawals", timeout.NewWithContext(getWithdrawalsV2Handler, cfg.Timeout))
+	v2.Get("/referrals", timeout.NewWithContext(getReferralsV2Handler, cfg.Timeout))
+	v2.Post("/balance/transfer", transferLimit, checkContentType("application/json"), timeout.NewWithContext(postTransferV2Handler, cfg.Timeout))
+	v2.Get("/transfers", timeout.NewWithContext(getTransfersV2Handler, cfg.Timeout))
+	v2.Get("/statement", timeout.NewWithContext(getStatementV2Handler, cfg.Timeout))
 
-	return &App{
-		App:    app,
-		cfg:    cfg,
-		db:     database,
-		worker: orderUpdater,
+	return &tenantApp{
+		App:      app,
+		id:       id,
+		hosts:    tenant.Hosts,
+		worker:   orderUpdater,
+		rpc:      rpc.NewLoyaltyServer(balanceService, withdrawalService, orderService),
+		password: passwordService,
 	}, nil
 }
 
+// GetRoutes lists the shared routes followed by the routes every tenant
+// serves.
+func (app *App) GetRoutes(filterUseOption ...bool) []fiber.Route {
+	return append(app.App.GetRoutes(filterUseOption...), app.tenants[0].GetRoutes(filterUseOption...)...)
+}
+
+func LoadConfig() (*config.Cfg, error) {
+	cfg, err := newConfigBuilder().
+		WithDefaultJWTKey().
+		Validate().
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize the configuration: %v", err)
+	}
+	return cfg, nil
+}
+
+func newConfigBuilder() *config.ConfigBuilder {
+	return config.NewConfigBuilder().
+		WithFileParsing(config.FilePath()).
+		WithEnvParsing().
+		WithFlagParsing()
+}
+
+func jwtErrorHandler(c *fiber.Ctx, err error) error {
+	logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	if err.Error() == jwtware.ErrJWTMissingOrMalformed.Error() {
+		return problem.ErrorHandler(c, problem.New(fiber.StatusBadRequest, problem.CodeMalformedToken))
+	}
+	return problem.ErrorHandler(c, problem.New(fiber.StatusUnauthorized, problem.CodeInvalidToken))
+}
+
+// newRateLimit builds a per-route rate limit policy. A zero limit leaves the
+// route unlimited.
+func newRateLimit(name string, limit int, window time.Duration, key middleware.RateLimitKey) fiber.Handler {
+	if limit <= 0 {
+		return passThrough
+	}
+	return middleware.RateLimiter(name, ratelimit.NewLimiter(limit, window), key)
+}
+
+// newRequestValidation picks how request bodies are checked. The OpenAPI
+// validator covers every documented route and supersedes the per-route
+// content type checks.
+func newRequestValidation(enabled bool) (fiber.Handler, func(...string) fiber.Handler, error) {
+	if !enabled {
+		return passThrough, middleware.ContentTypeChecker, nil
+	}
+	doc, err := openapi.Load(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return middleware.RequestValidator(router), func(...string) fiber.Handler {
+		return passThrough
+	}, nil
+}
+
+func passThrough(c *fiber.Ctx) error {
+	return c.Next()
+}
+
+// newGRPCServer builds the API for internal services. Callers authenticate
+// with a client certificate, a service token or both, as configured.
+func newGRPCServer(cfg *config.Cfg, srv loyalsysv1.LoyaltyServiceServer) (*grpc.Server, *health.Server, error) {
+	var opts []grpc.ServerOption
+	if cfg.GRPCTLSCert != "" {
+		creds, err := rpc.ServerCredentials(cfg.GRPCTLSCert, cfg.GRPCTLSKey, cfg.GRPCClientCA)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	if cfg.GRPCToken != "" {
+		auth := rpc.NewTokenAuth(cfg.GRPCToken)
+		opts = append(opts, grpc.ChainUnaryInterceptor(auth.Unary()), grpc.ChainStreamInterceptor(auth.Stream()))
+	}
+	s, healthSrv := rpc.NewServer(srv, opts...)
+	return s, healthSrv, nil
+}
+
+// newLoginThrottleService tracks failed logins per login and per client IP.
+// The IP limit is a multiple of the login one, since clients behind a NAT
+// share the address. Counters are kept apart per tenant, as the same login
+// may belong to different users of different tenants.
+func newLoginThrottleService(cfg *config.Cfg, pool *pgxpool.Pool, tenant models.TenantID) *services.LoginThrottleService {
+	loginCfg := throttle.Config{
+		Name:     string(tenant) + ":login",
+		Attempts: cfg.ThrottleAttempts,
+		Lockout:  cfg.ThrottleLockout,
+	}
+	ipCfg := throttle.Config{
+		Name:     string(tenant) + ":ip",
+		Attempts: cfg.ThrottleAttempts * ipThrottleFactor,
+		Lockout:  cfg.ThrottleLockout,
+	}
+
+	var byLogin, byIP *throttle.Throttler
+	switch cfg.ThrottleStore {
+	case config.ThrottlePostgres:
+		throttleStrg := storage.NewThrottleStorage(pool)
+		byLogin = throttle.NewThrottler(throttleStrg, loginCfg)
+		byIP = throttle.NewThrottler(throttleStrg, ipCfg)
+	default:
+		byLogin = throttle.NewThrottler(throttle.NewMemoryStore(), loginCfg)
+		byIP = throttle.NewThrottler(throttle.NewMemoryStore(), ipCfg)
+	}
+
+	return services.NewLoginThrottleService(byLogin, byIP)
+}
+
+// newNonceStore picks where the accrual callback remembers used nonces. The
+// memory cache only protects a single replica: a request replayed to another
+// one is accepted. Deployments with several replicas share the nonces
+// through the postgres store.
+func newNonceStore(cfg *config.Cfg, pool *pgxpool.Pool, tenant models.TenantID) nonceStore {
+	if cfg.NonceStore == config.NoncePostgres {
+		return storage.NewNonceStorage(pool, tenant)
+	}
+	return replay.NewCache()
+}
+
+// newPasswordHasher hashes new passwords with the configured algorithm and
+// keeps the other one for verifying hashes created before a switch.
+func newPasswordHasher(cfg *config.Cfg) *password.MultiHasher {
+	bcryptHasher := password.NewBCryptHasher(cfg.BCryptCost)
+	argon2idHasher := password.NewArgon2idHasher(password.Argon2Params{
+		Memory:      uint32(cfg.Argon2Memory),
+		Iterations:  uint32(cfg.Argon2Time),
+		Parallelism: uint8(cfg.Argon2Threads),
+	})
+	if cfg.Hasher == config.HasherBCrypt {
+		return password.NewMultiHasher(bcryptHasher, argon2idHasher)
+	}
+	return password.NewMultiHasher(argon2idHasher, bcryptHasher)
+}
+
+func newResetNotifier(cfg *config.Cfg) notifier.Notifier {
+	if cfg.ResetNotifier == config.NotifierFile {
+		return notifier.NewFileNotifier(cfg.ResetNotifyFile)
+	}
+	return notifier.NewLogNotifier()
+}
+
+func newWorkerConfig(cfg *config.Cfg) *worker.SyncWorkerConfig {
+	return worker.NewSyncWorkerConfigBuilder().
+		WithTimeout(cfg.Timeout).
+		WithTickerPeriod(cfg.WorkerPeriod).
+		WithFanOutPool(cfg.WorkerPool).
+		WithGracePeriod(cfg.GracePeriod).
+		WithFlushTimeout(flushTimeout).
+		Build()
+}
+
+// reload applies the runtime subset of the configuration: log level,
+// worker ticker period, worker fan-out pool (the number of concurrent
+// accrual requests), worker timeout, the database DSN, which is used for
+// new connections only, and the JWT key. A new key signs out every user. A
+// deployment without a configured key keeps the random one it started with
+// rather than getting a new one on every reload. Other settings need a
+// restart.
+func (app *App) reload() error {
+	cfg, err := newConfigBuilder().
+		Validate().
+		Build()
+	if err != nil {
+		return fmt.Errorf("can't initialize the configuration: %v", err)
+	}
+
+	err = logger.SetLevel(cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	for _, tenant := range app.tenants {
+		tenant.worker.Reload(newWorkerConfig(cfg))
+	}
+	app.dsn.Set(cfg.DatabaseURI)
+	if cfg.Key != "" {
+		app.jwtKey.Set(cfg.Key)
+	}
+
+	logger.Log.Info("Configuration reloaded",
+		zap.String("log_level", cfg.LogLevel),
+		zap.Duration("worker_period", cfg.WorkerPeriod),
+		zap.Int("worker_pool", cfg.WorkerPool),
+	)
+
+	return nil
+}
+
+func prepareSchema(ctx context.Context, schema *migrator.Migrator, mode string) error {
+	switch mode {
+	case config.MigrateAuto:
+		results, err := schema.Up(ctx)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			logger.Log.Info("Migration applied", zap.String("source", result.Source.Path), zap.Duration("duration", result.Duration))
+		}
+	case config.MigrateCheck:
+		_, err := schema.Check(ctx)
+		if err != nil {
+			return err
+		}
+	case config.MigrateOff:
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate mode %q", mode)
+	}
+
+	version, err := schema.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	logger.Log.Info("Database schema version", zap.Int64("version", version))
+
+	return nil
+}
+
 func (app *App) Run() error {
 	workerCtx, workerCancel := context.WithCancel(context.Background())
 	defer workerCancel()
 
-	doneCh := app.worker.Run(workerCtx)
+	var doneChs []<-chan struct{}
+	for _, tenant := range app.tenants {
+		doneChs = append(doneChs, tenant.worker.Run(workerCtx))
+	}
 
 	go func() {
 		err := app.Listen(app.cfg.RunAddr)
@@ -122,17 +613,44 @@ func (app *App) Run() error {
 		}
 	}()
 
+	if app.grpc != nil {
+		lis, err := net.Listen("tcp", app.cfg.GRPCAddr)
+		if err != nil {
+			return fmt.Errorf("can't listen on the gRPC address: %v", err)
+		}
+		go func() {
+			err := app.grpc.Serve(lis)
+			if err != nil {
+				log.Fatalf("gRPC server error: %v", err)
+			}
+		}()
+	}
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
-	<-shutdown
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer signal.Stop(reload)
+
+	for running := true; running; {
+		select {
+		case <-reload:
+			err := app.reload()
+			if err != nil {
+				logger.Log.Error("Configuration reload failed", zap.Error(err))
+			}
+		case <-shutdown:
+			running = false
+		}
+	}
 
 	workerCancel()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
 
-	err := app.shutdown(shutdownCtx, doneCh)
+	err := app.shutdown(shutdownCtx, doneChs)
 	if err != nil {
 		return fmt.Errorf("shutdown error: %v", err)
 	}
@@ -147,24 +665,57 @@ func (app *App) Run() error {
 	return nil
 }
 
-func (app *App) shutdown(ctx context.Context, doneCh <-chan struct{}) error {
-	select {
-	case <-ctx.Done():
-		return fmt.Errorf("worker shutdown timeout: %w", ctx.Err())
-	case <-doneCh:
+func (app *App) shutdown(ctx context.Context, doneChs []<-chan struct{}) error {
+	for _, doneCh := range doneChs {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("worker shutdown timeout: %w", ctx.Err())
+		case <-doneCh:
+		}
+	}
+
+	if app.grpc != nil {
+		app.stopGRPC(ctx)
 	}
 
 	if err := app.App.Shutdown(); err != nil {
 		return err
 	}
+
+	for _, tenant := range app.tenants {
+		err := tenant.password.Wait(ctx)
+		if err != nil {
+			return fmt.Errorf("password reset shutdown timeout: %w", err)
+		}
+	}
 	return nil
 }
 
+// stopGRPC lets in-flight calls finish and cuts them off when ctx expires.
+func (app *App) stopGRPC(ctx context.Context) {
+	app.grpcHealth.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		app.grpc.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-ctx.Done():
+		app.grpc.Stop()
+	case <-stopped:
+	}
+}
+
 func (app *App) cleanup() error {
 	defer logger.Log.Sync()
 
-	if err := app.db.Close(); err != nil {
-		return err
+	if app.migrateDB != nil {
+		if err := app.migrateDB.Close(); err != nil {
+			return err
+		}
+	}
+	if app.pool != nil {
+		app.pool.Close()
 	}
 
 	return nil
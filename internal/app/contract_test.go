@@ -0,0 +1,276 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/config"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/openapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDatabaseURIEnv = "TEST_DATABASE_URI"
+
+var (
+	pathParamRe  = regexp.MustCompile(`\{(\w+)\}`)
+	routeParamRe = regexp.MustCompile(`:(\w+)`)
+)
+
+type contractClient struct {
+	t      *testing.T
+	app    *App
+	router routers.Router
+	token  string
+}
+
+func newContractClient(t *testing.T, cfg *config.Cfg) *contractClient {
+	a, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		a.cleanup()
+	})
+
+	doc, err := openapi.Load(context.Background())
+	require.NoError(t, err)
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	return &contractClient{
+		t:      t,
+		app:    a,
+		router: router,
+	}
+}
+
+func newContractConfig(t *testing.T, databaseURI string) *config.Cfg {
+	cfg, err := config.NewConfigBuilder().
+		WithDefaultJWTKey().
+		Build()
+	require.NoError(t, err)
+	cfg.DatabaseURI = databaseURI
+	cfg.LogLevel = "error"
+	return cfg
+}
+
+// do sends a request to the app and checks the response status, headers and
+// body against the operation in the OpenAPI document.
+func (cc *contractClient) do(method, path, contentType, body string, wantStatus int) *http.Response {
+	cc.t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if cc.token != "" {
+		req.Header.Set("Authorization", cc.token)
+	}
+	route, pathParams, err := cc.router.FindRoute(req)
+	require.NoError(cc.t, err, "%s %s is not documented", method, path)
+
+	res, err := cc.app.Test(req, -1)
+	require.NoError(cc.t, err)
+	resBody, err := io.ReadAll(res.Body)
+	require.NoError(cc.t, err)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+	require.Equal(cc.t, wantStatus, res.StatusCode, "%s %s: %s", method, path, resBody)
+
+	err = openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: &openapi3filter.RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: res.StatusCode,
+		Header: res.Header,
+		Body:   io.NopCloser(bytes.NewReader(resBody)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	})
+	assert.NoError(cc.t, err, "%s %s", method, path)
+	return res
+}
+
+func TestContract_routes(t *testing.T) {
+	cc := newContractClient(t, newContractConfig(t, "postgres://localhost:5432/loyalsys"))
+
+	registered := make(map[string]bool)
+	for _, r := range cc.app.GetRoutes() {
+		registered[r.Method+" "+r.Path] = true
+	}
+
+	doc, err := openapi.Load(context.Background())
+	require.NoError(t, err)
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			route := method + " " + pathParamRe.ReplaceAllString(path, ":$1")
+			assert.True(t, registered[route], "%s is documented but not registered", route)
+		}
+	}
+	for _, r := range cc.app.GetRoutes(true) {
+		if r.Method == fiber.MethodHead || !strings.HasPrefix(r.Path, "/api/user/") && !strings.HasPrefix(r.Path, "/api/v2/user/") {
+			continue
+		}
+		item := doc.Paths.Find(routeParamRe.ReplaceAllString(r.Path, "{$1}"))
+		assert.True(t, item != nil && item.GetOperation(r.Method) != nil, "%s %s is registered but not documented", r.Method, r.Path)
+	}
+
+	t.Run("spec is served", func(t *testing.T) {
+		res, err := cc.app.Test(httptest.NewRequest(fiber.MethodGet, "/openapi.json", nil), -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, openapi.Spec, body)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		cc.do(fiber.MethodGet, "/api/user/balance", "", "", fiber.StatusUnauthorized)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		cc.token = "Basic abc"
+		defer func() {
+			cc.token = ""
+		}()
+		cc.do(fiber.MethodGet, "/api/user/balance", "", "", fiber.StatusBadRequest)
+	})
+
+	t.Run("wrong content type", func(t *testing.T) {
+		cc.do(fiber.MethodPost, "/api/user/login", "text/plain", "login", fiber.StatusBadRequest)
+	})
+
+	t.Run("v1 is deprecated", func(t *testing.T) {
+		res := cc.do(fiber.MethodGet, "/api/user/balance", "", "", fiber.StatusUnauthorized)
+		assert.Equal(t, "true", res.Header.Get("Deprecation"))
+		assert.Equal(t, `</api/v2/user>; rel="successor-version"`, res.Header.Get("Link"))
+	})
+
+	t.Run("v2 routes", func(t *testing.T) {
+		res := cc.do(fiber.MethodGet, "/api/v2/user/balance", "", "", fiber.StatusUnauthorized)
+		assert.Empty(t, res.Header.Get("Deprecation"))
+
+		cc.do(fiber.MethodPost, "/api/v2/user/login", "text/plain", "login", fiber.StatusBadRequest)
+		cc.do(fiber.MethodPost, "/api/v2/user/orders", "text/plain", "12345678903", fiber.StatusUnauthorized)
+	})
+}
+
+func TestContract_validation(t *testing.T) {
+	cfg := newContractConfig(t, "postgres://localhost:5432/loyalsys")
+	cfg.OpenAPIValidation = true
+	cc := newContractClient(t, cfg)
+
+	t.Run("invalid body", func(t *testing.T) {
+		cc.do(fiber.MethodPost, "/api/user/register", "application/json", `{"login":""}`, fiber.StatusBadRequest)
+	})
+
+	t.Run("wrong content type", func(t *testing.T) {
+		cc.do(fiber.MethodPost, "/api/user/register", "text/plain", "login", fiber.StatusBadRequest)
+	})
+
+	t.Run("v2 invalid body", func(t *testing.T) {
+		cc.do(fiber.MethodPost, "/api/v2/user/orders", "application/json", `{"number":""}`, fiber.StatusBadRequest)
+	})
+
+	t.Run("v2 wrong content type", func(t *testing.T) {
+		cc.do(fiber.MethodPost, "/api/v2/user/orders", "text/plain", "12345678903", fiber.StatusBadRequest)
+	})
+}
+
+// TestContract_scenario walks through every operation against a real
+// database. It runs only when TEST_DATABASE_URI is set.
+func TestContract_scenario(t *testing.T) {
+	databaseURI := os.Getenv(testDatabaseURIEnv)
+	if databaseURI == "" {
+		t.Skipf("%s is not set", testDatabaseURIEnv)
+	}
+	cfg := newContractConfig(t, databaseURI)
+	cfg.MigrateMode = config.MigrateAuto
+	testContractScenario(t, cfg)
+}
+
+// TestContract_scenarioMemory walks through every operation against the
+// in-memory storage.
+func TestContract_scenarioMemory(t *testing.T) {
+	cfg := newContractConfig(t, "")
+	cfg.Storage = config.StorageMemory
+	testContractScenario(t, cfg)
+}
+
+func testContractScenario(t *testing.T, cfg *config.Cfg) {
+	cc := newContractClient(t, cfg)
+
+	suffix := time.Now().UnixNano()
+	user := fmt.Sprintf(`{"login":"contract-%d","password":"correct-horse-battery-7"}`, suffix)
+	wrongPassword := strings.Replace(user, "correct", "wrong", 1)
+
+	res := cc.do(fiber.MethodPost, "/api/user/register", "application/json", user, fiber.StatusOK)
+	cc.token = res.Header.Get("Authorization")
+	require.NotEmpty(t, cc.token)
+
+	cc.do(fiber.MethodPost, "/api/user/register", "application/json", user, fiber.StatusConflict)
+	cc.do(fiber.MethodPost, "/api/user/register", "application/json", "{", fiber.StatusBadRequest)
+	cc.do(fiber.MethodPost, "/api/user/login", "application/json", wrongPassword, fiber.StatusUnauthorized)
+	cc.do(fiber.MethodPost, "/api/user/login", "application/json", user, fiber.StatusOK)
+	cc.do(fiber.MethodPost, "/api/user/login/2fa", "application/json", `{"challenge_token":"abc","code":"123456"}`, fiber.StatusUnauthorized)
+
+	cc.do(fiber.MethodPost, "/api/user/orders", "text/plain", "12345678903", fiber.StatusAccepted)
+	cc.do(fiber.MethodPost, "/api/user/orders", "text/plain", "12345678903", fiber.StatusOK)
+	cc.do(fiber.MethodPost, "/api/user/orders", "text/plain", "12345", fiber.StatusUnprocessableEntity)
+	cc.do(fiber.MethodPost, "/api/user/orders/batch", "application/json", `["79927398713","12345"]`, fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/user/orders", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/user/orders/12345678903", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/user/orders/2377225624", "", "", fiber.StatusNotFound)
+
+	cc.do(fiber.MethodGet, "/api/user/balance", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodPost, "/api/user/balance/withdraw", "application/json", `{"order":"2377225624","sum":10}`, fiber.StatusPaymentRequired)
+	cc.do(fiber.MethodGet, "/api/user/withdrawals", "", "", fiber.StatusNoContent)
+	cc.do(fiber.MethodPost, "/api/user/balance/transfer", "application/json", fmt.Sprintf(`{"login":"nobody-%d","sum":1,"idempotency_key":"k"}`, suffix), fiber.StatusNotFound)
+	cc.do(fiber.MethodGet, "/api/user/transfers", "", "", fiber.StatusNoContent)
+	cc.do(fiber.MethodGet, "/api/user/referrals", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/user/statement", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/user/statement?format=csv", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/user/statement?format=xml", "", "", fiber.StatusBadRequest)
+
+	cc.do(fiber.MethodPost, "/api/v2/user/orders", "application/json", `{"number":"12345678903"}`, fiber.StatusOK)
+	cc.do(fiber.MethodPost, "/api/v2/user/orders", "application/json", `{"number":"4539578763621486"}`, fiber.StatusAccepted)
+	cc.do(fiber.MethodPost, "/api/v2/user/orders/batch", "application/json", `["79927398713"]`, fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/v2/user/orders?limit=2", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/v2/user/orders/12345678903", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/v2/user/balance", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodPost, "/api/v2/user/balance/withdraw", "application/json", `{"order":"2377225624","sum":"10.00"}`, fiber.StatusPaymentRequired)
+	cc.do(fiber.MethodGet, "/api/v2/user/withdrawals", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodPost, "/api/v2/user/balance/transfer", "application/json", fmt.Sprintf(`{"login":"nobody-%d","sum":"1.00","idempotency_key":"k2"}`, suffix), fiber.StatusNotFound)
+	cc.do(fiber.MethodGet, "/api/v2/user/transfers", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/v2/user/referrals", "", "", fiber.StatusOK)
+	cc.do(fiber.MethodGet, "/api/v2/user/statement", "", "", fiber.StatusOK)
+
+	res = cc.do(fiber.MethodPost, "/api/user/2fa/totp", "", "", fiber.StatusOK)
+	var enrollment models.TOTPEnrollment
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&enrollment))
+	assert.NotEmpty(t, enrollment.Secret)
+	cc.do(fiber.MethodPost, "/api/user/2fa/totp/confirm", "application/json", `{"code":"abc"}`, fiber.StatusUnprocessableEntity)
+
+	cc.do(fiber.MethodPost, "/api/user/password/reset", "application/json", `{"login":"nobody"}`, fiber.StatusAccepted)
+	cc.do(fiber.MethodPost, "/api/user/password/reset/confirm", "application/json", `{"token":"abc","new_password":"another-horse-battery-8"}`, fiber.StatusUnauthorized)
+	cc.do(fiber.MethodPost, "/api/user/password", "application/json", `{"old_password":"correct-horse-battery-7","new_password":"another-horse-battery-8"}`, fiber.StatusOK)
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_reloadJWTKey(t *testing.T) {
+	oldArgs := os.Args
+	defer func() {
+		os.Args = oldArgs
+	}()
+	os.Args = []string{"gophermart"}
+
+	cfg := newContractConfig(t, "")
+	cfg.Storage = config.StorageMemory
+	a := newMultiTenantApp(t, cfg)
+
+	user := `{"login":"reload","password":"correct-horse-battery-7"}`
+	res, _ := doTenant(t, a, "", fiber.MethodPost, "/api/user/register", "application/json", user, "")
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+	oldToken := res.Header.Get("Authorization")
+	res, _ = doTenant(t, a, "", fiber.MethodGet, "/api/v2/user/balance", "", "", oldToken)
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+
+	keyFile := filepath.Join(t.TempDir(), "jwt_key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("rotated_key"), 0o600))
+	t.Setenv("STORAGE", config.StorageMemory)
+	t.Setenv("ACCRUAL_SYSTEM_ADDRESS", "http://localhost:8081")
+	t.Setenv("JWT_KEY_FILE", keyFile)
+	require.NoError(t, a.reload())
+
+	t.Run("old key", func(t *testing.T) {
+		res, _ := doTenant(t, a, "", fiber.MethodGet, "/api/v2/user/balance", "", "", oldToken)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("new key", func(t *testing.T) {
+		res, _ := doTenant(t, a, "", fiber.MethodPost, "/api/user/login", "application/json", user, "")
+		require.Equal(t, fiber.StatusOK, res.StatusCode)
+
+		res, _ = doTenant(t, a, "", fiber.MethodGet, "/api/v2/user/balance", "", "", res.Header.Get("Authorization"))
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+}
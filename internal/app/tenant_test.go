@@ -0,0 +1,196 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ShiraazMoollatjie/goluhn"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/config"
+	"github.com/rycln/loyalsys/internal/middleware"
+	"github.com/rycln/loyalsys/internal/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBrandHost      = "brand.example.com"
+	testOtherBrandHost = "other.example.com"
+)
+
+func newTenantConfig(t *testing.T, databaseURI string) *config.Cfg {
+	cfg := newContractConfig(t, databaseURI)
+	cfg.Tenants = []config.Tenant{
+		{ID: "brand", Hosts: []string{testBrandHost}, AccrualAddr: "http://localhost:8081", Audience: "brand"},
+		{ID: "other_brand", Hosts: []string{testOtherBrandHost}, AccrualAddr: "http://localhost:8082", Audience: "other_brand"},
+	}
+	return cfg
+}
+
+func newMultiTenantApp(t *testing.T, cfg *config.Cfg) *App {
+	a, err := New(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		a.cleanup()
+	})
+	return a
+}
+
+// doTenant sends a request to the app on behalf of the tenant served on host
+// and returns the response status and body.
+func doTenant(t *testing.T, a *App, host, method, path, contentType, body, token string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = host
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	res, err := a.Test(req, -1)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res, string(resBody)
+}
+
+func TestTenants_routing(t *testing.T) {
+	cfg := newTenantConfig(t, "postgres://localhost:5432/loyalsys")
+	a := newMultiTenantApp(t, cfg)
+
+	t.Run("unknown host", func(t *testing.T) {
+		res, _ := doTenant(t, a, "unknown.example.com", fiber.MethodGet, "/api/user/balance", "", "", "")
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("unknown tenant header", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/api/user/balance", nil)
+		req.Header.Set(cfg.TenantHeader, "nobody")
+		res, err := a.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("shared routes", func(t *testing.T) {
+		res, _ := doTenant(t, a, "unknown.example.com", fiber.MethodGet, "/openapi.json", "", "", "")
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("tenant routes", func(t *testing.T) {
+		res, _ := doTenant(t, a, testBrandHost, fiber.MethodGet, "/api/user/balance", "", "", "")
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+		assert.Equal(t, "true", res.Header.Get("Deprecation"))
+	})
+
+	t.Run("other tenant token", func(t *testing.T) {
+		token, err := services.NewJWTService(services.NewJWTKey(cfg.Key), "brand").NewJWTString(1)
+		require.NoError(t, err)
+
+		res, _ := doTenant(t, a, testOtherBrandHost, fiber.MethodGet, "/api/v2/user/balance", "", "", "Bearer "+token)
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+}
+
+// TestTenants_isolation checks that tenants sharing a database don't see
+// each other's data. It runs only when TEST_DATABASE_URI is set.
+func TestTenants_isolation(t *testing.T) {
+	databaseURI := os.Getenv(testDatabaseURIEnv)
+	if databaseURI == "" {
+		t.Skipf("%s is not set", testDatabaseURIEnv)
+	}
+	cfg := newTenantConfig(t, databaseURI)
+	cfg.MigrateMode = config.MigrateAuto
+	testTenantsIsolation(t, cfg)
+}
+
+// TestTenants_isolationMemory checks the same for tenants sharing the
+// in-memory storage.
+func TestTenants_isolationMemory(t *testing.T) {
+	cfg := newTenantConfig(t, "")
+	cfg.Storage = config.StorageMemory
+	testTenantsIsolation(t, cfg)
+}
+
+func testTenantsIsolation(t *testing.T, cfg *config.Cfg) {
+	a := newMultiTenantApp(t, cfg)
+
+	suffix := time.Now().UnixNano()
+	user := fmt.Sprintf(`{"login":"tenant-%d","password":"correct-horse-battery-7"}`, suffix)
+	_, order, err := goluhn.Calculate(strconv.FormatInt(suffix, 10))
+	require.NoError(t, err)
+
+	res, _ := doTenant(t, a, testBrandHost, fiber.MethodPost, "/api/user/register", "application/json", user, "")
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+	brandToken := res.Header.Get("Authorization")
+
+	res, _ = doTenant(t, a, testOtherBrandHost, fiber.MethodPost, "/api/user/register", "application/json", user, "")
+	require.Equal(t, fiber.StatusOK, res.StatusCode)
+	otherToken := res.Header.Get("Authorization")
+
+	res, body := doTenant(t, a, testBrandHost, fiber.MethodPost, "/api/user/orders", "text/plain", order, brandToken)
+	require.Equal(t, fiber.StatusAccepted, res.StatusCode, body)
+
+	res, _ = doTenant(t, a, testOtherBrandHost, fiber.MethodGet, "/api/user/orders/"+order, "", "", otherToken)
+	assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+
+	res, body = doTenant(t, a, testOtherBrandHost, fiber.MethodPost, "/api/user/orders", "text/plain", order, otherToken)
+	assert.Equal(t, fiber.StatusAccepted, res.StatusCode, body)
+
+	res, _ = doTenant(t, a, testOtherBrandHost, fiber.MethodGet, "/api/user/balance", "", "", brandToken)
+	assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+}
+
+func TestTenants_accrualCallback(t *testing.T) {
+	cfg := newTenantConfig(t, "postgres://localhost:5432/loyalsys")
+	cfg.Tenants[0].AccrualMode = config.AccrualPush
+	cfg.Tenants[0].AccrualKey = "accrual_key"
+	a := newMultiTenantApp(t, cfg)
+
+	body := `{"order":"12345678903"}`
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	doCallback := func(host, nonce, signature string) int {
+		req := httptest.NewRequest(fiber.MethodPost, "/internal/accrual/callback", strings.NewReader(body))
+		req.Host = host
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.HeaderSignatureTimestamp, timestamp)
+		req.Header.Set(middleware.HeaderSignatureNonce, nonce)
+		if signature != "" {
+			req.Header.Set(middleware.HeaderSignature, signature)
+		}
+		res, err := a.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+	sign := func(nonce string) string {
+		return middleware.Sign([]byte("accrual_key"), timestamp, nonce, []byte(body))
+	}
+
+	t.Run("signed request", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusBadRequest, doCallback(testBrandHost, "n1", sign("n1")))
+	})
+
+	t.Run("replayed request", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusUnauthorized, doCallback(testBrandHost, "n1", sign("n1")))
+	})
+
+	t.Run("unsigned request", func(t *testing.T) {
+		assert.Equal(t, fiber.StatusUnauthorized, doCallback(testBrandHost, "n2", ""))
+	})
+
+	t.Run("pull tenant", func(t *testing.T) {
+		assert.NotEqual(t, fiber.StatusBadRequest, doCallback(testOtherBrandHost, "n3", sign("n3")))
+	})
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+// OrderBatchClient talks to accrual systems that look up many orders per
+// request. The order numbers are posted as a JSON array, and the response
+// lists the orders the accrual system knows about.
+type OrderBatchClient struct {
+	client  *resty.Client
+	baseURL string
+}
+
+func NewOrderBatchClient(client *resty.Client, baseURL string, timeout time.Duration) *OrderBatchClient {
+	return &OrderBatchClient{
+		client:  client,
+		baseURL: baseURL,
+	}
+}
+
+func (c *OrderBatchClient) GetOrdersFromAccrual(ctx context.Context, nums []string) ([]*models.OrderAccrual, error) {
+	res, err := c.client.R().SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(nums).
+		Post(c.baseURL + "/api/orders/batch")
+	if err != nil {
+		return nil, fmt.Errorf("client error: %v", err)
+	}
+
+	if res.StatusCode() == http.StatusOK {
+		var orders []*models.OrderAccrual
+		err = json.Unmarshal(res.Body(), &orders)
+		if err != nil {
+			return nil, fmt.Errorf("client error: %v", err)
+		}
+		return orders, nil
+	}
+	if res.StatusCode() == http.StatusNoContent {
+		return nil, ErrNoContent
+	}
+	if res.StatusCode() == http.StatusTooManyRequests {
+		return nil, retryAfter(res)
+	}
+	return nil, fmt.Errorf("client received an unexpected status code: %s", res.Status())
+}
@@ -0,0 +1,87 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBatchClient_GetOrdersFromAccrual(t *testing.T) {
+	testOrders := []*models.OrderAccrual{
+		{
+			Number:  testOrderNum,
+			Status:  "some status",
+			Accrual: 10,
+		},
+	}
+	testOrdersJSON, err := json.Marshal(testOrders)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/orders/batch" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var nums []string
+		if err := json.NewDecoder(r.Body).Decode(&nums); err != nil || len(nums) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch nums[0] {
+		case testOrderNum:
+			w.WriteHeader(http.StatusOK)
+			w.Write(testOrdersJSON)
+		case testOrderWrongNum:
+			w.WriteHeader(http.StatusNoContent)
+		case testOrderNumTooManyRequests:
+			w.Header().Set("Retry-After", strings.TrimSuffix(testRetryAfterValue.String(), "s"))
+			w.WriteHeader(http.StatusTooManyRequests)
+		case testOrderUnexpected:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+
+	defer server.Close()
+
+	restyClient := resty.New()
+	client := NewOrderBatchClient(restyClient, server.URL, testTimeout)
+
+	t.Run("valid test", func(t *testing.T) {
+		orders, err := client.GetOrdersFromAccrual(context.Background(), []string{testOrderNum, testOrderWrongNum})
+		assert.NoError(t, err)
+		assert.Equal(t, testOrders, orders)
+	})
+
+	t.Run("no content", func(t *testing.T) {
+		_, err := client.GetOrdersFromAccrual(context.Background(), []string{testOrderWrongNum})
+		assert.ErrorIs(t, err, ErrNoContent)
+	})
+
+	t.Run("too many requests", func(t *testing.T) {
+		_, err := client.GetOrdersFromAccrual(context.Background(), []string{testOrderNumTooManyRequests})
+		assert.ErrorIs(t, err, ErrTooManyRequests)
+		e, ok := err.(*errRetryAfter)
+		assert.True(t, ok)
+		assert.Equal(t, testRetryAfterValue, e.GetRetryAfterDuration())
+	})
+
+	t.Run("unexpected status code", func(t *testing.T) {
+		_, err := client.GetOrdersFromAccrual(context.Background(), []string{testOrderUnexpected})
+		assert.Error(t, err)
+	})
+
+	t.Run("ok without body", func(t *testing.T) {
+		_, err := client.GetOrdersFromAccrual(context.Background(), []string{"000"})
+		assert.Error(t, err)
+	})
+}
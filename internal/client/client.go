@@ -44,11 +44,15 @@ func (c *OrderUpdateClient) GetOrderFromAccrual(ctx context.Context, num string)
 		return nil, ErrNoContent
 	}
 	if res.StatusCode() == http.StatusTooManyRequests {
-		dur, err := time.ParseDuration(res.Header().Get("Retry-After") + "s")
-		if err != nil {
-			return nil, fmt.Errorf("client error: %v", err)
-		}
-		return nil, newErrRetryAfter(dur, ErrTooManyRequests)
+		return nil, retryAfter(res)
 	}
 	return nil, fmt.Errorf("client received an unexpected status code: %s", res.Status())
 }
+
+func retryAfter(res *resty.Response) error {
+	dur, err := time.ParseDuration(res.Header().Get("Retry-After") + "s")
+	if err != nil {
+		return fmt.Errorf("client error: %v", err)
+	}
+	return newErrRetryAfter(dur, ErrTooManyRequests)
+}
@@ -2,29 +2,193 @@ package config
 
 import (
 	"crypto/rand"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
 	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/redact"
+	"go.uber.org/zap/zapcore"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
 )
 
 const (
-	defaultServerAddr  = ":8080"
-	defaultTimeout     = time.Duration(2) * time.Minute
-	defaultKeyLength   = 32
-	defaultLoggerLevel = "debug"
+	defaultServerAddr    = ":8080"
+	defaultStorage       = StoragePostgres
+	defaultAccrualMode   = AccrualPull
+	defaultReplayWindow  = time.Duration(5) * time.Minute
+	defaultNonceStore    = NonceMemory
+	defaultGracePeriod   = time.Duration(10) * time.Minute
+	defaultTimeout       = time.Duration(2) * time.Minute
+	defaultDBMaxConns    = 20
+	defaultDBMinConns    = 0
+	defaultDBIdleTime    = time.Duration(3) * time.Minute
+	defaultDBLifetime    = time.Hour
+	defaultKeyLength     = 32
+	defaultLoggerLevel   = "debug"
+	defaultReferrerBonus = 100
+	defaultRefereeBonus  = 50
+	defaultTransferLimit = 10000
+	defaultBatchLimit    = 1000
+	defaultMigrateMode   = MigrateOff
+	defaultWorkerPeriod  = time.Duration(5) * time.Second
+	defaultWorkerPool    = 10
+	defaultThrottleStore = ThrottleMemory
+	defaultThrottleTries = 5
+	defaultThrottleLock  = time.Duration(15) * time.Minute
+	defaultPasswordLen   = 8
+	defaultPasswordBits  = 36
+	defaultResetTTL      = time.Duration(30) * time.Minute
+	defaultResetNotifier = NotifierLog
+	defaultHasher        = HasherArgon2id
+	defaultBCryptCost    = bcrypt.DefaultCost
+	defaultArgon2Memory  = 19 * 1024
+	defaultArgon2Time    = 2
+	defaultArgon2Threads = 1
+	defaultTOTPIssuer    = "loyalsys"
+	defaultTOTPThreshold = 1000
+	defaultRateWindow    = time.Minute
+	defaultRatePublic    = 60
+	defaultRateUser      = 600
+	defaultRateOrders    = 30
+	defaultRateWithdraw  = 10
+	defaultRateTransfer  = 10
+	defaultPendingLimit  = 100
+	defaultTenantHeader  = "X-Tenant-ID"
+	configFileFlag       = "config"
+	configFileEnv        = "CONFIG_FILE"
+	secretFileSuffix     = "_FILE"
 )
 
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+const (
+	AccrualPull  = "pull"
+	AccrualBatch = "batch"
+	AccrualPush  = "push"
+)
+
+const (
+	MigrateAuto  = "auto"
+	MigrateCheck = "check"
+	MigrateOff   = "off"
+)
+
+const (
+	ThrottleMemory   = "memory"
+	ThrottlePostgres = "postgres"
+)
+
+const (
+	NonceMemory   = "memory"
+	NoncePostgres = "postgres"
+)
+
+const (
+	HasherArgon2id = "argon2id"
+	HasherBCrypt   = "bcrypt"
+)
+
+const (
+	NotifierLog  = "log"
+	NotifierFile = "file"
+)
+
+// DefaultTenant is the tenant a deployment without a tenant list runs as. It
+// also owns the data created before tenants were introduced.
+const DefaultTenant = "default"
+
+var tenantIDRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Tenant is a storefront brand with its own users, orders and accrual
+// system. Tenants are configured in the config file or with TENANTS_<n>_*
+// environment variables.
+type Tenant struct {
+	ID          string   `env:"ID" yaml:"id"`
+	Hosts       []string `env:"HOSTS" yaml:"hosts"`
+	AccrualAddr string   `env:"ACCRUAL_SYSTEM_ADDRESS" yaml:"accrual_system_address"`
+	AccrualMode string   `env:"ACCRUAL_MODE" yaml:"accrual_mode"`
+	AccrualKey  string   `env:"ACCRUAL_SIGNING_KEY" yaml:"accrual_signing_key"`
+	Audience    string   `env:"JWT_AUDIENCE" yaml:"jwt_audience"`
+}
+
 type Cfg struct {
-	RunAddr     string        `env:"RUN_ADDRESS"`
-	DatabaseURI string        `env:"DATABASE_URI"`
-	AccrualAddr string        `env:"ACCRUAL_SYSTEM_ADDRESS"`
-	Timeout     time.Duration `env:"TIMEOUT_DUR"`
-	Key         string        `env:"JWT_KEY"`
-	LogLevel    string        `env:"LOG_LEVEL"`
+	RunAddr           string        `env:"RUN_ADDRESS" yaml:"run_address"`
+	Storage           string        `env:"STORAGE" yaml:"storage"`
+	DatabaseURI       string        `env:"DATABASE_URI" yaml:"database_uri"`
+	DBMaxConns        int           `env:"DB_MAX_CONNS" yaml:"db_max_conns"`
+	DBMinConns        int           `env:"DB_MIN_CONNS" yaml:"db_min_conns"`
+	DBMaxConnIdle     time.Duration `env:"DB_MAX_CONN_IDLE_TIME" yaml:"db_max_conn_idle_time"`
+	DBMaxConnLife     time.Duration `env:"DB_MAX_CONN_LIFETIME" yaml:"db_max_conn_lifetime"`
+	AccrualAddr       string        `env:"ACCRUAL_SYSTEM_ADDRESS" yaml:"accrual_system_address"`
+	AccrualMode       string        `env:"ACCRUAL_MODE" yaml:"accrual_mode"`
+	AccrualKey        string        `env:"ACCRUAL_SIGNING_KEY" yaml:"accrual_signing_key"`
+	ReplayWindow      time.Duration `env:"ACCRUAL_REPLAY_WINDOW" yaml:"accrual_replay_window"`
+	NonceStore        string        `env:"ACCRUAL_NONCE_STORE" yaml:"accrual_nonce_store"`
+	GracePeriod       time.Duration `env:"ACCRUAL_GRACE_PERIOD" yaml:"accrual_grace_period"`
+	Timeout           time.Duration `env:"TIMEOUT_DUR" yaml:"timeout"`
+	Key               string        `env:"JWT_KEY" yaml:"jwt_key"`
+	LogLevel          string        `env:"LOG_LEVEL" yaml:"log_level"`
+	ReferrerBonus     float64       `env:"REFERRER_BONUS" yaml:"referrer_bonus"`
+	RefereeBonus      float64       `env:"REFEREE_BONUS" yaml:"referee_bonus"`
+	TransferLimit     float64       `env:"TRANSFER_DAILY_LIMIT" yaml:"transfer_daily_limit"`
+	BatchLimit        int           `env:"ORDER_BATCH_LIMIT" yaml:"order_batch_limit"`
+	MigrateMode       string        `env:"MIGRATE_MODE" yaml:"migrate"`
+	WorkerPeriod      time.Duration `env:"WORKER_TICKER_PERIOD" yaml:"worker_ticker_period"`
+	WorkerPool        int           `env:"WORKER_FAN_OUT_POOL" yaml:"worker_fan_out_pool"`
+	ThrottleStore     string        `env:"LOGIN_THROTTLE_STORE" yaml:"login_throttle_store"`
+	ThrottleAttempts  int           `env:"LOGIN_THROTTLE_ATTEMPTS" yaml:"login_throttle_attempts"`
+	ThrottleLockout   time.Duration `env:"LOGIN_THROTTLE_LOCKOUT" yaml:"login_throttle_lockout"`
+	PasswordMinLen    int           `env:"PASSWORD_MIN_LENGTH" yaml:"password_min_length"`
+	PasswordMinBits   float64       `env:"PASSWORD_MIN_ENTROPY" yaml:"password_min_entropy"`
+	BreachedList      string        `env:"PASSWORD_BREACHED_LIST" yaml:"password_breached_list"`
+	ResetTTL          time.Duration `env:"PASSWORD_RESET_TTL" yaml:"password_reset_ttl"`
+	ResetNotifier     string        `env:"PASSWORD_RESET_NOTIFIER" yaml:"password_reset_notifier"`
+	ResetNotifyFile   string        `env:"PASSWORD_RESET_NOTIFIER_FILE" yaml:"password_reset_notifier_file"`
+	Hasher            string        `env:"PASSWORD_HASHER" yaml:"password_hasher"`
+	BCryptCost        int           `env:"PASSWORD_BCRYPT_COST" yaml:"password_bcrypt_cost"`
+	Argon2Memory      uint          `env:"PASSWORD_ARGON2_MEMORY" yaml:"password_argon2_memory"`
+	Argon2Time        uint          `env:"PASSWORD_ARGON2_ITERATIONS" yaml:"password_argon2_iterations"`
+	Argon2Threads     uint          `env:"PASSWORD_ARGON2_PARALLELISM" yaml:"password_argon2_parallelism"`
+	TOTPIssuer        string        `env:"TOTP_ISSUER" yaml:"totp_issuer"`
+	TOTPWithdrawLimit float64       `env:"TOTP_WITHDRAWAL_THRESHOLD" yaml:"totp_withdrawal_threshold"`
+	RateLimitWindow   time.Duration `env:"RATE_LIMIT_WINDOW" yaml:"rate_limit_window"`
+	RateLimitPublic   int           `env:"RATE_LIMIT_PUBLIC" yaml:"rate_limit_public"`
+	RateLimitUser     int           `env:"RATE_LIMIT_USER" yaml:"rate_limit_user"`
+	RateLimitOrders   int           `env:"RATE_LIMIT_ORDERS" yaml:"rate_limit_orders"`
+	RateLimitWithdraw int           `env:"RATE_LIMIT_WITHDRAWALS" yaml:"rate_limit_withdrawals"`
+	RateLimitTransfer int           `env:"RATE_LIMIT_TRANSFERS" yaml:"rate_limit_transfers"`
+	PendingOrderLimit int           `env:"ORDER_PENDING_LIMIT" yaml:"order_pending_limit"`
+	OpenAPIValidation bool          `env:"OPENAPI_VALIDATION" yaml:"openapi_validation"`
+	GRPCAddr          string        `env:"GRPC_ADDRESS" yaml:"grpc_address"`
+	GRPCToken         string        `env:"GRPC_TOKEN" yaml:"grpc_token"`
+	GRPCTLSCert       string        `env:"GRPC_TLS_CERT" yaml:"grpc_tls_cert"`
+	GRPCTLSKey        string        `env:"GRPC_TLS_KEY" yaml:"grpc_tls_key"`
+	GRPCClientCA      string        `env:"GRPC_CLIENT_CA" yaml:"grpc_client_ca"`
+	TenantHeader      string        `env:"TENANT_HEADER" yaml:"tenant_header"`
+	Tenants           []Tenant      `envPrefix:"TENANTS" yaml:"tenants"`
+	PrintConfig       bool          `yaml:"-"`
 }
 
+// ConfigBuilder applies configuration sources in the order its methods are
+// called, so each step overrides the previous ones. The server uses
+// defaults < file < env < flags.
 type ConfigBuilder struct {
 	cfg *Cfg
 	err error
@@ -33,9 +197,47 @@ type ConfigBuilder struct {
 func NewConfigBuilder() *ConfigBuilder {
 	return &ConfigBuilder{
 		cfg: &Cfg{
-			RunAddr:  defaultServerAddr,
-			Timeout:  defaultTimeout,
-			LogLevel: defaultLoggerLevel,
+			RunAddr:           defaultServerAddr,
+			Storage:           defaultStorage,
+			AccrualMode:       defaultAccrualMode,
+			ReplayWindow:      defaultReplayWindow,
+			NonceStore:        defaultNonceStore,
+			GracePeriod:       defaultGracePeriod,
+			Timeout:           defaultTimeout,
+			DBMaxConns:        defaultDBMaxConns,
+			DBMinConns:        defaultDBMinConns,
+			DBMaxConnIdle:     defaultDBIdleTime,
+			DBMaxConnLife:     defaultDBLifetime,
+			LogLevel:          defaultLoggerLevel,
+			ReferrerBonus:     defaultReferrerBonus,
+			RefereeBonus:      defaultRefereeBonus,
+			TransferLimit:     defaultTransferLimit,
+			BatchLimit:        defaultBatchLimit,
+			MigrateMode:       defaultMigrateMode,
+			WorkerPeriod:      defaultWorkerPeriod,
+			WorkerPool:        defaultWorkerPool,
+			ThrottleStore:     defaultThrottleStore,
+			ThrottleAttempts:  defaultThrottleTries,
+			ThrottleLockout:   defaultThrottleLock,
+			PasswordMinLen:    defaultPasswordLen,
+			PasswordMinBits:   defaultPasswordBits,
+			ResetTTL:          defaultResetTTL,
+			ResetNotifier:     defaultResetNotifier,
+			Hasher:            defaultHasher,
+			BCryptCost:        defaultBCryptCost,
+			Argon2Memory:      defaultArgon2Memory,
+			Argon2Time:        defaultArgon2Time,
+			Argon2Threads:     defaultArgon2Threads,
+			TOTPIssuer:        defaultTOTPIssuer,
+			TOTPWithdrawLimit: defaultTOTPThreshold,
+			RateLimitWindow:   defaultRateWindow,
+			RateLimitPublic:   defaultRatePublic,
+			RateLimitUser:     defaultRateUser,
+			RateLimitOrders:   defaultRateOrders,
+			RateLimitWithdraw: defaultRateWithdraw,
+			RateLimitTransfer: defaultRateTransfer,
+			PendingOrderLimit: defaultPendingLimit,
+			TenantHeader:      defaultTenantHeader,
 		},
 		err: nil,
 	}
@@ -46,13 +248,162 @@ func (b *ConfigBuilder) WithFlagParsing() *ConfigBuilder {
 		return b
 	}
 
-	flag.StringVar(&b.cfg.RunAddr, "a", b.cfg.RunAddr, "Address and port to start the server")
-	flag.StringVar(&b.cfg.DatabaseURI, "d", b.cfg.DatabaseURI, "Database connection address")
-	flag.StringVar(&b.cfg.AccrualAddr, "r", b.cfg.AccrualAddr, "Accrual connection address")
-	flag.DurationVar(&b.cfg.Timeout, "t", b.cfg.Timeout, "Timeout duration in seconds")
-	flag.StringVar(&b.cfg.Key, "k", b.cfg.Key, "Key for jwt autorization")
-	flag.StringVar(&b.cfg.LogLevel, "l", b.cfg.LogLevel, "Logger level")
-	flag.Parse()
+	parsed := *b.cfg
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	fs.String(configFileFlag, "", "Path to a YAML or JSON config file")
+	fs.StringVar(&parsed.RunAddr, "a", parsed.RunAddr, "Address and port to start the server")
+	fs.StringVar(&parsed.Storage, "storage", parsed.Storage, "Storage backend: postgres or memory")
+	fs.StringVar(&parsed.DatabaseURI, "d", parsed.DatabaseURI, "Database connection address")
+	fs.IntVar(&parsed.DBMaxConns, "db-max-conns", parsed.DBMaxConns, "Maximum number of database connections")
+	fs.IntVar(&parsed.DBMinConns, "db-min-conns", parsed.DBMinConns, "Number of database connections kept open when idle")
+	fs.DurationVar(&parsed.DBMaxConnIdle, "db-max-conn-idle-time", parsed.DBMaxConnIdle, "Idle time after which a database connection is closed")
+	fs.DurationVar(&parsed.DBMaxConnLife, "db-max-conn-lifetime", parsed.DBMaxConnLife, "Age after which a database connection is closed")
+	fs.StringVar(&parsed.AccrualAddr, "r", parsed.AccrualAddr, "Accrual connection address")
+	fs.StringVar(&parsed.AccrualMode, "accrual-mode", parsed.AccrualMode, "How accrual results are received: pull, batch or push")
+	fs.StringVar(&parsed.AccrualKey, "accrual-signing-key", parsed.AccrualKey, "Key the accrual system signs pushed results with")
+	fs.DurationVar(&parsed.ReplayWindow, "accrual-replay-window", parsed.ReplayWindow, "How far the timestamp of a pushed result may be off")
+	fs.StringVar(&parsed.NonceStore, "accrual-nonce-store", parsed.NonceStore, "Store of used accrual callback nonces: memory or postgres")
+	fs.DurationVar(&parsed.GracePeriod, "accrual-grace-period", parsed.GracePeriod, "How long push mode waits for a result before polling the order")
+	fs.DurationVar(&parsed.Timeout, "t", parsed.Timeout, "Timeout duration in seconds")
+	fs.StringVar(&parsed.Key, "k", parsed.Key, "Key for jwt autorization")
+	fs.StringVar(&parsed.LogLevel, "l", parsed.LogLevel, "Logger level")
+	fs.Float64Var(&parsed.ReferrerBonus, "referrer-bonus", parsed.ReferrerBonus, "Bonus paid to the referrer")
+	fs.Float64Var(&parsed.RefereeBonus, "referee-bonus", parsed.RefereeBonus, "Bonus paid to the referee")
+	fs.Float64Var(&parsed.TransferLimit, "transfer-daily-limit", parsed.TransferLimit, "Daily limit of outgoing transfers per user, 0 means unlimited")
+	fs.IntVar(&parsed.BatchLimit, "order-batch-limit", parsed.BatchLimit, "Maximum number of orders in a single batch upload")
+	fs.StringVar(&parsed.MigrateMode, "migrate", parsed.MigrateMode, "Migrations on startup: auto, check or off")
+	fs.DurationVar(&parsed.WorkerPeriod, "worker-period", parsed.WorkerPeriod, "Period between accrual sync runs")
+	fs.IntVar(&parsed.WorkerPool, "worker-pool", parsed.WorkerPool, "Number of concurrent accrual requests")
+	fs.StringVar(&parsed.ThrottleStore, "login-throttle-store", parsed.ThrottleStore, "Login throttling store: memory or postgres")
+	fs.IntVar(&parsed.ThrottleAttempts, "login-throttle-attempts", parsed.ThrottleAttempts, "Failed logins allowed before delays start, 0 disables throttling")
+	fs.DurationVar(&parsed.ThrottleLockout, "login-throttle-lockout", parsed.ThrottleLockout, "Maximum lockout after repeated failed logins")
+	fs.IntVar(&parsed.PasswordMinLen, "password-min-length", parsed.PasswordMinLen, "Minimum password length")
+	fs.Float64Var(&parsed.PasswordMinBits, "password-min-entropy", parsed.PasswordMinBits, "Minimum estimated password entropy in bits")
+	fs.StringVar(&parsed.BreachedList, "password-breached-list", parsed.BreachedList, "Path to a newline separated list of breached passwords")
+	fs.DurationVar(&parsed.ResetTTL, "password-reset-ttl", parsed.ResetTTL, "Lifetime of password reset tokens")
+	fs.StringVar(&parsed.ResetNotifier, "password-reset-notifier", parsed.ResetNotifier, "Password reset delivery: log or file")
+	fs.StringVar(&parsed.ResetNotifyFile, "password-reset-notifier-file", parsed.ResetNotifyFile, "File the file notifier appends password reset messages to")
+	fs.StringVar(&parsed.Hasher, "password-hasher", parsed.Hasher, "Algorithm for new password hashes: argon2id or bcrypt")
+	fs.IntVar(&parsed.BCryptCost, "password-bcrypt-cost", parsed.BCryptCost, "Bcrypt cost factor")
+	fs.UintVar(&parsed.Argon2Memory, "password-argon2-memory", parsed.Argon2Memory, "Argon2id memory in KiB")
+	fs.UintVar(&parsed.Argon2Time, "password-argon2-iterations", parsed.Argon2Time, "Argon2id number of iterations")
+	fs.UintVar(&parsed.Argon2Threads, "password-argon2-parallelism", parsed.Argon2Threads, "Argon2id degree of parallelism")
+	fs.StringVar(&parsed.TOTPIssuer, "totp-issuer", parsed.TOTPIssuer, "Issuer shown in authenticator apps")
+	fs.Float64Var(&parsed.TOTPWithdrawLimit, "totp-withdrawal-threshold", parsed.TOTPWithdrawLimit, "Withdrawals and transfers above this sum require a TOTP code when 2FA is enabled")
+	fs.DurationVar(&parsed.RateLimitWindow, "rate-limit-window", parsed.RateLimitWindow, "Window the API rate limits are counted in")
+	fs.IntVar(&parsed.RateLimitPublic, "rate-limit-public", parsed.RateLimitPublic, "Requests per window per client IP on unauthenticated routes, 0 disables the limit")
+	fs.IntVar(&parsed.RateLimitUser, "rate-limit-user", parsed.RateLimitUser, "Requests per window per user on authenticated routes, 0 disables the limit")
+	fs.IntVar(&parsed.RateLimitOrders, "rate-limit-orders", parsed.RateLimitOrders, "Order uploads per window per user, 0 disables the limit")
+	fs.IntVar(&parsed.RateLimitWithdraw, "rate-limit-withdrawals", parsed.RateLimitWithdraw, "Withdrawals per window per user, 0 disables the limit")
+	fs.IntVar(&parsed.RateLimitTransfer, "rate-limit-transfers", parsed.RateLimitTransfer, "Transfers per window per user, 0 disables the limit")
+	fs.IntVar(&parsed.PendingOrderLimit, "order-pending-limit", parsed.PendingOrderLimit, "Maximum orders per user awaiting accrual, 0 means unlimited")
+	fs.BoolVar(&parsed.OpenAPIValidation, "openapi-validation", parsed.OpenAPIValidation, "Validate requests against the OpenAPI document instead of checking content types only")
+	fs.StringVar(&parsed.GRPCAddr, "grpc-address", parsed.GRPCAddr, "Address and port to start the gRPC server, empty disables it")
+	fs.StringVar(&parsed.GRPCToken, "grpc-token", parsed.GRPCToken, "Bearer token gRPC clients must present")
+	fs.StringVar(&parsed.GRPCTLSCert, "grpc-tls-cert", parsed.GRPCTLSCert, "Path to the gRPC server TLS certificate")
+	fs.StringVar(&parsed.GRPCTLSKey, "grpc-tls-key", parsed.GRPCTLSKey, "Path to the gRPC server TLS key")
+	fs.StringVar(&parsed.GRPCClientCA, "grpc-client-ca", parsed.GRPCClientCA, "Path to the CA bundle gRPC client certificates must chain to")
+	fs.StringVar(&parsed.TenantHeader, "tenant-header", parsed.TenantHeader, "Request header that selects the tenant, empty resolves tenants by host only")
+	fs.BoolVar(&parsed.PrintConfig, "print-config", parsed.PrintConfig, "Print the effective configuration with secrets masked and exit")
+	fs.Parse(os.Args[1:])
+
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	applyFlag(set, "a", &b.cfg.RunAddr, parsed.RunAddr)
+	applyFlag(set, "storage", &b.cfg.Storage, parsed.Storage)
+	applyFlag(set, "d", &b.cfg.DatabaseURI, parsed.DatabaseURI)
+	applyFlag(set, "db-max-conns", &b.cfg.DBMaxConns, parsed.DBMaxConns)
+	applyFlag(set, "db-min-conns", &b.cfg.DBMinConns, parsed.DBMinConns)
+	applyFlag(set, "db-max-conn-idle-time", &b.cfg.DBMaxConnIdle, parsed.DBMaxConnIdle)
+	applyFlag(set, "db-max-conn-lifetime", &b.cfg.DBMaxConnLife, parsed.DBMaxConnLife)
+	applyFlag(set, "r", &b.cfg.AccrualAddr, parsed.AccrualAddr)
+	applyFlag(set, "accrual-mode", &b.cfg.AccrualMode, parsed.AccrualMode)
+	applyFlag(set, "accrual-signing-key", &b.cfg.AccrualKey, parsed.AccrualKey)
+	applyFlag(set, "accrual-replay-window", &b.cfg.ReplayWindow, parsed.ReplayWindow)
+	applyFlag(set, "accrual-nonce-store", &b.cfg.NonceStore, parsed.NonceStore)
+	applyFlag(set, "accrual-grace-period", &b.cfg.GracePeriod, parsed.GracePeriod)
+	applyFlag(set, "t", &b.cfg.Timeout, parsed.Timeout)
+	applyFlag(set, "k", &b.cfg.Key, parsed.Key)
+	applyFlag(set, "l", &b.cfg.LogLevel, parsed.LogLevel)
+	applyFlag(set, "referrer-bonus", &b.cfg.ReferrerBonus, parsed.ReferrerBonus)
+	applyFlag(set, "referee-bonus", &b.cfg.RefereeBonus, parsed.RefereeBonus)
+	applyFlag(set, "transfer-daily-limit", &b.cfg.TransferLimit, parsed.TransferLimit)
+	applyFlag(set, "order-batch-limit", &b.cfg.BatchLimit, parsed.BatchLimit)
+	applyFlag(set, "migrate", &b.cfg.MigrateMode, parsed.MigrateMode)
+	applyFlag(set, "worker-period", &b.cfg.WorkerPeriod, parsed.WorkerPeriod)
+	applyFlag(set, "worker-pool", &b.cfg.WorkerPool, parsed.WorkerPool)
+	applyFlag(set, "login-throttle-store", &b.cfg.ThrottleStore, parsed.ThrottleStore)
+	applyFlag(set, "login-throttle-attempts", &b.cfg.ThrottleAttempts, parsed.ThrottleAttempts)
+	applyFlag(set, "login-throttle-lockout", &b.cfg.ThrottleLockout, parsed.ThrottleLockout)
+	applyFlag(set, "password-min-length", &b.cfg.PasswordMinLen, parsed.PasswordMinLen)
+	applyFlag(set, "password-min-entropy", &b.cfg.PasswordMinBits, parsed.PasswordMinBits)
+	applyFlag(set, "password-breached-list", &b.cfg.BreachedList, parsed.BreachedList)
+	applyFlag(set, "password-reset-ttl", &b.cfg.ResetTTL, parsed.ResetTTL)
+	applyFlag(set, "password-reset-notifier", &b.cfg.ResetNotifier, parsed.ResetNotifier)
+	applyFlag(set, "password-reset-notifier-file", &b.cfg.ResetNotifyFile, parsed.ResetNotifyFile)
+	applyFlag(set, "password-hasher", &b.cfg.Hasher, parsed.Hasher)
+	applyFlag(set, "password-bcrypt-cost", &b.cfg.BCryptCost, parsed.BCryptCost)
+	applyFlag(set, "password-argon2-memory", &b.cfg.Argon2Memory, parsed.Argon2Memory)
+	applyFlag(set, "password-argon2-iterations", &b.cfg.Argon2Time, parsed.Argon2Time)
+	applyFlag(set, "password-argon2-parallelism", &b.cfg.Argon2Threads, parsed.Argon2Threads)
+	applyFlag(set, "totp-issuer", &b.cfg.TOTPIssuer, parsed.TOTPIssuer)
+	applyFlag(set, "totp-withdrawal-threshold", &b.cfg.TOTPWithdrawLimit, parsed.TOTPWithdrawLimit)
+	applyFlag(set, "rate-limit-window", &b.cfg.RateLimitWindow, parsed.RateLimitWindow)
+	applyFlag(set, "rate-limit-public", &b.cfg.RateLimitPublic, parsed.RateLimitPublic)
+	applyFlag(set, "rate-limit-user", &b.cfg.RateLimitUser, parsed.RateLimitUser)
+	applyFlag(set, "rate-limit-orders", &b.cfg.RateLimitOrders, parsed.RateLimitOrders)
+	applyFlag(set, "rate-limit-withdrawals", &b.cfg.RateLimitWithdraw, parsed.RateLimitWithdraw)
+	applyFlag(set, "rate-limit-transfers", &b.cfg.RateLimitTransfer, parsed.RateLimitTransfer)
+	applyFlag(set, "order-pending-limit", &b.cfg.PendingOrderLimit, parsed.PendingOrderLimit)
+	applyFlag(set, "openapi-validation", &b.cfg.OpenAPIValidation, parsed.OpenAPIValidation)
+	applyFlag(set, "grpc-address", &b.cfg.GRPCAddr, parsed.GRPCAddr)
+	applyFlag(set, "grpc-token", &b.cfg.GRPCToken, parsed.GRPCToken)
+	applyFlag(set, "grpc-tls-cert", &b.cfg.GRPCTLSCert, parsed.GRPCTLSCert)
+	applyFlag(set, "grpc-tls-key", &b.cfg.GRPCTLSKey, parsed.GRPCTLSKey)
+	applyFlag(set, "grpc-client-ca", &b.cfg.GRPCClientCA, parsed.GRPCClientCA)
+	applyFlag(set, "tenant-header", &b.cfg.TenantHeader, parsed.TenantHeader)
+	applyFlag(set, "print-config", &b.cfg.PrintConfig, parsed.PrintConfig)
+
+	return b
+}
+
+func applyFlag[T any](set map[string]bool, name string, dst *T, value T) {
+	if set[name] {
+		*dst = value
+	}
+}
+
+func (b *ConfigBuilder) WithFileParsing(path string) *ConfigBuilder {
+	if b.err != nil || path == "" {
+		return b
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+	default:
+		b.cfg = nil
+		b.err = fmt.Errorf("unsupported config file format: %s", path)
+		return b
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		b.cfg = nil
+		b.err = err
+		return b
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	err = dec.Decode(b.cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		b.cfg = nil
+		b.err = fmt.Errorf("can't parse config file %s: %w", path, err)
+		return b
+	}
 
 	return b
 }
@@ -69,9 +420,50 @@ func (b *ConfigBuilder) WithEnvParsing() *ConfigBuilder {
 		return b
 	}
 
+	err = readSecretFile(&b.cfg.Key, "JWT_KEY")
+	if err != nil {
+		b.cfg = nil
+		b.err = err
+		return b
+	}
+	err = readSecretFile(&b.cfg.DatabaseURI, "DATABASE_URI")
+	if err != nil {
+		b.cfg = nil
+		b.err = err
+		return b
+	}
+	err = readSecretFile(&b.cfg.GRPCToken, "GRPC_TOKEN")
+	if err != nil {
+		b.cfg = nil
+		b.err = err
+		return b
+	}
+	err = readSecretFile(&b.cfg.AccrualKey, "ACCRUAL_SIGNING_KEY")
+	if err != nil {
+		b.cfg = nil
+		b.err = err
+		return b
+	}
+
 	return b
 }
 
+func readSecretFile(dst *string, name string) error {
+	path := os.Getenv(name + secretFileSuffix)
+	if path == "" {
+		return nil
+	}
+	if _, ok := os.LookupEnv(name); ok {
+		return fmt.Errorf("both %s and %s%s are set", name, name, secretFileSuffix)
+	}
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("can't read %s%s: %w", name, secretFileSuffix, err)
+	}
+	*dst = strings.TrimRight(string(secret), "\r\n")
+	return nil
+}
+
 func (b *ConfigBuilder) WithDefaultJWTKey() *ConfigBuilder {
 	if b.err != nil {
 		return b
@@ -100,6 +492,327 @@ func generateKey(n int) (string, error) {
 	return string(key), nil
 }
 
+func (b *ConfigBuilder) Validate() *ConfigBuilder {
+	if b.err != nil {
+		return b
+	}
+
+	err := b.cfg.validate()
+	if err != nil {
+		b.cfg = nil
+		b.err = err
+		return b
+	}
+
+	return b
+}
+
+func (cfg *Cfg) validate() error {
+	var errs []error
+	if _, port, err := net.SplitHostPort(cfg.RunAddr); err != nil {
+		errs = append(errs, fmt.Errorf("run address %q is malformed: %v", cfg.RunAddr, err))
+	} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		errs = append(errs, fmt.Errorf("run address %q has an invalid port", cfg.RunAddr))
+	}
+	switch cfg.Storage {
+	case StoragePostgres:
+		if cfg.DatabaseURI == "" {
+			errs = append(errs, errors.New("database URI is required (-d or DATABASE_URI)"))
+		}
+	case StorageMemory:
+		if cfg.MigrateMode != MigrateOff {
+			errs = append(errs, fmt.Errorf("migrate mode %q requires the postgres storage", cfg.MigrateMode))
+		}
+		if cfg.ThrottleStore == ThrottlePostgres {
+			errs = append(errs, errors.New("postgres login throttle store requires the postgres storage"))
+		}
+		if cfg.NonceStore == NoncePostgres {
+			errs = append(errs, errors.New("postgres accrual nonce store requires the postgres storage"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown storage %q, expected postgres or memory", cfg.Storage))
+	}
+	if cfg.DBMaxConns <= 0 {
+		errs = append(errs, fmt.Errorf("database max connections must be positive, got %d", cfg.DBMaxConns))
+	}
+	if cfg.DBMinConns < 0 || cfg.DBMinConns > cfg.DBMaxConns {
+		errs = append(errs, fmt.Errorf("database min connections must be between 0 and %d, got %d", cfg.DBMaxConns, cfg.DBMinConns))
+	}
+	if cfg.DBMaxConnIdle <= 0 {
+		errs = append(errs, fmt.Errorf("database connection idle time must be positive, got %s", cfg.DBMaxConnIdle))
+	}
+	if cfg.DBMaxConnLife <= 0 {
+		errs = append(errs, fmt.Errorf("database connection lifetime must be positive, got %s", cfg.DBMaxConnLife))
+	}
+	if cfg.AccrualAddr == "" {
+		if cfg.needsAccrualAddr() {
+			errs = append(errs, errors.New("accrual system address is required (-r or ACCRUAL_SYSTEM_ADDRESS)"))
+		}
+	} else if !isHTTPURL(cfg.AccrualAddr) {
+		errs = append(errs, fmt.Errorf("accrual system address %q must be an http(s) URL", cfg.AccrualAddr))
+	}
+	errs = append(errs, cfg.validateAccrual()...)
+	errs = append(errs, cfg.validateTenants()...)
+	if cfg.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("timeout must be positive, got %s", cfg.Timeout))
+	}
+	if _, err := zapcore.ParseLevel(cfg.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("unknown log level %q", cfg.LogLevel))
+	}
+	if cfg.ReferrerBonus < 0 || cfg.RefereeBonus < 0 {
+		errs = append(errs, errors.New("referral bonuses must not be negative"))
+	}
+	if cfg.TransferLimit < 0 {
+		errs = append(errs, errors.New("transfer daily limit must not be negative"))
+	}
+	if cfg.BatchLimit < 0 {
+		errs = append(errs, errors.New("order batch limit must not be negative"))
+	}
+	switch cfg.MigrateMode {
+	case MigrateAuto, MigrateCheck, MigrateOff:
+	default:
+		errs = append(errs, fmt.Errorf("unknown migrate mode %q, expected auto, check or off", cfg.MigrateMode))
+	}
+	if cfg.WorkerPeriod <= 0 {
+		errs = append(errs, fmt.Errorf("worker ticker period must be positive, got %s", cfg.WorkerPeriod))
+	}
+	if cfg.WorkerPool <= 0 {
+		errs = append(errs, fmt.Errorf("worker fan-out pool must be positive, got %d", cfg.WorkerPool))
+	}
+	switch cfg.ThrottleStore {
+	case ThrottleMemory, ThrottlePostgres:
+	default:
+		errs = append(errs, fmt.Errorf("unknown login throttle store %q, expected memory or postgres", cfg.ThrottleStore))
+	}
+	if cfg.ThrottleAttempts < 0 {
+		errs = append(errs, errors.New("login throttle attempts must not be negative"))
+	}
+	if cfg.ThrottleAttempts > 0 && cfg.ThrottleLockout <= 0 {
+		errs = append(errs, fmt.Errorf("login throttle lockout must be positive, got %s", cfg.ThrottleLockout))
+	}
+	if cfg.PasswordMinLen <= 0 {
+		errs = append(errs, fmt.Errorf("password minimum length must be positive, got %d", cfg.PasswordMinLen))
+	}
+	if cfg.PasswordMinBits < 0 {
+		errs = append(errs, errors.New("password minimum entropy must not be negative"))
+	}
+	if cfg.ResetTTL <= 0 {
+		errs = append(errs, fmt.Errorf("password reset token lifetime must be positive, got %s", cfg.ResetTTL))
+	}
+	switch cfg.ResetNotifier {
+	case NotifierLog:
+	case NotifierFile:
+		if cfg.ResetNotifyFile == "" {
+			errs = append(errs, errors.New("password reset notifier file is required for the file notifier"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown password reset notifier %q, expected log or file", cfg.ResetNotifier))
+	}
+	switch cfg.Hasher {
+	case HasherArgon2id, HasherBCrypt:
+	default:
+		errs = append(errs, fmt.Errorf("unknown password hasher %q, expected argon2id or bcrypt", cfg.Hasher))
+	}
+	if cfg.BCryptCost < bcrypt.MinCost || cfg.BCryptCost > bcrypt.MaxCost {
+		errs = append(errs, fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cfg.BCryptCost))
+	}
+	if cfg.Argon2Threads < 1 || cfg.Argon2Threads > math.MaxUint8 {
+		errs = append(errs, fmt.Errorf("argon2id parallelism must be between 1 and %d, got %d", math.MaxUint8, cfg.Argon2Threads))
+	}
+	if cfg.Argon2Time < 1 || cfg.Argon2Time > math.MaxUint32 {
+		errs = append(errs, fmt.Errorf("argon2id iterations must be positive, got %d", cfg.Argon2Time))
+	}
+	if cfg.Argon2Memory < 8*cfg.Argon2Threads || cfg.Argon2Memory > math.MaxUint32 {
+		errs = append(errs, fmt.Errorf("argon2id memory must be at least 8 KiB per thread, got %d KiB", cfg.Argon2Memory))
+	}
+	if cfg.TOTPIssuer == "" {
+		errs = append(errs, errors.New("totp issuer is required"))
+	}
+	if cfg.TOTPWithdrawLimit < 0 {
+		errs = append(errs, errors.New("totp withdrawal threshold must not be negative"))
+	}
+	if cfg.RateLimitWindow <= 0 {
+		errs = append(errs, fmt.Errorf("rate limit window must be positive, got %s", cfg.RateLimitWindow))
+	}
+	if cfg.RateLimitPublic < 0 || cfg.RateLimitUser < 0 || cfg.RateLimitOrders < 0 || cfg.RateLimitWithdraw < 0 || cfg.RateLimitTransfer < 0 {
+		errs = append(errs, errors.New("rate limits must not be negative"))
+	}
+	if cfg.PendingOrderLimit < 0 {
+		errs = append(errs, fmt.Errorf("pending order limit must not be negative, got %d", cfg.PendingOrderLimit))
+	}
+	if cfg.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(cfg.GRPCAddr); err != nil {
+			errs = append(errs, fmt.Errorf("grpc address %q is malformed: %v", cfg.GRPCAddr, err))
+		}
+		if cfg.GRPCToken == "" && cfg.GRPCClientCA == "" {
+			errs = append(errs, errors.New("grpc server needs a token or a client CA for authentication"))
+		}
+	}
+	if (cfg.GRPCTLSCert == "") != (cfg.GRPCTLSKey == "") {
+		errs = append(errs, errors.New("grpc tls certificate and key must be set together"))
+	}
+	if cfg.GRPCClientCA != "" && cfg.GRPCTLSCert == "" {
+		errs = append(errs, errors.New("grpc client CA requires a tls certificate and key"))
+	}
+	return errors.Join(errs...)
+}
+
+func (cfg *Cfg) validateTenants() []error {
+	var errs []error
+	ids := make(map[string]bool)
+	hosts := make(map[string]bool)
+	audiences := make(map[string]bool)
+	for _, tenant := range cfg.Tenants {
+		if !tenantIDRe.MatchString(tenant.ID) {
+			errs = append(errs, fmt.Errorf("tenant id %q must be 1-64 lowercase letters, digits, '-' or '_'", tenant.ID))
+		} else if ids[tenant.ID] {
+			errs = append(errs, fmt.Errorf("tenant %q is configured twice", tenant.ID))
+		}
+		ids[tenant.ID] = true
+		for _, host := range tenant.Hosts {
+			host = strings.ToLower(host)
+			if hosts[host] {
+				errs = append(errs, fmt.Errorf("host %q is assigned to more than one tenant", host))
+			}
+			hosts[host] = true
+		}
+		if tenant.AccrualAddr != "" && !isHTTPURL(tenant.AccrualAddr) {
+			errs = append(errs, fmt.Errorf("accrual system address %q of tenant %q must be an http(s) URL", tenant.AccrualAddr, tenant.ID))
+		}
+		if len(cfg.Tenants) > 1 {
+			if tenant.Audience == "" {
+				errs = append(errs, fmt.Errorf("tenant %q needs a jwt audience when several tenants are configured", tenant.ID))
+			} else if audiences[tenant.Audience] {
+				errs = append(errs, fmt.Errorf("jwt audience %q is shared by more than one tenant", tenant.Audience))
+			}
+			audiences[tenant.Audience] = true
+		}
+	}
+	return errs
+}
+
+func (cfg *Cfg) needsAccrualAddr() bool {
+	for _, tenant := range cfg.Tenants {
+		if tenant.AccrualAddr == "" {
+			return true
+		}
+	}
+	return len(cfg.Tenants) == 0
+}
+
+// validateAccrual checks the accrual mode of every tenant. A tenant the
+// accrual system pushes results to needs a key to check them with, and
+// still polls the orders that got no result within the grace period.
+func (cfg *Cfg) validateAccrual() []error {
+	var errs []error
+	for _, tenant := range cfg.TenantList() {
+		switch tenant.AccrualMode {
+		case AccrualPull, AccrualBatch:
+		case AccrualPush:
+			if tenant.AccrualKey == "" {
+				errs = append(errs, fmt.Errorf("accrual signing key of tenant %q is required in push mode (-accrual-signing-key or ACCRUAL_SIGNING_KEY)", tenant.ID))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("unknown accrual mode %q of tenant %q, expected pull, batch or push", tenant.AccrualMode, tenant.ID))
+		}
+	}
+	if cfg.ReplayWindow <= 0 {
+		errs = append(errs, fmt.Errorf("accrual replay window must be positive, got %s", cfg.ReplayWindow))
+	}
+	switch cfg.NonceStore {
+	case NonceMemory, NoncePostgres:
+	default:
+		errs = append(errs, fmt.Errorf("unknown accrual nonce store %q, expected memory or postgres", cfg.NonceStore))
+	}
+	if cfg.GracePeriod <= 0 {
+		errs = append(errs, fmt.Errorf("accrual grace period must be positive, got %s", cfg.GracePeriod))
+	}
+	return errs
+}
+
+func isHTTPURL(addr string) bool {
+	u, err := url.Parse(addr)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// TenantList returns the configured tenants with the accrual settings
+// defaulted to the global ones. Without a tenant list the deployment serves
+// DefaultTenant on every host.
+func (cfg *Cfg) TenantList() []Tenant {
+	if len(cfg.Tenants) == 0 {
+		return []Tenant{{
+			ID:          DefaultTenant,
+			AccrualAddr: cfg.AccrualAddr,
+			AccrualMode: cfg.AccrualMode,
+			AccrualKey:  cfg.AccrualKey,
+		}}
+	}
+	tenants := make([]Tenant, len(cfg.Tenants))
+	for i, tenant := range cfg.Tenants {
+		if tenant.AccrualAddr == "" {
+			tenant.AccrualAddr = cfg.AccrualAddr
+		}
+		if tenant.AccrualMode == "" {
+			tenant.AccrualMode = cfg.AccrualMode
+		}
+		if tenant.AccrualKey == "" {
+			tenant.AccrualKey = cfg.AccrualKey
+		}
+		tenants[i] = tenant
+	}
+	return tenants
+}
+
 func (b *ConfigBuilder) Build() (*Cfg, error) {
 	return b.cfg, b.err
 }
+
+func FilePath() string {
+	args := os.Args[1:]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg || len(arg)-len(name) > 2 {
+			continue
+		}
+		if value, ok := strings.CutPrefix(name, configFileFlag+"="); ok {
+			return value
+		}
+		if name == configFileFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv(configFileEnv)
+}
+
+func (cfg *Cfg) Redacted() *Cfg {
+	redacted := *cfg
+	if redacted.Key != "" {
+		redacted.Key = redact.Mask
+	}
+	if redacted.GRPCToken != "" {
+		redacted.GRPCToken = redact.Mask
+	}
+	if redacted.AccrualKey != "" {
+		redacted.AccrualKey = redact.Mask
+	}
+	redacted.Tenants = slices.Clone(redacted.Tenants)
+	for i := range redacted.Tenants {
+		if redacted.Tenants[i].AccrualKey != "" {
+			redacted.Tenants[i].AccrualKey = redact.Mask
+		}
+	}
+	redacted.DatabaseURI = redact.DSN(redacted.DatabaseURI)
+	return &redacted
+}
+
+func (cfg *Cfg) String() string {
+	out, err := yaml.Marshal(cfg.Redacted())
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN referral_code VARCHAR(32) UNIQUE;
+UPDATE users SET referral_code = UPPER(SUBSTRING(MD5(RANDOM()::TEXT || id::TEXT) FOR 10));
+ALTER TABLE users ALTER COLUMN referral_code SET NOT NULL;
+
+CREATE TABLE referrals (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY, 
+    referrer_id BIGINT NOT NULL REFERENCES users(id), 
+    referee_id BIGINT UNIQUE NOT NULL REFERENCES users(id), 
+    referrer_bonus DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    referee_bonus DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, 
+    rewarded_at TIMESTAMPTZ,
+    CHECK (referrer_id <> referee_id)
+);
+CREATE INDEX referrals_referrer_id_idx ON referrals (referrer_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS referrals;
+ALTER TABLE users DROP COLUMN IF EXISTS referral_code;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE transfers (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY, 
+    sender_id BIGINT NOT NULL REFERENCES users(id), 
+    recipient_id BIGINT NOT NULL REFERENCES users(id), 
+    sum DECIMAL(10, 2) NOT NULL, 
+    idempotency_key VARCHAR(255) NOT NULL, 
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, 
+    UNIQUE (sender_id, idempotency_key), 
+    CHECK (sender_id <> recipient_id), 
+    CHECK (sum > 0)
+);
+CREATE INDEX transfers_recipient_id_idx ON transfers (recipient_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS transfers;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders 
+    ADD COLUMN processed_at TIMESTAMPTZ, 
+    ADD COLUMN last_checked_at TIMESTAMPTZ, 
+    ADD COLUMN check_count INT NOT NULL DEFAULT 0;
+UPDATE orders SET processed_at = created_at WHERE status IN ('INVALID', 'PROCESSED');
+CREATE TABLE order_status_history (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY, 
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE, 
+    status VARCHAR(255) NOT NULL, 
+    accrual DECIMAL(10, 2) NOT NULL DEFAULT 0, 
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX order_status_history_order_id_idx ON order_status_history (order_id);
+INSERT INTO order_status_history (order_id, status, accrual, changed_at) 
+SELECT id, status, COALESCE(accrual, 0), created_at FROM orders;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_status_history;
+ALTER TABLE orders 
+    DROP COLUMN IF EXISTS processed_at, 
+    DROP COLUMN IF EXISTS last_checked_at, 
+    DROP COLUMN IF EXISTS check_count;
+-- +goose StatementEnd
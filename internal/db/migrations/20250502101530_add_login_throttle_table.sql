@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE login_throttle (
+    key VARCHAR(512) PRIMARY KEY, 
+    failures INT NOT NULL DEFAULT 0, 
+    last_failure TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, 
+    expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX login_throttle_expires_at_idx ON login_throttle (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS login_throttle;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN sessions_valid_after TIMESTAMPTZ;
+CREATE TABLE password_reset_tokens (
+    token_hash VARCHAR(64) PRIMARY KEY, 
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE, 
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, 
+    expires_at TIMESTAMPTZ NOT NULL, 
+    used_at TIMESTAMPTZ
+);
+CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS password_reset_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS sessions_valid_after;
+-- +goose StatementEnd
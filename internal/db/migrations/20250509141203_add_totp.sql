@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_totp (
+    user_id BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE, 
+    secret VARCHAR(64) NOT NULL, 
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP, 
+    enabled_at TIMESTAMPTZ, 
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+CREATE TABLE totp_recovery_codes (
+    id BIGINT PRIMARY KEY GENERATED ALWAYS AS IDENTITY, 
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE, 
+    code_hash VARCHAR(64) NOT NULL, 
+    used_at TIMESTAMPTZ, 
+    UNIQUE (user_id, code_hash)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS totp_recovery_codes;
+DROP TABLE IF EXISTS user_totp;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE withdrawals ADD COLUMN idempotency_key VARCHAR(255);
+CREATE UNIQUE INDEX withdrawals_user_id_idempotency_key_idx ON withdrawals (user_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS withdrawals_user_id_idempotency_key_idx;
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS idempotency_key;
+-- +goose StatementEnd
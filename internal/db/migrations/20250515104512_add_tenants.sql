@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE users ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE users DROP CONSTRAINT users_login_key;
+ALTER TABLE users ADD CONSTRAINT users_tenant_id_login_key UNIQUE (tenant_id, login);
+ALTER TABLE orders ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE orders ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE orders DROP CONSTRAINT orders_number_key;
+ALTER TABLE orders ADD CONSTRAINT orders_tenant_id_number_key UNIQUE (tenant_id, number);
+ALTER TABLE withdrawals ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+ALTER TABLE withdrawals ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE withdrawals DROP CONSTRAINT withdrawals_number_key;
+ALTER TABLE withdrawals ADD CONSTRAINT withdrawals_tenant_id_number_key UNIQUE (tenant_id, number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE withdrawals DROP CONSTRAINT IF EXISTS withdrawals_tenant_id_number_key;
+ALTER TABLE withdrawals ADD CONSTRAINT withdrawals_number_key UNIQUE (number);
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_tenant_id_number_key;
+ALTER TABLE orders ADD CONSTRAINT orders_number_key UNIQUE (number);
+ALTER TABLE orders DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_tenant_id_login_key;
+ALTER TABLE users ADD CONSTRAINT users_login_key UNIQUE (login);
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE request_nonces (
+    tenant_id VARCHAR(64) NOT NULL, 
+    nonce VARCHAR(256) NOT NULL, 
+    expires_at TIMESTAMPTZ NOT NULL, 
+    PRIMARY KEY (tenant_id, nonce)
+);
+CREATE INDEX request_nonces_expires_at_idx ON request_nonces (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS request_nonces;
+-- +goose StatementEnd
@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE withdrawals ADD CONSTRAINT withdrawals_sum_positive CHECK (sum > 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE withdrawals DROP CONSTRAINT IF EXISTS withdrawals_sum_positive;
+-- +goose StatementEnd
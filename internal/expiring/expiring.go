@@ -0,0 +1,61 @@
+package expiring
+
+import "time"
+
+const pruneInterval = 1024
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Map keeps values in process memory until they expire. Expired values are
+// invisible to Get and are dropped every pruneInterval calls of Set, so keys
+// that are never seen again don't pile up. Map isn't safe for concurrent use,
+// its owner guards it with its own lock.
+type Map[V any] struct {
+	entries map[string]entry[V]
+	sets    int
+}
+
+func NewMap[V any]() *Map[V] {
+	return &Map[V]{
+		entries: make(map[string]entry[V]),
+	}
+}
+
+// Get returns the value of the key if it hasn't expired by now.
+func (m *Map[V]) Get(key string, now time.Time) (V, bool) {
+	e, ok := m.entries[key]
+	if !ok || !now.Before(e.expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Set keeps the value of the key until expiresAt.
+func (m *Map[V]) Set(key string, value V, expiresAt, now time.Time) {
+	m.sets++
+	if m.sets%pruneInterval == 0 {
+		m.prune(now)
+	}
+	m.entries[key] = entry[V]{value: value, expiresAt: expiresAt}
+}
+
+func (m *Map[V]) Delete(key string) {
+	delete(m.entries, key)
+}
+
+// Len counts the kept values, expired ones not pruned yet included.
+func (m *Map[V]) Len() int {
+	return len(m.entries)
+}
+
+func (m *Map[V]) prune(now time.Time) {
+	for key, e := range m.entries {
+		if !now.Before(e.expiresAt) {
+			delete(m.entries, key)
+		}
+	}
+}
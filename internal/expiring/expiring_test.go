@@ -0,0 +1,55 @@
+package expiring
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring/expiringtest"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKey = "key"
+
+func TestMap(t *testing.T) {
+	clock := expiringtest.NewClock()
+	m := NewMap[int]()
+
+	t.Run("set", func(t *testing.T) {
+		m.Set(testKey, 1, clock.Now().Add(time.Minute), clock.Now())
+
+		v, ok := m.Get(testKey, clock.Now())
+		assert.True(t, ok)
+		assert.Equal(t, 1, v)
+
+		_, ok = m.Get("other", clock.Now())
+		assert.False(t, ok)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		clock.Add(time.Minute)
+
+		_, ok := m.Get(testKey, clock.Now())
+		assert.False(t, ok)
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		m.Set(testKey, 2, clock.Now().Add(time.Minute), clock.Now())
+		m.Delete(testKey)
+
+		_, ok := m.Get(testKey, clock.Now())
+		assert.False(t, ok)
+		assert.Zero(t, m.Len())
+	})
+
+	t.Run("prune", func(t *testing.T) {
+		m.Set("stale", 3, clock.Now().Add(time.Second), clock.Now())
+		clock.Add(time.Minute)
+		for i := range pruneInterval {
+			m.Set(strconv.Itoa(i), i, clock.Now().Add(time.Hour), clock.Now())
+		}
+
+		assert.NotContains(t, m.entries, "stale")
+	})
+}
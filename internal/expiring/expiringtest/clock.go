@@ -0,0 +1,24 @@
+package expiringtest
+
+import "time"
+
+// Start is the time every Clock starts at.
+var Start = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// Clock stands in for time.Now in tests of code that keeps values until
+// they expire. It only moves when told to.
+type Clock struct {
+	now time.Time
+}
+
+func NewClock() *Clock {
+	return &Clock{now: Start}
+}
+
+func (c *Clock) Now() time.Time {
+	return c.now
+}
+
+func (c *Clock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
@@ -37,19 +37,19 @@ func (h *GetBalanceHandler) handle(c *fiber.Ctx) error {
 	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	balance, err := h.getBalanceService.GetUserBalance(c.Context(), uid)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	resBody, err := json.Marshal(&balance)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 	c.Set("Content-Type", "application/json")
 	return c.Status(fiber.StatusOK).Send(resBody)
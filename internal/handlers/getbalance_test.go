@@ -11,6 +11,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/handlers/mocks"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,7 +25,7 @@ func TestGetBalanceHandler_handle(t *testing.T) {
 
 	getBalanceHandler := NewGetBalanceHandler(mService, mJWT)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Get("/", getBalanceHandler)
 
 	t.Run("valid test", func(t *testing.T) {
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getBalanceV2Servicer interface {
+	GetUserBalance(context.Context, models.UserID) (*models.Balance, error)
+}
+
+type getBalanceV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetBalanceV2Handler struct {
+	getBalanceService getBalanceV2Servicer
+	jwt               getBalanceV2JWT
+}
+
+func NewGetBalanceV2Handler(getBalanceService getBalanceV2Servicer, jwt getBalanceV2JWT) func(*fiber.Ctx) error {
+	h := &GetBalanceV2Handler{
+		getBalanceService: getBalanceService,
+		jwt:               jwt,
+	}
+	return h.handle
+}
+
+type balanceV2 struct {
+	Current   models.Amount `json:"current"`
+	Withdrawn models.Amount `json:"withdrawn"`
+}
+
+func (h *GetBalanceV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	balance, err := h.getBalanceService.GetUserBalance(c.Context(), uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(&balanceV2{
+		Current:   models.Amount(balance.Current),
+		Withdrawn: models.Amount(balance.Withdrawn),
+	})
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBalanceV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetBalanceV2Servicer(ctrl)
+	mJWT := mocks.NewMockgetBalanceV2JWT(ctrl)
+
+	getBalanceHandler := NewGetBalanceV2Handler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", getBalanceHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(&models.Balance{
+			UserID:    testUserID,
+			Current:   500.5,
+			Withdrawn: 42,
+		}, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `{"current":"500.50","withdrawn":"42.00"}`, string(body))
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(nil, errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getOrderDetailServicer interface {
+	GetUserOrder(context.Context, models.UserID, string) (*models.OrderDetail, error)
+}
+
+type getOrderDetailJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetOrderDetailHandler struct {
+	getOrderDetailService getOrderDetailServicer
+	jwt                   getOrderDetailJWT
+}
+
+func NewGetOrderDetailHandler(getOrderDetailService getOrderDetailServicer, jwt getOrderDetailJWT) func(*fiber.Ctx) error {
+	h := &GetOrderDetailHandler{
+		getOrderDetailService: getOrderDetailService,
+		jwt:                   jwt,
+	}
+	return h.handle
+}
+
+func (h *GetOrderDetailHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	order, err := h.getOrderDetailService.GetUserOrder(c.Context(), uid, c.Params("number"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(order)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrderDetailHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetOrderDetailServicer(ctrl)
+	mJWT := mocks.NewMockgetOrderDetailJWT(ctrl)
+
+	getOrderDetailHandler := NewGetOrderDetailHandler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/:number", getOrderDetailHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		testOrder := &models.OrderDetail{
+			Number:        validLuhnString,
+			Status:        models.StatusProcessed,
+			Accrual:       500,
+			CreatedAt:     time.Now().String(),
+			ProcessedAt:   time.Now().String(),
+			LastCheckedAt: time.Now().String(),
+			CheckCount:    2,
+			History: []*models.OrderStatusChange{
+				{
+					Status:    models.StatusNew,
+					ChangedAt: time.Now().String(),
+				},
+				{
+					Status:    models.StatusProcessed,
+					Accrual:   500,
+					ChangedAt: time.Now().String(),
+				},
+			},
+		}
+
+		testOrderJSON, err := json.Marshal(testOrder)
+		require.NoError(t, err)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrder(gomock.Any(), testUserID, validLuhnString).Return(testOrder, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, string(testOrderJSON), string(body))
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrOrderNotFound(ctrl)
+		mErr.EXPECT().IsErrOrderNotFound().Return(true)
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrder(gomock.Any(), testUserID, validLuhnString).Return(nil, mErr)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrder(gomock.Any(), testUserID, validLuhnString).Return(nil, errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
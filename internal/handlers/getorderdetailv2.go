@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getOrderDetailV2Servicer interface {
+	GetUserOrder(context.Context, models.UserID, string) (*models.OrderDetail, error)
+}
+
+type getOrderDetailV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetOrderDetailV2Handler struct {
+	getOrderDetailService getOrderDetailV2Servicer
+	jwt                   getOrderDetailV2JWT
+}
+
+func NewGetOrderDetailV2Handler(getOrderDetailService getOrderDetailV2Servicer, jwt getOrderDetailV2JWT) func(*fiber.Ctx) error {
+	h := &GetOrderDetailV2Handler{
+		getOrderDetailService: getOrderDetailService,
+		jwt:                   jwt,
+	}
+	return h.handle
+}
+
+type orderStatusChangeV2 struct {
+	Status    string        `json:"status"`
+	Accrual   models.Amount `json:"accrual,omitempty"`
+	ChangedAt string        `json:"changed_at"`
+}
+
+type orderDetailV2 struct {
+	Number        string                 `json:"number"`
+	Status        string                 `json:"status"`
+	Accrual       models.Amount          `json:"accrual,omitempty"`
+	UploadedAt    string                 `json:"uploaded_at"`
+	ProcessedAt   string                 `json:"processed_at,omitempty"`
+	LastCheckedAt string                 `json:"last_checked_at,omitempty"`
+	CheckCount    int                    `json:"check_count"`
+	History       []*orderStatusChangeV2 `json:"history"`
+}
+
+func newOrderDetailV2(order *models.OrderDetail) *orderDetailV2 {
+	detail := &orderDetailV2{
+		Number:        order.Number,
+		Status:        order.Status,
+		Accrual:       models.Amount(order.Accrual),
+		UploadedAt:    order.CreatedAt,
+		ProcessedAt:   order.ProcessedAt,
+		LastCheckedAt: order.LastCheckedAt,
+		CheckCount:    order.CheckCount,
+		History:       make([]*orderStatusChangeV2, 0, len(order.History)),
+	}
+	for _, change := range order.History {
+		detail.History = append(detail.History, &orderStatusChangeV2{
+			Status:    change.Status,
+			Accrual:   models.Amount(change.Accrual),
+			ChangedAt: change.ChangedAt,
+		})
+	}
+	return detail
+}
+
+func (h *GetOrderDetailV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	order, err := h.getOrderDetailService.GetUserOrder(c.Context(), uid, c.Params("number"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(newOrderDetailV2(order))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
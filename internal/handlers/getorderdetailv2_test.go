@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrderDetailV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetOrderDetailV2Servicer(ctrl)
+	mJWT := mocks.NewMockgetOrderDetailV2JWT(ctrl)
+
+	getOrderDetailHandler := NewGetOrderDetailV2Handler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/:number", getOrderDetailHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrder(gomock.Any(), testUserID, validLuhnString).Return(&models.OrderDetail{
+			ID:          1,
+			Number:      validLuhnString,
+			UserID:      testUserID,
+			Status:      models.StatusProcessed,
+			Accrual:     729.98,
+			CreatedAt:   "2025-04-01T10:00:00Z",
+			ProcessedAt: "2025-04-01T10:05:00Z",
+			CheckCount:  2,
+			History: []*models.OrderStatusChange{
+				{Status: models.StatusNew, ChangedAt: "2025-04-01T10:00:00Z"},
+				{Status: models.StatusProcessed, Accrual: 729.98, ChangedAt: "2025-04-01T10:05:00Z"},
+			},
+		}, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `{
+			"number":"4512812345678909",
+			"status":"PROCESSED",
+			"accrual":"729.98",
+			"uploaded_at":"2025-04-01T10:00:00Z",
+			"processed_at":"2025-04-01T10:05:00Z",
+			"check_count":2,
+			"history":[
+				{"status":"NEW","changed_at":"2025-04-01T10:00:00Z"},
+				{"status":"PROCESSED","accrual":"729.98","changed_at":"2025-04-01T10:05:00Z"}
+			]
+		}`, string(body))
+	})
+
+	t.Run("order not found error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrOrderNotFound(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrOrderNotFound().Return(true)
+		mService.EXPECT().GetUserOrder(gomock.Any(), testUserID, validLuhnString).Return(nil, mErr)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrder(gomock.Any(), testUserID, validLuhnString).Return(nil, errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/"+validLuhnString, nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -42,7 +42,7 @@ func (h *GetOrdersHandler) handle(c *fiber.Ctx) error {
 	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	orders, err := h.getOrderService.GetUserOrders(c.Context(), uid)
@@ -52,13 +52,13 @@ func (h *GetOrdersHandler) handle(c *fiber.Ctx) error {
 	}
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	resBody, err := json.Marshal(&orders)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 	c.Set("Content-Type", "application/json")
 	return c.Status(fiber.StatusOK).Send(resBody)
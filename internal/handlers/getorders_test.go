@@ -12,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/handlers/mocks"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -25,7 +26,7 @@ func TestGetOrderHandler_handle(t *testing.T) {
 
 	getOrdersHandler := NewGetOrdersHandler(mService, mJWT)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Get("/", getOrdersHandler)
 
 	t.Run("valid test", func(t *testing.T) {
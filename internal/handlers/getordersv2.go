@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getOrdersV2Servicer interface {
+	GetUserOrdersPage(context.Context, models.UserID, models.Page) ([]*models.OrderDB, bool, error)
+}
+
+type getOrdersV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetOrdersV2Handler struct {
+	getOrdersService getOrdersV2Servicer
+	jwt              getOrdersV2JWT
+}
+
+func NewGetOrdersV2Handler(getOrdersService getOrdersV2Servicer, jwt getOrdersV2JWT) func(*fiber.Ctx) error {
+	h := &GetOrdersV2Handler{
+		getOrdersService: getOrdersService,
+		jwt:              jwt,
+	}
+	return h.handle
+}
+
+type orderV2 struct {
+	Number     string        `json:"number"`
+	Status     string        `json:"status"`
+	Accrual    models.Amount `json:"accrual,omitempty"`
+	UploadedAt string        `json:"uploaded_at"`
+}
+
+func newOrderV2(order *models.OrderDB) *orderV2 {
+	return &orderV2{
+		Number:     order.Number,
+		Status:     order.Status,
+		Accrual:    models.Amount(order.Accrual),
+		UploadedAt: order.CreatedAt,
+	}
+}
+
+func (h *GetOrdersV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	orders, more, err := h.getOrdersService.GetUserOrdersPage(c.Context(), uid, page)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(newPageResponse(orders, more, page, newOrderV2))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOrdersV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetOrdersV2Servicer(ctrl)
+	mJWT := mocks.NewMockgetOrdersV2JWT(ctrl)
+
+	getOrdersHandler := NewGetOrdersV2Handler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", getOrdersHandler)
+
+	testOrders := []*models.OrderDB{
+		{
+			ID:        1,
+			Number:    "123",
+			UserID:    testUserID,
+			Status:    models.StatusProcessed,
+			Accrual:   500.5,
+			CreatedAt: "2025-04-02T10:00:00Z",
+		},
+		{
+			ID:        2,
+			Number:    "456",
+			UserID:    testUserID,
+			Status:    models.StatusNew,
+			CreatedAt: "2025-04-01T10:00:00Z",
+		},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrdersPage(gomock.Any(), testUserID, models.Page{Limit: 2, Offset: 4}).Return(testOrders, true, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?limit=2&offset=4", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `{
+			"items":[
+				{"number":"123","status":"PROCESSED","accrual":"500.50","uploaded_at":"2025-04-02T10:00:00Z"},
+				{"number":"456","status":"NEW","uploaded_at":"2025-04-01T10:00:00Z"}
+			],
+			"next_offset":6
+		}`, string(body))
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrdersPage(gomock.Any(), testUserID, models.Page{Limit: defaultPageLimit}).Return(nil, false, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `{"items":[]}`, string(body))
+	})
+
+	t.Run("wrong page", func(t *testing.T) {
+		for _, query := range []string{"limit=0", "limit=101", "limit=abc", "offset=-1", "offset=abc"} {
+			mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+			request := httptest.NewRequest(fiber.MethodGet, "/?"+query, nil)
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+			res, err := app.Test(request, -1)
+			require.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, query)
+		}
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserOrdersPage(gomock.Any(), testUserID, gomock.Any()).Return(nil, false, errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getReferralsServicer interface {
+	GetUserReferrals(context.Context, models.UserID) (*models.Referrals, error)
+}
+
+type getReferralsJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetReferralsHandler struct {
+	getReferralsService getReferralsServicer
+	jwt                 getReferralsJWT
+}
+
+func NewGetReferralsHandler(getReferralsService getReferralsServicer, jwt getReferralsJWT) func(*fiber.Ctx) error {
+	h := &GetReferralsHandler{
+		getReferralsService: getReferralsService,
+		jwt:                 jwt,
+	}
+	return h.handle
+}
+
+func (h *GetReferralsHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	referrals, err := h.getReferralsService.GetUserReferrals(c.Context(), uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(&referrals)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
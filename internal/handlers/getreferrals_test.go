@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReferralsHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetReferralsServicer(ctrl)
+	mJWT := mocks.NewMockgetReferralsJWT(ctrl)
+
+	getReferralsHandler := NewGetReferralsHandler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", getReferralsHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		testReferrals := &models.Referrals{
+			Code:   "ABCDEFGHIJ",
+			Earned: 100,
+			Invitees: []*models.Invitee{
+				{
+					Login:        "invitee",
+					Bonus:        100,
+					Rewarded:     true,
+					RegisteredAt: time.Now().String(),
+				},
+			},
+		}
+
+		testReferralsJSON, err := json.Marshal(&testReferrals)
+		require.NoError(t, err)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserReferrals(gomock.Any(), testUserID).Return(testReferrals, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, string(testReferralsJSON), string(body))
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().GetUserReferrals(gomock.Any(), testUserID).Return(nil, errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getReferralsV2Servicer interface {
+	GetUserReferrals(context.Context, models.UserID) (*models.Referrals, error)
+}
+
+type getReferralsV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetReferralsV2Handler struct {
+	getReferralsService getReferralsV2Servicer
+	jwt                 getReferralsV2JWT
+}
+
+func NewGetReferralsV2Handler(getReferralsService getReferralsV2Servicer, jwt getReferralsV2JWT) func(*fiber.Ctx) error {
+	h := &GetReferralsV2Handler{
+		getReferralsService: getReferralsService,
+		jwt:                 jwt,
+	}
+	return h.handle
+}
+
+type inviteeV2 struct {
+	Login        string        `json:"login"`
+	Bonus        models.Amount `json:"bonus"`
+	Rewarded     bool          `json:"rewarded"`
+	RegisteredAt string        `json:"registered_at"`
+}
+
+type referralsV2 struct {
+	Code     string        `json:"referral_code"`
+	Earned   models.Amount `json:"earned"`
+	Invitees []*inviteeV2  `json:"invitees"`
+}
+
+func newReferralsV2(referrals *models.Referrals) *referralsV2 {
+	res := &referralsV2{
+		Code:     referrals.Code,
+		Earned:   models.Amount(referrals.Earned),
+		Invitees: make([]*inviteeV2, 0, len(referrals.Invitees)),
+	}
+	for _, invitee := range referrals.Invitees {
+		res.Invitees = append(res.Invitees, &inviteeV2{
+			Login:        invitee.Login,
+			Bonus:        models.Amount(invitee.Bonus),
+			Rewarded:     invitee.Rewarded,
+			RegisteredAt: invitee.RegisteredAt,
+		})
+	}
+	return res
+}
+
+func (h *GetReferralsV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	referrals, err := h.getReferralsService.GetUserReferrals(c.Context(), uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(newReferralsV2(referrals))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
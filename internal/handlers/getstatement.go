@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+const (
+	statementFormatJSON = "json"
+	statementFormatCSV  = "csv"
+	statementDateLayout = "2006-01-02"
+)
+
+var errWrongStatementPeriod = errors.New("wrong statement period")
+
+type getStatementServicer interface {
+	StreamStatement(context.Context, models.UserID, time.Time, time.Time, func(float64) error, func(*models.StatementEntry) error) (float64, error)
+}
+
+type getStatementJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+// GetStatementHandler streams the statement after the handler returns, so
+// the request timeout doesn't cover it. timeout bounds the stream instead.
+type GetStatementHandler struct {
+	getStatementService getStatementServicer
+	jwt                 getStatementJWT
+	timeout             time.Duration
+	quoteAmounts        bool
+}
+
+func NewGetStatementHandler(getStatementService getStatementServicer, jwt getStatementJWT, timeout time.Duration) func(*fiber.Ctx) error {
+	h := &GetStatementHandler{
+		getStatementService: getStatementService,
+		jwt:                 jwt,
+		timeout:             timeout,
+	}
+	return h.handle
+}
+
+// NewGetStatementV2Handler serves the same statement as NewGetStatementHandler
+// but encodes JSON amounts as decimal strings.
+func NewGetStatementV2Handler(getStatementService getStatementServicer, jwt getStatementJWT, timeout time.Duration) func(*fiber.Ctx) error {
+	h := &GetStatementHandler{
+		getStatementService: getStatementService,
+		jwt:                 jwt,
+		timeout:             timeout,
+		quoteAmounts:        true,
+	}
+	return h.handle
+}
+
+func (h *GetStatementHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	from, to, err := parseStatementPeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	format := c.Query("format", statementFormatJSON)
+	var contentType string
+	switch format {
+	case statementFormatJSON:
+		contentType = "application/json"
+	case statementFormatCSV:
+		contentType = "text/csv"
+	default:
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	path := c.Path()
+	c.Set("Content-Type", contentType)
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+		defer cancel()
+
+		var sw statementWriter
+		if format == statementFormatCSV {
+			sw = newCSVStatementWriter(w)
+		} else {
+			sw = newJSONStatementWriter(w, h.quoteAmounts)
+		}
+		err := h.writeStatement(ctx, sw, uid, from, to)
+		if err != nil {
+			logger.Log.Debug("path:"+path, zap.Error(err))
+			// The status is already sent, so the error goes at the end of
+			// the body, where clients see it instead of the closing balance.
+			sw.writeError(statementErrorCode(err))
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// writeStatement stops at the first failed write, which ends the database
+// read together with the stream.
+func (h *GetStatementHandler) writeStatement(ctx context.Context, sw statementWriter, uid models.UserID, from, to time.Time) error {
+	closing, err := h.getStatementService.StreamStatement(ctx, uid, from, to, func(opening float64) error {
+		return sw.writeOpening(from, to, opening)
+	}, sw.writeEntry)
+	if err != nil {
+		return err
+	}
+	return sw.writeClosing(to, closing)
+}
+
+func statementErrorCode(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return problem.CodeTimeout
+	}
+	return problem.CodeInternal
+}
+
+func parseStatementPeriod(fromParam, toParam string) (time.Time, time.Time, error) {
+	from := time.Unix(0, 0).UTC()
+	to := time.Now().UTC()
+	var err error
+	if fromParam != "" {
+		from, _, err = parseStatementTime(fromParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if toParam != "" {
+		var dateOnly bool
+		to, dateOnly, err = parseStatementTime(toParam)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, errWrongStatementPeriod
+	}
+	return from, to, nil
+}
+
+func parseStatementTime(value string) (time.Time, bool, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, false, nil
+	}
+	t, err = time.Parse(statementDateLayout, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("%w: %v", errWrongStatementPeriod, err)
+	}
+	return t, true, nil
+}
+
+type statementWriter interface {
+	writeOpening(time.Time, time.Time, float64) error
+	writeEntry(*models.StatementEntry) error
+	writeClosing(time.Time, float64) error
+	writeError(string) error
+}
+
+type jsonStatementWriter struct {
+	w            io.Writer
+	opened       bool
+	entries      int
+	quoteAmounts bool
+}
+
+type statementEntryV2 struct {
+	Date      string        `json:"date"`
+	Type      string        `json:"type"`
+	Reference string        `json:"reference"`
+	Amount    models.Amount `json:"amount"`
+	Balance   models.Amount `json:"balance"`
+}
+
+func newJSONStatementWriter(w io.Writer, quoteAmounts bool) *jsonStatementWriter {
+	return &jsonStatementWriter{
+		w:            w,
+		quoteAmounts: quoteAmounts,
+	}
+}
+
+func (sw *jsonStatementWriter) amount(v float64) string {
+	if sw.quoteAmounts {
+		return strconv.Quote(formatAmount(v))
+	}
+	return formatAmount(v)
+}
+
+func (sw *jsonStatementWriter) writeOpening(from, to time.Time, balance float64) error {
+	sw.opened = true
+	_, err := fmt.Fprintf(sw.w, `{"from":%q,"to":%q,"opening_balance":%s,"entries":[`,
+		from.Format(time.RFC3339), to.Format(time.RFC3339), sw.amount(balance))
+	return err
+}
+
+func (sw *jsonStatementWriter) writeEntry(entry *models.StatementEntry) error {
+	var line []byte
+	var err error
+	if sw.quoteAmounts {
+		line, err = json.Marshal(&statementEntryV2{
+			Date:      entry.Date,
+			Type:      entry.Type,
+			Reference: entry.Reference,
+			Amount:    models.Amount(entry.Amount),
+			Balance:   models.Amount(entry.Balance),
+		})
+	} else {
+		line, err = json.Marshal(entry)
+	}
+	if err != nil {
+		return err
+	}
+	if sw.entries > 0 {
+		_, err = io.WriteString(sw.w, ",")
+		if err != nil {
+			return err
+		}
+	}
+	sw.entries++
+	_, err = sw.w.Write(line)
+	return err
+}
+
+func (sw *jsonStatementWriter) writeClosing(_ time.Time, balance float64) error {
+	_, err := fmt.Fprintf(sw.w, `],"closing_balance":%s}`, sw.amount(balance))
+	return err
+}
+
+// writeError ends the document with an error member in place of the
+// closing balance.
+func (sw *jsonStatementWriter) writeError(code string) error {
+	if !sw.opened {
+		_, err := fmt.Fprintf(sw.w, `{"error":%q}`, code)
+		return err
+	}
+	_, err := fmt.Fprintf(sw.w, `],"error":%q}`, code)
+	return err
+}
+
+type csvStatementWriter struct {
+	w *csv.Writer
+}
+
+func newCSVStatementWriter(w io.Writer) *csvStatementWriter {
+	return &csvStatementWriter{w: csv.NewWriter(w)}
+}
+
+func (sw *csvStatementWriter) writeOpening(from, _ time.Time, balance float64) error {
+	err := sw.w.Write([]string{"date", "type", "reference", "amount", "balance"})
+	if err != nil {
+		return err
+	}
+	return sw.w.Write([]string{from.Format(time.RFC3339), "opening_balance", "", "", formatAmount(balance)})
+}
+
+func (sw *csvStatementWriter) writeEntry(entry *models.StatementEntry) error {
+	return sw.w.Write([]string{entry.Date, entry.Type, entry.Reference, formatAmount(entry.Amount), formatAmount(entry.Balance)})
+}
+
+func (sw *csvStatementWriter) writeClosing(to time.Time, balance float64) error {
+	err := sw.w.Write([]string{to.Format(time.RFC3339), "closing_balance", "", "", formatAmount(balance)})
+	if err != nil {
+		return err
+	}
+	sw.w.Flush()
+	return sw.w.Error()
+}
+
+// writeError ends the statement with an error row in place of the closing
+// balance.
+func (sw *csvStatementWriter) writeError(code string) error {
+	err := sw.w.Write([]string{"", "error", code, "", ""})
+	if err != nil {
+		return err
+	}
+	sw.w.Flush()
+	return sw.w.Error()
+}
+
+func formatAmount(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testStatementTimeout = time.Duration(100) * time.Millisecond
+
+func TestGetStatementHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetStatementServicer(ctrl)
+	mJWT := mocks.NewMockgetStatementJWT(ctrl)
+
+	getStatementHandler := NewGetStatementHandler(mService, mJWT, testStatementTimeout)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", getStatementHandler)
+
+	testFrom := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	testTo := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	testEntries := []*models.StatementEntry{
+		{
+			Date:      "2025-04-02T10:00:00Z",
+			Type:      models.EntryAccrual,
+			Reference: "12345678903",
+			Amount:    500,
+			Balance:   600,
+		},
+		{
+			Date:      "2025-04-03T10:00:00Z",
+			Type:      models.EntryWithdrawal,
+			Reference: "2377225624",
+			Amount:    -150.5,
+			Balance:   449.5,
+		},
+	}
+	streamEntries := func(_ context.Context, _ models.UserID, _, _ time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) (float64, error) {
+		err := opening(100)
+		if err != nil {
+			return 0, err
+		}
+		for _, entry := range testEntries {
+			err = fn(entry)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return 449.5, nil
+	}
+	failAfterEntries := func(_ context.Context, _ models.UserID, _, _ time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) (float64, error) {
+		err := opening(100)
+		if err != nil {
+			return 0, err
+		}
+		err = fn(testEntries[0])
+		if err != nil {
+			return 0, err
+		}
+		return 0, errTest
+	}
+
+	t.Run("valid json test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(streamEntries)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=2025-04-01&to=2025-04-30", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"from":"2025-04-01T00:00:00Z",
+			"to":"2025-05-01T00:00:00Z",
+			"opening_balance":100.00,
+			"entries":[
+				{"date":"2025-04-02T10:00:00Z","type":"accrual","reference":"12345678903","amount":500,"balance":600},
+				{"date":"2025-04-03T10:00:00Z","type":"withdrawal","reference":"2377225624","amount":-150.5,"balance":449.5}
+			],
+			"closing_balance":449.50
+		}`, string(body))
+	})
+
+	t.Run("valid csv test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(streamEntries)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=2025-04-01T00:00:00Z&to=2025-05-01T00:00:00Z&format=csv", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/csv", res.Header.Get("Content-Type"))
+		assert.Equal(t, "date,type,reference,amount,balance\n"+
+			"2025-04-01T00:00:00Z,opening_balance,,,100.00\n"+
+			"2025-04-02T10:00:00Z,accrual,12345678903,500.00,600.00\n"+
+			"2025-04-03T10:00:00Z,withdrawal,2377225624,-150.50,449.50\n"+
+			"2025-05-01T00:00:00Z,closing_balance,,,449.50\n", string(body))
+	})
+
+	t.Run("wrong format error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?format=xml", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("wrong period error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=2025-05-01&to=2025-04-01", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("wrong date error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=yesterday", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(float64(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"internal"}`, string(body))
+	})
+
+	t.Run("json stream error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(failAfterEntries)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=2025-04-01&to=2025-04-30", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"from":"2025-04-01T00:00:00Z",
+			"to":"2025-05-01T00:00:00Z",
+			"opening_balance":100.00,
+			"entries":[
+				{"date":"2025-04-02T10:00:00Z","type":"accrual","reference":"12345678903","amount":500,"balance":600}
+			],
+			"error":"internal"
+		}`, string(body))
+	})
+
+	t.Run("csv stream error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(failAfterEntries)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=2025-04-01&to=2025-04-30&format=csv", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "date,type,reference,amount,balance\n"+
+			"2025-04-01T00:00:00Z,opening_balance,,,100.00\n"+
+			"2025-04-02T10:00:00Z,accrual,12345678903,500.00,600.00\n"+
+			",error,internal,,\n", string(body))
+	})
+
+	t.Run("stream timeout", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ models.UserID, _, _ time.Time, _ func(float64) error, _ func(*models.StatementEntry) error) (float64, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			})
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"error":"request.timeout"}`, string(body))
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
+
+func TestGetStatementV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockgetStatementServicer(ctrl)
+	mJWT := mocks.NewMockgetStatementJWT(ctrl)
+
+	getStatementHandler := NewGetStatementV2Handler(mService, mJWT, testStatementTimeout)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", getStatementHandler)
+
+	testFrom := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	testTo := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("valid json test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().StreamStatement(gomock.Any(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ models.UserID, _, _ time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) (float64, error) {
+				err := opening(100)
+				if err != nil {
+					return 0, err
+				}
+				err = fn(&models.StatementEntry{
+					Date:      "2025-04-03T10:00:00Z",
+					Type:      models.EntryWithdrawal,
+					Reference: "2377225624",
+					Amount:    -150.5,
+					Balance:   -50.5,
+				})
+				return -50.5, err
+			})
+
+		request := httptest.NewRequest(fiber.MethodGet, "/?from=2025-04-01&to=2025-04-30", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `{
+			"from":"2025-04-01T00:00:00Z",
+			"to":"2025-05-01T00:00:00Z",
+			"opening_balance":"100.00",
+			"entries":[
+				{"date":"2025-04-03T10:00:00Z","type":"withdrawal","reference":"2377225624","amount":"-150.50","balance":"-50.50"}
+			],
+			"closing_balance":"-50.50"
+		}`, string(body))
+	})
+}
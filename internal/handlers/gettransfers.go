@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getTransfersServicer interface {
+	GetUserTransfers(context.Context, models.UserID) ([]*models.TransferRecord, error)
+}
+
+type getTransfersJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetTransfersHandler struct {
+	getTransferService getTransfersServicer
+	jwt                getTransfersJWT
+}
+
+func NewGetTransfersHandler(getTransferService getTransfersServicer, jwt getTransfersJWT) func(*fiber.Ctx) error {
+	h := &GetTransfersHandler{
+		getTransferService: getTransferService,
+		jwt:                jwt,
+	}
+	return h.handle
+}
+
+type errNoTransfer interface {
+	error
+	IsErrNoTransfer() bool
+}
+
+func (h *GetTransfersHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	transfers, err := h.getTransferService.GetUserTransfers(c.Context(), uid)
+	if e, ok := err.(errNoTransfer); ok && e.IsErrNoTransfer() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(&transfers)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getTransfersV2Servicer interface {
+	GetUserTransfersPage(context.Context, models.UserID, models.Page) ([]*models.TransferRecord, bool, error)
+}
+
+type getTransfersV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetTransfersV2Handler struct {
+	getTransfersService getTransfersV2Servicer
+	jwt                 getTransfersV2JWT
+}
+
+func NewGetTransfersV2Handler(getTransfersService getTransfersV2Servicer, jwt getTransfersV2JWT) func(*fiber.Ctx) error {
+	h := &GetTransfersV2Handler{
+		getTransfersService: getTransfersService,
+		jwt:                 jwt,
+	}
+	return h.handle
+}
+
+type transferV2 struct {
+	Direction   string        `json:"direction"`
+	Login       string        `json:"login"`
+	Sum         models.Amount `json:"sum"`
+	ProcessedAt string        `json:"processed_at"`
+}
+
+func newTransferV2(transfer *models.TransferRecord) *transferV2 {
+	return &transferV2{
+		Direction:   transfer.Direction,
+		Login:       transfer.Counterparty,
+		Sum:         models.Amount(transfer.Sum),
+		ProcessedAt: transfer.ProcessedAt,
+	}
+}
+
+func (h *GetTransfersV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	transfers, more, err := h.getTransfersService.GetUserTransfersPage(c.Context(), uid, page)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(newPageResponse(transfers, more, page, newTransferV2))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type getWithdrawalsV2Servicer interface {
+	GetUserWithdrawalsPage(context.Context, models.UserID, models.Page) ([]*models.Withdrawal, bool, error)
+}
+
+type getWithdrawalsV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type GetWithdrawalsV2Handler struct {
+	getWithdrawalsService getWithdrawalsV2Servicer
+	jwt                   getWithdrawalsV2JWT
+}
+
+func NewGetWithdrawalsV2Handler(getWithdrawalsService getWithdrawalsV2Servicer, jwt getWithdrawalsV2JWT) func(*fiber.Ctx) error {
+	h := &GetWithdrawalsV2Handler{
+		getWithdrawalsService: getWithdrawalsService,
+		jwt:                   jwt,
+	}
+	return h.handle
+}
+
+type withdrawalV2 struct {
+	Order       string        `json:"order"`
+	Sum         models.Amount `json:"sum"`
+	ProcessedAt string        `json:"processed_at"`
+}
+
+func newWithdrawalV2(withdrawal *models.Withdrawal) *withdrawalV2 {
+	return &withdrawalV2{
+		Order:       withdrawal.Order,
+		Sum:         models.Amount(withdrawal.Sum),
+		ProcessedAt: withdrawal.ProcessedAt,
+	}
+}
+
+func (h *GetWithdrawalsV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	page, err := parsePage(c)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	withdrawals, more, err := h.getWithdrawalsService.GetUserWithdrawalsPage(c.Context(), uid, page)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(newPageResponse(withdrawals, more, page, newWithdrawalV2))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type healthSchemaVersioner interface {
+	SchemaVersion(context.Context) (int64, error)
+}
+
+type HealthHandler struct {
+	schema healthSchemaVersioner
+}
+
+func NewHealthHandler(schema healthSchemaVersioner) func(*fiber.Ctx) error {
+	h := &HealthHandler{
+		schema: schema,
+	}
+	return h.handle
+}
+
+func (h *HealthHandler) handle(c *fiber.Ctx) error {
+	health := &models.Health{
+		Status: models.HealthOK,
+	}
+	status := fiber.StatusOK
+
+	version, err := h.schema.SchemaVersion(c.Context())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		health.Status = models.HealthUnavailable
+		status = fiber.StatusServiceUnavailable
+	}
+	health.SchemaVersion = version
+
+	resBody, err := json.Marshal(health)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(status).Send(resBody)
+}
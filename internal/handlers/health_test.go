@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSchema := mocks.NewMockhealthSchemaVersioner(ctrl)
+
+	healthHandler := NewHealthHandler(mSchema)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", healthHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		mSchema.EXPECT().SchemaVersion(gomock.Any()).Return(int64(20250428153040), nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, `{"status":"ok","schema_version":20250428153040}`, string(body))
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mSchema.EXPECT().SchemaVersion(gomock.Any()).Return(int64(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusServiceUnavailable, res.StatusCode)
+		assert.JSONEq(t, `{"status":"unavailable"}`, string(body))
+	})
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rycln/loyalsys/internal/logger"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
 	"go.uber.org/zap"
 )
 
@@ -19,17 +23,32 @@ type loginServicer interface {
 
 type loginJWT interface {
 	NewJWTString(models.UserID) (string, error)
+	NewChallengeString(models.UserID) (string, error)
+}
+
+type loginTwoFactor interface {
+	IsEnabled(context.Context, models.UserID) (bool, error)
+}
+
+type loginThrottler interface {
+	Check(context.Context, string, string) (time.Duration, error)
+	Success(context.Context, string, string) error
+	Release(context.Context, string, string) error
 }
 
 type LoginHandler struct {
 	loginService loginServicer
 	jwt          loginJWT
+	throttler    loginThrottler
+	twoFactor    loginTwoFactor
 }
 
-func NewLoginHandler(loginService loginServicer, jwt loginJWT) func(*fiber.Ctx) error {
+func NewLoginHandler(loginService loginServicer, jwt loginJWT, throttler loginThrottler, twoFactor loginTwoFactor) func(*fiber.Ctx) error {
 	h := &LoginHandler{
 		loginService: loginService,
 		jwt:          jwt,
+		throttler:    throttler,
+		twoFactor:    twoFactor,
 	}
 	return h.handle
 }
@@ -49,34 +68,82 @@ func (h *LoginHandler) handle(c *fiber.Ctx) error {
 	err := json.Unmarshal(c.Body(), &user)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusBadRequest)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
 	}
 	err = user.Validate()
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusBadRequest)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	retry, err := h.throttler.Check(c.Context(), user.Login, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	if retry > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+		return problem.New(fiber.StatusTooManyRequests, problem.CodeThrottled)
 	}
 
 	uid, err := h.loginService.UserAuth(c.Context(), &user)
 	if e, ok := err.(errNoUser); ok && e.IsErrNoUser() {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusUnauthorized)
+		return err
 	}
 	if e, ok := err.(errWrongPassword); ok && e.IsErrWrongPassword() {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusUnauthorized)
+		return err
 	}
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		h.release(c, user.Login)
+		return err
+	}
+
+	err = h.throttler.Success(c.Context(), user.Login, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	}
+
+	enabled, err := h.twoFactor.IsEnabled(c.Context(), uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	if enabled {
+		return h.challenge(c, uid)
 	}
 
 	jwt, err := h.jwt.NewJWTString(uid)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 	c.Set("Content-Type", "application/json")
 	c.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
 	return c.SendStatus(fiber.StatusOK)
 }
+
+func (h *LoginHandler) release(c *fiber.Ctx, login string) {
+	err := h.throttler.Release(c.Context(), login, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	}
+}
+
+func (h *LoginHandler) challenge(c *fiber.Ctx, uid models.UserID) error {
+	token, err := h.jwt.NewChallengeString(uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(&models.LoginChallenge{ChallengeToken: token})
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusAccepted).Send(resBody)
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/handlers/mocks"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,10 +22,12 @@ func TestLoginHandler_handle(t *testing.T) {
 
 	mService := mocks.NewMockloginServicer(ctrl)
 	mJWT := mocks.NewMockloginJWT(ctrl)
+	mThrottler := mocks.NewMockloginThrottler(ctrl)
+	mTwoFactor := mocks.NewMockloginTwoFactor(ctrl)
 
-	loginHandler := NewLoginHandler(mService, mJWT)
+	loginHandler := NewLoginHandler(mService, mJWT, mThrottler, mTwoFactor)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Post("/", loginHandler)
 
 	t.Run("valid test", func(t *testing.T) {
@@ -31,7 +35,10 @@ func TestLoginHandler_handle(t *testing.T) {
 			Login:    testUserLogin,
 			Password: testUserPassword,
 		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
 		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(testUserID, nil)
+		mThrottler.EXPECT().Success(gomock.Any(), testUser.Login, gomock.Any()).Return(nil)
+		mTwoFactor.EXPECT().IsEnabled(gomock.Any(), testUserID).Return(false, nil)
 		mJWT.EXPECT().NewJWTString(testUserID).Return(testJWTString, nil)
 
 		body, err := json.Marshal(testUser)
@@ -49,6 +56,56 @@ func TestLoginHandler_handle(t *testing.T) {
 		assert.Contains(t, res.Header.Get("Authorization"), testJWTString)
 	})
 
+	t.Run("two factor challenge", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    testUserLogin,
+			Password: testUserPassword,
+		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(testUserID, nil)
+		mThrottler.EXPECT().Success(gomock.Any(), testUser.Login, gomock.Any()).Return(nil)
+		mTwoFactor.EXPECT().IsEnabled(gomock.Any(), testUserID).Return(true, nil)
+		mJWT.EXPECT().NewChallengeString(testUserID).Return(testJWTString, nil)
+
+		body, err := json.Marshal(testUser)
+		require.NoError(t, err)
+		bodyReader := bytes.NewReader(body)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusAccepted, res.StatusCode)
+		assert.Empty(t, res.Header.Get("Authorization"))
+		var challenge models.LoginChallenge
+		err = json.NewDecoder(res.Body).Decode(&challenge)
+		require.NoError(t, err)
+		assert.Equal(t, testJWTString, challenge.ChallengeToken)
+	})
+
+	t.Run("two factor error", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    testUserLogin,
+			Password: testUserPassword,
+		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(testUserID, nil)
+		mThrottler.EXPECT().Success(gomock.Any(), testUser.Login, gomock.Any()).Return(nil)
+		mTwoFactor.EXPECT().IsEnabled(gomock.Any(), testUserID).Return(false, errTest)
+
+		body, err := json.Marshal(testUser)
+		require.NoError(t, err)
+		bodyReader := bytes.NewReader(body)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
 	t.Run("wrong json body", func(t *testing.T) {
 		body := "wrong json string"
 		bodyReader := bytes.NewReader([]byte(body))
@@ -68,7 +125,8 @@ func TestLoginHandler_handle(t *testing.T) {
 		}
 
 		mErr := mocks.NewMockerrNoUser(ctrl)
-		mErr.EXPECT().IsErrNoUser().Return(true)
+		mErr.EXPECT().IsErrNoUser().Return(true).Times(2)
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
 		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(models.UserID(0), mErr)
 
 		body, err := json.Marshal(testUser)
@@ -89,7 +147,8 @@ func TestLoginHandler_handle(t *testing.T) {
 			Password: testUserPassword,
 		}
 		mErr := mocks.NewMockerrWrongPassword(ctrl)
-		mErr.EXPECT().IsErrWrongPassword().Return(true)
+		mErr.EXPECT().IsErrWrongPassword().Return(true).Times(2)
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
 		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(models.UserID(0), mErr)
 
 		body, err := json.Marshal(testUser)
@@ -109,7 +168,9 @@ func TestLoginHandler_handle(t *testing.T) {
 			Login:    testUserLogin,
 			Password: testUserPassword,
 		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
 		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(models.UserID(0), errTest)
+		mThrottler.EXPECT().Release(gomock.Any(), testUser.Login, gomock.Any()).Return(nil)
 
 		body, err := json.Marshal(testUser)
 		require.NoError(t, err)
@@ -146,7 +207,10 @@ func TestLoginHandler_handle(t *testing.T) {
 			Login:    testUserLogin,
 			Password: testUserPassword,
 		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), nil)
 		mService.EXPECT().UserAuth(gomock.Any(), testUser).Return(testUserID, nil)
+		mThrottler.EXPECT().Success(gomock.Any(), testUser.Login, gomock.Any()).Return(nil)
+		mTwoFactor.EXPECT().IsEnabled(gomock.Any(), testUserID).Return(false, nil)
 		mJWT.EXPECT().NewJWTString(testUserID).Return("", errTest)
 
 		body, err := json.Marshal(testUser)
@@ -160,4 +224,43 @@ func TestLoginHandler_handle(t *testing.T) {
 
 		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
 	})
+
+	t.Run("throttled", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    testUserLogin,
+			Password: testUserPassword,
+		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(1500)*time.Millisecond, nil)
+
+		body, err := json.Marshal(testUser)
+		require.NoError(t, err)
+		bodyReader := bytes.NewReader(body)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	t.Run("throttle error", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    testUserLogin,
+			Password: testUserPassword,
+		}
+		mThrottler.EXPECT().Check(gomock.Any(), testUser.Login, gomock.Any()).Return(time.Duration(0), errTest)
+
+		body, err := json.Marshal(testUser)
+		require.NoError(t, err)
+		bodyReader := bytes.NewReader(body)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
 }
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getbalancev2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetBalanceV2Servicer is a mock of getBalanceV2Servicer interface.
+type MockgetBalanceV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetBalanceV2ServicerMockRecorder
+}
+
+// MockgetBalanceV2ServicerMockRecorder is the mock recorder for MockgetBalanceV2Servicer.
+type MockgetBalanceV2ServicerMockRecorder struct {
+	mock *MockgetBalanceV2Servicer
+}
+
+// NewMockgetBalanceV2Servicer creates a new mock instance.
+func NewMockgetBalanceV2Servicer(ctrl *gomock.Controller) *MockgetBalanceV2Servicer {
+	mock := &MockgetBalanceV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockgetBalanceV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetBalanceV2Servicer) EXPECT() *MockgetBalanceV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserBalance mocks base method.
+func (m *MockgetBalanceV2Servicer) GetUserBalance(arg0 context.Context, arg1 models.UserID) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalance", arg0, arg1)
+	ret0, _ := ret[0].(*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalance indicates an expected call of GetUserBalance.
+func (mr *MockgetBalanceV2ServicerMockRecorder) GetUserBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockgetBalanceV2Servicer)(nil).GetUserBalance), arg0, arg1)
+}
+
+// MockgetBalanceV2JWT is a mock of getBalanceV2JWT interface.
+type MockgetBalanceV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetBalanceV2JWTMockRecorder
+}
+
+// MockgetBalanceV2JWTMockRecorder is the mock recorder for MockgetBalanceV2JWT.
+type MockgetBalanceV2JWTMockRecorder struct {
+	mock *MockgetBalanceV2JWT
+}
+
+// NewMockgetBalanceV2JWT creates a new mock instance.
+func NewMockgetBalanceV2JWT(ctrl *gomock.Controller) *MockgetBalanceV2JWT {
+	mock := &MockgetBalanceV2JWT{ctrl: ctrl}
+	mock.recorder = &MockgetBalanceV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetBalanceV2JWT) EXPECT() *MockgetBalanceV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetBalanceV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetBalanceV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetBalanceV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getorderdetail.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetOrderDetailServicer is a mock of getOrderDetailServicer interface.
+type MockgetOrderDetailServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetOrderDetailServicerMockRecorder
+}
+
+// MockgetOrderDetailServicerMockRecorder is the mock recorder for MockgetOrderDetailServicer.
+type MockgetOrderDetailServicerMockRecorder struct {
+	mock *MockgetOrderDetailServicer
+}
+
+// NewMockgetOrderDetailServicer creates a new mock instance.
+func NewMockgetOrderDetailServicer(ctrl *gomock.Controller) *MockgetOrderDetailServicer {
+	mock := &MockgetOrderDetailServicer{ctrl: ctrl}
+	mock.recorder = &MockgetOrderDetailServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetOrderDetailServicer) EXPECT() *MockgetOrderDetailServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserOrder mocks base method.
+func (m *MockgetOrderDetailServicer) GetUserOrder(arg0 context.Context, arg1 models.UserID, arg2 string) (*models.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrder indicates an expected call of GetUserOrder.
+func (mr *MockgetOrderDetailServicerMockRecorder) GetUserOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrder", reflect.TypeOf((*MockgetOrderDetailServicer)(nil).GetUserOrder), arg0, arg1, arg2)
+}
+
+// MockgetOrderDetailJWT is a mock of getOrderDetailJWT interface.
+type MockgetOrderDetailJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetOrderDetailJWTMockRecorder
+}
+
+// MockgetOrderDetailJWTMockRecorder is the mock recorder for MockgetOrderDetailJWT.
+type MockgetOrderDetailJWTMockRecorder struct {
+	mock *MockgetOrderDetailJWT
+}
+
+// NewMockgetOrderDetailJWT creates a new mock instance.
+func NewMockgetOrderDetailJWT(ctrl *gomock.Controller) *MockgetOrderDetailJWT {
+	mock := &MockgetOrderDetailJWT{ctrl: ctrl}
+	mock.recorder = &MockgetOrderDetailJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetOrderDetailJWT) EXPECT() *MockgetOrderDetailJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetOrderDetailJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetOrderDetailJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetOrderDetailJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
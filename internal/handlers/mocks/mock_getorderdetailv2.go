@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getorderdetailv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetOrderDetailV2Servicer is a mock of getOrderDetailV2Servicer interface.
+type MockgetOrderDetailV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetOrderDetailV2ServicerMockRecorder
+}
+
+// MockgetOrderDetailV2ServicerMockRecorder is the mock recorder for MockgetOrderDetailV2Servicer.
+type MockgetOrderDetailV2ServicerMockRecorder struct {
+	mock *MockgetOrderDetailV2Servicer
+}
+
+// NewMockgetOrderDetailV2Servicer creates a new mock instance.
+func NewMockgetOrderDetailV2Servicer(ctrl *gomock.Controller) *MockgetOrderDetailV2Servicer {
+	mock := &MockgetOrderDetailV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockgetOrderDetailV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetOrderDetailV2Servicer) EXPECT() *MockgetOrderDetailV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserOrder mocks base method.
+func (m *MockgetOrderDetailV2Servicer) GetUserOrder(arg0 context.Context, arg1 models.UserID, arg2 string) (*models.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrder", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserOrder indicates an expected call of GetUserOrder.
+func (mr *MockgetOrderDetailV2ServicerMockRecorder) GetUserOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrder", reflect.TypeOf((*MockgetOrderDetailV2Servicer)(nil).GetUserOrder), arg0, arg1, arg2)
+}
+
+// MockgetOrderDetailV2JWT is a mock of getOrderDetailV2JWT interface.
+type MockgetOrderDetailV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetOrderDetailV2JWTMockRecorder
+}
+
+// MockgetOrderDetailV2JWTMockRecorder is the mock recorder for MockgetOrderDetailV2JWT.
+type MockgetOrderDetailV2JWTMockRecorder struct {
+	mock *MockgetOrderDetailV2JWT
+}
+
+// NewMockgetOrderDetailV2JWT creates a new mock instance.
+func NewMockgetOrderDetailV2JWT(ctrl *gomock.Controller) *MockgetOrderDetailV2JWT {
+	mock := &MockgetOrderDetailV2JWT{ctrl: ctrl}
+	mock.recorder = &MockgetOrderDetailV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetOrderDetailV2JWT) EXPECT() *MockgetOrderDetailV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetOrderDetailV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetOrderDetailV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetOrderDetailV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getordersv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetOrdersV2Servicer is a mock of getOrdersV2Servicer interface.
+type MockgetOrdersV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetOrdersV2ServicerMockRecorder
+}
+
+// MockgetOrdersV2ServicerMockRecorder is the mock recorder for MockgetOrdersV2Servicer.
+type MockgetOrdersV2ServicerMockRecorder struct {
+	mock *MockgetOrdersV2Servicer
+}
+
+// NewMockgetOrdersV2Servicer creates a new mock instance.
+func NewMockgetOrdersV2Servicer(ctrl *gomock.Controller) *MockgetOrdersV2Servicer {
+	mock := &MockgetOrdersV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockgetOrdersV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetOrdersV2Servicer) EXPECT() *MockgetOrdersV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserOrdersPage mocks base method.
+func (m *MockgetOrdersV2Servicer) GetUserOrdersPage(arg0 context.Context, arg1 models.UserID, arg2 models.Page) ([]*models.OrderDB, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrdersPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.OrderDB)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserOrdersPage indicates an expected call of GetUserOrdersPage.
+func (mr *MockgetOrdersV2ServicerMockRecorder) GetUserOrdersPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrdersPage", reflect.TypeOf((*MockgetOrdersV2Servicer)(nil).GetUserOrdersPage), arg0, arg1, arg2)
+}
+
+// MockgetOrdersV2JWT is a mock of getOrdersV2JWT interface.
+type MockgetOrdersV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetOrdersV2JWTMockRecorder
+}
+
+// MockgetOrdersV2JWTMockRecorder is the mock recorder for MockgetOrdersV2JWT.
+type MockgetOrdersV2JWTMockRecorder struct {
+	mock *MockgetOrdersV2JWT
+}
+
+// NewMockgetOrdersV2JWT creates a new mock instance.
+func NewMockgetOrdersV2JWT(ctrl *gomock.Controller) *MockgetOrdersV2JWT {
+	mock := &MockgetOrdersV2JWT{ctrl: ctrl}
+	mock.recorder = &MockgetOrdersV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetOrdersV2JWT) EXPECT() *MockgetOrdersV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetOrdersV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetOrdersV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetOrdersV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getreferrals.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetReferralsServicer is a mock of getReferralsServicer interface.
+type MockgetReferralsServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetReferralsServicerMockRecorder
+}
+
+// MockgetReferralsServicerMockRecorder is the mock recorder for MockgetReferralsServicer.
+type MockgetReferralsServicerMockRecorder struct {
+	mock *MockgetReferralsServicer
+}
+
+// NewMockgetReferralsServicer creates a new mock instance.
+func NewMockgetReferralsServicer(ctrl *gomock.Controller) *MockgetReferralsServicer {
+	mock := &MockgetReferralsServicer{ctrl: ctrl}
+	mock.recorder = &MockgetReferralsServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetReferralsServicer) EXPECT() *MockgetReferralsServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserReferrals mocks base method.
+func (m *MockgetReferralsServicer) GetUserReferrals(arg0 context.Context, arg1 models.UserID) (*models.Referrals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReferrals", arg0, arg1)
+	ret0, _ := ret[0].(*models.Referrals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReferrals indicates an expected call of GetUserReferrals.
+func (mr *MockgetReferralsServicerMockRecorder) GetUserReferrals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReferrals", reflect.TypeOf((*MockgetReferralsServicer)(nil).GetUserReferrals), arg0, arg1)
+}
+
+// MockgetReferralsJWT is a mock of getReferralsJWT interface.
+type MockgetReferralsJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetReferralsJWTMockRecorder
+}
+
+// MockgetReferralsJWTMockRecorder is the mock recorder for MockgetReferralsJWT.
+type MockgetReferralsJWTMockRecorder struct {
+	mock *MockgetReferralsJWT
+}
+
+// NewMockgetReferralsJWT creates a new mock instance.
+func NewMockgetReferralsJWT(ctrl *gomock.Controller) *MockgetReferralsJWT {
+	mock := &MockgetReferralsJWT{ctrl: ctrl}
+	mock.recorder = &MockgetReferralsJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetReferralsJWT) EXPECT() *MockgetReferralsJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetReferralsJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetReferralsJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetReferralsJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
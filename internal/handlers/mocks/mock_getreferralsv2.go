@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getreferralsv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetReferralsV2Servicer is a mock of getReferralsV2Servicer interface.
+type MockgetReferralsV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetReferralsV2ServicerMockRecorder
+}
+
+// MockgetReferralsV2ServicerMockRecorder is the mock recorder for MockgetReferralsV2Servicer.
+type MockgetReferralsV2ServicerMockRecorder struct {
+	mock *MockgetReferralsV2Servicer
+}
+
+// NewMockgetReferralsV2Servicer creates a new mock instance.
+func NewMockgetReferralsV2Servicer(ctrl *gomock.Controller) *MockgetReferralsV2Servicer {
+	mock := &MockgetReferralsV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockgetReferralsV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetReferralsV2Servicer) EXPECT() *MockgetReferralsV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserReferrals mocks base method.
+func (m *MockgetReferralsV2Servicer) GetUserReferrals(arg0 context.Context, arg1 models.UserID) (*models.Referrals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserReferrals", arg0, arg1)
+	ret0, _ := ret[0].(*models.Referrals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserReferrals indicates an expected call of GetUserReferrals.
+func (mr *MockgetReferralsV2ServicerMockRecorder) GetUserReferrals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserReferrals", reflect.TypeOf((*MockgetReferralsV2Servicer)(nil).GetUserReferrals), arg0, arg1)
+}
+
+// MockgetReferralsV2JWT is a mock of getReferralsV2JWT interface.
+type MockgetReferralsV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetReferralsV2JWTMockRecorder
+}
+
+// MockgetReferralsV2JWTMockRecorder is the mock recorder for MockgetReferralsV2JWT.
+type MockgetReferralsV2JWTMockRecorder struct {
+	mock *MockgetReferralsV2JWT
+}
+
+// NewMockgetReferralsV2JWT creates a new mock instance.
+func NewMockgetReferralsV2JWT(ctrl *gomock.Controller) *MockgetReferralsV2JWT {
+	mock := &MockgetReferralsV2JWT{ctrl: ctrl}
+	mock.recorder = &MockgetReferralsV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetReferralsV2JWT) EXPECT() *MockgetReferralsV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetReferralsV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetReferralsV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetReferralsV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
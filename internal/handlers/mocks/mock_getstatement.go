@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getstatement.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetStatementServicer is a mock of getStatementServicer interface.
+type MockgetStatementServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetStatementServicerMockRecorder
+}
+
+// MockgetStatementServicerMockRecorder is the mock recorder for MockgetStatementServicer.
+type MockgetStatementServicerMockRecorder struct {
+	mock *MockgetStatementServicer
+}
+
+// NewMockgetStatementServicer creates a new mock instance.
+func NewMockgetStatementServicer(ctrl *gomock.Controller) *MockgetStatementServicer {
+	mock := &MockgetStatementServicer{ctrl: ctrl}
+	mock.recorder = &MockgetStatementServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetStatementServicer) EXPECT() *MockgetStatementServicerMockRecorder {
+	return m.recorder
+}
+
+// StreamStatement mocks base method.
+func (m *MockgetStatementServicer) StreamStatement(arg0 context.Context, arg1 models.UserID, arg2, arg3 time.Time, arg4 func(float64) error, arg5 func(*models.StatementEntry) error) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamStatement", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamStatement indicates an expected call of StreamStatement.
+func (mr *MockgetStatementServicerMockRecorder) StreamStatement(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamStatement", reflect.TypeOf((*MockgetStatementServicer)(nil).StreamStatement), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// MockgetStatementJWT is a mock of getStatementJWT interface.
+type MockgetStatementJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetStatementJWTMockRecorder
+}
+
+// MockgetStatementJWTMockRecorder is the mock recorder for MockgetStatementJWT.
+type MockgetStatementJWTMockRecorder struct {
+	mock *MockgetStatementJWT
+}
+
+// NewMockgetStatementJWT creates a new mock instance.
+func NewMockgetStatementJWT(ctrl *gomock.Controller) *MockgetStatementJWT {
+	mock := &MockgetStatementJWT{ctrl: ctrl}
+	mock.recorder = &MockgetStatementJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetStatementJWT) EXPECT() *MockgetStatementJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetStatementJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetStatementJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetStatementJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
+
+// MockstatementWriter is a mock of statementWriter interface.
+type MockstatementWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatementWriterMockRecorder
+}
+
+// MockstatementWriterMockRecorder is the mock recorder for MockstatementWriter.
+type MockstatementWriterMockRecorder struct {
+	mock *MockstatementWriter
+}
+
+// NewMockstatementWriter creates a new mock instance.
+func NewMockstatementWriter(ctrl *gomock.Controller) *MockstatementWriter {
+	mock := &MockstatementWriter{ctrl: ctrl}
+	mock.recorder = &MockstatementWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatementWriter) EXPECT() *MockstatementWriterMockRecorder {
+	return m.recorder
+}
+
+// writeClosing mocks base method.
+func (m *MockstatementWriter) writeClosing(arg0 time.Time, arg1 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "writeClosing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// writeClosing indicates an expected call of writeClosing.
+func (mr *MockstatementWriterMockRecorder) writeClosing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "writeClosing", reflect.TypeOf((*MockstatementWriter)(nil).writeClosing), arg0, arg1)
+}
+
+// writeEntry mocks base method.
+func (m *MockstatementWriter) writeEntry(arg0 *models.StatementEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "writeEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// writeEntry indicates an expected call of writeEntry.
+func (mr *MockstatementWriterMockRecorder) writeEntry(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "writeEntry", reflect.TypeOf((*MockstatementWriter)(nil).writeEntry), arg0)
+}
+
+// writeError mocks base method.
+func (m *MockstatementWriter) writeError(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "writeError", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// writeError indicates an expected call of writeError.
+func (mr *MockstatementWriterMockRecorder) writeError(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "writeError", reflect.TypeOf((*MockstatementWriter)(nil).writeError), arg0)
+}
+
+// writeOpening mocks base method.
+func (m *MockstatementWriter) writeOpening(arg0, arg1 time.Time, arg2 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "writeOpening", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// writeOpening indicates an expected call of writeOpening.
+func (mr *MockstatementWriterMockRecorder) writeOpening(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "writeOpening", reflect.TypeOf((*MockstatementWriter)(nil).writeOpening), arg0, arg1, arg2)
+}
@@ -0,0 +1,140 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gettransfers.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetTransfersServicer is a mock of getTransfersServicer interface.
+type MockgetTransfersServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetTransfersServicerMockRecorder
+}
+
+// MockgetTransfersServicerMockRecorder is the mock recorder for MockgetTransfersServicer.
+type MockgetTransfersServicerMockRecorder struct {
+	mock *MockgetTransfersServicer
+}
+
+// NewMockgetTransfersServicer creates a new mock instance.
+func NewMockgetTransfersServicer(ctrl *gomock.Controller) *MockgetTransfersServicer {
+	mock := &MockgetTransfersServicer{ctrl: ctrl}
+	mock.recorder = &MockgetTransfersServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetTransfersServicer) EXPECT() *MockgetTransfersServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserTransfers mocks base method.
+func (m *MockgetTransfersServicer) GetUserTransfers(arg0 context.Context, arg1 models.UserID) ([]*models.TransferRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]*models.TransferRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTransfers indicates an expected call of GetUserTransfers.
+func (mr *MockgetTransfersServicerMockRecorder) GetUserTransfers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransfers", reflect.TypeOf((*MockgetTransfersServicer)(nil).GetUserTransfers), arg0, arg1)
+}
+
+// MockgetTransfersJWT is a mock of getTransfersJWT interface.
+type MockgetTransfersJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetTransfersJWTMockRecorder
+}
+
+// MockgetTransfersJWTMockRecorder is the mock recorder for MockgetTransfersJWT.
+type MockgetTransfersJWTMockRecorder struct {
+	mock *MockgetTransfersJWT
+}
+
+// NewMockgetTransfersJWT creates a new mock instance.
+func NewMockgetTransfersJWT(ctrl *gomock.Controller) *MockgetTransfersJWT {
+	mock := &MockgetTransfersJWT{ctrl: ctrl}
+	mock.recorder = &MockgetTransfersJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetTransfersJWT) EXPECT() *MockgetTransfersJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetTransfersJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetTransfersJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetTransfersJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
+
+// MockerrNoTransfer is a mock of errNoTransfer interface.
+type MockerrNoTransfer struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrNoTransferMockRecorder
+}
+
+// MockerrNoTransferMockRecorder is the mock recorder for MockerrNoTransfer.
+type MockerrNoTransferMockRecorder struct {
+	mock *MockerrNoTransfer
+}
+
+// NewMockerrNoTransfer creates a new mock instance.
+func NewMockerrNoTransfer(ctrl *gomock.Controller) *MockerrNoTransfer {
+	mock := &MockerrNoTransfer{ctrl: ctrl}
+	mock.recorder = &MockerrNoTransferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrNoTransfer) EXPECT() *MockerrNoTransferMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrNoTransfer) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrNoTransferMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrNoTransfer)(nil).Error))
+}
+
+// IsErrNoTransfer mocks base method.
+func (m *MockerrNoTransfer) IsErrNoTransfer() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNoTransfer")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNoTransfer indicates an expected call of IsErrNoTransfer.
+func (mr *MockerrNoTransferMockRecorder) IsErrNoTransfer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNoTransfer", reflect.TypeOf((*MockerrNoTransfer)(nil).IsErrNoTransfer))
+}
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: gettransfersv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetTransfersV2Servicer is a mock of getTransfersV2Servicer interface.
+type MockgetTransfersV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetTransfersV2ServicerMockRecorder
+}
+
+// MockgetTransfersV2ServicerMockRecorder is the mock recorder for MockgetTransfersV2Servicer.
+type MockgetTransfersV2ServicerMockRecorder struct {
+	mock *MockgetTransfersV2Servicer
+}
+
+// NewMockgetTransfersV2Servicer creates a new mock instance.
+func NewMockgetTransfersV2Servicer(ctrl *gomock.Controller) *MockgetTransfersV2Servicer {
+	mock := &MockgetTransfersV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockgetTransfersV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetTransfersV2Servicer) EXPECT() *MockgetTransfersV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserTransfersPage mocks base method.
+func (m *MockgetTransfersV2Servicer) GetUserTransfersPage(arg0 context.Context, arg1 models.UserID, arg2 models.Page) ([]*models.TransferRecord, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTransfersPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.TransferRecord)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserTransfersPage indicates an expected call of GetUserTransfersPage.
+func (mr *MockgetTransfersV2ServicerMockRecorder) GetUserTransfersPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTransfersPage", reflect.TypeOf((*MockgetTransfersV2Servicer)(nil).GetUserTransfersPage), arg0, arg1, arg2)
+}
+
+// MockgetTransfersV2JWT is a mock of getTransfersV2JWT interface.
+type MockgetTransfersV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetTransfersV2JWTMockRecorder
+}
+
+// MockgetTransfersV2JWTMockRecorder is the mock recorder for MockgetTransfersV2JWT.
+type MockgetTransfersV2JWTMockRecorder struct {
+	mock *MockgetTransfersV2JWT
+}
+
+// NewMockgetTransfersV2JWT creates a new mock instance.
+func NewMockgetTransfersV2JWT(ctrl *gomock.Controller) *MockgetTransfersV2JWT {
+	mock := &MockgetTransfersV2JWT{ctrl: ctrl}
+	mock.recorder = &MockgetTransfersV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetTransfersV2JWT) EXPECT() *MockgetTransfersV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetTransfersV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetTransfersV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetTransfersV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: getwithdrawalsv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockgetWithdrawalsV2Servicer is a mock of getWithdrawalsV2Servicer interface.
+type MockgetWithdrawalsV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetWithdrawalsV2ServicerMockRecorder
+}
+
+// MockgetWithdrawalsV2ServicerMockRecorder is the mock recorder for MockgetWithdrawalsV2Servicer.
+type MockgetWithdrawalsV2ServicerMockRecorder struct {
+	mock *MockgetWithdrawalsV2Servicer
+}
+
+// NewMockgetWithdrawalsV2Servicer creates a new mock instance.
+func NewMockgetWithdrawalsV2Servicer(ctrl *gomock.Controller) *MockgetWithdrawalsV2Servicer {
+	mock := &MockgetWithdrawalsV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockgetWithdrawalsV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetWithdrawalsV2Servicer) EXPECT() *MockgetWithdrawalsV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserWithdrawalsPage mocks base method.
+func (m *MockgetWithdrawalsV2Servicer) GetUserWithdrawalsPage(arg0 context.Context, arg1 models.UserID, arg2 models.Page) ([]*models.Withdrawal, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserWithdrawalsPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.Withdrawal)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserWithdrawalsPage indicates an expected call of GetUserWithdrawalsPage.
+func (mr *MockgetWithdrawalsV2ServicerMockRecorder) GetUserWithdrawalsPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserWithdrawalsPage", reflect.TypeOf((*MockgetWithdrawalsV2Servicer)(nil).GetUserWithdrawalsPage), arg0, arg1, arg2)
+}
+
+// MockgetWithdrawalsV2JWT is a mock of getWithdrawalsV2JWT interface.
+type MockgetWithdrawalsV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockgetWithdrawalsV2JWTMockRecorder
+}
+
+// MockgetWithdrawalsV2JWTMockRecorder is the mock recorder for MockgetWithdrawalsV2JWT.
+type MockgetWithdrawalsV2JWTMockRecorder struct {
+	mock *MockgetWithdrawalsV2JWT
+}
+
+// NewMockgetWithdrawalsV2JWT creates a new mock instance.
+func NewMockgetWithdrawalsV2JWT(ctrl *gomock.Controller) *MockgetWithdrawalsV2JWT {
+	mock := &MockgetWithdrawalsV2JWT{ctrl: ctrl}
+	mock.recorder = &MockgetWithdrawalsV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockgetWithdrawalsV2JWT) EXPECT() *MockgetWithdrawalsV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockgetWithdrawalsV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockgetWithdrawalsV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockgetWithdrawalsV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: health.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockhealthSchemaVersioner is a mock of healthSchemaVersioner interface.
+type MockhealthSchemaVersioner struct {
+	ctrl     *gomock.Controller
+	recorder *MockhealthSchemaVersionerMockRecorder
+}
+
+// MockhealthSchemaVersionerMockRecorder is the mock recorder for MockhealthSchemaVersioner.
+type MockhealthSchemaVersionerMockRecorder struct {
+	mock *MockhealthSchemaVersioner
+}
+
+// NewMockhealthSchemaVersioner creates a new mock instance.
+func NewMockhealthSchemaVersioner(ctrl *gomock.Controller) *MockhealthSchemaVersioner {
+	mock := &MockhealthSchemaVersioner{ctrl: ctrl}
+	mock.recorder = &MockhealthSchemaVersionerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockhealthSchemaVersioner) EXPECT() *MockhealthSchemaVersionerMockRecorder {
+	return m.recorder
+}
+
+// SchemaVersion mocks base method.
+func (m *MockhealthSchemaVersioner) SchemaVersion(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaVersion", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaVersion indicates an expected call of SchemaVersion.
+func (mr *MockhealthSchemaVersionerMockRecorder) SchemaVersion(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaVersion", reflect.TypeOf((*MockhealthSchemaVersioner)(nil).SchemaVersion), arg0)
+}
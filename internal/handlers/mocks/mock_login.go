@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/rycln/loyalsys/internal/models"
@@ -73,6 +74,21 @@ func (m *MockloginJWT) EXPECT() *MockloginJWTMockRecorder {
 	return m.recorder
 }
 
+// NewChallengeString mocks base method.
+func (m *MockloginJWT) NewChallengeString(arg0 models.UserID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewChallengeString", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewChallengeString indicates an expected call of NewChallengeString.
+func (mr *MockloginJWTMockRecorder) NewChallengeString(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewChallengeString", reflect.TypeOf((*MockloginJWT)(nil).NewChallengeString), arg0)
+}
+
 // NewJWTString mocks base method.
 func (m *MockloginJWT) NewJWTString(arg0 models.UserID) (string, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +104,110 @@ func (mr *MockloginJWTMockRecorder) NewJWTString(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewJWTString", reflect.TypeOf((*MockloginJWT)(nil).NewJWTString), arg0)
 }
 
+// MockloginTwoFactor is a mock of loginTwoFactor interface.
+type MockloginTwoFactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginTwoFactorMockRecorder
+}
+
+// MockloginTwoFactorMockRecorder is the mock recorder for MockloginTwoFactor.
+type MockloginTwoFactorMockRecorder struct {
+	mock *MockloginTwoFactor
+}
+
+// NewMockloginTwoFactor creates a new mock instance.
+func NewMockloginTwoFactor(ctrl *gomock.Controller) *MockloginTwoFactor {
+	mock := &MockloginTwoFactor{ctrl: ctrl}
+	mock.recorder = &MockloginTwoFactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginTwoFactor) EXPECT() *MockloginTwoFactorMockRecorder {
+	return m.recorder
+}
+
+// IsEnabled mocks base method.
+func (m *MockloginTwoFactor) IsEnabled(arg0 context.Context, arg1 models.UserID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsEnabled", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsEnabled indicates an expected call of IsEnabled.
+func (mr *MockloginTwoFactorMockRecorder) IsEnabled(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsEnabled", reflect.TypeOf((*MockloginTwoFactor)(nil).IsEnabled), arg0, arg1)
+}
+
+// MockloginThrottler is a mock of loginThrottler interface.
+type MockloginThrottler struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginThrottlerMockRecorder
+}
+
+// MockloginThrottlerMockRecorder is the mock recorder for MockloginThrottler.
+type MockloginThrottlerMockRecorder struct {
+	mock *MockloginThrottler
+}
+
+// NewMockloginThrottler creates a new mock instance.
+func NewMockloginThrottler(ctrl *gomock.Controller) *MockloginThrottler {
+	mock := &MockloginThrottler{ctrl: ctrl}
+	mock.recorder = &MockloginThrottlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginThrottler) EXPECT() *MockloginThrottlerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockloginThrottler) Check(arg0 context.Context, arg1, arg2 string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockloginThrottlerMockRecorder) Check(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockloginThrottler)(nil).Check), arg0, arg1, arg2)
+}
+
+// Release mocks base method.
+func (m *MockloginThrottler) Release(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockloginThrottlerMockRecorder) Release(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockloginThrottler)(nil).Release), arg0, arg1, arg2)
+}
+
+// Success mocks base method.
+func (m *MockloginThrottler) Success(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Success", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Success indicates an expected call of Success.
+func (mr *MockloginThrottlerMockRecorder) Success(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Success", reflect.TypeOf((*MockloginThrottler)(nil).Success), arg0, arg1, arg2)
+}
+
 // MockerrNoUser is a mock of errNoUser interface.
 type MockerrNoUser struct {
 	ctrl     *gomock.Controller
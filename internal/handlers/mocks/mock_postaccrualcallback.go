@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postaccrualcallback.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockaccrualPusher is a mock of accrualPusher interface.
+type MockaccrualPusher struct {
+	ctrl     *gomock.Controller
+	recorder *MockaccrualPusherMockRecorder
+}
+
+// MockaccrualPusherMockRecorder is the mock recorder for MockaccrualPusher.
+type MockaccrualPusherMockRecorder struct {
+	mock *MockaccrualPusher
+}
+
+// NewMockaccrualPusher creates a new mock instance.
+func NewMockaccrualPusher(ctrl *gomock.Controller) *MockaccrualPusher {
+	mock := &MockaccrualPusher{ctrl: ctrl}
+	mock.recorder = &MockaccrualPusherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockaccrualPusher) EXPECT() *MockaccrualPusherMockRecorder {
+	return m.recorder
+}
+
+// Push mocks base method.
+func (m *MockaccrualPusher) Push(arg0 context.Context, arg1 []*models.OrderAccrual) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockaccrualPusherMockRecorder) Push(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockaccrualPusher)(nil).Push), arg0, arg1)
+}
@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postlogin2fa.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostLogin2FAServicer is a mock of postLogin2FAServicer interface.
+type MockpostLogin2FAServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostLogin2FAServicerMockRecorder
+}
+
+// MockpostLogin2FAServicerMockRecorder is the mock recorder for MockpostLogin2FAServicer.
+type MockpostLogin2FAServicerMockRecorder struct {
+	mock *MockpostLogin2FAServicer
+}
+
+// NewMockpostLogin2FAServicer creates a new mock instance.
+func NewMockpostLogin2FAServicer(ctrl *gomock.Controller) *MockpostLogin2FAServicer {
+	mock := &MockpostLogin2FAServicer{ctrl: ctrl}
+	mock.recorder = &MockpostLogin2FAServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostLogin2FAServicer) EXPECT() *MockpostLogin2FAServicerMockRecorder {
+	return m.recorder
+}
+
+// VerifyLogin mocks base method.
+func (m *MockpostLogin2FAServicer) VerifyLogin(arg0 context.Context, arg1 models.UserID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLogin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyLogin indicates an expected call of VerifyLogin.
+func (mr *MockpostLogin2FAServicerMockRecorder) VerifyLogin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockpostLogin2FAServicer)(nil).VerifyLogin), arg0, arg1, arg2)
+}
+
+// MockpostLogin2FAJWT is a mock of postLogin2FAJWT interface.
+type MockpostLogin2FAJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostLogin2FAJWTMockRecorder
+}
+
+// MockpostLogin2FAJWTMockRecorder is the mock recorder for MockpostLogin2FAJWT.
+type MockpostLogin2FAJWTMockRecorder struct {
+	mock *MockpostLogin2FAJWT
+}
+
+// NewMockpostLogin2FAJWT creates a new mock instance.
+func NewMockpostLogin2FAJWT(ctrl *gomock.Controller) *MockpostLogin2FAJWT {
+	mock := &MockpostLogin2FAJWT{ctrl: ctrl}
+	mock.recorder = &MockpostLogin2FAJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostLogin2FAJWT) EXPECT() *MockpostLogin2FAJWTMockRecorder {
+	return m.recorder
+}
+
+// NewJWTString mocks base method.
+func (m *MockpostLogin2FAJWT) NewJWTString(arg0 models.UserID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewJWTString", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewJWTString indicates an expected call of NewJWTString.
+func (mr *MockpostLogin2FAJWTMockRecorder) NewJWTString(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewJWTString", reflect.TypeOf((*MockpostLogin2FAJWT)(nil).NewJWTString), arg0)
+}
+
+// ParseChallenge mocks base method.
+func (m *MockpostLogin2FAJWT) ParseChallenge(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseChallenge", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseChallenge indicates an expected call of ParseChallenge.
+func (mr *MockpostLogin2FAJWTMockRecorder) ParseChallenge(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseChallenge", reflect.TypeOf((*MockpostLogin2FAJWT)(nil).ParseChallenge), arg0)
+}
+
+// MockerrWrongTOTPCode is a mock of errWrongTOTPCode interface.
+type MockerrWrongTOTPCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWrongTOTPCodeMockRecorder
+}
+
+// MockerrWrongTOTPCodeMockRecorder is the mock recorder for MockerrWrongTOTPCode.
+type MockerrWrongTOTPCodeMockRecorder struct {
+	mock *MockerrWrongTOTPCode
+}
+
+// NewMockerrWrongTOTPCode creates a new mock instance.
+func NewMockerrWrongTOTPCode(ctrl *gomock.Controller) *MockerrWrongTOTPCode {
+	mock := &MockerrWrongTOTPCode{ctrl: ctrl}
+	mock.recorder = &MockerrWrongTOTPCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWrongTOTPCode) EXPECT() *MockerrWrongTOTPCodeMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWrongTOTPCode) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWrongTOTPCodeMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongTOTPCode)(nil).Error))
+}
+
+// IsErrWrongTOTPCode mocks base method.
+func (m *MockerrWrongTOTPCode) IsErrWrongTOTPCode() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongTOTPCode")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongTOTPCode indicates an expected call of IsErrWrongTOTPCode.
+func (mr *MockerrWrongTOTPCodeMockRecorder) IsErrWrongTOTPCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongTOTPCode", reflect.TypeOf((*MockerrWrongTOTPCode)(nil).IsErrWrongTOTPCode))
+}
+
+// MockerrTOTPNotEnrolled is a mock of errTOTPNotEnrolled interface.
+type MockerrTOTPNotEnrolled struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTOTPNotEnrolledMockRecorder
+}
+
+// MockerrTOTPNotEnrolledMockRecorder is the mock recorder for MockerrTOTPNotEnrolled.
+type MockerrTOTPNotEnrolledMockRecorder struct {
+	mock *MockerrTOTPNotEnrolled
+}
+
+// NewMockerrTOTPNotEnrolled creates a new mock instance.
+func NewMockerrTOTPNotEnrolled(ctrl *gomock.Controller) *MockerrTOTPNotEnrolled {
+	mock := &MockerrTOTPNotEnrolled{ctrl: ctrl}
+	mock.recorder = &MockerrTOTPNotEnrolledMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTOTPNotEnrolled) EXPECT() *MockerrTOTPNotEnrolledMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTOTPNotEnrolled) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTOTPNotEnrolledMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTOTPNotEnrolled)(nil).Error))
+}
+
+// IsErrTOTPNotEnrolled mocks base method.
+func (m *MockerrTOTPNotEnrolled) IsErrTOTPNotEnrolled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTOTPNotEnrolled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTOTPNotEnrolled indicates an expected call of IsErrTOTPNotEnrolled.
+func (mr *MockerrTOTPNotEnrolledMockRecorder) IsErrTOTPNotEnrolled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTOTPNotEnrolled", reflect.TypeOf((*MockerrTOTPNotEnrolled)(nil).IsErrTOTPNotEnrolled))
+}
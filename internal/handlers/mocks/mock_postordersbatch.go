@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postordersbatch.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostOrdersBatchServicer is a mock of postOrdersBatchServicer interface.
+type MockpostOrdersBatchServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostOrdersBatchServicerMockRecorder
+}
+
+// MockpostOrdersBatchServicerMockRecorder is the mock recorder for MockpostOrdersBatchServicer.
+type MockpostOrdersBatchServicerMockRecorder struct {
+	mock *MockpostOrdersBatchServicer
+}
+
+// NewMockpostOrdersBatchServicer creates a new mock instance.
+func NewMockpostOrdersBatchServicer(ctrl *gomock.Controller) *MockpostOrdersBatchServicer {
+	mock := &MockpostOrdersBatchServicer{ctrl: ctrl}
+	mock.recorder = &MockpostOrdersBatchServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostOrdersBatchServicer) EXPECT() *MockpostOrdersBatchServicerMockRecorder {
+	return m.recorder
+}
+
+// SaveOrdersBatch mocks base method.
+func (m *MockpostOrdersBatchServicer) SaveOrdersBatch(arg0 context.Context, arg1 models.UserID, arg2 []string) ([]*models.OrderBatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrdersBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.OrderBatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveOrdersBatch indicates an expected call of SaveOrdersBatch.
+func (mr *MockpostOrdersBatchServicerMockRecorder) SaveOrdersBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrdersBatch", reflect.TypeOf((*MockpostOrdersBatchServicer)(nil).SaveOrdersBatch), arg0, arg1, arg2)
+}
+
+// MockpostOrdersBatchJWT is a mock of postOrdersBatchJWT interface.
+type MockpostOrdersBatchJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostOrdersBatchJWTMockRecorder
+}
+
+// MockpostOrdersBatchJWTMockRecorder is the mock recorder for MockpostOrdersBatchJWT.
+type MockpostOrdersBatchJWTMockRecorder struct {
+	mock *MockpostOrdersBatchJWT
+}
+
+// NewMockpostOrdersBatchJWT creates a new mock instance.
+func NewMockpostOrdersBatchJWT(ctrl *gomock.Controller) *MockpostOrdersBatchJWT {
+	mock := &MockpostOrdersBatchJWT{ctrl: ctrl}
+	mock.recorder = &MockpostOrdersBatchJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostOrdersBatchJWT) EXPECT() *MockpostOrdersBatchJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostOrdersBatchJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostOrdersBatchJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostOrdersBatchJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
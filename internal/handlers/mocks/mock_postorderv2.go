@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postorderv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostOrderV2Servicer is a mock of postOrderV2Servicer interface.
+type MockpostOrderV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostOrderV2ServicerMockRecorder
+}
+
+// MockpostOrderV2ServicerMockRecorder is the mock recorder for MockpostOrderV2Servicer.
+type MockpostOrderV2ServicerMockRecorder struct {
+	mock *MockpostOrderV2Servicer
+}
+
+// NewMockpostOrderV2Servicer creates a new mock instance.
+func NewMockpostOrderV2Servicer(ctrl *gomock.Controller) *MockpostOrderV2Servicer {
+	mock := &MockpostOrderV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockpostOrderV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostOrderV2Servicer) EXPECT() *MockpostOrderV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// SaveOrder mocks base method.
+func (m *MockpostOrderV2Servicer) SaveOrder(arg0 context.Context, arg1 *models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockpostOrderV2ServicerMockRecorder) SaveOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockpostOrderV2Servicer)(nil).SaveOrder), arg0, arg1)
+}
+
+// MockpostOrderV2JWT is a mock of postOrderV2JWT interface.
+type MockpostOrderV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostOrderV2JWTMockRecorder
+}
+
+// MockpostOrderV2JWTMockRecorder is the mock recorder for MockpostOrderV2JWT.
+type MockpostOrderV2JWTMockRecorder struct {
+	mock *MockpostOrderV2JWT
+}
+
+// NewMockpostOrderV2JWT creates a new mock instance.
+func NewMockpostOrderV2JWT(ctrl *gomock.Controller) *MockpostOrderV2JWT {
+	mock := &MockpostOrderV2JWT{ctrl: ctrl}
+	mock.recorder = &MockpostOrderV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostOrderV2JWT) EXPECT() *MockpostOrderV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostOrderV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostOrderV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostOrderV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postpassword.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostPasswordServicer is a mock of postPasswordServicer interface.
+type MockpostPasswordServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostPasswordServicerMockRecorder
+}
+
+// MockpostPasswordServicerMockRecorder is the mock recorder for MockpostPasswordServicer.
+type MockpostPasswordServicerMockRecorder struct {
+	mock *MockpostPasswordServicer
+}
+
+// NewMockpostPasswordServicer creates a new mock instance.
+func NewMockpostPasswordServicer(ctrl *gomock.Controller) *MockpostPasswordServicer {
+	mock := &MockpostPasswordServicer{ctrl: ctrl}
+	mock.recorder = &MockpostPasswordServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostPasswordServicer) EXPECT() *MockpostPasswordServicerMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockpostPasswordServicer) ChangePassword(arg0 context.Context, arg1 models.UserID, arg2 *models.PasswordChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockpostPasswordServicerMockRecorder) ChangePassword(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockpostPasswordServicer)(nil).ChangePassword), arg0, arg1, arg2)
+}
+
+// MockpostPasswordJWT is a mock of postPasswordJWT interface.
+type MockpostPasswordJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostPasswordJWTMockRecorder
+}
+
+// MockpostPasswordJWTMockRecorder is the mock recorder for MockpostPasswordJWT.
+type MockpostPasswordJWTMockRecorder struct {
+	mock *MockpostPasswordJWT
+}
+
+// NewMockpostPasswordJWT creates a new mock instance.
+func NewMockpostPasswordJWT(ctrl *gomock.Controller) *MockpostPasswordJWT {
+	mock := &MockpostPasswordJWT{ctrl: ctrl}
+	mock.recorder = &MockpostPasswordJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostPasswordJWT) EXPECT() *MockpostPasswordJWTMockRecorder {
+	return m.recorder
+}
+
+// NewJWTString mocks base method.
+func (m *MockpostPasswordJWT) NewJWTString(arg0 models.UserID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewJWTString", arg0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewJWTString indicates an expected call of NewJWTString.
+func (mr *MockpostPasswordJWTMockRecorder) NewJWTString(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewJWTString", reflect.TypeOf((*MockpostPasswordJWT)(nil).NewJWTString), arg0)
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostPasswordJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostPasswordJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostPasswordJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
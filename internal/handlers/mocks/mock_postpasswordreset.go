@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postpasswordreset.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockpostPasswordResetServicer is a mock of postPasswordResetServicer interface.
+type MockpostPasswordResetServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostPasswordResetServicerMockRecorder
+}
+
+// MockpostPasswordResetServicerMockRecorder is the mock recorder for MockpostPasswordResetServicer.
+type MockpostPasswordResetServicerMockRecorder struct {
+	mock *MockpostPasswordResetServicer
+}
+
+// NewMockpostPasswordResetServicer creates a new mock instance.
+func NewMockpostPasswordResetServicer(ctrl *gomock.Controller) *MockpostPasswordResetServicer {
+	mock := &MockpostPasswordResetServicer{ctrl: ctrl}
+	mock.recorder = &MockpostPasswordResetServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostPasswordResetServicer) EXPECT() *MockpostPasswordResetServicerMockRecorder {
+	return m.recorder
+}
+
+// RequestReset mocks base method.
+func (m *MockpostPasswordResetServicer) RequestReset(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestReset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestReset indicates an expected call of RequestReset.
+func (mr *MockpostPasswordResetServicerMockRecorder) RequestReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestReset", reflect.TypeOf((*MockpostPasswordResetServicer)(nil).RequestReset), arg0, arg1)
+}
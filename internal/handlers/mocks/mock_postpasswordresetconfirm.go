@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postpasswordresetconfirm.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostPasswordResetConfirmServicer is a mock of postPasswordResetConfirmServicer interface.
+type MockpostPasswordResetConfirmServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostPasswordResetConfirmServicerMockRecorder
+}
+
+// MockpostPasswordResetConfirmServicerMockRecorder is the mock recorder for MockpostPasswordResetConfirmServicer.
+type MockpostPasswordResetConfirmServicerMockRecorder struct {
+	mock *MockpostPasswordResetConfirmServicer
+}
+
+// NewMockpostPasswordResetConfirmServicer creates a new mock instance.
+func NewMockpostPasswordResetConfirmServicer(ctrl *gomock.Controller) *MockpostPasswordResetConfirmServicer {
+	mock := &MockpostPasswordResetConfirmServicer{ctrl: ctrl}
+	mock.recorder = &MockpostPasswordResetConfirmServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostPasswordResetConfirmServicer) EXPECT() *MockpostPasswordResetConfirmServicerMockRecorder {
+	return m.recorder
+}
+
+// ConfirmReset mocks base method.
+func (m *MockpostPasswordResetConfirmServicer) ConfirmReset(arg0 context.Context, arg1 *models.PasswordResetConfirm) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmReset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmReset indicates an expected call of ConfirmReset.
+func (mr *MockpostPasswordResetConfirmServicerMockRecorder) ConfirmReset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmReset", reflect.TypeOf((*MockpostPasswordResetConfirmServicer)(nil).ConfirmReset), arg0, arg1)
+}
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: posttotp.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostTOTPServicer is a mock of postTOTPServicer interface.
+type MockpostTOTPServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTOTPServicerMockRecorder
+}
+
+// MockpostTOTPServicerMockRecorder is the mock recorder for MockpostTOTPServicer.
+type MockpostTOTPServicerMockRecorder struct {
+	mock *MockpostTOTPServicer
+}
+
+// NewMockpostTOTPServicer creates a new mock instance.
+func NewMockpostTOTPServicer(ctrl *gomock.Controller) *MockpostTOTPServicer {
+	mock := &MockpostTOTPServicer{ctrl: ctrl}
+	mock.recorder = &MockpostTOTPServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTOTPServicer) EXPECT() *MockpostTOTPServicerMockRecorder {
+	return m.recorder
+}
+
+// BeginEnrollment mocks base method.
+func (m *MockpostTOTPServicer) BeginEnrollment(arg0 context.Context, arg1 models.UserID) (*models.TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginEnrollment", arg0, arg1)
+	ret0, _ := ret[0].(*models.TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginEnrollment indicates an expected call of BeginEnrollment.
+func (mr *MockpostTOTPServicerMockRecorder) BeginEnrollment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginEnrollment", reflect.TypeOf((*MockpostTOTPServicer)(nil).BeginEnrollment), arg0, arg1)
+}
+
+// MockpostTOTPJWT is a mock of postTOTPJWT interface.
+type MockpostTOTPJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTOTPJWTMockRecorder
+}
+
+// MockpostTOTPJWTMockRecorder is the mock recorder for MockpostTOTPJWT.
+type MockpostTOTPJWTMockRecorder struct {
+	mock *MockpostTOTPJWT
+}
+
+// NewMockpostTOTPJWT creates a new mock instance.
+func NewMockpostTOTPJWT(ctrl *gomock.Controller) *MockpostTOTPJWT {
+	mock := &MockpostTOTPJWT{ctrl: ctrl}
+	mock.recorder = &MockpostTOTPJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTOTPJWT) EXPECT() *MockpostTOTPJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostTOTPJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostTOTPJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostTOTPJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: posttotpconfirm.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostTOTPConfirmServicer is a mock of postTOTPConfirmServicer interface.
+type MockpostTOTPConfirmServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTOTPConfirmServicerMockRecorder
+}
+
+// MockpostTOTPConfirmServicerMockRecorder is the mock recorder for MockpostTOTPConfirmServicer.
+type MockpostTOTPConfirmServicerMockRecorder struct {
+	mock *MockpostTOTPConfirmServicer
+}
+
+// NewMockpostTOTPConfirmServicer creates a new mock instance.
+func NewMockpostTOTPConfirmServicer(ctrl *gomock.Controller) *MockpostTOTPConfirmServicer {
+	mock := &MockpostTOTPConfirmServicer{ctrl: ctrl}
+	mock.recorder = &MockpostTOTPConfirmServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTOTPConfirmServicer) EXPECT() *MockpostTOTPConfirmServicerMockRecorder {
+	return m.recorder
+}
+
+// ConfirmEnrollment mocks base method.
+func (m *MockpostTOTPConfirmServicer) ConfirmEnrollment(arg0 context.Context, arg1 models.UserID, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmEnrollment", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmEnrollment indicates an expected call of ConfirmEnrollment.
+func (mr *MockpostTOTPConfirmServicerMockRecorder) ConfirmEnrollment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEnrollment", reflect.TypeOf((*MockpostTOTPConfirmServicer)(nil).ConfirmEnrollment), arg0, arg1, arg2)
+}
+
+// MockpostTOTPConfirmJWT is a mock of postTOTPConfirmJWT interface.
+type MockpostTOTPConfirmJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTOTPConfirmJWTMockRecorder
+}
+
+// MockpostTOTPConfirmJWTMockRecorder is the mock recorder for MockpostTOTPConfirmJWT.
+type MockpostTOTPConfirmJWTMockRecorder struct {
+	mock *MockpostTOTPConfirmJWT
+}
+
+// NewMockpostTOTPConfirmJWT creates a new mock instance.
+func NewMockpostTOTPConfirmJWT(ctrl *gomock.Controller) *MockpostTOTPConfirmJWT {
+	mock := &MockpostTOTPConfirmJWT{ctrl: ctrl}
+	mock.recorder = &MockpostTOTPConfirmJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTOTPConfirmJWT) EXPECT() *MockpostTOTPConfirmJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostTOTPConfirmJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostTOTPConfirmJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostTOTPConfirmJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: posttransfer.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostTransferServicer is a mock of postTransferServicer interface.
+type MockpostTransferServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTransferServicerMockRecorder
+}
+
+// MockpostTransferServicerMockRecorder is the mock recorder for MockpostTransferServicer.
+type MockpostTransferServicerMockRecorder struct {
+	mock *MockpostTransferServicer
+}
+
+// NewMockpostTransferServicer creates a new mock instance.
+func NewMockpostTransferServicer(ctrl *gomock.Controller) *MockpostTransferServicer {
+	mock := &MockpostTransferServicer{ctrl: ctrl}
+	mock.recorder = &MockpostTransferServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTransferServicer) EXPECT() *MockpostTransferServicerMockRecorder {
+	return m.recorder
+}
+
+// TransferProcessing mocks base method.
+func (m *MockpostTransferServicer) TransferProcessing(arg0 context.Context, arg1 *models.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferProcessing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferProcessing indicates an expected call of TransferProcessing.
+func (mr *MockpostTransferServicerMockRecorder) TransferProcessing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferProcessing", reflect.TypeOf((*MockpostTransferServicer)(nil).TransferProcessing), arg0, arg1)
+}
+
+// MockpostTransferJWT is a mock of postTransferJWT interface.
+type MockpostTransferJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTransferJWTMockRecorder
+}
+
+// MockpostTransferJWTMockRecorder is the mock recorder for MockpostTransferJWT.
+type MockpostTransferJWTMockRecorder struct {
+	mock *MockpostTransferJWT
+}
+
+// NewMockpostTransferJWT creates a new mock instance.
+func NewMockpostTransferJWT(ctrl *gomock.Controller) *MockpostTransferJWT {
+	mock := &MockpostTransferJWT{ctrl: ctrl}
+	mock.recorder = &MockpostTransferJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTransferJWT) EXPECT() *MockpostTransferJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostTransferJWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostTransferJWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostTransferJWT)(nil).ParseIDFromAuthHeader), arg0)
+}
+
+// MockpostTransferStepUp is a mock of postTransferStepUp interface.
+type MockpostTransferStepUp struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTransferStepUpMockRecorder
+}
+
+// MockpostTransferStepUpMockRecorder is the mock recorder for MockpostTransferStepUp.
+type MockpostTransferStepUpMockRecorder struct {
+	mock *MockpostTransferStepUp
+}
+
+// NewMockpostTransferStepUp creates a new mock instance.
+func NewMockpostTransferStepUp(ctrl *gomock.Controller) *MockpostTransferStepUp {
+	mock := &MockpostTransferStepUp{ctrl: ctrl}
+	mock.recorder = &MockpostTransferStepUpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTransferStepUp) EXPECT() *MockpostTransferStepUpMockRecorder {
+	return m.recorder
+}
+
+// VerifyStepUp mocks base method.
+func (m *MockpostTransferStepUp) VerifyStepUp(arg0 context.Context, arg1 models.UserID, arg2 float64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyStepUp", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyStepUp indicates an expected call of VerifyStepUp.
+func (mr *MockpostTransferStepUpMockRecorder) VerifyStepUp(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyStepUp", reflect.TypeOf((*MockpostTransferStepUp)(nil).VerifyStepUp), arg0, arg1, arg2, arg3)
+}
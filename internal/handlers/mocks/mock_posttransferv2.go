@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: posttransferv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostTransferV2Servicer is a mock of postTransferV2Servicer interface.
+type MockpostTransferV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTransferV2ServicerMockRecorder
+}
+
+// MockpostTransferV2ServicerMockRecorder is the mock recorder for MockpostTransferV2Servicer.
+type MockpostTransferV2ServicerMockRecorder struct {
+	mock *MockpostTransferV2Servicer
+}
+
+// NewMockpostTransferV2Servicer creates a new mock instance.
+func NewMockpostTransferV2Servicer(ctrl *gomock.Controller) *MockpostTransferV2Servicer {
+	mock := &MockpostTransferV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockpostTransferV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTransferV2Servicer) EXPECT() *MockpostTransferV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// TransferProcessing mocks base method.
+func (m *MockpostTransferV2Servicer) TransferProcessing(arg0 context.Context, arg1 *models.Transfer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferProcessing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferProcessing indicates an expected call of TransferProcessing.
+func (mr *MockpostTransferV2ServicerMockRecorder) TransferProcessing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferProcessing", reflect.TypeOf((*MockpostTransferV2Servicer)(nil).TransferProcessing), arg0, arg1)
+}
+
+// MockpostTransferV2JWT is a mock of postTransferV2JWT interface.
+type MockpostTransferV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTransferV2JWTMockRecorder
+}
+
+// MockpostTransferV2JWTMockRecorder is the mock recorder for MockpostTransferV2JWT.
+type MockpostTransferV2JWTMockRecorder struct {
+	mock *MockpostTransferV2JWT
+}
+
+// NewMockpostTransferV2JWT creates a new mock instance.
+func NewMockpostTransferV2JWT(ctrl *gomock.Controller) *MockpostTransferV2JWT {
+	mock := &MockpostTransferV2JWT{ctrl: ctrl}
+	mock.recorder = &MockpostTransferV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTransferV2JWT) EXPECT() *MockpostTransferV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostTransferV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostTransferV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostTransferV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
+
+// MockpostTransferV2StepUp is a mock of postTransferV2StepUp interface.
+type MockpostTransferV2StepUp struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostTransferV2StepUpMockRecorder
+}
+
+// MockpostTransferV2StepUpMockRecorder is the mock recorder for MockpostTransferV2StepUp.
+type MockpostTransferV2StepUpMockRecorder struct {
+	mock *MockpostTransferV2StepUp
+}
+
+// NewMockpostTransferV2StepUp creates a new mock instance.
+func NewMockpostTransferV2StepUp(ctrl *gomock.Controller) *MockpostTransferV2StepUp {
+	mock := &MockpostTransferV2StepUp{ctrl: ctrl}
+	mock.recorder = &MockpostTransferV2StepUpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostTransferV2StepUp) EXPECT() *MockpostTransferV2StepUpMockRecorder {
+	return m.recorder
+}
+
+// VerifyStepUp mocks base method.
+func (m *MockpostTransferV2StepUp) VerifyStepUp(arg0 context.Context, arg1 models.UserID, arg2 float64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyStepUp", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyStepUp indicates an expected call of VerifyStepUp.
+func (mr *MockpostTransferV2StepUpMockRecorder) VerifyStepUp(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyStepUp", reflect.TypeOf((*MockpostTransferV2StepUp)(nil).VerifyStepUp), arg0, arg1, arg2, arg3)
+}
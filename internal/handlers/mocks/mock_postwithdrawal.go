@@ -87,104 +87,39 @@ func (mr *MockpostWithdrawalJWTMockRecorder) ParseIDFromAuthHeader(arg0 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostWithdrawalJWT)(nil).ParseIDFromAuthHeader), arg0)
 }
 
-// MockerrNotEnoughCurrency is a mock of errNotEnoughCurrency interface.
-type MockerrNotEnoughCurrency struct {
+// MockpostWithdrawalStepUp is a mock of postWithdrawalStepUp interface.
+type MockpostWithdrawalStepUp struct {
 	ctrl     *gomock.Controller
-	recorder *MockerrNotEnoughCurrencyMockRecorder
+	recorder *MockpostWithdrawalStepUpMockRecorder
 }
 
-// MockerrNotEnoughCurrencyMockRecorder is the mock recorder for MockerrNotEnoughCurrency.
-type MockerrNotEnoughCurrencyMockRecorder struct {
-	mock *MockerrNotEnoughCurrency
+// MockpostWithdrawalStepUpMockRecorder is the mock recorder for MockpostWithdrawalStepUp.
+type MockpostWithdrawalStepUpMockRecorder struct {
+	mock *MockpostWithdrawalStepUp
 }
 
-// NewMockerrNotEnoughCurrency creates a new mock instance.
-func NewMockerrNotEnoughCurrency(ctrl *gomock.Controller) *MockerrNotEnoughCurrency {
-	mock := &MockerrNotEnoughCurrency{ctrl: ctrl}
-	mock.recorder = &MockerrNotEnoughCurrencyMockRecorder{mock}
+// NewMockpostWithdrawalStepUp creates a new mock instance.
+func NewMockpostWithdrawalStepUp(ctrl *gomock.Controller) *MockpostWithdrawalStepUp {
+	mock := &MockpostWithdrawalStepUp{ctrl: ctrl}
+	mock.recorder = &MockpostWithdrawalStepUpMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockerrNotEnoughCurrency) EXPECT() *MockerrNotEnoughCurrencyMockRecorder {
+func (m *MockpostWithdrawalStepUp) EXPECT() *MockpostWithdrawalStepUpMockRecorder {
 	return m.recorder
 }
 
-// Error mocks base method.
-func (m *MockerrNotEnoughCurrency) Error() string {
+// VerifyStepUp mocks base method.
+func (m *MockpostWithdrawalStepUp) VerifyStepUp(arg0 context.Context, arg1 models.UserID, arg2 float64, arg3 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Error")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// Error indicates an expected call of Error.
-func (mr *MockerrNotEnoughCurrencyMockRecorder) Error() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrNotEnoughCurrency)(nil).Error))
-}
-
-// IsErrNotEnoughCurrency mocks base method.
-func (m *MockerrNotEnoughCurrency) IsErrNotEnoughCurrency() bool {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsErrNotEnoughCurrency")
-	ret0, _ := ret[0].(bool)
-	return ret0
-}
-
-// IsErrNotEnoughCurrency indicates an expected call of IsErrNotEnoughCurrency.
-func (mr *MockerrNotEnoughCurrencyMockRecorder) IsErrNotEnoughCurrency() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotEnoughCurrency", reflect.TypeOf((*MockerrNotEnoughCurrency)(nil).IsErrNotEnoughCurrency))
-}
-
-// MockerrWrongOrderNum is a mock of errWrongOrderNum interface.
-type MockerrWrongOrderNum struct {
-	ctrl     *gomock.Controller
-	recorder *MockerrWrongOrderNumMockRecorder
-}
-
-// MockerrWrongOrderNumMockRecorder is the mock recorder for MockerrWrongOrderNum.
-type MockerrWrongOrderNumMockRecorder struct {
-	mock *MockerrWrongOrderNum
-}
-
-// NewMockerrWrongOrderNum creates a new mock instance.
-func NewMockerrWrongOrderNum(ctrl *gomock.Controller) *MockerrWrongOrderNum {
-	mock := &MockerrWrongOrderNum{ctrl: ctrl}
-	mock.recorder = &MockerrWrongOrderNumMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockerrWrongOrderNum) EXPECT() *MockerrWrongOrderNumMockRecorder {
-	return m.recorder
-}
-
-// Error mocks base method.
-func (m *MockerrWrongOrderNum) Error() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Error")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// Error indicates an expected call of Error.
-func (mr *MockerrWrongOrderNumMockRecorder) Error() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongOrderNum)(nil).Error))
-}
-
-// IsErrWrongOrderNum mocks base method.
-func (m *MockerrWrongOrderNum) IsErrWrongOrderNum() bool {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsErrWrongOrderNum")
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "VerifyStepUp", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// IsErrWrongOrderNum indicates an expected call of IsErrWrongOrderNum.
-func (mr *MockerrWrongOrderNumMockRecorder) IsErrWrongOrderNum() *gomock.Call {
+// VerifyStepUp indicates an expected call of VerifyStepUp.
+func (mr *MockpostWithdrawalStepUpMockRecorder) VerifyStepUp(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongOrderNum", reflect.TypeOf((*MockerrWrongOrderNum)(nil).IsErrWrongOrderNum))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyStepUp", reflect.TypeOf((*MockpostWithdrawalStepUp)(nil).VerifyStepUp), arg0, arg1, arg2, arg3)
 }
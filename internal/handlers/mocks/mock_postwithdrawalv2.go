@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: postwithdrawalv2.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpostWithdrawalV2Servicer is a mock of postWithdrawalV2Servicer interface.
+type MockpostWithdrawalV2Servicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostWithdrawalV2ServicerMockRecorder
+}
+
+// MockpostWithdrawalV2ServicerMockRecorder is the mock recorder for MockpostWithdrawalV2Servicer.
+type MockpostWithdrawalV2ServicerMockRecorder struct {
+	mock *MockpostWithdrawalV2Servicer
+}
+
+// NewMockpostWithdrawalV2Servicer creates a new mock instance.
+func NewMockpostWithdrawalV2Servicer(ctrl *gomock.Controller) *MockpostWithdrawalV2Servicer {
+	mock := &MockpostWithdrawalV2Servicer{ctrl: ctrl}
+	mock.recorder = &MockpostWithdrawalV2ServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostWithdrawalV2Servicer) EXPECT() *MockpostWithdrawalV2ServicerMockRecorder {
+	return m.recorder
+}
+
+// WithdrawalProcessing mocks base method.
+func (m *MockpostWithdrawalV2Servicer) WithdrawalProcessing(arg0 context.Context, arg1 *models.Withdrawal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawalProcessing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithdrawalProcessing indicates an expected call of WithdrawalProcessing.
+func (mr *MockpostWithdrawalV2ServicerMockRecorder) WithdrawalProcessing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawalProcessing", reflect.TypeOf((*MockpostWithdrawalV2Servicer)(nil).WithdrawalProcessing), arg0, arg1)
+}
+
+// MockpostWithdrawalV2JWT is a mock of postWithdrawalV2JWT interface.
+type MockpostWithdrawalV2JWT struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostWithdrawalV2JWTMockRecorder
+}
+
+// MockpostWithdrawalV2JWTMockRecorder is the mock recorder for MockpostWithdrawalV2JWT.
+type MockpostWithdrawalV2JWTMockRecorder struct {
+	mock *MockpostWithdrawalV2JWT
+}
+
+// NewMockpostWithdrawalV2JWT creates a new mock instance.
+func NewMockpostWithdrawalV2JWT(ctrl *gomock.Controller) *MockpostWithdrawalV2JWT {
+	mock := &MockpostWithdrawalV2JWT{ctrl: ctrl}
+	mock.recorder = &MockpostWithdrawalV2JWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostWithdrawalV2JWT) EXPECT() *MockpostWithdrawalV2JWTMockRecorder {
+	return m.recorder
+}
+
+// ParseIDFromAuthHeader mocks base method.
+func (m *MockpostWithdrawalV2JWT) ParseIDFromAuthHeader(arg0 string) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseIDFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseIDFromAuthHeader indicates an expected call of ParseIDFromAuthHeader.
+func (mr *MockpostWithdrawalV2JWTMockRecorder) ParseIDFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseIDFromAuthHeader", reflect.TypeOf((*MockpostWithdrawalV2JWT)(nil).ParseIDFromAuthHeader), arg0)
+}
+
+// MockpostWithdrawalV2StepUp is a mock of postWithdrawalV2StepUp interface.
+type MockpostWithdrawalV2StepUp struct {
+	ctrl     *gomock.Controller
+	recorder *MockpostWithdrawalV2StepUpMockRecorder
+}
+
+// MockpostWithdrawalV2StepUpMockRecorder is the mock recorder for MockpostWithdrawalV2StepUp.
+type MockpostWithdrawalV2StepUpMockRecorder struct {
+	mock *MockpostWithdrawalV2StepUp
+}
+
+// NewMockpostWithdrawalV2StepUp creates a new mock instance.
+func NewMockpostWithdrawalV2StepUp(ctrl *gomock.Controller) *MockpostWithdrawalV2StepUp {
+	mock := &MockpostWithdrawalV2StepUp{ctrl: ctrl}
+	mock.recorder = &MockpostWithdrawalV2StepUpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpostWithdrawalV2StepUp) EXPECT() *MockpostWithdrawalV2StepUpMockRecorder {
+	return m.recorder
+}
+
+// VerifyStepUp mocks base method.
+func (m *MockpostWithdrawalV2StepUp) VerifyStepUp(arg0 context.Context, arg1 models.UserID, arg2 float64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyStepUp", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyStepUp indicates an expected call of VerifyStepUp.
+func (mr *MockpostWithdrawalV2StepUpMockRecorder) VerifyStepUp(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyStepUp", reflect.TypeOf((*MockpostWithdrawalV2StepUp)(nil).VerifyStepUp), arg0, arg1, arg2, arg3)
+}
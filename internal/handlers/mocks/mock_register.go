@@ -87,54 +87,3 @@ func (mr *MockregJWTMockRecorder) NewJWTString(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewJWTString", reflect.TypeOf((*MockregJWT)(nil).NewJWTString), arg0)
 }
-
-// MockerrLoginConflict is a mock of errLoginConflict interface.
-type MockerrLoginConflict struct {
-	ctrl     *gomock.Controller
-	recorder *MockerrLoginConflictMockRecorder
-}
-
-// MockerrLoginConflictMockRecorder is the mock recorder for MockerrLoginConflict.
-type MockerrLoginConflictMockRecorder struct {
-	mock *MockerrLoginConflict
-}
-
-// NewMockerrLoginConflict creates a new mock instance.
-func NewMockerrLoginConflict(ctrl *gomock.Controller) *MockerrLoginConflict {
-	mock := &MockerrLoginConflict{ctrl: ctrl}
-	mock.recorder = &MockerrLoginConflictMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockerrLoginConflict) EXPECT() *MockerrLoginConflictMockRecorder {
-	return m.recorder
-}
-
-// Error mocks base method.
-func (m *MockerrLoginConflict) Error() string {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Error")
-	ret0, _ := ret[0].(string)
-	return ret0
-}
-
-// Error indicates an expected call of Error.
-func (mr *MockerrLoginConflictMockRecorder) Error() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrLoginConflict)(nil).Error))
-}
-
-// IsErrLoginConflict mocks base method.
-func (m *MockerrLoginConflict) IsErrLoginConflict() bool {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsErrLoginConflict")
-	ret0, _ := ret[0].(bool)
-	return ret0
-}
-
-// IsErrLoginConflict indicates an expected call of IsErrLoginConflict.
-func (mr *MockerrLoginConflictMockRecorder) IsErrLoginConflict() *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrLoginConflict", reflect.TypeOf((*MockerrLoginConflict)(nil).IsErrLoginConflict))
-}
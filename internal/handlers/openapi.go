@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type OpenAPIHandler struct {
+	spec []byte
+}
+
+func NewOpenAPIHandler(spec []byte) func(*fiber.Ctx) error {
+	h := &OpenAPIHandler{
+		spec: spec,
+	}
+	return h.handle
+}
+
+func (h *OpenAPIHandler) handle(c *fiber.Ctx) error {
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(h.spec)
+}
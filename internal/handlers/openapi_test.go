@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenAPIHandler_handle(t *testing.T) {
+	testSpec := `{"openapi":"3.0.3"}`
+
+	openAPIHandler := NewOpenAPIHandler([]byte(testSpec))
+
+	app := fiber.New()
+	app.Get("/", openAPIHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+		assert.JSONEq(t, testSpec, string(body))
+	})
+}
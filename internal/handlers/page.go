@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+var errWrongPage = errors.New("wrong page")
+
+type pageResponse[T any] struct {
+	Items      []T  `json:"items"`
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+func parsePage(c *fiber.Ctx) (models.Page, error) {
+	page := models.Page{
+		Limit: defaultPageLimit,
+	}
+	var err error
+	if v := c.Query("limit"); v != "" {
+		page.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return models.Page{}, errWrongPage
+		}
+	}
+	if v := c.Query("offset"); v != "" {
+		page.Offset, err = strconv.Atoi(v)
+		if err != nil {
+			return models.Page{}, errWrongPage
+		}
+	}
+	if page.Limit < 1 || page.Limit > maxPageLimit || page.Offset < 0 {
+		return models.Page{}, errWrongPage
+	}
+	return page, nil
+}
+
+func newPageResponse[S, T any](items []S, more bool, page models.Page, convert func(S) T) *pageResponse[T] {
+	res := &pageResponse[T]{
+		Items: make([]T, 0, len(items)),
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, convert(item))
+	}
+	if more {
+		next := page.Offset + page.Limit
+		res.NextOffset = &next
+	}
+	return res
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+var (
+	errEmptyAccrualBatch = errors.New("empty accrual batch")
+	errIncompleteAccrual = errors.New("accrual result without order number or status")
+	errUnknownStatus     = errors.New("accrual result with an unknown status")
+)
+
+var orderStatuses = map[string]bool{
+	models.StatusNew:        true,
+	models.StatusProcessing: true,
+	models.StatusInvalid:    true,
+	models.StatusProcessed:  true,
+}
+
+type accrualPusher interface {
+	Push(context.Context, []*models.OrderAccrual) error
+}
+
+// PostAccrualCallbackHandler receives the accrual results an accrual
+// system pushes to us, one or many per request. The request signature is
+// checked by the middleware in front of it. 202 means the results were
+// queued, not stored, so delivery is at most once: a result lost at
+// shutdown isn't sent again, and the order is polled instead once the
+// grace period passes. Once the sync worker has stopped the callback
+// answers 503, so the accrual system retries against another instance.
+type PostAccrualCallbackHandler struct {
+	pusher accrualPusher
+}
+
+func NewPostAccrualCallbackHandler(pusher accrualPusher) func(*fiber.Ctx) error {
+	h := &PostAccrualCallbackHandler{
+		pusher: pusher,
+	}
+	return h.handle
+}
+
+func (h *PostAccrualCallbackHandler) handle(c *fiber.Ctx) error {
+	orders, err := parseOrderAccruals(c.Body())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	err = h.pusher.Push(c.UserContext(), orders)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusAccepted)
+}
+
+// parseOrderAccruals accepts a single result or an array of them. Every
+// result must carry one of the order statuses.
+func parseOrderAccruals(body []byte) ([]*models.OrderAccrual, error) {
+	var orders []*models.OrderAccrual
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err := json.Unmarshal(trimmed, &orders)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var order models.OrderAccrual
+		err := json.Unmarshal(body, &order)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, &order)
+	}
+	if len(orders) == 0 {
+		return nil, errEmptyAccrualBatch
+	}
+	for _, order := range orders {
+		if order == nil || order.Number == "" || order.Status == "" {
+			return nil, errIncompleteAccrual
+		}
+		if !orderStatuses[order.Status] {
+			return nil, errUnknownStatus
+		}
+	}
+	return orders, nil
+}
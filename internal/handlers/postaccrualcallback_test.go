@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostAccrualCallbackHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mPusher := mocks.NewMockaccrualPusher(ctrl)
+
+	postAccrualCallbackHandler := NewPostAccrualCallbackHandler(mPusher)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postAccrualCallbackHandler)
+
+	send := func(body string) int {
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mPusher.EXPECT().Push(gomock.Any(), []*models.OrderAccrual{
+			{Number: validLuhnString, Status: models.StatusProcessed, Accrual: 10},
+		}).Return(nil)
+
+		status := send(`{"order":"` + validLuhnString + `","status":"PROCESSED","accrual":10}`)
+		assert.Equal(t, fiber.StatusAccepted, status)
+	})
+
+	t.Run("many results", func(t *testing.T) {
+		mPusher.EXPECT().Push(gomock.Any(), []*models.OrderAccrual{
+			{Number: validLuhnString, Status: models.StatusProcessed, Accrual: 10},
+			{Number: "12345678903", Status: models.StatusInvalid},
+		}).Return(nil)
+
+		status := send(` [{"order":"` + validLuhnString + `","status":"PROCESSED","accrual":10},{"order":"12345678903","status":"INVALID"}]`)
+		assert.Equal(t, fiber.StatusAccepted, status)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		status := send("[]")
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("incomplete result in batch", func(t *testing.T) {
+		status := send(`[{"order":"` + validLuhnString + `","status":"PROCESSED"},null]`)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("wrong json body", func(t *testing.T) {
+		status := send("wrong json")
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("missing status", func(t *testing.T) {
+		status := send(`{"order":"` + validLuhnString + `"}`)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		status := send(`[{"order":"` + validLuhnString + `","status":"PROCESSED"},{"order":"12345678903","status":"DONE"}]`)
+		assert.Equal(t, fiber.StatusBadRequest, status)
+	})
+
+	t.Run("source closed", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrSourceClosed(ctrl)
+		mErr.EXPECT().IsErrSourceClosed().Return(true)
+		mPusher.EXPECT().Push(gomock.Any(), gomock.Any()).Return(mErr)
+
+		status := send(`{"order":"` + validLuhnString + `","status":"PROCESSING"}`)
+		assert.Equal(t, fiber.StatusServiceUnavailable, status)
+	})
+
+	t.Run("push error", func(t *testing.T) {
+		mPusher.EXPECT().Push(gomock.Any(), gomock.Any()).Return(errTest)
+
+		status := send(`{"order":"` + validLuhnString + `","status":"PROCESSING"}`)
+		assert.Equal(t, fiber.StatusInternalServerError, status)
+	})
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postLogin2FAServicer interface {
+	VerifyLogin(context.Context, models.UserID, string) error
+}
+
+type postLogin2FAJWT interface {
+	ParseChallenge(string) (models.UserID, error)
+	NewJWTString(models.UserID) (string, error)
+}
+
+type PostLogin2FAHandler struct {
+	postLogin2FAService postLogin2FAServicer
+	jwt                 postLogin2FAJWT
+	throttler           loginThrottler
+}
+
+func NewPostLogin2FAHandler(postLogin2FAService postLogin2FAServicer, jwt postLogin2FAJWT, throttler loginThrottler) func(*fiber.Ctx) error {
+	h := &PostLogin2FAHandler{
+		postLogin2FAService: postLogin2FAService,
+		jwt:                 jwt,
+		throttler:           throttler,
+	}
+	return h.handle
+}
+
+type errWrongTOTPCode interface {
+	error
+	IsErrWrongTOTPCode() bool
+}
+
+type errTOTPNotEnrolled interface {
+	error
+	IsErrTOTPNotEnrolled() bool
+}
+
+func (h *PostLogin2FAHandler) handle(c *fiber.Ctx) error {
+	var login models.TOTPLogin
+	err := json.Unmarshal(c.Body(), &login)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	err = login.Validate()
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	uid, err := h.jwt.ParseChallenge(login.ChallengeToken)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusUnauthorized, problem.CodeInvalidChallenge)
+	}
+	key := fmt.Sprintf("2fa:%d", uid)
+
+	retry, err := h.throttler.Check(c.Context(), key, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	if retry > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+		return problem.New(fiber.StatusTooManyRequests, problem.CodeThrottled)
+	}
+
+	err = h.postLogin2FAService.VerifyLogin(c.Context(), uid, login.Code)
+	if e, ok := err.(errWrongTOTPCode); ok && e.IsErrWrongTOTPCode() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.WithStatus(fiber.StatusUnauthorized, err)
+	}
+	if e, ok := err.(errTOTPNotEnrolled); ok && e.IsErrTOTPNotEnrolled() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		h.release(c, key)
+		return problem.WithStatus(fiber.StatusUnauthorized, err)
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		h.release(c, key)
+		return err
+	}
+
+	err = h.throttler.Success(c.Context(), key, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	}
+
+	jwt, err := h.jwt.NewJWTString(uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	c.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *PostLogin2FAHandler) release(c *fiber.Ctx, key string) {
+	err := h.throttler.Release(c.Context(), key, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	}
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostLogin2FAHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostLogin2FAServicer(ctrl)
+	mJWT := mocks.NewMockpostLogin2FAJWT(ctrl)
+	mThrottler := mocks.NewMockloginThrottler(ctrl)
+
+	postLogin2FAHandler := NewPostLogin2FAHandler(mService, mJWT, mThrottler)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postLogin2FAHandler)
+
+	testLogin := &models.TOTPLogin{
+		ChallengeToken: testJWTString,
+		Code:           "123456",
+	}
+	testLoginJSON, err := json.Marshal(testLogin)
+	require.NoError(t, err)
+	testThrottleKey := fmt.Sprintf("2fa:%d", testUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseChallenge(testJWTString).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testThrottleKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().VerifyLogin(gomock.Any(), testUserID, testLogin.Code).Return(nil)
+		mThrottler.EXPECT().Success(gomock.Any(), testThrottleKey, gomock.Any()).Return(nil)
+		mJWT.EXPECT().NewJWTString(testUserID).Return(testJWTString, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testLoginJSON))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Authorization"), testJWTString)
+	})
+
+	t.Run("wrong json body", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader([]byte("wrong json")))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		mJWT.EXPECT().ParseChallenge(testJWTString).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testLoginJSON))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mJWT.EXPECT().ParseChallenge(testJWTString).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testThrottleKey, gomock.Any()).Return(time.Duration(1500)*time.Millisecond, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testLoginJSON))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true).Times(2)
+		mJWT.EXPECT().ParseChallenge(testJWTString).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testThrottleKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().VerifyLogin(gomock.Any(), testUserID, testLogin.Code).Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testLoginJSON))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseChallenge(testJWTString).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testThrottleKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().VerifyLogin(gomock.Any(), testUserID, testLogin.Code).Return(errTest)
+		mThrottler.EXPECT().Release(gomock.Any(), testThrottleKey, gomock.Any()).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testLoginJSON))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
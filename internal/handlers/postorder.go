@@ -37,21 +37,11 @@ type errOrderExists interface {
 	IsErrOrderExists() bool
 }
 
-type errWrongNum interface {
-	error
-	IsErrWrongNum() bool
-}
-
-type errOrderConflict interface {
-	error
-	IsErrOrderConflict() bool
-}
-
 func (h *PostOrderHandler) handle(c *fiber.Ctx) error {
 	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	order := &models.Order{
@@ -63,17 +53,9 @@ func (h *PostOrderHandler) handle(c *fiber.Ctx) error {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
 		return c.SendStatus(fiber.StatusOK)
 	}
-	if e, ok := err.(errWrongNum); ok && e.IsErrWrongNum() {
-		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusUnprocessableEntity)
-	}
-	if e, ok := err.(errOrderConflict); ok && e.IsErrOrderConflict() {
-		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusConflict)
-	}
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 	return c.SendStatus(fiber.StatusAccepted)
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/handlers/mocks"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,7 +25,7 @@ func TestPostOrderHandler_handle(t *testing.T) {
 
 	postOrderHandler := NewPostOrderHandler(mService, mJWT)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Post("/", postOrderHandler)
 
 	t.Run("valid test", func(t *testing.T) {
@@ -73,7 +75,7 @@ func TestPostOrderHandler_handle(t *testing.T) {
 			UserID: testUserID,
 		}
 
-		mErr := mocks.NewMockerrWrongNum(ctrl)
+		mErr := problemmocks.NewMockerrWrongNum(ctrl)
 		mErr.EXPECT().IsErrWrongNum().Return(true)
 		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
 		mService.EXPECT().SaveOrder(gomock.Any(), order).Return(mErr)
@@ -95,7 +97,7 @@ func TestPostOrderHandler_handle(t *testing.T) {
 			UserID: testUserID,
 		}
 
-		mErr := mocks.NewMockerrOrderConflict(ctrl)
+		mErr := problemmocks.NewMockerrOrderConflict(ctrl)
 		mErr.EXPECT().IsErrOrderConflict().Return(true)
 		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
 		mService.EXPECT().SaveOrder(gomock.Any(), order).Return(mErr)
@@ -111,6 +113,28 @@ func TestPostOrderHandler_handle(t *testing.T) {
 		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
 	})
 
+	t.Run("too many pending orders", func(t *testing.T) {
+		order := &models.Order{
+			Number: validLuhnString,
+			UserID: testUserID,
+		}
+
+		mErr := problemmocks.NewMockerrTooManyOrders(ctrl)
+		mErr.EXPECT().IsErrTooManyOrders().Return(true)
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().SaveOrder(gomock.Any(), order).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(validLuhnString))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+	})
+
 	t.Run("some error", func(t *testing.T) {
 		order := &models.Order{
 			Number: validLuhnString,
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+var errEmptyOrdersBatch = errors.New("empty orders batch")
+
+type postOrdersBatchServicer interface {
+	SaveOrdersBatch(context.Context, models.UserID, []string) ([]*models.OrderBatchResult, error)
+}
+
+type postOrdersBatchJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type PostOrdersBatchHandler struct {
+	postOrdersBatchService postOrdersBatchServicer
+	jwt                    postOrdersBatchJWT
+	limit                  int
+}
+
+func NewPostOrdersBatchHandler(postOrdersBatchService postOrdersBatchServicer, jwt postOrdersBatchJWT, limit int) func(*fiber.Ctx) error {
+	h := &PostOrdersBatchHandler{
+		postOrdersBatchService: postOrdersBatchService,
+		jwt:                    jwt,
+		limit:                  limit,
+	}
+	return h.handle
+}
+
+func (h *PostOrdersBatchHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	nums, err := parseOrderNums(c)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	if h.limit > 0 && len(nums) > h.limit {
+		return problem.New(fiber.StatusRequestEntityTooLarge, problem.CodeBatchTooLarge)
+	}
+
+	results, err := h.postOrdersBatchService.SaveOrdersBatch(c.Context(), uid, nums)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	res, err := json.Marshal(&results)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(res)
+}
+
+func parseOrderNums(c *fiber.Ctx) ([]string, error) {
+	var nums []string
+	if c.Is("json") {
+		err := json.Unmarshal(c.Body(), &nums)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, line := range strings.Split(string(c.Body()), "\n") {
+			num := strings.TrimSpace(line)
+			if num != "" {
+				nums = append(nums, num)
+			}
+		}
+	}
+	if len(nums) == 0 {
+		return nil, errEmptyOrdersBatch
+	}
+	return nums, nil
+}
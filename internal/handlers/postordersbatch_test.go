@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostOrdersBatchHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostOrdersBatchServicer(ctrl)
+	mJWT := mocks.NewMockpostOrdersBatchJWT(ctrl)
+
+	postOrdersBatchHandler := NewPostOrdersBatchHandler(mService, mJWT, 2)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postOrdersBatchHandler)
+
+	testResults := []*models.OrderBatchResult{
+		{Number: validLuhnString, Status: models.BatchAccepted},
+		{Number: "12345", Status: models.BatchWrongNum},
+	}
+	testResultsJSON := `[{"number":"4512812345678909","status":"accepted"},{"number":"12345","status":"invalid_number"}]`
+
+	t.Run("valid json test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().SaveOrdersBatch(gomock.Any(), testUserID, []string{validLuhnString, "12345"}).Return(testResults, nil)
+
+		bodyReader := bytes.NewReader([]byte(`["4512812345678909","12345"]`))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set("Content-Type", "application/json")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, testResultsJSON, string(body))
+	})
+
+	t.Run("valid text test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().SaveOrdersBatch(gomock.Any(), testUserID, []string{validLuhnString, "12345"}).Return(testResults, nil)
+
+		bodyReader := bytes.NewReader([]byte("4512812345678909\r\n\n12345\n"))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set("Content-Type", "text/plain")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.JSONEq(t, testResultsJSON, string(body))
+	})
+
+	t.Run("too many orders", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		bodyReader := bytes.NewReader([]byte("1\n2\n3"))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set("Content-Type", "text/plain")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusRequestEntityTooLarge, res.StatusCode)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		bodyReader := bytes.NewReader([]byte("[]"))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set("Content-Type", "application/json")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("wrong json", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		bodyReader := bytes.NewReader([]byte(`{"number":"1"}`))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set("Content-Type", "application/json")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().SaveOrdersBatch(gomock.Any(), testUserID, gomock.Any()).Return(nil, errTest)
+
+		bodyReader := bytes.NewReader([]byte(validLuhnString))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set("Content-Type", "text/plain")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postOrderV2Servicer interface {
+	SaveOrder(context.Context, *models.Order) error
+}
+
+type postOrderV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type PostOrderV2Handler struct {
+	postOrderService postOrderV2Servicer
+	jwt              postOrderV2JWT
+}
+
+func NewPostOrderV2Handler(postOrderService postOrderV2Servicer, jwt postOrderV2JWT) func(*fiber.Ctx) error {
+	h := &PostOrderV2Handler{
+		postOrderService: postOrderService,
+		jwt:              jwt,
+	}
+	return h.handle
+}
+
+type orderUploadV2 struct {
+	Number string `json:"number"`
+}
+
+func (h *PostOrderV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	var upload orderUploadV2
+	err = json.Unmarshal(c.Body(), &upload)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	if upload.Number == "" {
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	order := &models.Order{
+		Number: upload.Number,
+		UserID: uid,
+	}
+	err = h.postOrderService.SaveOrder(c.Context(), order)
+	if e, ok := err.(errOrderExists); ok && e.IsErrOrderExists() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return c.SendStatus(fiber.StatusOK)
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostOrderV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostOrderV2Servicer(ctrl)
+	mJWT := mocks.NewMockpostOrderV2JWT(ctrl)
+
+	postOrderHandler := NewPostOrderV2Handler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postOrderHandler)
+
+	order := &models.Order{
+		Number: validLuhnString,
+		UserID: testUserID,
+	}
+	testBody := fmt.Sprintf(`{"number":%q}`, validLuhnString)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().SaveOrder(gomock.Any(), order).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusAccepted, res.StatusCode)
+	})
+
+	t.Run("order exists", func(t *testing.T) {
+		mErr := mocks.NewMockerrOrderExists(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrOrderExists().Return(true)
+		mService.EXPECT().SaveOrder(gomock.Any(), order).Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("wrong json body", func(t *testing.T) {
+		for _, body := range []string{validLuhnString, `{}`, `{"number":123}`} {
+			mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+			request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(body))
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+			res, err := app.Test(request, -1)
+			require.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, fiber.StatusBadRequest, res.StatusCode, body)
+		}
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().SaveOrder(gomock.Any(), order).Return(errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postPasswordServicer interface {
+	ChangePassword(context.Context, models.UserID, *models.PasswordChange) error
+}
+
+type postPasswordJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+	NewJWTString(models.UserID) (string, error)
+}
+
+type PostPasswordHandler struct {
+	postPasswordService postPasswordServicer
+	jwt                 postPasswordJWT
+	throttler           loginThrottler
+}
+
+func NewPostPasswordHandler(postPasswordService postPasswordServicer, jwt postPasswordJWT, throttler loginThrottler) func(*fiber.Ctx) error {
+	h := &PostPasswordHandler{
+		postPasswordService: postPasswordService,
+		jwt:                 jwt,
+		throttler:           throttler,
+	}
+	return h.handle
+}
+
+func (h *PostPasswordHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	var change models.PasswordChange
+	err = json.Unmarshal(c.Body(), &change)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	err = change.Validate()
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	// The old password is guessed like a login password, so it is
+	// throttled the same way.
+	key := fmt.Sprintf("password:%d", uid)
+	retry, err := h.throttler.Check(c.Context(), key, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	if retry > 0 {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+		return problem.New(fiber.StatusTooManyRequests, problem.CodeThrottled)
+	}
+
+	err = h.postPasswordService.ChangePassword(c.Context(), uid, &change)
+	if e, ok := err.(errWrongPassword); ok && e.IsErrWrongPassword() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		h.release(c, key)
+		return err
+	}
+
+	err = h.throttler.Success(c.Context(), key, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	}
+
+	jwt, err := h.jwt.NewJWTString(uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func (h *PostPasswordHandler) release(c *fiber.Ctx, key string) {
+	err := h.throttler.Release(c.Context(), key, c.IP())
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+	}
+}
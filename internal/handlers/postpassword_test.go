@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPasswordHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostPasswordServicer(ctrl)
+	mJWT := mocks.NewMockpostPasswordJWT(ctrl)
+	mThrottler := mocks.NewMockloginThrottler(ctrl)
+
+	postPasswordHandler := NewPostPasswordHandler(mService, mJWT, mThrottler)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postPasswordHandler)
+
+	testChange := &models.PasswordChange{
+		OldPassword: testUserPassword,
+		NewPassword: "new_password",
+	}
+	testKey := fmt.Sprintf("password:%d", testUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(gomock.Any()).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().ChangePassword(gomock.Any(), testUserID, testChange).Return(nil)
+		mThrottler.EXPECT().Success(gomock.Any(), testKey, gomock.Any()).Return(nil)
+		mJWT.EXPECT().NewJWTString(testUserID).Return(testJWTString, nil)
+
+		body, err := json.Marshal(testChange)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Contains(t, res.Header.Get("Authorization"), testJWTString)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(gomock.Any()).Return(testUserID, nil)
+
+		body, err := json.Marshal(&models.PasswordChange{NewPassword: "new_password"})
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("wrong old password", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongPassword(ctrl)
+		mErr.EXPECT().IsErrWrongPassword().Return(true).Times(2)
+		mJWT.EXPECT().ParseIDFromAuthHeader(gomock.Any()).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().ChangePassword(gomock.Any(), testUserID, testChange).Return(mErr)
+
+		body, err := json.Marshal(testChange)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(gomock.Any()).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testKey, gomock.Any()).Return(time.Duration(1500)*time.Millisecond, nil)
+
+		body, err := json.Marshal(testChange)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "2", res.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrWeakPassword(ctrl)
+		mErr.EXPECT().IsErrWeakPassword().Return(true)
+		mErr.EXPECT().Error().Return("weak password: too short")
+		mJWT.EXPECT().ParseIDFromAuthHeader(gomock.Any()).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().ChangePassword(gomock.Any(), testUserID, testChange).Return(mErr)
+		mThrottler.EXPECT().Release(gomock.Any(), testKey, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(testChange)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(gomock.Any()).Return(testUserID, nil)
+		mThrottler.EXPECT().Check(gomock.Any(), testKey, gomock.Any()).Return(time.Duration(0), nil)
+		mService.EXPECT().ChangePassword(gomock.Any(), testUserID, testChange).Return(errTest)
+		mThrottler.EXPECT().Release(gomock.Any(), testKey, gomock.Any()).Return(nil)
+
+		body, err := json.Marshal(testChange)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
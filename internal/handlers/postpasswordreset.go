@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postPasswordResetServicer interface {
+	RequestReset(context.Context, string) error
+}
+
+type PostPasswordResetHandler struct {
+	postPasswordResetService postPasswordResetServicer
+}
+
+func NewPostPasswordResetHandler(postPasswordResetService postPasswordResetServicer) func(*fiber.Ctx) error {
+	h := &PostPasswordResetHandler{
+		postPasswordResetService: postPasswordResetService,
+	}
+	return h.handle
+}
+
+func (h *PostPasswordResetHandler) handle(c *fiber.Ctx) error {
+	var req models.PasswordResetRequest
+	err := json.Unmarshal(c.Body(), &req)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	err = req.Validate()
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	err = h.postPasswordResetService.RequestReset(c.Context(), req.Login)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPasswordResetHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostPasswordResetServicer(ctrl)
+
+	postPasswordResetHandler := NewPostPasswordResetHandler(mService)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postPasswordResetHandler)
+
+	t.Run("valid test", func(t *testing.T) {
+		mService.EXPECT().RequestReset(gomock.Any(), testUserLogin).Return(nil)
+
+		body, err := json.Marshal(&models.PasswordResetRequest{Login: testUserLogin})
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusAccepted, res.StatusCode)
+	})
+
+	t.Run("empty login", func(t *testing.T) {
+		body, err := json.Marshal(&models.PasswordResetRequest{})
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mService.EXPECT().RequestReset(gomock.Any(), testUserLogin).Return(errTest)
+
+		body, err := json.Marshal(&models.PasswordResetRequest{Login: testUserLogin})
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
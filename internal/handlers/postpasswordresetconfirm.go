@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postPasswordResetConfirmServicer interface {
+	ConfirmReset(context.Context, *models.PasswordResetConfirm) error
+}
+
+type PostPasswordResetConfirmHandler struct {
+	postPasswordResetConfirmService postPasswordResetConfirmServicer
+}
+
+func NewPostPasswordResetConfirmHandler(postPasswordResetConfirmService postPasswordResetConfirmServicer) func(*fiber.Ctx) error {
+	h := &PostPasswordResetConfirmHandler{
+		postPasswordResetConfirmService: postPasswordResetConfirmService,
+	}
+	return h.handle
+}
+
+func (h *PostPasswordResetConfirmHandler) handle(c *fiber.Ctx) error {
+	var confirm models.PasswordResetConfirm
+	err := json.Unmarshal(c.Body(), &confirm)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	err = confirm.Validate()
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	err = h.postPasswordResetConfirmService.ConfirmReset(c.Context(), &confirm)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
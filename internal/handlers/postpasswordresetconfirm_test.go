@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostPasswordResetConfirmHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostPasswordResetConfirmServicer(ctrl)
+
+	postPasswordResetConfirmHandler := NewPostPasswordResetConfirmHandler(mService)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postPasswordResetConfirmHandler)
+
+	testConfirm := &models.PasswordResetConfirm{
+		Token:       "token",
+		NewPassword: "new_password",
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mService.EXPECT().ConfirmReset(gomock.Any(), testConfirm).Return(nil)
+
+		body, err := json.Marshal(testConfirm)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrInvalidResetToken(ctrl)
+		mErr.EXPECT().IsErrInvalidResetToken().Return(true)
+		mService.EXPECT().ConfirmReset(gomock.Any(), testConfirm).Return(mErr)
+
+		body, err := json.Marshal(testConfirm)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrWeakPassword(ctrl)
+		mErr.EXPECT().IsErrWeakPassword().Return(true)
+		mErr.EXPECT().Error().Return("weak password: too short")
+		mService.EXPECT().ConfirmReset(gomock.Any(), testConfirm).Return(mErr)
+
+		body, err := json.Marshal(testConfirm)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mService.EXPECT().ConfirmReset(gomock.Any(), testConfirm).Return(errTest)
+
+		body, err := json.Marshal(testConfirm)
+		require.NoError(t, err)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(body))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postTOTPServicer interface {
+	BeginEnrollment(context.Context, models.UserID) (*models.TOTPEnrollment, error)
+}
+
+type postTOTPJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type PostTOTPHandler struct {
+	postTOTPService postTOTPServicer
+	jwt             postTOTPJWT
+}
+
+func NewPostTOTPHandler(postTOTPService postTOTPServicer, jwt postTOTPJWT) func(*fiber.Ctx) error {
+	h := &PostTOTPHandler{
+		postTOTPService: postTOTPService,
+		jwt:             jwt,
+	}
+	return h.handle
+}
+
+func (h *PostTOTPHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	enrollment, err := h.postTOTPService.BeginEnrollment(c.Context(), uid)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(enrollment)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostTOTPHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostTOTPServicer(ctrl)
+	mJWT := mocks.NewMockpostTOTPJWT(ctrl)
+
+	postTOTPHandler := NewPostTOTPHandler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postTOTPHandler)
+
+	testEnrollment := &models.TOTPEnrollment{
+		Secret:          "GEZDGNBVGY3TQOJQ",
+		ProvisioningURI: "otpauth://totp/loyalsys:login?secret=GEZDGNBVGY3TQOJQ",
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().BeginEnrollment(gomock.Any(), testUserID).Return(testEnrollment, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		var enrollment models.TOTPEnrollment
+		err = json.NewDecoder(res.Body).Decode(&enrollment)
+		require.NoError(t, err)
+		assert.Equal(t, testEnrollment, &enrollment)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrTOTPEnabled(ctrl)
+		mErr.EXPECT().IsErrTOTPEnabled().Return(true)
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().BeginEnrollment(gomock.Any(), testUserID).Return(nil, mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().BeginEnrollment(gomock.Any(), testUserID).Return(nil, errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", nil)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postTOTPConfirmServicer interface {
+	ConfirmEnrollment(context.Context, models.UserID, string) ([]string, error)
+}
+
+type postTOTPConfirmJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type PostTOTPConfirmHandler struct {
+	postTOTPConfirmService postTOTPConfirmServicer
+	jwt                    postTOTPConfirmJWT
+}
+
+func NewPostTOTPConfirmHandler(postTOTPConfirmService postTOTPConfirmServicer, jwt postTOTPConfirmJWT) func(*fiber.Ctx) error {
+	h := &PostTOTPConfirmHandler{
+		postTOTPConfirmService: postTOTPConfirmService,
+		jwt:                    jwt,
+	}
+	return h.handle
+}
+
+func (h *PostTOTPConfirmHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	var code models.TOTPCode
+	err = json.Unmarshal(c.Body(), &code)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	err = code.Validate()
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+
+	codes, err := h.postTOTPConfirmService.ConfirmEnrollment(c.Context(), uid, code.Code)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	resBody, err := json.Marshal(&models.RecoveryCodes{Codes: codes})
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(fiber.StatusOK).Send(resBody)
+}
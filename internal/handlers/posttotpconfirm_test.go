@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostTOTPConfirmHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostTOTPConfirmServicer(ctrl)
+	mJWT := mocks.NewMockpostTOTPConfirmJWT(ctrl)
+
+	postTOTPConfirmHandler := NewPostTOTPConfirmHandler(mService, mJWT)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postTOTPConfirmHandler)
+
+	testCode := &models.TOTPCode{Code: "123456"}
+	testCodeJSON, err := json.Marshal(testCode)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		testCodes := []string{"abcde-fghij", "klmno-pqrst"}
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().ConfirmEnrollment(gomock.Any(), testUserID, testCode.Code).Return(testCodes, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testCodeJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		var codes models.RecoveryCodes
+		err = json.NewDecoder(res.Body).Decode(&codes)
+		require.NoError(t, err)
+		assert.Equal(t, testCodes, codes.Codes)
+	})
+
+	t.Run("empty code", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader([]byte(`{"code":""}`)))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true)
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().ConfirmEnrollment(gomock.Any(), testUserID, testCode.Code).Return(nil, mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testCodeJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mErr := mocks.NewMockerrTOTPNotEnrolled(ctrl)
+		mErr.EXPECT().IsErrTOTPNotEnrolled().Return(true)
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().ConfirmEnrollment(gomock.Any(), testUserID, testCode.Code).Return(nil, mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testCodeJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mService.EXPECT().ConfirmEnrollment(gomock.Any(), testUserID, testCode.Code).Return(nil, errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testCodeJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
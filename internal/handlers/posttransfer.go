@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postTransferServicer interface {
+	TransferProcessing(context.Context, *models.Transfer) error
+}
+
+type postTransferJWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type postTransferStepUp interface {
+	VerifyStepUp(context.Context, models.UserID, float64, string) error
+}
+
+type PostTransferHandler struct {
+	postTransferService postTransferServicer
+	jwt                 postTransferJWT
+	stepUp              postTransferStepUp
+}
+
+func NewPostTransferHandler(postTransferService postTransferServicer, jwt postTransferJWT, stepUp postTransferStepUp) func(*fiber.Ctx) error {
+	h := &PostTransferHandler{
+		postTransferService: postTransferService,
+		jwt:                 jwt,
+		stepUp:              stepUp,
+	}
+	return h.handle
+}
+
+func (h *PostTransferHandler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	var transfer models.Transfer
+	err = json.Unmarshal(c.Body(), &transfer)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	transfer.SenderID = uid
+
+	err = h.stepUp.VerifyStepUp(c.Context(), uid, transfer.Sum, c.Get(totpCodeHeader))
+	if e, ok := err.(errWrongTOTPCode); ok && e.IsErrWrongTOTPCode() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.WithStatus(fiber.StatusForbidden, err)
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	err = h.postTransferService.TransferProcessing(c.Context(), &transfer)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostTransferHandler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostTransferServicer(ctrl)
+	mJWT := mocks.NewMockpostTransferJWT(ctrl)
+	mStepUp := mocks.NewMockpostTransferStepUp(ctrl)
+
+	postTransferHandler := NewPostTransferHandler(mService, mJWT, mStepUp)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postTransferHandler)
+
+	transfer := &models.Transfer{
+		SenderID:       testUserID,
+		Recipient:      "recipient",
+		Sum:            10,
+		IdempotencyKey: "key",
+	}
+	testTransferJSON, err := json.Marshal(transfer)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(nil)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("wrong json body", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		bodyReader := bytes.NewReader([]byte("wrong json"))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("not enough currency error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrNotEnoughCurrency(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrNotEnoughCurrency().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusPaymentRequired, res.StatusCode)
+	})
+
+	t.Run("idempotency conflict error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrIdempotencyConflict(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrIdempotencyConflict().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+	})
+
+	t.Run("invalid transfer error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrInvalidTransfer(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrInvalidTransfer().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("unknown recipient error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrUnknownRecipient(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrUnknownRecipient().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("self transfer error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrSelfTransfer(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrSelfTransfer().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+	})
+
+	t.Run("daily limit error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrTransferLimitExceeded(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrTransferLimitExceeded().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(errTest)
+
+		bodyReader := bytes.NewReader([]byte(testTransferJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("totp code", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "123456").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testTransferJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "123456")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("totp required", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrTOTPRequired(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrTOTPRequired().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testTransferJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("wrong totp code", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true).Times(2)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "000000").Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", bytes.NewReader(testTransferJSON))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "000000")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+}
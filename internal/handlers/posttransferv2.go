@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postTransferV2Servicer interface {
+	TransferProcessing(context.Context, *models.Transfer) error
+}
+
+type postTransferV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type postTransferV2StepUp interface {
+	VerifyStepUp(context.Context, models.UserID, float64, string) error
+}
+
+type PostTransferV2Handler struct {
+	postTransferService postTransferV2Servicer
+	jwt                 postTransferV2JWT
+	stepUp              postTransferV2StepUp
+}
+
+func NewPostTransferV2Handler(postTransferService postTransferV2Servicer, jwt postTransferV2JWT, stepUp postTransferV2StepUp) func(*fiber.Ctx) error {
+	h := &PostTransferV2Handler{
+		postTransferService: postTransferService,
+		jwt:                 jwt,
+		stepUp:              stepUp,
+	}
+	return h.handle
+}
+
+type transferRequestV2 struct {
+	Login          string        `json:"login"`
+	Sum            models.Amount `json:"sum"`
+	IdempotencyKey string        `json:"idempotency_key"`
+}
+
+func (h *PostTransferV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	var req transferRequestV2
+	err = json.Unmarshal(c.Body(), &req)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	transfer := &models.Transfer{
+		SenderID:       uid,
+		Recipient:      req.Login,
+		Sum:            float64(req.Sum),
+		IdempotencyKey: req.IdempotencyKey,
+	}
+
+	err = h.stepUp.VerifyStepUp(c.Context(), uid, transfer.Sum, c.Get(totpCodeHeader))
+	if e, ok := err.(errWrongTOTPCode); ok && e.IsErrWrongTOTPCode() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.WithStatus(fiber.StatusForbidden, err)
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	err = h.postTransferService.TransferProcessing(c.Context(), transfer)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
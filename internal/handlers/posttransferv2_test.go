@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostTransferV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostTransferV2Servicer(ctrl)
+	mJWT := mocks.NewMockpostTransferV2JWT(ctrl)
+	mStepUp := mocks.NewMockpostTransferV2StepUp(ctrl)
+
+	postTransferHandler := NewPostTransferV2Handler(mService, mJWT, mStepUp)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postTransferHandler)
+
+	transfer := &models.Transfer{
+		SenderID:       testUserID,
+		Recipient:      "friend",
+		Sum:            15.5,
+		IdempotencyKey: "key",
+	}
+	testBody := `{"login":"friend","sum":"15.50","idempotency_key":"key"}`
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("wrong json body", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{"login":"friend","sum":"1.234"}`))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("totp code", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "123456").Return(nil)
+		mService.EXPECT().TransferProcessing(gomock.Any(), transfer).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "123456")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("totp required", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrTOTPRequired(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrTOTPRequired().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "").Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("wrong totp code", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true).Times(2)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, transfer.Sum, "000000").Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "000000")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+}
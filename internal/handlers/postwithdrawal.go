@@ -7,11 +7,14 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/rycln/loyalsys/internal/logger"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
 	"go.uber.org/zap"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+const totpCodeHeader = "X-TOTP-Code"
+
 type postWithdrawalServicer interface {
 	WithdrawalProcessing(context.Context, *models.Withdrawal) error
 }
@@ -20,56 +23,54 @@ type postWithdrawalJWT interface {
 	ParseIDFromAuthHeader(string) (models.UserID, error)
 }
 
+type postWithdrawalStepUp interface {
+	VerifyStepUp(context.Context, models.UserID, float64, string) error
+}
+
 type PostWithdrawalHandler struct {
 	postWithdrawalService postWithdrawalServicer
 	jwt                   postWithdrawalJWT
+	stepUp                postWithdrawalStepUp
 }
 
-func NewPostWithdrawalHandler(postWithdrawalService postWithdrawalServicer, jwt postWithdrawalJWT) func(*fiber.Ctx) error {
+func NewPostWithdrawalHandler(postWithdrawalService postWithdrawalServicer, jwt postWithdrawalJWT, stepUp postWithdrawalStepUp) func(*fiber.Ctx) error {
 	h := &PostWithdrawalHandler{
 		postWithdrawalService: postWithdrawalService,
 		jwt:                   jwt,
+		stepUp:                stepUp,
 	}
 	return h.handle
 }
 
-type errNotEnoughCurrency interface {
-	error
-	IsErrNotEnoughCurrency() bool
-}
-
-type errWrongOrderNum interface {
-	error
-	IsErrWrongOrderNum() bool
-}
-
 func (h *PostWithdrawalHandler) handle(c *fiber.Ctx) error {
 	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	var withdrawal models.Withdrawal
 	err = json.Unmarshal(c.Body(), &withdrawal)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusBadRequest)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
 	}
 	withdrawal.UserID = uid
 
-	err = h.postWithdrawalService.WithdrawalProcessing(c.Context(), &withdrawal)
-	if e, ok := err.(errNotEnoughCurrency); ok && e.IsErrNotEnoughCurrency() {
+	err = h.stepUp.VerifyStepUp(c.Context(), uid, withdrawal.Sum, c.Get(totpCodeHeader))
+	if e, ok := err.(errWrongTOTPCode); ok && e.IsErrWrongTOTPCode() {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusPaymentRequired)
+		return problem.WithStatus(fiber.StatusForbidden, err)
 	}
-	if e, ok := err.(errWrongOrderNum); ok && e.IsErrWrongOrderNum() {
+	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusUnprocessableEntity)
+		return err
 	}
+
+	err = h.postWithdrawalService.WithdrawalProcessing(c.Context(), &withdrawal)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 	return c.SendStatus(fiber.StatusOK)
 }
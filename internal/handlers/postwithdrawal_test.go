@@ -11,6 +11,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/handlers/mocks"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,10 +23,11 @@ func TestPostWithdrawalHandler_handle(t *testing.T) {
 
 	mService := mocks.NewMockpostWithdrawalServicer(ctrl)
 	mJWT := mocks.NewMockpostWithdrawalJWT(ctrl)
+	mStepUp := mocks.NewMockpostWithdrawalStepUp(ctrl)
 
-	postWithdrawalHandler := NewPostWithdrawalHandler(mService, mJWT)
+	postWithdrawalHandler := NewPostWithdrawalHandler(mService, mJWT, mStepUp)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Post("/", postWithdrawalHandler)
 
 	withdrawal := &models.Withdrawal{
@@ -37,6 +40,7 @@ func TestPostWithdrawalHandler_handle(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
 		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(nil)
 
 		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
@@ -79,10 +83,11 @@ func TestPostWithdrawalHandler_handle(t *testing.T) {
 	})
 
 	t.Run("not enough currency error", func(t *testing.T) {
-		mErr := mocks.NewMockerrNotEnoughCurrency(ctrl)
+		mErr := problemmocks.NewMockerrNotEnoughCurrency(ctrl)
 
 		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
 		mErr.EXPECT().IsErrNotEnoughCurrency().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
 		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(mErr)
 
 		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
@@ -96,11 +101,31 @@ func TestPostWithdrawalHandler_handle(t *testing.T) {
 		assert.Equal(t, fiber.StatusPaymentRequired, res.StatusCode)
 	})
 
+	t.Run("idempotency conflict error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrIdempotencyConflict(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrIdempotencyConflict().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
+		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
+	})
+
 	t.Run("luhn validation error", func(t *testing.T) {
-		mErr := mocks.NewMockerrWrongOrderNum(ctrl)
+		mErr := problemmocks.NewMockerrWrongOrderNum(ctrl)
 
 		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
 		mErr.EXPECT().IsErrWrongOrderNum().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
 		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(mErr)
 
 		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
@@ -116,6 +141,7 @@ func TestPostWithdrawalHandler_handle(t *testing.T) {
 
 	t.Run("some error", func(t *testing.T) {
 		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
 		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(errTest)
 
 		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
@@ -128,4 +154,73 @@ func TestPostWithdrawalHandler_handle(t *testing.T) {
 
 		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
 	})
+
+	t.Run("totp code", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "123456").Return(nil)
+		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(nil)
+
+		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "123456")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("totp required", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrTOTPRequired(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrTOTPRequired().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("wrong totp code", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true).Times(2)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "000000").Return(mErr)
+
+		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "000000")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("step up error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(errTest)
+
+		bodyReader := bytes.NewReader([]byte(testWithdrawalsJSON))
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
 }
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type postWithdrawalV2Servicer interface {
+	WithdrawalProcessing(context.Context, *models.Withdrawal) error
+}
+
+type postWithdrawalV2JWT interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type postWithdrawalV2StepUp interface {
+	VerifyStepUp(context.Context, models.UserID, float64, string) error
+}
+
+type PostWithdrawalV2Handler struct {
+	postWithdrawalService postWithdrawalV2Servicer
+	jwt                   postWithdrawalV2JWT
+	stepUp                postWithdrawalV2StepUp
+}
+
+func NewPostWithdrawalV2Handler(postWithdrawalService postWithdrawalV2Servicer, jwt postWithdrawalV2JWT, stepUp postWithdrawalV2StepUp) func(*fiber.Ctx) error {
+	h := &PostWithdrawalV2Handler{
+		postWithdrawalService: postWithdrawalService,
+		jwt:                   jwt,
+		stepUp:                stepUp,
+	}
+	return h.handle
+}
+
+type withdrawalRequestV2 struct {
+	Order string        `json:"order"`
+	Sum   models.Amount `json:"sum"`
+}
+
+func (h *PostWithdrawalV2Handler) handle(c *fiber.Ctx) error {
+	uid, err := h.jwt.ParseIDFromAuthHeader(c.Get("Authorization"))
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	var req withdrawalRequestV2
+	err = json.Unmarshal(c.Body(), &req)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
+	}
+	withdrawal := &models.Withdrawal{
+		Order:  req.Order,
+		UserID: uid,
+		Sum:    float64(req.Sum),
+	}
+	if withdrawal.Sum <= 0 {
+		return problem.New(fiber.StatusUnprocessableEntity, problem.CodeInvalidWithdrawal)
+	}
+
+	err = h.stepUp.VerifyStepUp(c.Context(), uid, withdrawal.Sum, c.Get(totpCodeHeader))
+	if e, ok := err.(errWrongTOTPCode); ok && e.IsErrWrongTOTPCode() {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return problem.WithStatus(fiber.StatusForbidden, err)
+	}
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+
+	err = h.postWithdrawalService.WithdrawalProcessing(c.Context(), withdrawal)
+	if err != nil {
+		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+		return err
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/handlers/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostWithdrawalV2Handler_handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mService := mocks.NewMockpostWithdrawalV2Servicer(ctrl)
+	mJWT := mocks.NewMockpostWithdrawalV2JWT(ctrl)
+	mStepUp := mocks.NewMockpostWithdrawalV2StepUp(ctrl)
+
+	postWithdrawalHandler := NewPostWithdrawalV2Handler(mService, mJWT, mStepUp)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", postWithdrawalHandler)
+
+	withdrawal := &models.Withdrawal{
+		Order:  validLuhnString,
+		UserID: testUserID,
+		Sum:    10.25,
+	}
+	testBody := fmt.Sprintf(`{"order":%q,"sum":"10.25"}`, validLuhnString)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "123456").Return(nil)
+		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "123456")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("numeric sum", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(fmt.Sprintf(`{"order":%q,"sum":10.25}`, validLuhnString)))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("non-positive sum", func(t *testing.T) {
+		for _, sum := range []string{"0", "0.00"} {
+			mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+			request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(fmt.Sprintf(`{"order":%q,"sum":%q}`, validLuhnString, sum)))
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+			res, err := app.Test(request, -1)
+			require.NoError(t, err)
+			res.Body.Close()
+
+			assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+			assert.Equal(t, problem.ContentType, res.Header.Get("Content-Type"))
+		}
+	})
+
+	t.Run("negative sum", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(fmt.Sprintf(`{"order":%q,"sum":"-10"}`, validLuhnString)))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("not enough currency error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrNotEnoughCurrency(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrNotEnoughCurrency().Return(true)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
+		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusPaymentRequired, res.StatusCode)
+		assert.Equal(t, problem.ContentType, res.Header.Get("Content-Type"))
+	})
+
+	t.Run("wrong totp code", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true).Times(2)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "000000").Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+		request.Header.Set(totpCodeHeader, "000000")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(testUserID, nil)
+		mStepUp.EXPECT().VerifyStepUp(gomock.Any(), testUserID, withdrawal.Sum, "").Return(nil)
+		mService.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mJWT.EXPECT().ParseIDFromAuthHeader(fmt.Sprintf("Bearer %s", testJWTString)).Return(models.UserID(0), errTest)
+
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(testBody))
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", testJWTString))
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/rycln/loyalsys/internal/logger"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
 	"go.uber.org/zap"
 )
 
@@ -34,37 +35,29 @@ func NewRegisterHandler(regService regServicer, jwt regJWT) func(*fiber.Ctx) err
 	return h.handle
 }
 
-type errLoginConflict interface {
-	error
-	IsErrLoginConflict() bool
-}
-
 func (h *RegisterHandler) handle(c *fiber.Ctx) error {
 	var user models.User
 	err := json.Unmarshal(c.Body(), &user)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusBadRequest)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
 	}
 	err = user.Validate()
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusBadRequest)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidRequest)
 	}
 
 	uid, err := h.regService.CreateUser(c.Context(), &user)
-	if e, ok := err.(errLoginConflict); ok && e.IsErrLoginConflict() {
-		return c.SendStatus(fiber.StatusConflict)
-	}
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 
 	jwt, err := h.jwt.NewJWTString(uid)
 	if err != nil {
 		logger.Log.Debug("path:"+c.Path(), zap.Error(err))
-		return c.SendStatus(fiber.StatusInternalServerError)
+		return err
 	}
 	c.Set("Content-Type", "application/json")
 	c.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
@@ -10,6 +10,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/handlers/mocks"
 	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,7 +25,7 @@ func TestRegisterHandler_handle(t *testing.T) {
 
 	registerHandler := NewRegisterHandler(mService, mJWT)
 
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Post("/", registerHandler)
 
 	t.Run("valid test", func(t *testing.T) {
@@ -65,7 +67,7 @@ func TestRegisterHandler_handle(t *testing.T) {
 			Login:    testUserLogin,
 			Password: testUserPassword,
 		}
-		mErr := mocks.NewMockerrLoginConflict(ctrl)
+		mErr := problemmocks.NewMockerrLoginConflict(ctrl)
 		mErr.EXPECT().IsErrLoginConflict().Return(true)
 		mService.EXPECT().CreateUser(gomock.Any(), testUser).Return(models.UserID(0), mErr)
 
@@ -81,6 +83,56 @@ func TestRegisterHandler_handle(t *testing.T) {
 		assert.Equal(t, fiber.StatusConflict, res.StatusCode)
 	})
 
+	t.Run("wrong referral code", func(t *testing.T) {
+		testUser := &models.User{
+			Login:        testUserLogin,
+			Password:     testUserPassword,
+			ReferralCode: "ABCDEFGHIJ",
+		}
+		mErr := problemmocks.NewMockerrWrongReferralCode(ctrl)
+		mErr.EXPECT().IsErrWrongReferralCode().Return(true)
+		mService.EXPECT().CreateUser(gomock.Any(), testUser).Return(models.UserID(0), mErr)
+
+		body, err := json.Marshal(testUser)
+		require.NoError(t, err)
+		bodyReader := bytes.NewReader(body)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, res.StatusCode)
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    testUserLogin,
+			Password: testUserPassword,
+		}
+		mErr := problemmocks.NewMockerrWeakPassword(ctrl)
+		mErr.EXPECT().IsErrWeakPassword().Return(true)
+		mErr.EXPECT().Error().Return("weak password: too short")
+		mService.EXPECT().CreateUser(gomock.Any(), testUser).Return(models.UserID(0), mErr)
+
+		body, err := json.Marshal(testUser)
+		require.NoError(t, err)
+		bodyReader := bytes.NewReader(body)
+		request := httptest.NewRequest(fiber.MethodPost, "/", bodyReader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, problem.ContentType, res.Header.Get("Content-Type"))
+		var prob problem.Problem
+		err = json.NewDecoder(res.Body).Decode(&prob)
+		require.NoError(t, err)
+		assert.Equal(t, problem.CodeWeakPassword, prob.Code)
+		assert.Equal(t, "weak password: too short", prob.Detail)
+	})
+
 	t.Run("some error", func(t *testing.T) {
 		testUser := &models.User{
 			Login:    testUserLogin,
@@ -2,19 +2,22 @@ package logger
 
 import (
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 var Log *zap.Logger = zap.NewNop()
 
-func LogInit(level string) error {
-	lvl, err := zap.ParseAtomicLevel(level)
+var level = zap.NewAtomicLevel()
+
+func LogInit(lvl string) error {
+	err := SetLevel(lvl)
 	if err != nil {
 		return err
 	}
 
 	cfg := zap.NewDevelopmentConfig()
-	cfg.Level = lvl
-	if cfg.Level.Level() != zap.DebugLevel {
+	cfg.Level = level
+	if level.Level() != zap.DebugLevel {
 		cfg.DisableCaller = true
 	}
 
@@ -26,3 +29,17 @@ func LogInit(level string) error {
 	Log = zl
 	return nil
 }
+
+func SetLevel(lvl string) error {
+	l, err := zapcore.ParseLevel(lvl)
+	if err != nil {
+		return err
+	}
+
+	level.SetLevel(l)
+	return nil
+}
+
+func Level() zapcore.Level {
+	return level.Level()
+}
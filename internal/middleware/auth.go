@@ -2,13 +2,14 @@ package middleware
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/problem"
 )
 
 func NoTokenChecker() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.SendStatus(fiber.StatusUnauthorized)
+			return problem.New(fiber.StatusUnauthorized, problem.CodeUnauthorized)
 		}
 		return c.Next()
 	}
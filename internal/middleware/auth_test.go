@@ -5,12 +5,13 @@ import (
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/problem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestNoTokenChecker(t *testing.T) {
-	app := fiber.New()
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
 	app.Get("/", NoTokenChecker(), SendStausOK)
 
 	t.Run("valid test", func(t *testing.T) {
@@ -6,6 +6,7 @@ import (
 	"slices"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/problem"
 )
 
 func ContentTypeChecker(allowedTypes ...string) fiber.Handler {
@@ -18,6 +19,6 @@ func ContentTypeChecker(allowedTypes ...string) fiber.Handler {
 		if slices.Contains(allowedTypes, mimeType) {
 			return c.Next()
 		}
-		return c.SendStatus(fiber.StatusBadRequest)
+		return problem.New(fiber.StatusBadRequest, problem.CodeInvalidContentType)
 	}
 }
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Deprecation marks responses as coming from a deprecated API version and
+// points clients to its successor.
+func Deprecation(successor string) fiber.Handler {
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+	return func(c *fiber.Ctx) error {
+		c.Set("Deprecation", "true")
+		c.Set(fiber.HeaderLink, link)
+		return c.Next()
+	}
+}
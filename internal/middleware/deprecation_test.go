@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecation(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Use("/old", Deprecation("/new"))
+	app.Get("/old", SendStausOK)
+	app.Get("/old/error", func(c *fiber.Ctx) error {
+		return problem.New(fiber.StatusNotFound, problem.CodeInvalidRequest)
+	})
+	app.Get("/new", SendStausOK)
+
+	t.Run("valid test", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/old", nil), -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "true", res.Header.Get("Deprecation"))
+		assert.Equal(t, `</new>; rel="successor-version"`, res.Header.Get("Link"))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/old/error", nil), -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+		assert.Equal(t, "true", res.Header.Get("Deprecation"))
+	})
+
+	t.Run("other route", func(t *testing.T) {
+		res, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/new", nil), -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Empty(t, res.Header.Get("Deprecation"))
+	})
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: openapi.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	http "net/http"
+	reflect "reflect"
+
+	routers "github.com/getkin/kin-openapi/routers"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockrouteFinder is a mock of routeFinder interface.
+type MockrouteFinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockrouteFinderMockRecorder
+}
+
+// MockrouteFinderMockRecorder is the mock recorder for MockrouteFinder.
+type MockrouteFinderMockRecorder struct {
+	mock *MockrouteFinder
+}
+
+// NewMockrouteFinder creates a new mock instance.
+func NewMockrouteFinder(ctrl *gomock.Controller) *MockrouteFinder {
+	mock := &MockrouteFinder{ctrl: ctrl}
+	mock.recorder = &MockrouteFinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrouteFinder) EXPECT() *MockrouteFinderMockRecorder {
+	return m.recorder
+}
+
+// FindRoute mocks base method.
+func (m *MockrouteFinder) FindRoute(arg0 *http.Request) (*routers.Route, map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRoute", arg0)
+	ret0, _ := ret[0].(*routers.Route)
+	ret1, _ := ret[1].(map[string]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindRoute indicates an expected call of FindRoute.
+func (mr *MockrouteFinderMockRecorder) FindRoute(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRoute", reflect.TypeOf((*MockrouteFinder)(nil).FindRoute), arg0)
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocksessionValidator is a mock of sessionValidator interface.
+type MocksessionValidator struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionValidatorMockRecorder
+}
+
+// MocksessionValidatorMockRecorder is the mock recorder for MocksessionValidator.
+type MocksessionValidatorMockRecorder struct {
+	mock *MocksessionValidator
+}
+
+// NewMocksessionValidator creates a new mock instance.
+func NewMocksessionValidator(ctrl *gomock.Controller) *MocksessionValidator {
+	mock := &MocksessionValidator{ctrl: ctrl}
+	mock.recorder = &MocksessionValidatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionValidator) EXPECT() *MocksessionValidatorMockRecorder {
+	return m.recorder
+}
+
+// ValidateSession mocks base method.
+func (m *MocksessionValidator) ValidateSession(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ValidateSession indicates an expected call of ValidateSession.
+func (mr *MocksessionValidatorMockRecorder) ValidateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSession", reflect.TypeOf((*MocksessionValidator)(nil).ValidateSession), arg0, arg1)
+}
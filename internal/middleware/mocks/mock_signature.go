@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: signature.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MocknonceStore is a mock of nonceStore interface.
+type MocknonceStore struct {
+	ctrl     *gomock.Controller
+	recorder *MocknonceStoreMockRecorder
+}
+
+// MocknonceStoreMockRecorder is the mock recorder for MocknonceStore.
+type MocknonceStoreMockRecorder struct {
+	mock *MocknonceStore
+}
+
+// NewMocknonceStore creates a new mock instance.
+func NewMocknonceStore(ctrl *gomock.Controller) *MocknonceStore {
+	mock := &MocknonceStore{ctrl: ctrl}
+	mock.recorder = &MocknonceStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocknonceStore) EXPECT() *MocknonceStoreMockRecorder {
+	return m.recorder
+}
+
+// Use mocks base method.
+func (m *MocknonceStore) Use(arg0 context.Context, arg1 string, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Use", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Use indicates an expected call of Use.
+func (mr *MocknonceStoreMockRecorder) Use(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Use", reflect.TypeOf((*MocknonceStore)(nil).Use), arg0, arg1, arg2)
+}
@@ -0,0 +1,106 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/items": {
+      "post": {
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "required": ["name"],
+                "properties": {"name": {"type": "string", "minLength": 1}}
+              }
+            }
+          }
+        },
+        "responses": {"200": {"description": "ok"}}
+      }
+    }
+  }
+}`
+
+func TestRequestValidator(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(testSpec))
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Use(RequestValidator(router))
+	app.Post("/items", SendStausOK)
+	app.Post("/other", SendStausOK)
+
+	t.Run("valid test", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodPost, "/items", strings.NewReader(`{"name":"item"}`))
+		request.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("wrong content type", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodPost, "/items", strings.NewReader(`{"name":"item"}`))
+		request.Header.Set("Content-Type", "text/plain")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		var prob problem.Problem
+		err = json.NewDecoder(res.Body).Decode(&prob)
+		require.NoError(t, err)
+		assert.Equal(t, problem.CodeInvalidContentType, prob.Code)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodPost, "/items", strings.NewReader(`{"name":""}`))
+		request.Header.Set("Content-Type", "application/json")
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		var prob problem.Problem
+		err = json.NewDecoder(res.Body).Decode(&prob)
+		require.NoError(t, err)
+		assert.Equal(t, problem.CodeInvalidRequest, prob.Code)
+		assert.NotEmpty(t, prob.Detail)
+	})
+
+	t.Run("undocumented route", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodPost, "/other", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+}
@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+const (
+	headerRateLimitLimit     = "RateLimit-Limit"
+	headerRateLimitRemaining = "RateLimit-Remaining"
+	headerRateLimitReset     = "RateLimit-Reset"
+)
+
+type rateLimiter interface {
+	Allow(context.Context, string) (*models.RateLimit, error)
+}
+
+type userIDParser interface {
+	ParseIDFromAuthHeader(string) (models.UserID, error)
+}
+
+type RateLimitKey func(*fiber.Ctx) (string, error)
+
+func ByIP() RateLimitKey {
+	return func(c *fiber.Ctx) (string, error) {
+		return "ip:" + c.IP(), nil
+	}
+}
+
+func ByUser(parser userIDParser) RateLimitKey {
+	return func(c *fiber.Ctx) (string, error) {
+		uid, err := parser.ParseIDFromAuthHeader(c.Get("Authorization"))
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("user:%d", uid), nil
+	}
+}
+
+func RateLimiter(name string, limiter rateLimiter, key RateLimitKey) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		k, err := key(c)
+		if err != nil {
+			logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+			return err
+		}
+
+		res, err := limiter.Allow(c.Context(), name+":"+k)
+		if err != nil {
+			logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+			return err
+		}
+
+		reset := ceilSeconds(res.Reset)
+		c.Set(headerRateLimitLimit, strconv.Itoa(res.Limit))
+		c.Set(headerRateLimitRemaining, strconv.Itoa(res.Remaining))
+		c.Set(headerRateLimitReset, reset)
+		if !res.Allowed {
+			c.Set(fiber.HeaderRetryAfter, reset)
+			return problem.New(fiber.StatusTooManyRequests, problem.CodeRateLimited)
+		}
+		return c.Next()
+	}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/middleware/mocks"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mLimiter := mocks.NewMockrateLimiter(ctrl)
+	mParser := mocks.NewMockuserIDParser(ctrl)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/user", RateLimiter("orders", mLimiter, ByUser(mParser)), SendStausOK)
+	app.Get("/ip", RateLimiter("public", mLimiter, ByIP()), SendStausOK)
+
+	t.Run("valid test", func(t *testing.T) {
+		mParser.EXPECT().ParseIDFromAuthHeader(testAuthHeader).Return(models.UserID(1), nil)
+		mLimiter.EXPECT().Allow(gomock.Any(), "orders:user:1").Return(&models.RateLimit{
+			Limit:     10,
+			Remaining: 9,
+			Reset:     time.Duration(1500) * time.Millisecond,
+			Allowed:   true,
+		}, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/user", nil)
+		request.Header.Set("Authorization", testAuthHeader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "10", res.Header.Get("RateLimit-Limit"))
+		assert.Equal(t, "9", res.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "2", res.Header.Get("RateLimit-Reset"))
+		assert.Empty(t, res.Header.Get(fiber.HeaderRetryAfter))
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		mLimiter.EXPECT().Allow(gomock.Any(), "public:ip:0.0.0.0").Return(&models.RateLimit{
+			Limit: 10,
+			Reset: time.Duration(30) * time.Second,
+		}, nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/ip", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusTooManyRequests, res.StatusCode)
+		assert.Equal(t, "0", res.Header.Get("RateLimit-Remaining"))
+		assert.Equal(t, "30", res.Header.Get(fiber.HeaderRetryAfter))
+		assert.Equal(t, problem.ContentType, res.Header.Get("Content-Type"))
+	})
+
+	t.Run("jwt error", func(t *testing.T) {
+		mParser.EXPECT().ParseIDFromAuthHeader(testAuthHeader).Return(models.UserID(0), errors.New("test error"))
+
+		request := httptest.NewRequest(fiber.MethodGet, "/user", nil)
+		request.Header.Set("Authorization", testAuthHeader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+
+	t.Run("limiter error", func(t *testing.T) {
+		mLimiter.EXPECT().Allow(gomock.Any(), "public:ip:0.0.0.0").Return(nil, errors.New("test error"))
+
+		request := httptest.NewRequest(fiber.MethodGet, "/ip", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/logger"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type sessionValidator interface {
+	ValidateSession(context.Context, string) error
+}
+
+func SessionChecker(validator sessionValidator) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := validator.ValidateSession(c.Context(), c.Get("Authorization"))
+		if err != nil {
+			logger.Log.Debug("path:"+c.Path(), zap.Error(err))
+			return err
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/middleware/mocks"
+	"github.com/rycln/loyalsys/internal/problem"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAuthHeader = "Bearer abc.def.ghi"
+
+func TestSessionChecker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mValidator := mocks.NewMocksessionValidator(ctrl)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", SessionChecker(mValidator), SendStausOK)
+
+	t.Run("valid test", func(t *testing.T) {
+		mValidator.EXPECT().ValidateSession(gomock.Any(), testAuthHeader).Return(nil)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", testAuthHeader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrSessionRevoked(ctrl)
+		mErr.EXPECT().IsErrSessionRevoked().Return(true)
+		mErr.EXPECT().Error().Return("session was revoked").AnyTimes()
+		mValidator.EXPECT().ValidateSession(gomock.Any(), testAuthHeader).Return(mErr)
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", testAuthHeader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mValidator.EXPECT().ValidateSession(gomock.Any(), testAuthHeader).Return(errors.New("test error"))
+
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		request.Header.Set("Authorization", testAuthHeader)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusInternalServerError, res.StatusCode)
+	})
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/middleware/mocks"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testSignatureBody  = `{"order":"123","status":"PROCESSED","accrual":10}`
+	testSignatureNonce = "b7f3c1d2"
+	testReplayWindow   = time.Duration(5) * time.Minute
+)
+
+var testSecret = []byte("secret")
+
+func TestSignatureChecker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mNonces := mocks.NewMocknonceStore(ctrl)
+
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Post("/", SignatureChecker(testSecret, testReplayWindow, mNonces), SendStausOK)
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-2*testReplayWindow).Unix(), 10)
+	validSignature := Sign(testSecret, now, testSignatureNonce, []byte(testSignatureBody))
+
+	send := func(t *testing.T, signature, timestamp, nonce, body string) int {
+		request := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(body))
+		if signature != "" {
+			request.Header.Set(HeaderSignature, signature)
+		}
+		request.Header.Set(HeaderSignatureTimestamp, timestamp)
+		request.Header.Set(HeaderSignatureNonce, nonce)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mNonces.EXPECT().Use(gomock.Any(), testSignatureNonce, gomock.Any()).Return(true, nil)
+
+		assert.Equal(t, fiber.StatusOK, send(t, validSignature, now, testSignatureNonce, testSignatureBody))
+	})
+
+	t.Run("replayed nonce", func(t *testing.T) {
+		mNonces.EXPECT().Use(gomock.Any(), testSignatureNonce, gomock.Any()).Return(false, nil)
+
+		assert.Equal(t, fiber.StatusUnauthorized, send(t, validSignature, now, testSignatureNonce, testSignatureBody))
+	})
+
+	t.Run("nonce store error", func(t *testing.T) {
+		mNonces.EXPECT().Use(gomock.Any(), testSignatureNonce, gomock.Any()).Return(false, errors.New("test error"))
+
+		assert.Equal(t, fiber.StatusInternalServerError, send(t, validSignature, now, testSignatureNonce, testSignatureBody))
+	})
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		nonce     string
+		body      string
+	}{
+		{"no signature", "", now, testSignatureNonce, testSignatureBody},
+		{"no prefix", strings.TrimPrefix(validSignature, "sha256="), now, testSignatureNonce, testSignatureBody},
+		{"not hex", "sha256=xyz", now, testSignatureNonce, testSignatureBody},
+		{"other secret", Sign([]byte("other"), now, testSignatureNonce, []byte(testSignatureBody)), now, testSignatureNonce, testSignatureBody},
+		{"tampered body", validSignature, now, testSignatureNonce, strings.Replace(testSignatureBody, "10", "1000", 1)},
+		{"tampered nonce", validSignature, now, "a91e04f5", testSignatureBody},
+		{"no nonce", Sign(testSecret, now, "", []byte(testSignatureBody)), now, "", testSignatureBody},
+		{"malformed timestamp", Sign(testSecret, "yesterday", testSignatureNonce, []byte(testSignatureBody)), "yesterday", testSignatureNonce, testSignatureBody},
+		{"stale timestamp", Sign(testSecret, stale, testSignatureNonce, []byte(testSignatureBody)), stale, testSignatureNonce, testSignatureBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, fiber.StatusUnauthorized, send(t, tt.signature, tt.timestamp, tt.nonce, tt.body))
+		})
+	}
+}
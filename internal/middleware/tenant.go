@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/valyala/fasthttp"
+)
+
+// TenantRoute is the handler serving a tenant and the hosts it is reached on.
+type TenantRoute struct {
+	ID      models.TenantID
+	Hosts   []string
+	Handler fasthttp.RequestHandler
+}
+
+// TenantRouter hands each request to the handler of the tenant it is
+// addressed to. The tenant header takes precedence over the host. With a
+// single tenant, requests that name neither go to it.
+func TenantRouter(header string, routes []TenantRoute) fiber.Handler {
+	byID := make(map[models.TenantID]fasthttp.RequestHandler, len(routes))
+	byHost := make(map[string]fasthttp.RequestHandler)
+	for _, route := range routes {
+		byID[route.ID] = route.Handler
+		for _, host := range route.Hosts {
+			byHost[strings.ToLower(host)] = route.Handler
+		}
+	}
+	var fallback fasthttp.RequestHandler
+	if len(routes) == 1 {
+		fallback = routes[0].Handler
+	}
+
+	return func(c *fiber.Ctx) error {
+		handler := fallback
+		if id := c.Get(header); header != "" && id != "" {
+			handler = byID[models.TenantID(id)]
+		} else if h, ok := byHost[hostname(c)]; ok {
+			handler = h
+		}
+		if handler == nil {
+			return problem.New(fiber.StatusBadRequest, problem.CodeUnknownTenant)
+		}
+		handler(c.Context())
+		return nil
+	}
+}
+
+func hostname(c *fiber.Ctx) string {
+	host := c.Hostname()
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(host)
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rycln/loyalsys/internal/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+)
+
+const testTenantHeader = "X-Tenant-ID"
+
+func newTenantHandler(name string) fasthttp.RequestHandler {
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(name)
+	})
+	return app.Handler()
+}
+
+func TestTenantRouter(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Use(TenantRouter(testTenantHeader, []TenantRoute{
+		{ID: "brand", Hosts: []string{"brand.example.com"}, Handler: newTenantHandler("brand")},
+		{ID: "other_brand", Hosts: []string{"Other.example.com"}, Handler: newTenantHandler("other_brand")},
+	}))
+
+	tests := []struct {
+		name   string
+		host   string
+		tenant string
+		want   string
+	}{
+		{"by host", "brand.example.com", "", "brand"},
+		{"by host with port", "other.example.com:8080", "", "other_brand"},
+		{"header wins over host", "brand.example.com", "other_brand", "other_brand"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Host = tt.host
+			if tt.tenant != "" {
+				req.Header.Set(testTenantHeader, tt.tenant)
+			}
+			res, err := app.Test(req, -1)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, fiber.StatusOK, res.StatusCode)
+			assert.Equal(t, tt.want, string(body))
+		})
+	}
+
+	t.Run("unknown host", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Host = "unknown.example.com"
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, problem.ContentType, res.Header.Get("Content-Type"))
+	})
+
+	t.Run("unknown tenant header", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Host = "brand.example.com"
+		req.Header.Set(testTenantHeader, "nobody")
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+	})
+}
+
+func TestTenantRouter_singleTenant(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: problem.ErrorHandler})
+	app.Use(TenantRouter("", []TenantRoute{
+		{ID: "default", Handler: newTenantHandler("default")},
+	}))
+
+	t.Run("valid test", func(t *testing.T) {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(testTenantHeader, "other_brand")
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, res.StatusCode)
+		assert.Equal(t, "default", string(body))
+	})
+}
@@ -0,0 +1,256 @@
+package migrator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	"github.com/rycln/loyalsys/internal/db"
+)
+
+const migrationsDir = "migrations"
+
+const (
+	sqlAdvisoryLock   = `SELECT pg_advisory_lock($1)`
+	sqlAdvisoryUnlock = `SELECT pg_advisory_unlock($1)`
+)
+
+var (
+	ErrSchemaBehind = errors.New("database schema is behind the migrations")
+	ErrSchemaAhead  = errors.New("database schema is ahead of the migrations")
+)
+
+type Step struct {
+	Version int64
+	Path    string
+	Up      bool
+	SQL     string
+}
+
+// Migrator runs every command under the advisory lock of goose. unlocked
+// runs without taking it, for the commands that take the lock themselves
+// to hold it across several migrations.
+type Migrator struct {
+	db       *sql.DB
+	provider *goose.Provider
+	unlocked *goose.Provider
+	fsys     fs.FS
+}
+
+func New(database *sql.DB) (*Migrator, error) {
+	fsys, err := MigrationsFS()
+	if err != nil {
+		return nil, err
+	}
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+	provider, err := goose.NewProvider(goose.DialectPostgres, database, fsys, goose.WithSessionLocker(locker))
+	if err != nil {
+		return nil, err
+	}
+	unlocked, err := goose.NewProvider(goose.DialectPostgres, database, fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:       database,
+		provider: provider,
+		unlocked: unlocked,
+		fsys:     fsys,
+	}, nil
+}
+
+func MigrationsFS() (fs.FS, error) {
+	return fs.Sub(db.MigrationsFS, migrationsDir)
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*goose.MigrationResult, error) {
+	return m.provider.Up(ctx)
+}
+
+func (m *Migrator) UpTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	return m.provider.UpTo(ctx, version)
+}
+
+func (m *Migrator) Down(ctx context.Context) (*goose.MigrationResult, error) {
+	return m.provider.Down(ctx)
+}
+
+func (m *Migrator) DownTo(ctx context.Context, version int64) ([]*goose.MigrationResult, error) {
+	return m.provider.DownTo(ctx, version)
+}
+
+// Redo holds the lock from the down migration to the up one, so no other
+// migrator can run in between.
+func (m *Migrator) Redo(ctx context.Context) ([]*goose.MigrationResult, error) {
+	var results []*goose.MigrationResult
+	err := m.withLock(ctx, func() error {
+		down, err := m.unlocked.Down(ctx)
+		if err != nil {
+			return err
+		}
+		results = append(results, down)
+		up, err := m.unlocked.ApplyVersion(ctx, down.Source.Version, true)
+		if err != nil {
+			return err
+		}
+		results = append(results, up)
+		return nil
+	})
+	return results, err
+}
+
+// withLock runs fn holding the advisory lock goose takes for every command
+// on a connection of its own.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, sqlAdvisoryLock, lock.DefaultLockID)
+	if err != nil {
+		return err
+	}
+	fnErr := fn()
+	_, err = conn.ExecContext(context.WithoutCancel(ctx), sqlAdvisoryUnlock, lock.DefaultLockID)
+	if err != nil {
+		// A connection that still holds the lock must not go back to the
+		// pool.
+		conn.Raw(func(any) error {
+			return driver.ErrBadConn
+		})
+	}
+	return errors.Join(fnErr, err)
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]*goose.MigrationStatus, error) {
+	return m.provider.Status(ctx)
+}
+
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	return m.provider.GetDBVersion(ctx)
+}
+
+func (m *Migrator) SchemaVersion(ctx context.Context) (int64, error) {
+	current, _, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return current, nil
+}
+
+func (m *Migrator) Check(ctx context.Context) (int64, error) {
+	current, target, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if current > target {
+		return current, fmt.Errorf("%w: version %d, newest migration %d", ErrSchemaAhead, current, target)
+	}
+	pending, err := m.provider.HasPending(ctx)
+	if err != nil {
+		return current, err
+	}
+	if current < target || pending {
+		return current, fmt.Errorf("%w: version %d, newest migration %d", ErrSchemaBehind, current, target)
+	}
+	return current, nil
+}
+
+func (m *Migrator) PlanUp(ctx context.Context, version int64) ([]*Step, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.steps(selectUp(statuses, version), true)
+}
+
+func (m *Migrator) PlanDown(ctx context.Context, version int64, limit int) ([]*Step, error) {
+	statuses, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.steps(selectDown(statuses, version, limit), false)
+}
+
+func (m *Migrator) PlanRedo(ctx context.Context) ([]*Step, error) {
+	down, err := m.PlanDown(ctx, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(down) == 0 {
+		return nil, goose.ErrNoNextVersion
+	}
+	up, err := m.steps([]*goose.Source{{Path: down[0].Path, Version: down[0].Version}}, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(down, up...), nil
+}
+
+func (m *Migrator) Close() error {
+	return m.provider.Close()
+}
+
+func (m *Migrator) steps(sources []*goose.Source, up bool) ([]*Step, error) {
+	steps := make([]*Step, 0, len(sources))
+	for _, source := range sources {
+		content, err := fs.ReadFile(m.fsys, source.Path)
+		if err != nil {
+			return nil, err
+		}
+		upSQL, downSQL, err := parseMigration(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", source.Path, err)
+		}
+		step := &Step{
+			Version: source.Version,
+			Path:    source.Path,
+			Up:      up,
+			SQL:     upSQL,
+		}
+		if !up {
+			step.SQL = downSQL
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+func selectUp(statuses []*goose.MigrationStatus, version int64) []*goose.Source {
+	var sources []*goose.Source
+	for _, status := range statuses {
+		if status.State == goose.StatePending && status.Source.Version <= version {
+			sources = append(sources, status.Source)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Version < sources[j].Version
+	})
+	return sources
+}
+
+func selectDown(statuses []*goose.MigrationStatus, version int64, limit int) []*goose.Source {
+	var sources []*goose.Source
+	for _, status := range statuses {
+		if status.State == goose.StateApplied && status.Source.Version > version {
+			sources = append(sources, status.Source)
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Version > sources[j].Version
+	})
+	if limit > 0 && len(sources) > limit {
+		sources = sources[:limit]
+	}
+	return sources
+}
@@ -0,0 +1,131 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+func testStatuses() []*goose.MigrationStatus {
+	return []*goose.MigrationStatus{
+		{State: goose.StateApplied, Source: &goose.Source{Path: "1_first.sql", Version: 1}},
+		{State: goose.StateApplied, Source: &goose.Source{Path: "2_second.sql", Version: 2}},
+		{State: goose.StatePending, Source: &goose.Source{Path: "3_third.sql", Version: 3}},
+		{State: goose.StatePending, Source: &goose.Source{Path: "4_fourth.sql", Version: 4}},
+	}
+}
+
+func TestSelectUp(t *testing.T) {
+	t.Run("all pending", func(t *testing.T) {
+		sources := selectUp(testStatuses(), goose.MaxVersion)
+		require.Len(t, sources, 2)
+		assert.Equal(t, int64(3), sources[0].Version)
+		assert.Equal(t, int64(4), sources[1].Version)
+	})
+
+	t.Run("up to version", func(t *testing.T) {
+		sources := selectUp(testStatuses(), 3)
+		require.Len(t, sources, 1)
+		assert.Equal(t, int64(3), sources[0].Version)
+	})
+}
+
+func TestSelectDown(t *testing.T) {
+	t.Run("down to zero", func(t *testing.T) {
+		sources := selectDown(testStatuses(), 0, 0)
+		require.Len(t, sources, 2)
+		assert.Equal(t, int64(2), sources[0].Version)
+		assert.Equal(t, int64(1), sources[1].Version)
+	})
+
+	t.Run("down by one", func(t *testing.T) {
+		sources := selectDown(testStatuses(), 0, 1)
+		require.Len(t, sources, 1)
+		assert.Equal(t, int64(2), sources[0].Version)
+	})
+
+	t.Run("down to version", func(t *testing.T) {
+		sources := selectDown(testStatuses(), 2, 0)
+		assert.Empty(t, sources)
+	})
+}
+
+func TestMigrator_steps(t *testing.T) {
+	m := &Migrator{
+		fsys: fstest.MapFS{
+			"1_first.sql": {Data: []byte(testMigration)},
+		},
+	}
+	sources := []*goose.Source{{Path: "1_first.sql", Version: 1}}
+
+	t.Run("up steps", func(t *testing.T) {
+		steps, err := m.steps(sources, true)
+		assert.NoError(t, err)
+		assert.Equal(t, []*Step{{Version: 1, Path: "1_first.sql", Up: true, SQL: "CREATE TABLE test (id INT);"}}, steps)
+	})
+
+	t.Run("down steps", func(t *testing.T) {
+		steps, err := m.steps(sources, false)
+		assert.NoError(t, err)
+		assert.Equal(t, []*Step{{Version: 1, Path: "1_first.sql", Up: false, SQL: "DROP TABLE test;"}}, steps)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := m.steps([]*goose.Source{{Path: "2_second.sql", Version: 2}}, true)
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrator_withLock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	m := &Migrator{db: db}
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlAdvisoryLock)).WithArgs(lock.DefaultLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAdvisoryUnlock)).WithArgs(lock.DefaultLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		var called bool
+		err := m.withLock(context.Background(), func() error {
+			called = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unlocks after error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlAdvisoryLock)).WithArgs(lock.DefaultLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(sqlAdvisoryUnlock)).WithArgs(lock.DefaultLockID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := m.withLock(context.Background(), func() error {
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlAdvisoryLock)).WithArgs(lock.DefaultLockID).WillReturnError(errTest)
+
+		err := m.withLock(context.Background(), func() error {
+			t.Fatal("fn must not run without the lock")
+			return nil
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,115 @@
+package migrator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+const annotationPrefix = "-- +goose"
+
+var (
+	ErrNoUpAnnotation          = errors.New("missing -- +goose Up annotation")
+	ErrUnbalancedStatement     = errors.New("unbalanced StatementBegin/StatementEnd annotations")
+	ErrUnknownAnnotation       = errors.New("unknown goose annotation")
+	ErrDuplicateVersion        = errors.New("duplicate migration version")
+	ErrStatementOutsideSection = errors.New("statement outside of Up/Down sections")
+)
+
+func parseMigration(content string) (string, string, error) {
+	var up, down strings.Builder
+	var section *strings.Builder
+	var hasUp, inStatement bool
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, annotationPrefix) {
+			if section == nil {
+				if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+					return "", "", ErrStatementOutsideSection
+				}
+				continue
+			}
+			section.WriteString(line)
+			section.WriteString("\n")
+			continue
+		}
+		switch annotation := strings.TrimSpace(strings.TrimPrefix(trimmed, annotationPrefix)); annotation {
+		case "Up":
+			if inStatement {
+				return "", "", ErrUnbalancedStatement
+			}
+			hasUp = true
+			section = &up
+		case "Down":
+			if inStatement {
+				return "", "", ErrUnbalancedStatement
+			}
+			section = &down
+		case "StatementBegin":
+			if inStatement {
+				return "", "", ErrUnbalancedStatement
+			}
+			inStatement = true
+		case "StatementEnd":
+			if !inStatement {
+				return "", "", ErrUnbalancedStatement
+			}
+			inStatement = false
+		case "NO TRANSACTION", "ENVSUB ON", "ENVSUB OFF":
+		default:
+			return "", "", fmt.Errorf("%w: %s", ErrUnknownAnnotation, annotation)
+		}
+	}
+	err := scanner.Err()
+	if err != nil {
+		return "", "", err
+	}
+	if !hasUp {
+		return "", "", ErrNoUpAnnotation
+	}
+	if inStatement {
+		return "", "", ErrUnbalancedStatement
+	}
+	return strings.TrimSpace(up.String()), strings.TrimSpace(down.String()), nil
+}
+
+func Validate(fsys fs.FS) error {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return err
+	}
+	var errs []error
+	versions := make(map[int64]string, len(names))
+	for _, name := range names {
+		version, err := goose.NumericComponent(path.Base(name))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		if prev, ok := versions[version]; ok {
+			errs = append(errs, fmt.Errorf("%s: %w with %s", name, ErrDuplicateVersion, prev))
+		}
+		versions[version] = name
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		_, _, err = parseMigration(string(content))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func Create(dir, name string) error {
+	return goose.Create(nil, dir, name, "sql")
+}
@@ -0,0 +1,75 @@
+package migrator
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMigration = `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE test (id INT);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE test;
+-- +goose StatementEnd
+`
+
+func TestParseMigration(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		up, down, err := parseMigration(testMigration)
+		assert.NoError(t, err)
+		assert.Equal(t, "CREATE TABLE test (id INT);", up)
+		assert.Equal(t, "DROP TABLE test;", down)
+	})
+
+	t.Run("no up annotation", func(t *testing.T) {
+		_, _, err := parseMigration("-- +goose Down\nDROP TABLE test;\n")
+		assert.ErrorIs(t, err, ErrNoUpAnnotation)
+	})
+
+	t.Run("unbalanced statement", func(t *testing.T) {
+		_, _, err := parseMigration("-- +goose Up\n-- +goose StatementBegin\nSELECT 1;\n-- +goose Down\n")
+		assert.ErrorIs(t, err, ErrUnbalancedStatement)
+	})
+
+	t.Run("unknown annotation", func(t *testing.T) {
+		_, _, err := parseMigration("-- +goose Up\n-- +goose Sideways\n")
+		assert.ErrorIs(t, err, ErrUnknownAnnotation)
+	})
+
+	t.Run("statement outside section", func(t *testing.T) {
+		_, _, err := parseMigration("SELECT 1;\n-- +goose Up\n")
+		assert.ErrorIs(t, err, ErrStatementOutsideSection)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("embedded migrations", func(t *testing.T) {
+		fsys, err := MigrationsFS()
+		require.NoError(t, err)
+
+		assert.NoError(t, Validate(fsys))
+	})
+
+	t.Run("duplicate version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"1_first.sql":  {Data: []byte(testMigration)},
+			"1_second.sql": {Data: []byte(testMigration)},
+		}
+
+		assert.ErrorIs(t, Validate(fsys), ErrDuplicateVersion)
+	})
+
+	t.Run("broken migration", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"1_first.sql": {Data: []byte("-- +goose Down\n")},
+		}
+
+		assert.ErrorIs(t, Validate(fsys), ErrNoUpAnnotation)
+	})
+}
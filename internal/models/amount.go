@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+)
+
+var ErrInvalidAmount = errors.New("invalid amount")
+
+var amountRe = regexp.MustCompile(`^\d+(\.\d{1,2})?$`)
+
+// Amount is a number of points encoded as a decimal string with two
+// fractional digits, so clients never round it through a float.
+type Amount float64
+
+func (a Amount) String() string {
+	return strconv.FormatFloat(float64(a), 'f', 2, 64)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return ErrInvalidAmount
+	}
+	*a, err = ParseAmount(s)
+	return err
+}
+
+func ParseAmount(s string) (Amount, error) {
+	if !amountRe.MatchString(s) {
+		return 0, ErrInvalidAmount
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(v), nil
+}
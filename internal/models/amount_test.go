@@ -0,0 +1,56 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmount_MarshalJSON(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		data, err := json.Marshal(Amount(729.98))
+		require.NoError(t, err)
+		assert.Equal(t, `"729.98"`, string(data))
+	})
+
+	t.Run("whole number", func(t *testing.T) {
+		data, err := json.Marshal(Amount(500))
+		require.NoError(t, err)
+		assert.Equal(t, `"500.00"`, string(data))
+	})
+}
+
+func TestAmount_UnmarshalJSON(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		var a Amount
+		err := json.Unmarshal([]byte(`"12.5"`), &a)
+		assert.NoError(t, err)
+		assert.Equal(t, Amount(12.5), a)
+	})
+
+	t.Run("number", func(t *testing.T) {
+		var a Amount
+		err := json.Unmarshal([]byte(`12.5`), &a)
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+
+	t.Run("too many digits", func(t *testing.T) {
+		var a Amount
+		err := json.Unmarshal([]byte(`"12.505"`), &a)
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		var a Amount
+		err := json.Unmarshal([]byte(`"-12.5"`), &a)
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+
+	t.Run("exponent", func(t *testing.T) {
+		var a Amount
+		err := json.Unmarshal([]byte(`"1e3"`), &a)
+		assert.ErrorIs(t, err, ErrInvalidAmount)
+	})
+}
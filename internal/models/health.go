@@ -0,0 +1,11 @@
+package models
+
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+)
+
+type Health struct {
+	Status        string `json:"status"`
+	SchemaVersion int64  `json:"schema_version,omitempty"`
+}
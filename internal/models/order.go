@@ -1,5 +1,12 @@
 package models
 
+const (
+	StatusNew        = "NEW"
+	StatusProcessing = "PROCESSING"
+	StatusInvalid    = "INVALID"
+	StatusProcessed  = "PROCESSED"
+)
+
 type Order struct {
 	Number string
 	UserID UserID
@@ -19,3 +26,35 @@ type OrderAccrual struct {
 	Status  string  `json:"status"`
 	Accrual float64 `json:"accrual"`
 }
+
+const (
+	BatchAccepted      = "accepted"
+	BatchExists        = "already_uploaded"
+	BatchConflict      = "conflict"
+	BatchWrongNum      = "invalid_number"
+	BatchTooManyOrders = "too_many_pending_orders"
+)
+
+type OrderBatchResult struct {
+	Number string `json:"number"`
+	Status string `json:"status"`
+}
+
+type OrderStatusChange struct {
+	Status    string  `json:"status"`
+	Accrual   float64 `json:"accrual,omitempty"`
+	ChangedAt string  `json:"changed_at"`
+}
+
+type OrderDetail struct {
+	ID            int64                `json:"-"`
+	Number        string               `json:"number"`
+	UserID        UserID               `json:"-"`
+	Status        string               `json:"status"`
+	Accrual       float64              `json:"accrual,omitempty"`
+	CreatedAt     string               `json:"uploaded_at"`
+	ProcessedAt   string               `json:"processed_at,omitempty"`
+	LastCheckedAt string               `json:"last_checked_at,omitempty"`
+	CheckCount    int                  `json:"check_count"`
+	History       []*OrderStatusChange `json:"history"`
+}
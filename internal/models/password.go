@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidPasswordChange = errors.New("invalid password change")
+	ErrInvalidPasswordReset  = errors.New("invalid password reset")
+)
+
+type PasswordChange struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+func (p *PasswordChange) Validate() error {
+	if p.OldPassword == "" || p.NewPassword == "" {
+		return ErrInvalidPasswordChange
+	}
+	return nil
+}
+
+type PasswordResetRequest struct {
+	Login string `json:"login"`
+}
+
+func (p *PasswordResetRequest) Validate() error {
+	if p.Login == "" {
+		return ErrInvalidPasswordReset
+	}
+	return nil
+}
+
+type PasswordResetConfirm struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+func (p *PasswordResetConfirm) Validate() error {
+	if p.Token == "" || p.NewPassword == "" {
+		return ErrInvalidPasswordReset
+	}
+	return nil
+}
+
+type ResetToken struct {
+	UserID    UserID
+	TokenHash string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordChange_Validate(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		p := &PasswordChange{
+			OldPassword: "not empty",
+			NewPassword: "not empty",
+		}
+		err := p.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty old password", func(t *testing.T) {
+		p := &PasswordChange{
+			NewPassword: "not empty",
+		}
+		err := p.Validate()
+		assert.ErrorIs(t, err, ErrInvalidPasswordChange)
+	})
+
+	t.Run("empty new password", func(t *testing.T) {
+		p := &PasswordChange{
+			OldPassword: "not empty",
+		}
+		err := p.Validate()
+		assert.ErrorIs(t, err, ErrInvalidPasswordChange)
+	})
+}
+
+func TestPasswordResetRequest_Validate(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		p := &PasswordResetRequest{Login: "not empty"}
+		err := p.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty login", func(t *testing.T) {
+		p := &PasswordResetRequest{}
+		err := p.Validate()
+		assert.ErrorIs(t, err, ErrInvalidPasswordReset)
+	})
+}
+
+func TestPasswordResetConfirm_Validate(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		p := &PasswordResetConfirm{
+			Token:       "not empty",
+			NewPassword: "not empty",
+		}
+		err := p.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("empty token", func(t *testing.T) {
+		p := &PasswordResetConfirm{
+			NewPassword: "not empty",
+		}
+		err := p.Validate()
+		assert.ErrorIs(t, err, ErrInvalidPasswordReset)
+	})
+}
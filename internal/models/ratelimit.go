@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Duration
+	Allowed   bool
+}
@@ -0,0 +1,21 @@
+package models
+
+type Referral struct {
+	ReferrerID    UserID
+	RefereeID     UserID
+	ReferrerBonus float64
+	RefereeBonus  float64
+}
+
+type Invitee struct {
+	Login        string  `json:"login"`
+	Bonus        float64 `json:"bonus"`
+	Rewarded     bool    `json:"rewarded"`
+	RegisteredAt string  `json:"registered_at"`
+}
+
+type Referrals struct {
+	Code     string     `json:"referral_code"`
+	Earned   float64    `json:"earned"`
+	Invitees []*Invitee `json:"invitees"`
+}
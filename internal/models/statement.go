@@ -0,0 +1,17 @@
+package models
+
+const (
+	EntryAccrual       = "accrual"
+	EntryWithdrawal    = "withdrawal"
+	EntryReferralBonus = "referral_bonus"
+	EntryTransferIn    = "transfer_in"
+	EntryTransferOut   = "transfer_out"
+)
+
+type StatementEntry struct {
+	Date      string  `json:"date"`
+	Type      string  `json:"type"`
+	Reference string  `json:"reference"`
+	Amount    float64 `json:"amount"`
+	Balance   float64 `json:"balance"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "errors"
+
+var ErrInvalidTOTPCode = errors.New("invalid totp code")
+
+type TOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TOTPCode struct {
+	Code string `json:"code"`
+}
+
+func (c *TOTPCode) Validate() error {
+	if c.Code == "" {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+type LoginChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+}
+
+type TOTPLogin struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+func (l *TOTPLogin) Validate() error {
+	if l.ChallengeToken == "" || l.Code == "" {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+type TOTPDB struct {
+	UserID  UserID
+	Secret  string
+	Enabled bool
+}
@@ -0,0 +1,22 @@
+package models
+
+const (
+	TransferIn  = "in"
+	TransferOut = "out"
+)
+
+type Transfer struct {
+	ID             int64   `json:"-"`
+	SenderID       UserID  `json:"-"`
+	RecipientID    UserID  `json:"-"`
+	Recipient      string  `json:"login"`
+	Sum            float64 `json:"sum"`
+	IdempotencyKey string  `json:"idempotency_key"`
+}
+
+type TransferRecord struct {
+	Direction    string  `json:"direction"`
+	Counterparty string  `json:"login"`
+	Sum          float64 `json:"sum"`
+	ProcessedAt  string  `json:"processed_at"`
+}
@@ -7,8 +7,9 @@ var ErrInvalidUser = errors.New("invalid user")
 type UserID int64
 
 type User struct {
-	Login    string `json:"login"`
-	Password string `json:"password"`
+	Login        string `json:"login"`
+	Password     string `json:"password"`
+	ReferralCode string `json:"referral_code,omitempty"`
 }
 
 func (u *User) Validate() error {
@@ -22,4 +23,5 @@ type UserDB struct {
 	ID           UserID
 	Login        string
 	PasswordHash string
+	ReferralCode string
 }
@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/logger"
+	"go.uber.org/zap"
+)
+
+type Notifier interface {
+	NotifyPasswordReset(ctx context.Context, login, token string, expiresAt time.Time) error
+}
+
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) NotifyPasswordReset(_ context.Context, login, token string, expiresAt time.Time) error {
+	logger.Log.Info("Password reset requested",
+		zap.String("login", login),
+		zap.String("token", token),
+		zap.Time("expires_at", expiresAt),
+	)
+	return nil
+}
+
+type passwordResetMessage struct {
+	Login     string    `json:"login"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FileNotifier appends one JSON object per notification to a file, so a
+// local mail catcher or a test can pick the tokens up.
+type FileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{
+		path: path,
+	}
+}
+
+func (n *FileNotifier) NotifyPasswordReset(_ context.Context, login, token string, expiresAt time.Time) error {
+	msg, err := json.Marshal(passwordResetMessage{
+		Login:     login,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(msg, '\n'))
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
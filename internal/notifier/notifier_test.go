@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogNotifier_NotifyPasswordReset(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		err := NewLogNotifier().NotifyPasswordReset(context.Background(), "login", "token", time.Now())
+		assert.NoError(t, err)
+	})
+}
+
+func TestFileNotifier_NotifyPasswordReset(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notifications.jsonl")
+		n := NewFileNotifier(path)
+		expiresAt := time.Date(2025, 5, 6, 12, 0, 0, 0, time.UTC)
+
+		err := n.NotifyPasswordReset(context.Background(), "first", "token1", expiresAt)
+		require.NoError(t, err)
+		err = n.NotifyPasswordReset(context.Background(), "second", "token2", expiresAt)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		assert.Equal(t, []string{
+			`{"login":"first","token":"token1","expires_at":"2025-05-06T12:00:00Z"}`,
+			`{"login":"second","token":"token2","expires_at":"2025-05-06T12:00:00Z"}`,
+		}, lines)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		n := NewFileNotifier(filepath.Join(t.TempDir(), "missing", "notifications.jsonl"))
+
+		err := n.NotifyPasswordReset(context.Background(), "login", "token", time.Now())
+		assert.Error(t, err)
+	})
+}
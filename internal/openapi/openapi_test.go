@@ -0,0 +1,24 @@
+package openapi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	doc, err := Load(context.Background())
+	require.NoError(t, err)
+
+	for path, item := range doc.Paths.Map() {
+		assert.Regexp(t, `^/api/(v2/)?user/`, path)
+		for method, op := range item.Operations() {
+			assert.Equal(t, strings.HasPrefix(path, "/api/user/"), op.Deprecated, "%s %s", method, path)
+		}
+	}
+	assert.NotNil(t, doc.Paths.Find("/api/user/orders"))
+	assert.NotNil(t, doc.Paths.Find("/api/v2/user/orders"))
+}
@@ -0,0 +1,1235 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rules.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockerrWrongNum is a mock of errWrongNum interface.
+type MockerrWrongNum struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWrongNumMockRecorder
+}
+
+// MockerrWrongNumMockRecorder is the mock recorder for MockerrWrongNum.
+type MockerrWrongNumMockRecorder struct {
+	mock *MockerrWrongNum
+}
+
+// NewMockerrWrongNum creates a new mock instance.
+func NewMockerrWrongNum(ctrl *gomock.Controller) *MockerrWrongNum {
+	mock := &MockerrWrongNum{ctrl: ctrl}
+	mock.recorder = &MockerrWrongNumMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWrongNum) EXPECT() *MockerrWrongNumMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWrongNum) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWrongNumMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongNum)(nil).Error))
+}
+
+// IsErrWrongNum mocks base method.
+func (m *MockerrWrongNum) IsErrWrongNum() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongNum")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongNum indicates an expected call of IsErrWrongNum.
+func (mr *MockerrWrongNumMockRecorder) IsErrWrongNum() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongNum", reflect.TypeOf((*MockerrWrongNum)(nil).IsErrWrongNum))
+}
+
+// MockerrWrongOrderNum is a mock of errWrongOrderNum interface.
+type MockerrWrongOrderNum struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWrongOrderNumMockRecorder
+}
+
+// MockerrWrongOrderNumMockRecorder is the mock recorder for MockerrWrongOrderNum.
+type MockerrWrongOrderNumMockRecorder struct {
+	mock *MockerrWrongOrderNum
+}
+
+// NewMockerrWrongOrderNum creates a new mock instance.
+func NewMockerrWrongOrderNum(ctrl *gomock.Controller) *MockerrWrongOrderNum {
+	mock := &MockerrWrongOrderNum{ctrl: ctrl}
+	mock.recorder = &MockerrWrongOrderNumMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWrongOrderNum) EXPECT() *MockerrWrongOrderNumMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWrongOrderNum) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWrongOrderNumMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongOrderNum)(nil).Error))
+}
+
+// IsErrWrongOrderNum mocks base method.
+func (m *MockerrWrongOrderNum) IsErrWrongOrderNum() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongOrderNum")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongOrderNum indicates an expected call of IsErrWrongOrderNum.
+func (mr *MockerrWrongOrderNumMockRecorder) IsErrWrongOrderNum() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongOrderNum", reflect.TypeOf((*MockerrWrongOrderNum)(nil).IsErrWrongOrderNum))
+}
+
+// MockerrOrderConflict is a mock of errOrderConflict interface.
+type MockerrOrderConflict struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrOrderConflictMockRecorder
+}
+
+// MockerrOrderConflictMockRecorder is the mock recorder for MockerrOrderConflict.
+type MockerrOrderConflictMockRecorder struct {
+	mock *MockerrOrderConflict
+}
+
+// NewMockerrOrderConflict creates a new mock instance.
+func NewMockerrOrderConflict(ctrl *gomock.Controller) *MockerrOrderConflict {
+	mock := &MockerrOrderConflict{ctrl: ctrl}
+	mock.recorder = &MockerrOrderConflictMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrOrderConflict) EXPECT() *MockerrOrderConflictMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrOrderConflict) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrOrderConflictMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrOrderConflict)(nil).Error))
+}
+
+// IsErrOrderConflict mocks base method.
+func (m *MockerrOrderConflict) IsErrOrderConflict() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrOrderConflict")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrOrderConflict indicates an expected call of IsErrOrderConflict.
+func (mr *MockerrOrderConflictMockRecorder) IsErrOrderConflict() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrOrderConflict", reflect.TypeOf((*MockerrOrderConflict)(nil).IsErrOrderConflict))
+}
+
+// MockerrOrderNotFound is a mock of errOrderNotFound interface.
+type MockerrOrderNotFound struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrOrderNotFoundMockRecorder
+}
+
+// MockerrOrderNotFoundMockRecorder is the mock recorder for MockerrOrderNotFound.
+type MockerrOrderNotFoundMockRecorder struct {
+	mock *MockerrOrderNotFound
+}
+
+// NewMockerrOrderNotFound creates a new mock instance.
+func NewMockerrOrderNotFound(ctrl *gomock.Controller) *MockerrOrderNotFound {
+	mock := &MockerrOrderNotFound{ctrl: ctrl}
+	mock.recorder = &MockerrOrderNotFoundMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrOrderNotFound) EXPECT() *MockerrOrderNotFoundMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrOrderNotFound) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrOrderNotFoundMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrOrderNotFound)(nil).Error))
+}
+
+// IsErrOrderNotFound mocks base method.
+func (m *MockerrOrderNotFound) IsErrOrderNotFound() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrOrderNotFound")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrOrderNotFound indicates an expected call of IsErrOrderNotFound.
+func (mr *MockerrOrderNotFoundMockRecorder) IsErrOrderNotFound() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrOrderNotFound", reflect.TypeOf((*MockerrOrderNotFound)(nil).IsErrOrderNotFound))
+}
+
+// MockerrTooManyOrders is a mock of errTooManyOrders interface.
+type MockerrTooManyOrders struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTooManyOrdersMockRecorder
+}
+
+// MockerrTooManyOrdersMockRecorder is the mock recorder for MockerrTooManyOrders.
+type MockerrTooManyOrdersMockRecorder struct {
+	mock *MockerrTooManyOrders
+}
+
+// NewMockerrTooManyOrders creates a new mock instance.
+func NewMockerrTooManyOrders(ctrl *gomock.Controller) *MockerrTooManyOrders {
+	mock := &MockerrTooManyOrders{ctrl: ctrl}
+	mock.recorder = &MockerrTooManyOrdersMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTooManyOrders) EXPECT() *MockerrTooManyOrdersMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTooManyOrders) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTooManyOrdersMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTooManyOrders)(nil).Error))
+}
+
+// IsErrTooManyOrders mocks base method.
+func (m *MockerrTooManyOrders) IsErrTooManyOrders() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTooManyOrders")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTooManyOrders indicates an expected call of IsErrTooManyOrders.
+func (mr *MockerrTooManyOrdersMockRecorder) IsErrTooManyOrders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTooManyOrders", reflect.TypeOf((*MockerrTooManyOrders)(nil).IsErrTooManyOrders))
+}
+
+// MockerrNotEnoughCurrency is a mock of errNotEnoughCurrency interface.
+type MockerrNotEnoughCurrency struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrNotEnoughCurrencyMockRecorder
+}
+
+// MockerrNotEnoughCurrencyMockRecorder is the mock recorder for MockerrNotEnoughCurrency.
+type MockerrNotEnoughCurrencyMockRecorder struct {
+	mock *MockerrNotEnoughCurrency
+}
+
+// NewMockerrNotEnoughCurrency creates a new mock instance.
+func NewMockerrNotEnoughCurrency(ctrl *gomock.Controller) *MockerrNotEnoughCurrency {
+	mock := &MockerrNotEnoughCurrency{ctrl: ctrl}
+	mock.recorder = &MockerrNotEnoughCurrencyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrNotEnoughCurrency) EXPECT() *MockerrNotEnoughCurrencyMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrNotEnoughCurrency) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrNotEnoughCurrencyMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrNotEnoughCurrency)(nil).Error))
+}
+
+// IsErrNotEnoughCurrency mocks base method.
+func (m *MockerrNotEnoughCurrency) IsErrNotEnoughCurrency() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNotEnoughCurrency")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNotEnoughCurrency indicates an expected call of IsErrNotEnoughCurrency.
+func (mr *MockerrNotEnoughCurrencyMockRecorder) IsErrNotEnoughCurrency() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNotEnoughCurrency", reflect.TypeOf((*MockerrNotEnoughCurrency)(nil).IsErrNotEnoughCurrency))
+}
+
+// MockerrNoUser is a mock of errNoUser interface.
+type MockerrNoUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrNoUserMockRecorder
+}
+
+// MockerrNoUserMockRecorder is the mock recorder for MockerrNoUser.
+type MockerrNoUserMockRecorder struct {
+	mock *MockerrNoUser
+}
+
+// NewMockerrNoUser creates a new mock instance.
+func NewMockerrNoUser(ctrl *gomock.Controller) *MockerrNoUser {
+	mock := &MockerrNoUser{ctrl: ctrl}
+	mock.recorder = &MockerrNoUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrNoUser) EXPECT() *MockerrNoUserMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrNoUser) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrNoUserMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrNoUser)(nil).Error))
+}
+
+// IsErrNoUser mocks base method.
+func (m *MockerrNoUser) IsErrNoUser() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNoUser")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNoUser indicates an expected call of IsErrNoUser.
+func (mr *MockerrNoUserMockRecorder) IsErrNoUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNoUser", reflect.TypeOf((*MockerrNoUser)(nil).IsErrNoUser))
+}
+
+// MockerrWrongPassword is a mock of errWrongPassword interface.
+type MockerrWrongPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWrongPasswordMockRecorder
+}
+
+// MockerrWrongPasswordMockRecorder is the mock recorder for MockerrWrongPassword.
+type MockerrWrongPasswordMockRecorder struct {
+	mock *MockerrWrongPassword
+}
+
+// NewMockerrWrongPassword creates a new mock instance.
+func NewMockerrWrongPassword(ctrl *gomock.Controller) *MockerrWrongPassword {
+	mock := &MockerrWrongPassword{ctrl: ctrl}
+	mock.recorder = &MockerrWrongPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWrongPassword) EXPECT() *MockerrWrongPasswordMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWrongPassword) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWrongPasswordMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongPassword)(nil).Error))
+}
+
+// IsErrWrongPassword mocks base method.
+func (m *MockerrWrongPassword) IsErrWrongPassword() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongPassword")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongPassword indicates an expected call of IsErrWrongPassword.
+func (mr *MockerrWrongPasswordMockRecorder) IsErrWrongPassword() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongPassword", reflect.TypeOf((*MockerrWrongPassword)(nil).IsErrWrongPassword))
+}
+
+// MockerrLoginConflict is a mock of errLoginConflict interface.
+type MockerrLoginConflict struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrLoginConflictMockRecorder
+}
+
+// MockerrLoginConflictMockRecorder is the mock recorder for MockerrLoginConflict.
+type MockerrLoginConflictMockRecorder struct {
+	mock *MockerrLoginConflict
+}
+
+// NewMockerrLoginConflict creates a new mock instance.
+func NewMockerrLoginConflict(ctrl *gomock.Controller) *MockerrLoginConflict {
+	mock := &MockerrLoginConflict{ctrl: ctrl}
+	mock.recorder = &MockerrLoginConflictMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrLoginConflict) EXPECT() *MockerrLoginConflictMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrLoginConflict) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrLoginConflictMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrLoginConflict)(nil).Error))
+}
+
+// IsErrLoginConflict mocks base method.
+func (m *MockerrLoginConflict) IsErrLoginConflict() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrLoginConflict")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrLoginConflict indicates an expected call of IsErrLoginConflict.
+func (mr *MockerrLoginConflictMockRecorder) IsErrLoginConflict() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrLoginConflict", reflect.TypeOf((*MockerrLoginConflict)(nil).IsErrLoginConflict))
+}
+
+// MockerrWeakPassword is a mock of errWeakPassword interface.
+type MockerrWeakPassword struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWeakPasswordMockRecorder
+}
+
+// MockerrWeakPasswordMockRecorder is the mock recorder for MockerrWeakPassword.
+type MockerrWeakPasswordMockRecorder struct {
+	mock *MockerrWeakPassword
+}
+
+// NewMockerrWeakPassword creates a new mock instance.
+func NewMockerrWeakPassword(ctrl *gomock.Controller) *MockerrWeakPassword {
+	mock := &MockerrWeakPassword{ctrl: ctrl}
+	mock.recorder = &MockerrWeakPasswordMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWeakPassword) EXPECT() *MockerrWeakPasswordMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWeakPassword) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWeakPasswordMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWeakPassword)(nil).Error))
+}
+
+// IsErrWeakPassword mocks base method.
+func (m *MockerrWeakPassword) IsErrWeakPassword() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWeakPassword")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWeakPassword indicates an expected call of IsErrWeakPassword.
+func (mr *MockerrWeakPasswordMockRecorder) IsErrWeakPassword() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWeakPassword", reflect.TypeOf((*MockerrWeakPassword)(nil).IsErrWeakPassword))
+}
+
+// MockerrWrongReferralCode is a mock of errWrongReferralCode interface.
+type MockerrWrongReferralCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWrongReferralCodeMockRecorder
+}
+
+// MockerrWrongReferralCodeMockRecorder is the mock recorder for MockerrWrongReferralCode.
+type MockerrWrongReferralCodeMockRecorder struct {
+	mock *MockerrWrongReferralCode
+}
+
+// NewMockerrWrongReferralCode creates a new mock instance.
+func NewMockerrWrongReferralCode(ctrl *gomock.Controller) *MockerrWrongReferralCode {
+	mock := &MockerrWrongReferralCode{ctrl: ctrl}
+	mock.recorder = &MockerrWrongReferralCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWrongReferralCode) EXPECT() *MockerrWrongReferralCodeMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWrongReferralCode) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWrongReferralCodeMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongReferralCode)(nil).Error))
+}
+
+// IsErrWrongReferralCode mocks base method.
+func (m *MockerrWrongReferralCode) IsErrWrongReferralCode() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongReferralCode")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongReferralCode indicates an expected call of IsErrWrongReferralCode.
+func (mr *MockerrWrongReferralCodeMockRecorder) IsErrWrongReferralCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongReferralCode", reflect.TypeOf((*MockerrWrongReferralCode)(nil).IsErrWrongReferralCode))
+}
+
+// MockerrInvalidResetToken is a mock of errInvalidResetToken interface.
+type MockerrInvalidResetToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrInvalidResetTokenMockRecorder
+}
+
+// MockerrInvalidResetTokenMockRecorder is the mock recorder for MockerrInvalidResetToken.
+type MockerrInvalidResetTokenMockRecorder struct {
+	mock *MockerrInvalidResetToken
+}
+
+// NewMockerrInvalidResetToken creates a new mock instance.
+func NewMockerrInvalidResetToken(ctrl *gomock.Controller) *MockerrInvalidResetToken {
+	mock := &MockerrInvalidResetToken{ctrl: ctrl}
+	mock.recorder = &MockerrInvalidResetTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrInvalidResetToken) EXPECT() *MockerrInvalidResetTokenMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrInvalidResetToken) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrInvalidResetTokenMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrInvalidResetToken)(nil).Error))
+}
+
+// IsErrInvalidResetToken mocks base method.
+func (m *MockerrInvalidResetToken) IsErrInvalidResetToken() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrInvalidResetToken")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrInvalidResetToken indicates an expected call of IsErrInvalidResetToken.
+func (mr *MockerrInvalidResetTokenMockRecorder) IsErrInvalidResetToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrInvalidResetToken", reflect.TypeOf((*MockerrInvalidResetToken)(nil).IsErrInvalidResetToken))
+}
+
+// MockerrSessionRevoked is a mock of errSessionRevoked interface.
+type MockerrSessionRevoked struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrSessionRevokedMockRecorder
+}
+
+// MockerrSessionRevokedMockRecorder is the mock recorder for MockerrSessionRevoked.
+type MockerrSessionRevokedMockRecorder struct {
+	mock *MockerrSessionRevoked
+}
+
+// NewMockerrSessionRevoked creates a new mock instance.
+func NewMockerrSessionRevoked(ctrl *gomock.Controller) *MockerrSessionRevoked {
+	mock := &MockerrSessionRevoked{ctrl: ctrl}
+	mock.recorder = &MockerrSessionRevokedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrSessionRevoked) EXPECT() *MockerrSessionRevokedMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrSessionRevoked) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrSessionRevokedMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrSessionRevoked)(nil).Error))
+}
+
+// IsErrSessionRevoked mocks base method.
+func (m *MockerrSessionRevoked) IsErrSessionRevoked() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrSessionRevoked")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrSessionRevoked indicates an expected call of IsErrSessionRevoked.
+func (mr *MockerrSessionRevokedMockRecorder) IsErrSessionRevoked() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrSessionRevoked", reflect.TypeOf((*MockerrSessionRevoked)(nil).IsErrSessionRevoked))
+}
+
+// MockerrSourceClosed is a mock of errSourceClosed interface.
+type MockerrSourceClosed struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrSourceClosedMockRecorder
+}
+
+// MockerrSourceClosedMockRecorder is the mock recorder for MockerrSourceClosed.
+type MockerrSourceClosedMockRecorder struct {
+	mock *MockerrSourceClosed
+}
+
+// NewMockerrSourceClosed creates a new mock instance.
+func NewMockerrSourceClosed(ctrl *gomock.Controller) *MockerrSourceClosed {
+	mock := &MockerrSourceClosed{ctrl: ctrl}
+	mock.recorder = &MockerrSourceClosedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrSourceClosed) EXPECT() *MockerrSourceClosedMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrSourceClosed) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrSourceClosedMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrSourceClosed)(nil).Error))
+}
+
+// IsErrSourceClosed mocks base method.
+func (m *MockerrSourceClosed) IsErrSourceClosed() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrSourceClosed")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrSourceClosed indicates an expected call of IsErrSourceClosed.
+func (mr *MockerrSourceClosedMockRecorder) IsErrSourceClosed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrSourceClosed", reflect.TypeOf((*MockerrSourceClosed)(nil).IsErrSourceClosed))
+}
+
+// MockerrInvalidWithdrawalSum is a mock of errInvalidWithdrawalSum interface.
+type MockerrInvalidWithdrawalSum struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrInvalidWithdrawalSumMockRecorder
+}
+
+// MockerrInvalidWithdrawalSumMockRecorder is the mock recorder for MockerrInvalidWithdrawalSum.
+type MockerrInvalidWithdrawalSumMockRecorder struct {
+	mock *MockerrInvalidWithdrawalSum
+}
+
+// NewMockerrInvalidWithdrawalSum creates a new mock instance.
+func NewMockerrInvalidWithdrawalSum(ctrl *gomock.Controller) *MockerrInvalidWithdrawalSum {
+	mock := &MockerrInvalidWithdrawalSum{ctrl: ctrl}
+	mock.recorder = &MockerrInvalidWithdrawalSumMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrInvalidWithdrawalSum) EXPECT() *MockerrInvalidWithdrawalSumMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrInvalidWithdrawalSum) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrInvalidWithdrawalSumMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrInvalidWithdrawalSum)(nil).Error))
+}
+
+// IsErrInvalidWithdrawalSum mocks base method.
+func (m *MockerrInvalidWithdrawalSum) IsErrInvalidWithdrawalSum() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrInvalidWithdrawalSum")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrInvalidWithdrawalSum indicates an expected call of IsErrInvalidWithdrawalSum.
+func (mr *MockerrInvalidWithdrawalSumMockRecorder) IsErrInvalidWithdrawalSum() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrInvalidWithdrawalSum", reflect.TypeOf((*MockerrInvalidWithdrawalSum)(nil).IsErrInvalidWithdrawalSum))
+}
+
+// MockerrInvalidTransfer is a mock of errInvalidTransfer interface.
+type MockerrInvalidTransfer struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrInvalidTransferMockRecorder
+}
+
+// MockerrInvalidTransferMockRecorder is the mock recorder for MockerrInvalidTransfer.
+type MockerrInvalidTransferMockRecorder struct {
+	mock *MockerrInvalidTransfer
+}
+
+// NewMockerrInvalidTransfer creates a new mock instance.
+func NewMockerrInvalidTransfer(ctrl *gomock.Controller) *MockerrInvalidTransfer {
+	mock := &MockerrInvalidTransfer{ctrl: ctrl}
+	mock.recorder = &MockerrInvalidTransferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrInvalidTransfer) EXPECT() *MockerrInvalidTransferMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrInvalidTransfer) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrInvalidTransferMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrInvalidTransfer)(nil).Error))
+}
+
+// IsErrInvalidTransfer mocks base method.
+func (m *MockerrInvalidTransfer) IsErrInvalidTransfer() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrInvalidTransfer")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrInvalidTransfer indicates an expected call of IsErrInvalidTransfer.
+func (mr *MockerrInvalidTransferMockRecorder) IsErrInvalidTransfer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrInvalidTransfer", reflect.TypeOf((*MockerrInvalidTransfer)(nil).IsErrInvalidTransfer))
+}
+
+// MockerrUnknownRecipient is a mock of errUnknownRecipient interface.
+type MockerrUnknownRecipient struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrUnknownRecipientMockRecorder
+}
+
+// MockerrUnknownRecipientMockRecorder is the mock recorder for MockerrUnknownRecipient.
+type MockerrUnknownRecipientMockRecorder struct {
+	mock *MockerrUnknownRecipient
+}
+
+// NewMockerrUnknownRecipient creates a new mock instance.
+func NewMockerrUnknownRecipient(ctrl *gomock.Controller) *MockerrUnknownRecipient {
+	mock := &MockerrUnknownRecipient{ctrl: ctrl}
+	mock.recorder = &MockerrUnknownRecipientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrUnknownRecipient) EXPECT() *MockerrUnknownRecipientMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrUnknownRecipient) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrUnknownRecipientMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrUnknownRecipient)(nil).Error))
+}
+
+// IsErrUnknownRecipient mocks base method.
+func (m *MockerrUnknownRecipient) IsErrUnknownRecipient() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrUnknownRecipient")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrUnknownRecipient indicates an expected call of IsErrUnknownRecipient.
+func (mr *MockerrUnknownRecipientMockRecorder) IsErrUnknownRecipient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrUnknownRecipient", reflect.TypeOf((*MockerrUnknownRecipient)(nil).IsErrUnknownRecipient))
+}
+
+// MockerrSelfTransfer is a mock of errSelfTransfer interface.
+type MockerrSelfTransfer struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrSelfTransferMockRecorder
+}
+
+// MockerrSelfTransferMockRecorder is the mock recorder for MockerrSelfTransfer.
+type MockerrSelfTransferMockRecorder struct {
+	mock *MockerrSelfTransfer
+}
+
+// NewMockerrSelfTransfer creates a new mock instance.
+func NewMockerrSelfTransfer(ctrl *gomock.Controller) *MockerrSelfTransfer {
+	mock := &MockerrSelfTransfer{ctrl: ctrl}
+	mock.recorder = &MockerrSelfTransferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrSelfTransfer) EXPECT() *MockerrSelfTransferMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrSelfTransfer) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrSelfTransferMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrSelfTransfer)(nil).Error))
+}
+
+// IsErrSelfTransfer mocks base method.
+func (m *MockerrSelfTransfer) IsErrSelfTransfer() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrSelfTransfer")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrSelfTransfer indicates an expected call of IsErrSelfTransfer.
+func (mr *MockerrSelfTransferMockRecorder) IsErrSelfTransfer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrSelfTransfer", reflect.TypeOf((*MockerrSelfTransfer)(nil).IsErrSelfTransfer))
+}
+
+// MockerrTransferLimitExceeded is a mock of errTransferLimitExceeded interface.
+type MockerrTransferLimitExceeded struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTransferLimitExceededMockRecorder
+}
+
+// MockerrTransferLimitExceededMockRecorder is the mock recorder for MockerrTransferLimitExceeded.
+type MockerrTransferLimitExceededMockRecorder struct {
+	mock *MockerrTransferLimitExceeded
+}
+
+// NewMockerrTransferLimitExceeded creates a new mock instance.
+func NewMockerrTransferLimitExceeded(ctrl *gomock.Controller) *MockerrTransferLimitExceeded {
+	mock := &MockerrTransferLimitExceeded{ctrl: ctrl}
+	mock.recorder = &MockerrTransferLimitExceededMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTransferLimitExceeded) EXPECT() *MockerrTransferLimitExceededMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTransferLimitExceeded) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTransferLimitExceededMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTransferLimitExceeded)(nil).Error))
+}
+
+// IsErrTransferLimitExceeded mocks base method.
+func (m *MockerrTransferLimitExceeded) IsErrTransferLimitExceeded() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTransferLimitExceeded")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTransferLimitExceeded indicates an expected call of IsErrTransferLimitExceeded.
+func (mr *MockerrTransferLimitExceededMockRecorder) IsErrTransferLimitExceeded() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTransferLimitExceeded", reflect.TypeOf((*MockerrTransferLimitExceeded)(nil).IsErrTransferLimitExceeded))
+}
+
+// MockerrIdempotencyConflict is a mock of errIdempotencyConflict interface.
+type MockerrIdempotencyConflict struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrIdempotencyConflictMockRecorder
+}
+
+// MockerrIdempotencyConflictMockRecorder is the mock recorder for MockerrIdempotencyConflict.
+type MockerrIdempotencyConflictMockRecorder struct {
+	mock *MockerrIdempotencyConflict
+}
+
+// NewMockerrIdempotencyConflict creates a new mock instance.
+func NewMockerrIdempotencyConflict(ctrl *gomock.Controller) *MockerrIdempotencyConflict {
+	mock := &MockerrIdempotencyConflict{ctrl: ctrl}
+	mock.recorder = &MockerrIdempotencyConflictMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrIdempotencyConflict) EXPECT() *MockerrIdempotencyConflictMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrIdempotencyConflict) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrIdempotencyConflictMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrIdempotencyConflict)(nil).Error))
+}
+
+// IsErrIdempotencyConflict mocks base method.
+func (m *MockerrIdempotencyConflict) IsErrIdempotencyConflict() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrIdempotencyConflict")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrIdempotencyConflict indicates an expected call of IsErrIdempotencyConflict.
+func (mr *MockerrIdempotencyConflictMockRecorder) IsErrIdempotencyConflict() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrIdempotencyConflict", reflect.TypeOf((*MockerrIdempotencyConflict)(nil).IsErrIdempotencyConflict))
+}
+
+// MockerrTOTPNotEnrolled is a mock of errTOTPNotEnrolled interface.
+type MockerrTOTPNotEnrolled struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTOTPNotEnrolledMockRecorder
+}
+
+// MockerrTOTPNotEnrolledMockRecorder is the mock recorder for MockerrTOTPNotEnrolled.
+type MockerrTOTPNotEnrolledMockRecorder struct {
+	mock *MockerrTOTPNotEnrolled
+}
+
+// NewMockerrTOTPNotEnrolled creates a new mock instance.
+func NewMockerrTOTPNotEnrolled(ctrl *gomock.Controller) *MockerrTOTPNotEnrolled {
+	mock := &MockerrTOTPNotEnrolled{ctrl: ctrl}
+	mock.recorder = &MockerrTOTPNotEnrolledMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTOTPNotEnrolled) EXPECT() *MockerrTOTPNotEnrolledMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTOTPNotEnrolled) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTOTPNotEnrolledMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTOTPNotEnrolled)(nil).Error))
+}
+
+// IsErrTOTPNotEnrolled mocks base method.
+func (m *MockerrTOTPNotEnrolled) IsErrTOTPNotEnrolled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTOTPNotEnrolled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTOTPNotEnrolled indicates an expected call of IsErrTOTPNotEnrolled.
+func (mr *MockerrTOTPNotEnrolledMockRecorder) IsErrTOTPNotEnrolled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTOTPNotEnrolled", reflect.TypeOf((*MockerrTOTPNotEnrolled)(nil).IsErrTOTPNotEnrolled))
+}
+
+// MockerrTOTPEnabled is a mock of errTOTPEnabled interface.
+type MockerrTOTPEnabled struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTOTPEnabledMockRecorder
+}
+
+// MockerrTOTPEnabledMockRecorder is the mock recorder for MockerrTOTPEnabled.
+type MockerrTOTPEnabledMockRecorder struct {
+	mock *MockerrTOTPEnabled
+}
+
+// NewMockerrTOTPEnabled creates a new mock instance.
+func NewMockerrTOTPEnabled(ctrl *gomock.Controller) *MockerrTOTPEnabled {
+	mock := &MockerrTOTPEnabled{ctrl: ctrl}
+	mock.recorder = &MockerrTOTPEnabledMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTOTPEnabled) EXPECT() *MockerrTOTPEnabledMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTOTPEnabled) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTOTPEnabledMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTOTPEnabled)(nil).Error))
+}
+
+// IsErrTOTPEnabled mocks base method.
+func (m *MockerrTOTPEnabled) IsErrTOTPEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTOTPEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTOTPEnabled indicates an expected call of IsErrTOTPEnabled.
+func (mr *MockerrTOTPEnabledMockRecorder) IsErrTOTPEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTOTPEnabled", reflect.TypeOf((*MockerrTOTPEnabled)(nil).IsErrTOTPEnabled))
+}
+
+// MockerrWrongTOTPCode is a mock of errWrongTOTPCode interface.
+type MockerrWrongTOTPCode struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrWrongTOTPCodeMockRecorder
+}
+
+// MockerrWrongTOTPCodeMockRecorder is the mock recorder for MockerrWrongTOTPCode.
+type MockerrWrongTOTPCodeMockRecorder struct {
+	mock *MockerrWrongTOTPCode
+}
+
+// NewMockerrWrongTOTPCode creates a new mock instance.
+func NewMockerrWrongTOTPCode(ctrl *gomock.Controller) *MockerrWrongTOTPCode {
+	mock := &MockerrWrongTOTPCode{ctrl: ctrl}
+	mock.recorder = &MockerrWrongTOTPCodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrWrongTOTPCode) EXPECT() *MockerrWrongTOTPCodeMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrWrongTOTPCode) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrWrongTOTPCodeMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrWrongTOTPCode)(nil).Error))
+}
+
+// IsErrWrongTOTPCode mocks base method.
+func (m *MockerrWrongTOTPCode) IsErrWrongTOTPCode() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrWrongTOTPCode")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrWrongTOTPCode indicates an expected call of IsErrWrongTOTPCode.
+func (mr *MockerrWrongTOTPCodeMockRecorder) IsErrWrongTOTPCode() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrWrongTOTPCode", reflect.TypeOf((*MockerrWrongTOTPCode)(nil).IsErrWrongTOTPCode))
+}
+
+// MockerrTOTPRequired is a mock of errTOTPRequired interface.
+type MockerrTOTPRequired struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrTOTPRequiredMockRecorder
+}
+
+// MockerrTOTPRequiredMockRecorder is the mock recorder for MockerrTOTPRequired.
+type MockerrTOTPRequiredMockRecorder struct {
+	mock *MockerrTOTPRequired
+}
+
+// NewMockerrTOTPRequired creates a new mock instance.
+func NewMockerrTOTPRequired(ctrl *gomock.Controller) *MockerrTOTPRequired {
+	mock := &MockerrTOTPRequired{ctrl: ctrl}
+	mock.recorder = &MockerrTOTPRequiredMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrTOTPRequired) EXPECT() *MockerrTOTPRequiredMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrTOTPRequired) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrTOTPRequiredMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrTOTPRequired)(nil).Error))
+}
+
+// IsErrTOTPRequired mocks base method.
+func (m *MockerrTOTPRequired) IsErrTOTPRequired() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrTOTPRequired")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrTOTPRequired indicates an expected call of IsErrTOTPRequired.
+func (mr *MockerrTOTPRequiredMockRecorder) IsErrTOTPRequired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrTOTPRequired", reflect.TypeOf((*MockerrTOTPRequired)(nil).IsErrTOTPRequired))
+}
@@ -0,0 +1,166 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const (
+	ContentType = "application/problem+json"
+	typePrefix  = "urn:loyalsys:problem:"
+)
+
+const (
+	CodeInvalidRequest      = "request.invalid"
+	CodeInvalidContentType  = "request.invalid_content_type"
+	CodeNotFound            = "request.not_found"
+	CodeMethodNotAllowed    = "request.method_not_allowed"
+	CodeTimeout             = "request.timeout"
+	CodeRateLimited         = "request.rate_limited"
+	CodeUnknownTenant       = "request.unknown_tenant"
+	CodeIdempotencyConflict = "request.idempotency_conflict"
+	CodeInternal            = "internal"
+	CodeUnavailable         = "unavailable"
+	CodeUnauthorized        = "auth.unauthorized"
+	CodeMalformedToken      = "auth.malformed_token"
+	CodeInvalidToken        = "auth.invalid_token"
+	CodeSessionRevoked      = "auth.session_revoked"
+	CodeInvalidSignature    = "auth.invalid_signature"
+	CodeInvalidCredentials  = "auth.invalid_credentials"
+	CodeLoginTaken          = "auth.login_taken"
+	CodeWeakPassword        = "auth.weak_password"
+	CodeThrottled           = "auth.throttled"
+	CodeInvalidResetToken   = "auth.invalid_reset_token"
+	CodeInvalidChallenge    = "auth.invalid_challenge"
+	CodeTOTPNotEnrolled     = "auth.totp_not_enrolled"
+	CodeTOTPEnabled         = "auth.totp_enabled"
+	CodeWrongTOTPCode       = "auth.totp_invalid_code"
+	CodeTOTPRequired        = "auth.totp_required"
+	CodeInvalidReferral     = "referral.invalid_code"
+	CodeInvalidOrderNumber  = "order.invalid_number"
+	CodeOrderConflict       = "order.conflict"
+	CodeOrderNotFound       = "order.not_found"
+	CodeTooManyOrders       = "order.too_many_pending"
+	CodeBatchTooLarge       = "order.batch_too_large"
+	CodeInsufficientFunds   = "balance.insufficient"
+	CodeInvalidWithdrawal   = "withdrawal.invalid_sum"
+	CodeInvalidTransfer     = "transfer.invalid"
+	CodeUnknownRecipient    = "transfer.unknown_recipient"
+	CodeSelfTransfer        = "transfer.self"
+	CodeTransferLimit       = "transfer.limit_exceeded"
+)
+
+// Problem is an RFC 7807 problem details object. Code is the stable,
+// machine-readable identifier clients should branch on.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Code   string `json:"code"`
+	Detail string `json:"detail,omitempty"`
+}
+
+func New(status int, code string) *Problem {
+	return &Problem{
+		Type:   typePrefix + code,
+		Title:  utils.StatusMessage(status),
+		Status: status,
+		Code:   code,
+	}
+}
+
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Code + ": " + p.Detail
+	}
+	return p.Code
+}
+
+type statusError struct {
+	status int
+	err    error
+}
+
+func (err *statusError) Error() string {
+	return err.err.Error()
+}
+
+func (err *statusError) Unwrap() error {
+	return err.err
+}
+
+// WithStatus keeps the problem code mapped from err but answers with a
+// different status, for routes whose historical status differs from the
+// default one.
+func WithStatus(status int, err error) error {
+	return &statusError{
+		status: status,
+		err:    err,
+	}
+}
+
+// From converts any error returned by a handler into a problem.
+func From(err error) *Problem {
+	var p *Problem
+	if errors.As(err, &p) {
+		return p
+	}
+
+	var prob *Problem
+	for _, r := range rules {
+		if r.match(err) {
+			prob = New(r.status, r.code)
+			if r.detail {
+				prob.Detail = err.Error()
+			}
+			break
+		}
+	}
+	if prob == nil {
+		var fe *fiber.Error
+		if errors.As(err, &fe) {
+			prob = New(fe.Code, codeForStatus(fe.Code))
+		} else {
+			prob = New(fiber.StatusInternalServerError, CodeInternal)
+		}
+	}
+
+	var se *statusError
+	if errors.As(err, &se) {
+		prob.Status = se.status
+		prob.Title = utils.StatusMessage(se.status)
+	}
+	return prob
+}
+
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	p := From(err)
+	body, err := json.Marshal(p)
+	if err != nil {
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+	c.Set(fiber.HeaderContentType, ContentType)
+	return c.Status(p.Status).Send(body)
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case fiber.StatusNotFound:
+		return CodeNotFound
+	case fiber.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case fiber.StatusRequestTimeout:
+		return CodeTimeout
+	case fiber.StatusTooManyRequests:
+		return CodeRateLimited
+	case fiber.StatusUnauthorized:
+		return CodeUnauthorized
+	}
+	if status >= fiber.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
@@ -0,0 +1,110 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test error")
+
+func TestFrom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("problem", func(t *testing.T) {
+		p := New(fiber.StatusBadRequest, CodeInvalidRequest)
+
+		assert.Same(t, p, From(p))
+	})
+
+	t.Run("mapped error", func(t *testing.T) {
+		mErr := mocks.NewMockerrNotEnoughCurrency(ctrl)
+		mErr.EXPECT().IsErrNotEnoughCurrency().Return(true)
+
+		assert.Equal(t, &Problem{
+			Type:   "urn:loyalsys:problem:balance.insufficient",
+			Title:  "Payment Required",
+			Status: fiber.StatusPaymentRequired,
+			Code:   CodeInsufficientFunds,
+		}, From(mErr))
+	})
+
+	t.Run("mapped error with detail", func(t *testing.T) {
+		mErr := mocks.NewMockerrWeakPassword(ctrl)
+		mErr.EXPECT().IsErrWeakPassword().Return(true)
+		mErr.EXPECT().Error().Return("password is too short")
+
+		p := From(mErr)
+		assert.Equal(t, CodeWeakPassword, p.Code)
+		assert.Equal(t, "password is too short", p.Detail)
+	})
+
+	t.Run("status override", func(t *testing.T) {
+		mErr := mocks.NewMockerrWrongTOTPCode(ctrl)
+		mErr.EXPECT().IsErrWrongTOTPCode().Return(true)
+
+		p := From(WithStatus(fiber.StatusForbidden, mErr))
+		assert.Equal(t, fiber.StatusForbidden, p.Status)
+		assert.Equal(t, "Forbidden", p.Title)
+		assert.Equal(t, CodeWrongTOTPCode, p.Code)
+	})
+
+	t.Run("fiber error", func(t *testing.T) {
+		p := From(fiber.ErrRequestTimeout)
+		assert.Equal(t, fiber.StatusRequestTimeout, p.Status)
+		assert.Equal(t, CodeTimeout, p.Code)
+	})
+
+	t.Run("unknown error", func(t *testing.T) {
+		p := From(errTest)
+		assert.Equal(t, fiber.StatusInternalServerError, p.Status)
+		assert.Equal(t, CodeInternal, p.Code)
+		assert.Empty(t, p.Detail)
+	})
+}
+
+func TestErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/", func(c *fiber.Ctx) error {
+		return New(fiber.StatusBadRequest, CodeInvalidRequest)
+	})
+
+	t.Run("valid test", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodGet, "/", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusBadRequest, res.StatusCode)
+		assert.Equal(t, ContentType, res.Header.Get("Content-Type"))
+		var p Problem
+		err = json.NewDecoder(res.Body).Decode(&p)
+		require.NoError(t, err)
+		assert.Equal(t, Problem{
+			Type:   "urn:loyalsys:problem:request.invalid",
+			Title:  "Bad Request",
+			Status: fiber.StatusBadRequest,
+			Code:   CodeInvalidRequest,
+		}, p)
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		request := httptest.NewRequest(fiber.MethodGet, "/unknown", nil)
+
+		res, err := app.Test(request, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		assert.Equal(t, fiber.StatusNotFound, res.StatusCode)
+		assert.Equal(t, ContentType, res.Header.Get("Content-Type"))
+	})
+}
@@ -0,0 +1,172 @@
+package problem
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type errWrongNum interface {
+	error
+	IsErrWrongNum() bool
+}
+
+type errWrongOrderNum interface {
+	error
+	IsErrWrongOrderNum() bool
+}
+
+type errOrderConflict interface {
+	error
+	IsErrOrderConflict() bool
+}
+
+type errOrderNotFound interface {
+	error
+	IsErrOrderNotFound() bool
+}
+
+type errTooManyOrders interface {
+	error
+	IsErrTooManyOrders() bool
+}
+
+type errNotEnoughCurrency interface {
+	error
+	IsErrNotEnoughCurrency() bool
+}
+
+type errNoUser interface {
+	error
+	IsErrNoUser() bool
+}
+
+type errWrongPassword interface {
+	error
+	IsErrWrongPassword() bool
+}
+
+type errLoginConflict interface {
+	error
+	IsErrLoginConflict() bool
+}
+
+type errWeakPassword interface {
+	error
+	IsErrWeakPassword() bool
+}
+
+type errWrongReferralCode interface {
+	error
+	IsErrWrongReferralCode() bool
+}
+
+type errInvalidResetToken interface {
+	error
+	IsErrInvalidResetToken() bool
+}
+
+type errSessionRevoked interface {
+	error
+	IsErrSessionRevoked() bool
+}
+
+type errSourceClosed interface {
+	error
+	IsErrSourceClosed() bool
+}
+
+type errInvalidWithdrawalSum interface {
+	error
+	IsErrInvalidWithdrawalSum() bool
+}
+
+type errInvalidTransfer interface {
+	error
+	IsErrInvalidTransfer() bool
+}
+
+type errUnknownRecipient interface {
+	error
+	IsErrUnknownRecipient() bool
+}
+
+type errSelfTransfer interface {
+	error
+	IsErrSelfTransfer() bool
+}
+
+type errTransferLimitExceeded interface {
+	error
+	IsErrTransferLimitExceeded() bool
+}
+
+type errIdempotencyConflict interface {
+	error
+	IsErrIdempotencyConflict() bool
+}
+
+type errTOTPNotEnrolled interface {
+	error
+	IsErrTOTPNotEnrolled() bool
+}
+
+type errTOTPEnabled interface {
+	error
+	IsErrTOTPEnabled() bool
+}
+
+type errWrongTOTPCode interface {
+	error
+	IsErrWrongTOTPCode() bool
+}
+
+type errTOTPRequired interface {
+	error
+	IsErrTOTPRequired() bool
+}
+
+type rule struct {
+	match  func(error) bool
+	status int
+	code   string
+	detail bool
+}
+
+// rules map service and storage error types to problems. The statuses are
+// the ones the handlers answered with before problems were introduced.
+var rules = []rule{
+	{match: is(errWrongNum.IsErrWrongNum), status: fiber.StatusUnprocessableEntity, code: CodeInvalidOrderNumber},
+	{match: is(errWrongOrderNum.IsErrWrongOrderNum), status: fiber.StatusUnprocessableEntity, code: CodeInvalidOrderNumber},
+	{match: is(errOrderConflict.IsErrOrderConflict), status: fiber.StatusConflict, code: CodeOrderConflict},
+	{match: is(errOrderNotFound.IsErrOrderNotFound), status: fiber.StatusNotFound, code: CodeOrderNotFound},
+	{match: is(errTooManyOrders.IsErrTooManyOrders), status: fiber.StatusTooManyRequests, code: CodeTooManyOrders},
+	{match: is(errNotEnoughCurrency.IsErrNotEnoughCurrency), status: fiber.StatusPaymentRequired, code: CodeInsufficientFunds},
+	{match: is(errNoUser.IsErrNoUser), status: fiber.StatusUnauthorized, code: CodeInvalidCredentials},
+	{match: is(errWrongPassword.IsErrWrongPassword), status: fiber.StatusUnauthorized, code: CodeInvalidCredentials},
+	{match: is(errLoginConflict.IsErrLoginConflict), status: fiber.StatusConflict, code: CodeLoginTaken},
+	{match: is(errWeakPassword.IsErrWeakPassword), status: fiber.StatusBadRequest, code: CodeWeakPassword, detail: true},
+	{match: is(errWrongReferralCode.IsErrWrongReferralCode), status: fiber.StatusUnprocessableEntity, code: CodeInvalidReferral},
+	{match: is(errInvalidResetToken.IsErrInvalidResetToken), status: fiber.StatusUnauthorized, code: CodeInvalidResetToken},
+	{match: is(errSessionRevoked.IsErrSessionRevoked), status: fiber.StatusUnauthorized, code: CodeSessionRevoked},
+	{match: is(errSourceClosed.IsErrSourceClosed), status: fiber.StatusServiceUnavailable, code: CodeUnavailable},
+	{match: is(errInvalidWithdrawalSum.IsErrInvalidWithdrawalSum), status: fiber.StatusUnprocessableEntity, code: CodeInvalidWithdrawal},
+	{match: is(errInvalidTransfer.IsErrInvalidTransfer), status: fiber.StatusBadRequest, code: CodeInvalidTransfer},
+	{match: is(errUnknownRecipient.IsErrUnknownRecipient), status: fiber.StatusNotFound, code: CodeUnknownRecipient},
+	{match: is(errSelfTransfer.IsErrSelfTransfer), status: fiber.StatusUnprocessableEntity, code: CodeSelfTransfer},
+	{match: is(errTransferLimitExceeded.IsErrTransferLimitExceeded), status: fiber.StatusForbidden, code: CodeTransferLimit},
+	{match: is(errIdempotencyConflict.IsErrIdempotencyConflict), status: fiber.StatusConflict, code: CodeIdempotencyConflict},
+	{match: is(errTOTPNotEnrolled.IsErrTOTPNotEnrolled), status: fiber.StatusConflict, code: CodeTOTPNotEnrolled},
+	{match: is(errTOTPEnabled.IsErrTOTPEnabled), status: fiber.StatusConflict, code: CodeTOTPEnabled},
+	{match: is(errWrongTOTPCode.IsErrWrongTOTPCode), status: fiber.StatusUnprocessableEntity, code: CodeWrongTOTPCode},
+	{match: is(errTOTPRequired.IsErrTOTPRequired), status: fiber.StatusForbidden, code: CodeTOTPRequired},
+}
+
+func is[T error](check func(T) bool) func(error) bool {
+	return func(err error) bool {
+		var e T
+		return errors.As(err, &e) && check(e)
+	}
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type window struct {
+	count   int
+	resetAt time.Time
+}
+
+// Limiter counts requests per key in fixed windows. Counters live in
+// process memory, so every instance enforces its own share of the limit.
+type Limiter struct {
+	limit   int
+	period  time.Duration
+	mu      sync.Mutex
+	windows *expiring.Map[*window]
+	now     func() time.Time
+}
+
+func NewLimiter(limit int, period time.Duration) *Limiter {
+	return &Limiter{
+		limit:   limit,
+		period:  period,
+		windows: expiring.NewMap[*window](),
+		now:     time.Now,
+	}
+}
+
+func (l *Limiter) Allow(_ context.Context, key string) (*models.RateLimit, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w, ok := l.windows.Get(key, now)
+	if !ok {
+		w = &window{resetAt: now.Add(l.period)}
+		l.windows.Set(key, w, w.resetAt, now)
+	}
+	allowed := w.count < l.limit
+	if allowed {
+		w.count++
+	}
+	return &models.RateLimit{
+		Limit:     l.limit,
+		Remaining: l.limit - w.count,
+		Reset:     w.resetAt.Sub(now),
+		Allowed:   allowed,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring/expiringtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "orders:1"
+
+func TestLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	clock := expiringtest.NewClock()
+	limiter := NewLimiter(2, time.Minute)
+	limiter.now = clock.Now
+
+	t.Run("within limit", func(t *testing.T) {
+		res, err := limiter.Allow(ctx, testKey)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2, res.Limit)
+		assert.Equal(t, 1, res.Remaining)
+		assert.Equal(t, time.Minute, res.Reset)
+
+		clock.Add(10 * time.Second)
+		res, err = limiter.Allow(ctx, testKey)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+		assert.Equal(t, 50*time.Second, res.Reset)
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		res, err := limiter.Allow(ctx, testKey)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+
+		res, err = limiter.Allow(ctx, "orders:2")
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+
+	t.Run("new window", func(t *testing.T) {
+		clock.Add(time.Minute)
+
+		res, err := limiter.Allow(ctx, testKey)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 1, res.Remaining)
+	})
+
+}
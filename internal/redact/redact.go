@@ -0,0 +1,28 @@
+package redact
+
+import (
+	"net/url"
+	"regexp"
+)
+
+// Mask replaces a secret wherever one would be logged or printed.
+const Mask = "REDACTED"
+
+var dsnPasswordRe = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// DSN masks the password of a database URI or keyword/value DSN.
+func DSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err == nil && u.Scheme != "" {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.UserPassword(u.User.Username(), Mask)
+		}
+		query := u.Query()
+		if query.Has("password") {
+			query.Set("password", Mask)
+			u.RawQuery = query.Encode()
+		}
+		return u.String()
+	}
+	return dsnPasswordRe.ReplaceAllString(dsn, "${1}"+Mask)
+}
@@ -0,0 +1,40 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring"
+)
+
+// Cache remembers the nonces of signed requests until they expire, so a
+// captured request can't be sent again. Nonces live in process memory, so
+// the cache only protects a single replica. Deployments with several
+// replicas keep the nonces in postgres with storage.NonceStorage.
+type Cache struct {
+	mu     sync.Mutex
+	nonces *expiring.Map[struct{}]
+	now    func() time.Time
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		nonces: expiring.NewMap[struct{}](),
+		now:    time.Now,
+	}
+}
+
+// Use records the nonce until expiresAt. It reports false if the nonce was
+// already used and hasn't expired yet.
+func (c *Cache) Use(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.nonces.Get(nonce, now); ok {
+		return false, nil
+	}
+	c.nonces.Set(nonce, struct{}{}, expiresAt, now)
+	return true, nil
+}
@@ -0,0 +1,46 @@
+package replay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring/expiringtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNonce = "b7f3c1d2"
+
+func TestCache_Use(t *testing.T) {
+	ctx := context.Background()
+	clock := expiringtest.NewClock()
+	cache := NewCache()
+	cache.now = clock.Now
+
+	t.Run("new nonce", func(t *testing.T) {
+		ok, err := cache.Use(ctx, testNonce, clock.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("used nonce", func(t *testing.T) {
+		clock.Add(30 * time.Second)
+
+		ok, err := cache.Use(ctx, testNonce, clock.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		ok, err = cache.Use(ctx, "a91e04f5", clock.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("expired nonce", func(t *testing.T) {
+		clock.Add(30 * time.Second)
+
+		ok, err := cache.Use(ctx, testNonce, clock.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/rpc/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestTokenAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBalance := mocks.NewMockbalanceServicer(ctrl)
+	srv := NewLoyaltyServer(mBalance, mocks.NewMockwithdrawalServicer(ctrl), mocks.NewMockorderServicer(ctrl))
+	auth := NewTokenAuth(testToken)
+	conn := newTestConn(t, srv, []grpc.ServerOption{
+		grpc.UnaryInterceptor(auth.Unary()),
+		grpc.StreamInterceptor(auth.Stream()),
+	})
+	client := loyalsysv1.NewLoyaltyServiceClient(conn)
+	authorized := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+testToken)
+
+	t.Run("valid test", func(t *testing.T) {
+		mBalance.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(&models.Balance{}, nil)
+
+		_, err := client.GetBalance(authorized, &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := client.GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer wrong")
+		_, err := client.GetBalance(ctx, &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("health without token", func(t *testing.T) {
+		res, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{
+			Service: loyalsysv1.LoyaltyService_ServiceDesc.ServiceName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, res.GetStatus())
+	})
+
+	t.Run("reflection", func(t *testing.T) {
+		listServices := func(ctx context.Context) ([]string, error) {
+			stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+			if err != nil {
+				return nil, err
+			}
+			err = stream.Send(&reflectionpb.ServerReflectionRequest{
+				MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+			})
+			if err != nil {
+				return nil, err
+			}
+			res, err := stream.Recv()
+			if err != nil {
+				return nil, err
+			}
+			var names []string
+			for _, s := range res.GetListServicesResponse().GetService() {
+				names = append(names, s.GetName())
+			}
+			return names, nil
+		}
+
+		names, err := listServices(authorized)
+		require.NoError(t, err)
+		assert.Contains(t, names, loyalsysv1.LoyaltyService_ServiceDesc.ServiceName)
+		assert.Contains(t, names, grpc_health_v1.Health_ServiceDesc.ServiceName)
+
+		_, err = listServices(context.Background())
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testUserID      = models.UserID(1)
+	testToken       = "service_token"
+	validLuhnString = "4512812345678909"
+	bufSize         = 1024 * 1024
+)
+
+var errTest = errors.New("test error")
+
+// newTestConn serves srv over an in-memory listener and dials it.
+func newTestConn(t *testing.T, srv loyalsysv1.LoyaltyServiceServer, serverOpts []grpc.ServerOption, dialOpts ...grpc.DialOption) *grpc.ClientConn {
+	lis := bufconn.Listen(bufSize)
+	s, _ := NewServer(srv, serverOpts...)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	if len(dialOpts) == 0 {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	dialOpts = append(dialOpts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOpts...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+	return conn
+}
@@ -0,0 +1,59 @@
+package rpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+var errNoClientCA = errors.New("no certificates found in the client CA file")
+
+// NewServer registers the loyalty service along with the health and
+// reflection services. The returned health server reports the loyalty
+// service as serving until it is shut down.
+func NewServer(srv loyalsysv1.LoyaltyServiceServer, opts ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	s := grpc.NewServer(opts...)
+	loyalsysv1.RegisterLoyaltyServiceServer(s, srv)
+
+	healthSrv := health.NewServer()
+	healthSrv.SetServingStatus(loyalsysv1.LoyaltyService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(s, healthSrv)
+
+	reflection.Register(s)
+	return s, healthSrv
+}
+
+// ServerCredentials loads the server key pair. With a client CA, clients
+// must present a certificate signed by it.
+func ServerCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: %s", errNoClientCA, clientCAFile)
+		}
+		tlsCfg.ClientCAs = pool
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(tlsCfg), nil
+}
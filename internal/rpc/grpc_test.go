@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/rpc/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signerCert, signerKey := template, key
+	if parent != nil {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) write(t *testing.T, name string) (string, string) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	keyDER, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return certFile, keyFile
+}
+
+func (c *testCert) tlsCertificate() tls.Certificate {
+	return tls.Certificate{
+		Certificate: [][]byte{c.der},
+		PrivateKey:  c.key,
+	}
+}
+
+func TestServerCredentials(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	ca := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}, nil)
+	server := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "loyalsys"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	client := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "checkout"},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	caFile, _ := ca.write(t, "ca")
+	certFile, keyFile := server.write(t, "server")
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	creds, err := ServerCredentials(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	mBalance := mocks.NewMockbalanceServicer(ctrl)
+	srv := NewLoyaltyServer(mBalance, mocks.NewMockwithdrawalServicer(ctrl), mocks.NewMockorderServicer(ctrl))
+
+	t.Run("valid test", func(t *testing.T) {
+		conn := newTestConn(t, srv, []grpc.ServerOption{grpc.Creds(creds)},
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				ServerName:   "localhost",
+				RootCAs:      roots,
+				Certificates: []tls.Certificate{client.tlsCertificate()},
+			})))
+		mBalance.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(&models.Balance{}, nil)
+
+		_, err := loyalsysv1.NewLoyaltyServiceClient(conn).GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.NoError(t, err)
+	})
+
+	t.Run("no client certificate", func(t *testing.T) {
+		conn := newTestConn(t, srv, []grpc.ServerOption{grpc.Creds(creds)},
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				ServerName: "localhost",
+				RootCAs:    roots,
+			})))
+
+		_, err := loyalsysv1.NewLoyaltyServiceClient(conn).GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong client CA", func(t *testing.T) {
+		_, err := ServerCredentials(certFile, keyFile, keyFile)
+		assert.ErrorIs(t, err, errNoClientCA)
+	})
+
+	t.Run("missing key pair", func(t *testing.T) {
+		_, err := ServerCredentials(filepath.Join(t.TempDir(), "missing.crt"), keyFile, "")
+		assert.Error(t, err)
+	})
+}
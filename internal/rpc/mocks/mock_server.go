@@ -0,0 +1,192 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: server.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockbalanceServicer is a mock of balanceServicer interface.
+type MockbalanceServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockbalanceServicerMockRecorder
+}
+
+// MockbalanceServicerMockRecorder is the mock recorder for MockbalanceServicer.
+type MockbalanceServicerMockRecorder struct {
+	mock *MockbalanceServicer
+}
+
+// NewMockbalanceServicer creates a new mock instance.
+func NewMockbalanceServicer(ctrl *gomock.Controller) *MockbalanceServicer {
+	mock := &MockbalanceServicer{ctrl: ctrl}
+	mock.recorder = &MockbalanceServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbalanceServicer) EXPECT() *MockbalanceServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserBalance mocks base method.
+func (m *MockbalanceServicer) GetUserBalance(arg0 context.Context, arg1 models.UserID) (*models.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserBalance", arg0, arg1)
+	ret0, _ := ret[0].(*models.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserBalance indicates an expected call of GetUserBalance.
+func (mr *MockbalanceServicerMockRecorder) GetUserBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserBalance", reflect.TypeOf((*MockbalanceServicer)(nil).GetUserBalance), arg0, arg1)
+}
+
+// MockwithdrawalServicer is a mock of withdrawalServicer interface.
+type MockwithdrawalServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockwithdrawalServicerMockRecorder
+}
+
+// MockwithdrawalServicerMockRecorder is the mock recorder for MockwithdrawalServicer.
+type MockwithdrawalServicerMockRecorder struct {
+	mock *MockwithdrawalServicer
+}
+
+// NewMockwithdrawalServicer creates a new mock instance.
+func NewMockwithdrawalServicer(ctrl *gomock.Controller) *MockwithdrawalServicer {
+	mock := &MockwithdrawalServicer{ctrl: ctrl}
+	mock.recorder = &MockwithdrawalServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockwithdrawalServicer) EXPECT() *MockwithdrawalServicerMockRecorder {
+	return m.recorder
+}
+
+// WithdrawalProcessing mocks base method.
+func (m *MockwithdrawalServicer) WithdrawalProcessing(arg0 context.Context, arg1 *models.Withdrawal) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawalProcessing", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WithdrawalProcessing indicates an expected call of WithdrawalProcessing.
+func (mr *MockwithdrawalServicerMockRecorder) WithdrawalProcessing(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawalProcessing", reflect.TypeOf((*MockwithdrawalServicer)(nil).WithdrawalProcessing), arg0, arg1)
+}
+
+// MockorderServicer is a mock of orderServicer interface.
+type MockorderServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderServicerMockRecorder
+}
+
+// MockorderServicerMockRecorder is the mock recorder for MockorderServicer.
+type MockorderServicerMockRecorder struct {
+	mock *MockorderServicer
+}
+
+// NewMockorderServicer creates a new mock instance.
+func NewMockorderServicer(ctrl *gomock.Controller) *MockorderServicer {
+	mock := &MockorderServicer{ctrl: ctrl}
+	mock.recorder = &MockorderServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderServicer) EXPECT() *MockorderServicerMockRecorder {
+	return m.recorder
+}
+
+// GetUserOrdersPage mocks base method.
+func (m *MockorderServicer) GetUserOrdersPage(arg0 context.Context, arg1 models.UserID, arg2 models.Page) ([]*models.OrderDB, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserOrdersPage", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.OrderDB)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserOrdersPage indicates an expected call of GetUserOrdersPage.
+func (mr *MockorderServicerMockRecorder) GetUserOrdersPage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserOrdersPage", reflect.TypeOf((*MockorderServicer)(nil).GetUserOrdersPage), arg0, arg1, arg2)
+}
+
+// SaveOrder mocks base method.
+func (m *MockorderServicer) SaveOrder(arg0 context.Context, arg1 *models.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOrder", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOrder indicates an expected call of SaveOrder.
+func (mr *MockorderServicerMockRecorder) SaveOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockorderServicer)(nil).SaveOrder), arg0, arg1)
+}
+
+// MockerrOrderExists is a mock of errOrderExists interface.
+type MockerrOrderExists struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrOrderExistsMockRecorder
+}
+
+// MockerrOrderExistsMockRecorder is the mock recorder for MockerrOrderExists.
+type MockerrOrderExistsMockRecorder struct {
+	mock *MockerrOrderExists
+}
+
+// NewMockerrOrderExists creates a new mock instance.
+func NewMockerrOrderExists(ctrl *gomock.Controller) *MockerrOrderExists {
+	mock := &MockerrOrderExists{ctrl: ctrl}
+	mock.recorder = &MockerrOrderExistsMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrOrderExists) EXPECT() *MockerrOrderExistsMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrOrderExists) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrOrderExistsMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrOrderExists)(nil).Error))
+}
+
+// IsErrOrderExists mocks base method.
+func (m *MockerrOrderExists) IsErrOrderExists() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrOrderExists")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrOrderExists indicates an expected call of IsErrOrderExists.
+func (mr *MockerrOrderExistsMockRecorder) IsErrOrderExists() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrOrderExists", reflect.TypeOf((*MockerrOrderExists)(nil).IsErrOrderExists))
+}
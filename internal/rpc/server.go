@@ -0,0 +1,173 @@
+package rpc
+
+import (
+	"context"
+
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+type balanceServicer interface {
+	GetUserBalance(context.Context, models.UserID) (*models.Balance, error)
+}
+
+type withdrawalServicer interface {
+	WithdrawalProcessing(context.Context, *models.Withdrawal) error
+}
+
+type orderServicer interface {
+	SaveOrder(context.Context, *models.Order) error
+	GetUserOrdersPage(context.Context, models.UserID, models.Page) ([]*models.OrderDB, bool, error)
+}
+
+type errOrderExists interface {
+	error
+	IsErrOrderExists() bool
+}
+
+// LoyaltyServer serves the gRPC API for internal services. Callers are
+// trusted to have authenticated the user, so there is no step-up check on
+// withdrawals.
+type LoyaltyServer struct {
+	loyalsysv1.UnimplementedLoyaltyServiceServer
+	balance    balanceServicer
+	withdrawal withdrawalServicer
+	order      orderServicer
+}
+
+func NewLoyaltyServer(balance balanceServicer, withdrawal withdrawalServicer, order orderServicer) *LoyaltyServer {
+	return &LoyaltyServer{
+		balance:    balance,
+		withdrawal: withdrawal,
+		order:      order,
+	}
+}
+
+func (s *LoyaltyServer) GetBalance(ctx context.Context, req *loyalsysv1.GetBalanceRequest) (*loyalsysv1.GetBalanceResponse, error) {
+	uid, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.balance.GetUserBalance(ctx, uid)
+	if err != nil {
+		logger.Log.Debug("rpc:GetBalance", zap.Error(err))
+		return nil, toStatus(err)
+	}
+
+	return &loyalsysv1.GetBalanceResponse{
+		Current:   models.Amount(balance.Current).String(),
+		Withdrawn: models.Amount(balance.Withdrawn).String(),
+	}, nil
+}
+
+func (s *LoyaltyServer) Withdraw(ctx context.Context, req *loyalsysv1.WithdrawRequest) (*loyalsysv1.WithdrawResponse, error) {
+	uid, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	sum, err := models.ParseAmount(req.GetSum())
+	if err != nil || sum <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "sum must be a positive decimal with at most two fractional digits")
+	}
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency key is required")
+	}
+
+	err = s.withdrawal.WithdrawalProcessing(ctx, &models.Withdrawal{
+		Order:          req.GetOrder(),
+		UserID:         uid,
+		Sum:            float64(sum),
+		IdempotencyKey: req.GetIdempotencyKey(),
+	})
+	if err != nil {
+		logger.Log.Debug("rpc:Withdraw", zap.Error(err))
+		return nil, toStatus(err)
+	}
+
+	return &loyalsysv1.WithdrawResponse{}, nil
+}
+
+func (s *LoyaltyServer) GetOrders(ctx context.Context, req *loyalsysv1.GetOrdersRequest) (*loyalsysv1.GetOrdersResponse, error) {
+	uid, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	page := models.Page{
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	}
+	if page.Limit == 0 {
+		page.Limit = defaultPageLimit
+	}
+	if page.Limit < 0 || page.Limit > maxPageLimit || page.Offset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d and offset must not be negative", maxPageLimit)
+	}
+
+	orders, more, err := s.order.GetUserOrdersPage(ctx, uid, page)
+	if err != nil {
+		logger.Log.Debug("rpc:GetOrders", zap.Error(err))
+		return nil, toStatus(err)
+	}
+
+	res := &loyalsysv1.GetOrdersResponse{
+		Orders: make([]*loyalsysv1.Order, 0, len(orders)),
+	}
+	for _, order := range orders {
+		o := &loyalsysv1.Order{
+			Number:     order.Number,
+			Status:     order.Status,
+			UploadedAt: order.CreatedAt,
+		}
+		if order.Accrual != 0 {
+			o.Accrual = models.Amount(order.Accrual).String()
+		}
+		res.Orders = append(res.Orders, o)
+	}
+	if more {
+		res.NextOffset = int32(page.Offset + page.Limit)
+	}
+	return res, nil
+}
+
+func (s *LoyaltyServer) RegisterOrder(ctx context.Context, req *loyalsysv1.RegisterOrderRequest) (*loyalsysv1.RegisterOrderResponse, error) {
+	uid, err := userID(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetNumber() == "" {
+		return nil, status.Error(codes.InvalidArgument, "order number is required")
+	}
+
+	err = s.order.SaveOrder(ctx, &models.Order{
+		Number: req.GetNumber(),
+		UserID: uid,
+	})
+	if e, ok := err.(errOrderExists); ok && e.IsErrOrderExists() {
+		return &loyalsysv1.RegisterOrderResponse{AlreadyRegistered: true}, nil
+	}
+	if err != nil {
+		logger.Log.Debug("rpc:RegisterOrder", zap.Error(err))
+		return nil, toStatus(err)
+	}
+
+	return &loyalsysv1.RegisterOrderResponse{}, nil
+}
+
+func userID(id int64) (models.UserID, error) {
+	if id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, "user id must be positive")
+	}
+	return models.UserID(id), nil
+}
@@ -0,0 +1,364 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ShiraazMoollatjie/goluhn"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v5/stdlib"
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/migrator"
+	"github.com/rycln/loyalsys/internal/models"
+	problemmocks "github.com/rycln/loyalsys/internal/problem/mocks"
+	"github.com/rycln/loyalsys/internal/rpc/mocks"
+	"github.com/rycln/loyalsys/internal/services"
+	"github.com/rycln/loyalsys/internal/storage"
+	"github.com/rycln/loyalsys/internal/storage/memory"
+	"github.com/rycln/loyalsys/internal/storage/storagetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoyaltyServer_GetBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBalance := mocks.NewMockbalanceServicer(ctrl)
+	srv := NewLoyaltyServer(mBalance, mocks.NewMockwithdrawalServicer(ctrl), mocks.NewMockorderServicer(ctrl))
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, srv, nil))
+
+	t.Run("valid test", func(t *testing.T) {
+		mBalance.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(&models.Balance{
+			UserID:    testUserID,
+			Current:   500.5,
+			Withdrawn: 42,
+		}, nil)
+
+		res, err := client.GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		require.NoError(t, err)
+		assert.Equal(t, "500.50", res.GetCurrent())
+		assert.Equal(t, "42.00", res.GetWithdrawn())
+	})
+
+	t.Run("wrong user id", func(t *testing.T) {
+		_, err := client.GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mBalance.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(nil, errTest)
+
+		_, err := client.GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestLoyaltyServer_Withdraw(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mWithdrawal := mocks.NewMockwithdrawalServicer(ctrl)
+	srv := NewLoyaltyServer(mocks.NewMockbalanceServicer(ctrl), mWithdrawal, mocks.NewMockorderServicer(ctrl))
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, srv, nil))
+
+	withdrawal := &models.Withdrawal{
+		Order:          validLuhnString,
+		UserID:         testUserID,
+		Sum:            10.25,
+		IdempotencyKey: "key",
+	}
+	req := &loyalsysv1.WithdrawRequest{
+		UserId:         int64(testUserID),
+		Order:          validLuhnString,
+		Sum:            "10.25",
+		IdempotencyKey: "key",
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mWithdrawal.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(nil)
+
+		_, err := client.Withdraw(context.Background(), req)
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong sum", func(t *testing.T) {
+		for _, sum := range []string{"", "0", "-1", "1.234", "abc"} {
+			_, err := client.Withdraw(context.Background(), &loyalsysv1.WithdrawRequest{
+				UserId:         int64(testUserID),
+				Order:          validLuhnString,
+				Sum:            sum,
+				IdempotencyKey: "key",
+			})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), sum)
+		}
+	})
+
+	t.Run("missing idempotency key", func(t *testing.T) {
+		_, err := client.Withdraw(context.Background(), &loyalsysv1.WithdrawRequest{
+			UserId: int64(testUserID),
+			Order:  validLuhnString,
+			Sum:    "10",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("not enough currency error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrNotEnoughCurrency(ctrl)
+		mErr.EXPECT().IsErrNotEnoughCurrency().Return(true)
+		mWithdrawal.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(mErr)
+
+		_, err := client.Withdraw(context.Background(), req)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "balance.insufficient")
+	})
+
+	t.Run("luhn validation error", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrWrongOrderNum(ctrl)
+		mErr.EXPECT().IsErrWrongOrderNum().Return(true)
+		mWithdrawal.EXPECT().WithdrawalProcessing(gomock.Any(), withdrawal).Return(mErr)
+
+		_, err := client.Withdraw(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// TestLoyaltyServer_WithdrawStorage runs withdrawals through the server on
+// a real storage. Postgres is used when TEST_DATABASE_URI is set.
+func TestLoyaltyServer_WithdrawStorage(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		db := memory.NewDB()
+		tenant := models.TenantID("tenant")
+		testWithdrawStorage(t, storagetest.Repositories{
+			Users:       memory.NewUserStorage(db, tenant),
+			Orders:      memory.NewOrderStorage(db, tenant),
+			Withdrawals: memory.NewWithdrawalStorage(db, tenant),
+			Balance:     memory.NewBalanceStorage(db, tenant),
+		})
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		databaseURI := os.Getenv("TEST_DATABASE_URI")
+		if databaseURI == "" {
+			t.Skip("TEST_DATABASE_URI is not set")
+		}
+		ctx := context.Background()
+		pool, err := storage.NewPool(ctx, storage.NewDSN(databaseURI), storage.PoolConfig{
+			MaxConns:        8,
+			MaxConnIdleTime: time.Minute,
+			MaxConnLifetime: time.Hour,
+		})
+		require.NoError(t, err)
+		t.Cleanup(pool.Close)
+		db := stdlib.OpenDBFromPool(pool)
+		t.Cleanup(func() { db.Close() })
+
+		m, err := migrator.New(db)
+		require.NoError(t, err)
+		_, err = m.Up(ctx)
+		require.NoError(t, err)
+
+		tenant := models.TenantID(fmt.Sprintf("rpc%d", time.Now().UnixNano()))
+		testWithdrawStorage(t, storagetest.Repositories{
+			Users:       storage.NewUserStorage(pool, tenant),
+			Orders:      storage.NewOrderSyncStorage(storage.NewOrderStorage(pool, tenant)),
+			Withdrawals: storage.NewWithdrawalStorage(pool, tenant),
+			Balance:     storage.NewBalanceStorage(pool, tenant),
+		})
+	})
+}
+
+func testWithdrawStorage(t *testing.T, repos storagetest.Repositories) {
+	t.Run("concurrent", func(t *testing.T) {
+		testWithdrawConcurrent(t, repos)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		srv := NewLoyaltyServer(services.NewBalanceService(repos.Balance), services.NewWithdrawalService(repos.Withdrawals), nil)
+		client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, srv, nil))
+
+		_, err := client.Withdraw(context.Background(), &loyalsysv1.WithdrawRequest{
+			UserId:         1 << 40,
+			Order:          validLuhnString,
+			Sum:            "10",
+			IdempotencyKey: "unknown",
+		})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+// testWithdrawConcurrent sends more withdrawals than the balance covers at
+// once.
+func testWithdrawConcurrent(t *testing.T, repos storagetest.Repositories) {
+	const (
+		attempts = 20
+		funded   = 10
+	)
+	ctx := context.Background()
+	uid, err := repos.Users.AddUser(ctx, &models.UserDB{
+		Login:        "user",
+		PasswordHash: "hash",
+		ReferralCode: "R",
+	})
+	require.NoError(t, err)
+	_, _, err = repos.Orders.AddOrdersBatch(ctx, uid, []string{validLuhnString}, 0)
+	require.NoError(t, err)
+	err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{{Number: validLuhnString, Status: models.StatusProcessed, Accrual: funded * 10}})
+	require.NoError(t, err)
+
+	srv := NewLoyaltyServer(services.NewBalanceService(repos.Balance), services.NewWithdrawalService(repos.Withdrawals), nil)
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, srv, nil))
+
+	var wg sync.WaitGroup
+	codesCh := make(chan codes.Code, attempts)
+	for i := range attempts {
+		_, order, err := goluhn.Calculate(strconv.Itoa(1000 + i))
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.Withdraw(ctx, &loyalsysv1.WithdrawRequest{
+				UserId:         int64(uid),
+				Order:          order,
+				Sum:            "10",
+				IdempotencyKey: strconv.Itoa(i),
+			})
+			codesCh <- status.Code(err)
+		}()
+	}
+	wg.Wait()
+	close(codesCh)
+
+	counts := make(map[codes.Code]int)
+	for code := range codesCh {
+		counts[code]++
+	}
+	assert.Equal(t, map[codes.Code]int{codes.OK: funded, codes.FailedPrecondition: attempts - funded}, counts)
+
+	res, err := client.GetBalance(ctx, &loyalsysv1.GetBalanceRequest{UserId: int64(uid)})
+	require.NoError(t, err)
+	assert.Equal(t, "0.00", res.GetCurrent())
+	assert.Equal(t, "100.00", res.GetWithdrawn())
+}
+
+func TestLoyaltyServer_GetOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mOrder := mocks.NewMockorderServicer(ctrl)
+	srv := NewLoyaltyServer(mocks.NewMockbalanceServicer(ctrl), mocks.NewMockwithdrawalServicer(ctrl), mOrder)
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, srv, nil))
+
+	testOrders := []*models.OrderDB{
+		{
+			Number:    "123",
+			UserID:    testUserID,
+			Status:    models.StatusProcessed,
+			Accrual:   500.5,
+			CreatedAt: "2025-04-02T10:00:00Z",
+		},
+		{
+			Number:    "456",
+			UserID:    testUserID,
+			Status:    models.StatusNew,
+			CreatedAt: "2025-04-01T10:00:00Z",
+		},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mOrder.EXPECT().GetUserOrdersPage(gomock.Any(), testUserID, models.Page{Limit: 2, Offset: 4}).Return(testOrders, true, nil)
+
+		res, err := client.GetOrders(context.Background(), &loyalsysv1.GetOrdersRequest{
+			UserId: int64(testUserID),
+			Limit:  2,
+			Offset: 4,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.GetOrders(), 2)
+		assert.Equal(t, "500.50", res.GetOrders()[0].GetAccrual())
+		assert.Empty(t, res.GetOrders()[1].GetAccrual())
+		assert.Equal(t, models.StatusNew, res.GetOrders()[1].GetStatus())
+		assert.Equal(t, int32(6), res.GetNextOffset())
+	})
+
+	t.Run("default limit", func(t *testing.T) {
+		mOrder.EXPECT().GetUserOrdersPage(gomock.Any(), testUserID, models.Page{Limit: defaultPageLimit}).Return(nil, false, nil)
+
+		res, err := client.GetOrders(context.Background(), &loyalsysv1.GetOrdersRequest{UserId: int64(testUserID)})
+		require.NoError(t, err)
+		assert.Empty(t, res.GetOrders())
+		assert.Zero(t, res.GetNextOffset())
+	})
+
+	t.Run("wrong page", func(t *testing.T) {
+		_, err := client.GetOrders(context.Background(), &loyalsysv1.GetOrdersRequest{
+			UserId: int64(testUserID),
+			Limit:  maxPageLimit + 1,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mOrder.EXPECT().GetUserOrdersPage(gomock.Any(), testUserID, gomock.Any()).Return(nil, false, errTest)
+
+		_, err := client.GetOrders(context.Background(), &loyalsysv1.GetOrdersRequest{UserId: int64(testUserID)})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestLoyaltyServer_RegisterOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mOrder := mocks.NewMockorderServicer(ctrl)
+	srv := NewLoyaltyServer(mocks.NewMockbalanceServicer(ctrl), mocks.NewMockwithdrawalServicer(ctrl), mOrder)
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, srv, nil))
+
+	order := &models.Order{
+		Number: validLuhnString,
+		UserID: testUserID,
+	}
+	req := &loyalsysv1.RegisterOrderRequest{
+		UserId: int64(testUserID),
+		Number: validLuhnString,
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mOrder.EXPECT().SaveOrder(gomock.Any(), order).Return(nil)
+
+		res, err := client.RegisterOrder(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, res.GetAlreadyRegistered())
+	})
+
+	t.Run("order exists", func(t *testing.T) {
+		mErr := mocks.NewMockerrOrderExists(ctrl)
+		mErr.EXPECT().IsErrOrderExists().Return(true)
+		mOrder.EXPECT().SaveOrder(gomock.Any(), order).Return(mErr)
+
+		res, err := client.RegisterOrder(context.Background(), req)
+		require.NoError(t, err)
+		assert.True(t, res.GetAlreadyRegistered())
+	})
+
+	t.Run("order conflict", func(t *testing.T) {
+		mErr := problemmocks.NewMockerrOrderConflict(ctrl)
+		mErr.EXPECT().IsErrOrderConflict().Return(true)
+		mOrder.EXPECT().SaveOrder(gomock.Any(), order).Return(mErr)
+
+		_, err := client.RegisterOrder(context.Background(), req)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("missing number", func(t *testing.T) {
+		_, err := client.RegisterOrder(context.Background(), &loyalsysv1.RegisterOrderRequest{UserId: int64(testUserID)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"context"
+
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tenantMetadataKey = "x-tenant-id"
+
+// TenantRouter passes each call to the server of the tenant named in the
+// x-tenant-id metadata. With a single tenant the metadata may be omitted.
+type TenantRouter struct {
+	loyalsysv1.UnimplementedLoyaltyServiceServer
+	servers  map[models.TenantID]loyalsysv1.LoyaltyServiceServer
+	fallback loyalsysv1.LoyaltyServiceServer
+}
+
+func NewTenantRouter(servers map[models.TenantID]loyalsysv1.LoyaltyServiceServer) *TenantRouter {
+	r := &TenantRouter{
+		servers: servers,
+	}
+	if len(servers) == 1 {
+		for _, srv := range servers {
+			r.fallback = srv
+		}
+	}
+	return r
+}
+
+func (r *TenantRouter) server(ctx context.Context) (loyalsysv1.LoyaltyServiceServer, error) {
+	srv := r.fallback
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(tenantMetadataKey); len(ids) > 0 {
+		srv = r.servers[models.TenantID(ids[0])]
+	}
+	if srv == nil {
+		return nil, status.Error(codes.InvalidArgument, "unknown tenant")
+	}
+	return srv, nil
+}
+
+func (r *TenantRouter) GetBalance(ctx context.Context, req *loyalsysv1.GetBalanceRequest) (*loyalsysv1.GetBalanceResponse, error) {
+	srv, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return srv.GetBalance(ctx, req)
+}
+
+func (r *TenantRouter) Withdraw(ctx context.Context, req *loyalsysv1.WithdrawRequest) (*loyalsysv1.WithdrawResponse, error) {
+	srv, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return srv.Withdraw(ctx, req)
+}
+
+func (r *TenantRouter) GetOrders(ctx context.Context, req *loyalsysv1.GetOrdersRequest) (*loyalsysv1.GetOrdersResponse, error) {
+	srv, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return srv.GetOrders(ctx, req)
+}
+
+func (r *TenantRouter) RegisterOrder(ctx context.Context, req *loyalsysv1.RegisterOrderRequest) (*loyalsysv1.RegisterOrderResponse, error) {
+	srv, err := r.server(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return srv.RegisterOrder(ctx, req)
+}
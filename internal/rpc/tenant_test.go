@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	loyalsysv1 "github.com/rycln/loyalsys/api/loyalsys/v1"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/rpc/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantRouter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBrand := mocks.NewMockbalanceServicer(ctrl)
+	mOther := mocks.NewMockbalanceServicer(ctrl)
+	router := NewTenantRouter(map[models.TenantID]loyalsysv1.LoyaltyServiceServer{
+		"brand":       NewLoyaltyServer(mBrand, mocks.NewMockwithdrawalServicer(ctrl), mocks.NewMockorderServicer(ctrl)),
+		"other_brand": NewLoyaltyServer(mOther, mocks.NewMockwithdrawalServicer(ctrl), mocks.NewMockorderServicer(ctrl)),
+	})
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, router, nil))
+	req := &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)}
+
+	t.Run("valid test", func(t *testing.T) {
+		mOther.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(&models.Balance{UserID: testUserID, Current: 5}, nil)
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), tenantMetadataKey, "other_brand")
+		res, err := client.GetBalance(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "5.00", res.GetCurrent())
+	})
+
+	t.Run("no tenant", func(t *testing.T) {
+		_, err := client.GetBalance(context.Background(), req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("unknown tenant", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), tenantMetadataKey, "nobody")
+		_, err := client.GetBalance(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestTenantRouter_singleTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mBalance := mocks.NewMockbalanceServicer(ctrl)
+	router := NewTenantRouter(map[models.TenantID]loyalsysv1.LoyaltyServiceServer{
+		"default": NewLoyaltyServer(mBalance, mocks.NewMockwithdrawalServicer(ctrl), mocks.NewMockorderServicer(ctrl)),
+	})
+	client := loyalsysv1.NewLoyaltyServiceClient(newTestConn(t, router, nil))
+
+	t.Run("valid test", func(t *testing.T) {
+		mBalance.EXPECT().GetUserBalance(gomock.Any(), testUserID).Return(&models.Balance{UserID: testUserID}, nil)
+
+		_, err := client.GetBalance(context.Background(), &loyalsysv1.GetBalanceRequest{UserId: int64(testUserID)})
+		assert.NoError(t, err)
+	})
+}
@@ -3,23 +3,53 @@ package services
 import (
 	"errors"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rycln/loyalsys/internal/models"
 )
 
-const tokenExp = time.Duration(2) * time.Hour
+const (
+	tokenExp     = time.Duration(2) * time.Hour
+	challengeExp = time.Duration(5) * time.Minute
+	challengeKey = "/2fa-challenge"
+)
 
 var ErrNoUserID = errors.New("jwt does not contain user id")
 
+// JWTKey holds the signing key, which can be replaced at runtime. A new key
+// signs out every user, as tokens signed with the old one stop verifying.
+type JWTKey struct {
+	key atomic.Pointer[string]
+}
+
+func NewJWTKey(key string) *JWTKey {
+	k := &JWTKey{}
+	k.Set(key)
+	return k
+}
+
+func (k *JWTKey) Set(key string) {
+	k.key.Store(&key)
+}
+
+func (k *JWTKey) Get() string {
+	return *k.key.Load()
+}
+
+// JWTService issues and parses tokens for a single tenant. Tenants share the
+// signing key, so a non-empty audience is what keeps one tenant's tokens from
+// being accepted by another.
 type JWTService struct {
-	key string
+	key      *JWTKey
+	audience string
 }
 
-func NewJWTService(key string) *JWTService {
+func NewJWTService(key *JWTKey, audience string) *JWTService {
 	return &JWTService{
-		key: key,
+		key:      key,
+		audience: audience,
 	}
 }
 
@@ -35,15 +65,26 @@ func (c jwtClaims) Validate() error {
 	return nil
 }
 
+type challengeClaims struct {
+	jwt.RegisteredClaims
+	UserID models.UserID `json:"challenge_id"`
+}
+
+func (c challengeClaims) Validate() error {
+	if c.UserID == 0 {
+		return ErrNoUserID
+	}
+	return nil
+}
+
 func (s *JWTService) NewJWTString(userID models.UserID) (string, error) {
+	now := time.Now()
 	claims := jwtClaims{
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenExp)),
-		},
-		UserID: userID,
+		RegisteredClaims: s.registeredClaims(now, tokenExp),
+		UserID:           userID,
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.key))
+	tokenString, err := token.SignedString([]byte(s.key.Get()))
 	if err != nil {
 		return "", err
 	}
@@ -51,15 +92,77 @@ func (s *JWTService) NewJWTString(userID models.UserID) (string, error) {
 }
 
 func (s *JWTService) ParseIDFromAuthHeader(header string) (models.UserID, error) {
+	claims, err := s.parseAuthHeader(header)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+func (s *JWTService) ParseSessionFromAuthHeader(header string) (models.UserID, time.Time, error) {
+	claims, err := s.parseAuthHeader(header)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if claims.IssuedAt == nil {
+		return claims.UserID, time.Time{}, nil
+	}
+	return claims.UserID, claims.IssuedAt.Time, nil
+}
+
+func (s *JWTService) parseAuthHeader(header string) (*jwtClaims, error) {
 	tokenString := strings.TrimPrefix(header, "Bearer")
 	tokenString = strings.TrimSpace(tokenString)
 
 	claims := &jwtClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, s.Keyfunc, s.parserOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Keyfunc returns the current signing key for verifying a token, so the
+// JWT middleware picks up a reloaded key too.
+func (s *JWTService) Keyfunc(*jwt.Token) (interface{}, error) {
+	return []byte(s.key.Get()), nil
+}
+
+func (s *JWTService) NewChallengeString(userID models.UserID) (string, error) {
+	now := time.Now()
+	claims := challengeClaims{
+		RegisteredClaims: s.registeredClaims(now, challengeExp),
+		UserID:           userID,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(s.key.Get() + challengeKey))
+}
+
+func (s *JWTService) ParseChallenge(tokenString string) (models.UserID, error) {
+	claims := &challengeClaims{}
 	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-		return []byte(s.key), nil
-	})
+		return []byte(s.key.Get() + challengeKey), nil
+	}, append(s.parserOptions(), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))...)
 	if err != nil {
 		return 0, err
 	}
 	return claims.UserID, nil
 }
+
+func (s *JWTService) registeredClaims(now time.Time, exp time.Duration) jwt.RegisteredClaims {
+	claims := jwt.RegisteredClaims{
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(exp)),
+	}
+	if s.audience != "" {
+		claims.Audience = jwt.ClaimStrings{s.audience}
+	}
+	return claims
+}
+
+func (s *JWTService) parserOptions() []jwt.ParserOption {
+	if s.audience == "" {
+		return nil
+	}
+	return []jwt.ParserOption{jwt.WithAudience(s.audience)}
+}
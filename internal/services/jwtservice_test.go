@@ -15,7 +15,7 @@ const (
 )
 
 func TestNewJWTString(t *testing.T) {
-	jwtService := NewJWTService(testKey)
+	jwtService := NewJWTService(NewJWTKey(testKey), "")
 
 	t.Run("valid test", func(t *testing.T) {
 		jwtString, err := jwtService.NewJWTString(testUserID)
@@ -25,7 +25,7 @@ func TestNewJWTString(t *testing.T) {
 }
 
 func TestParseIDFromAuthHeader(t *testing.T) {
-	jwtService := NewJWTService(testKey)
+	jwtService := NewJWTService(NewJWTKey(testKey), "")
 
 	t.Run("valid test", func(t *testing.T) {
 		claims := jwtClaims{
@@ -57,3 +57,126 @@ func TestParseIDFromAuthHeader(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestParseSessionFromAuthHeader(t *testing.T) {
+	jwtService := NewJWTService(NewJWTKey(testKey), "")
+
+	t.Run("valid test", func(t *testing.T) {
+		issuedAt := time.Now().Truncate(time.Second)
+		claims := jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				IssuedAt:  jwt.NewNumericDate(issuedAt),
+				ExpiresAt: jwt.NewNumericDate(issuedAt.Add(testExp)),
+			},
+			UserID: testUserID,
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, err := token.SignedString([]byte(testKey))
+		require.NoError(t, err)
+		uid, iat, err := jwtService.ParseSessionFromAuthHeader("Bearer " + tokenString)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+		assert.True(t, issuedAt.Equal(iat))
+	})
+
+	t.Run("no issued at", func(t *testing.T) {
+		claims := jwtClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(testExp)),
+			},
+			UserID: testUserID,
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		tokenString, err := token.SignedString([]byte(testKey))
+		require.NoError(t, err)
+		_, iat, err := jwtService.ParseSessionFromAuthHeader("Bearer " + tokenString)
+		assert.NoError(t, err)
+		assert.True(t, iat.IsZero())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		_, _, err := jwtService.ParseSessionFromAuthHeader("Bearer wrong")
+		assert.Error(t, err)
+	})
+}
+
+func TestChallenge(t *testing.T) {
+	jwtService := NewJWTService(NewJWTKey(testKey), "")
+
+	t.Run("valid test", func(t *testing.T) {
+		challenge, err := jwtService.NewChallengeString(testUserID)
+		require.NoError(t, err)
+		uid, err := jwtService.ParseChallenge(challenge)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+	})
+
+	t.Run("challenge is not an access token", func(t *testing.T) {
+		challenge, err := jwtService.NewChallengeString(testUserID)
+		require.NoError(t, err)
+		_, err = jwtService.ParseIDFromAuthHeader("Bearer " + challenge)
+		assert.Error(t, err)
+	})
+
+	t.Run("access token is not a challenge", func(t *testing.T) {
+		tokenString, err := jwtService.NewJWTString(testUserID)
+		require.NoError(t, err)
+		_, err = jwtService.ParseChallenge(tokenString)
+		assert.Error(t, err)
+	})
+}
+
+func TestAudience(t *testing.T) {
+	brandService := NewJWTService(NewJWTKey(testKey), "brand")
+	otherService := NewJWTService(NewJWTKey(testKey), "other_brand")
+
+	t.Run("valid test", func(t *testing.T) {
+		tokenString, err := brandService.NewJWTString(testUserID)
+		require.NoError(t, err)
+		uid, err := brandService.ParseIDFromAuthHeader("Bearer " + tokenString)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+	})
+
+	t.Run("other tenant token", func(t *testing.T) {
+		tokenString, err := otherService.NewJWTString(testUserID)
+		require.NoError(t, err)
+		_, err = brandService.ParseIDFromAuthHeader("Bearer " + tokenString)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+		_, _, err = brandService.ParseSessionFromAuthHeader("Bearer " + tokenString)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+
+	t.Run("token without audience", func(t *testing.T) {
+		tokenString, err := NewJWTService(NewJWTKey(testKey), "").NewJWTString(testUserID)
+		require.NoError(t, err)
+		_, err = brandService.ParseIDFromAuthHeader("Bearer " + tokenString)
+		assert.Error(t, err)
+	})
+
+	t.Run("other tenant challenge", func(t *testing.T) {
+		challenge, err := otherService.NewChallengeString(testUserID)
+		require.NoError(t, err)
+		_, err = brandService.ParseChallenge(challenge)
+		assert.ErrorIs(t, err, jwt.ErrTokenInvalidAudience)
+	})
+}
+
+func TestJWTService_keyRotation(t *testing.T) {
+	key := NewJWTKey(testKey)
+	jwtService := NewJWTService(key, "")
+
+	oldToken, err := jwtService.NewJWTString(testUserID)
+	require.NoError(t, err)
+
+	key.Set("rotated_key")
+
+	_, err = jwtService.ParseIDFromAuthHeader("Bearer " + oldToken)
+	assert.ErrorIs(t, err, jwt.ErrTokenSignatureInvalid)
+
+	newToken, err := jwtService.NewJWTString(testUserID)
+	require.NoError(t, err)
+	uid, err := jwtService.ParseIDFromAuthHeader("Bearer " + newToken)
+	assert.NoError(t, err)
+	assert.Equal(t, testUserID, uid)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type throttler interface {
+	Check(context.Context, string) (time.Duration, error)
+	Release(context.Context, string) error
+	Reset(context.Context, string) error
+}
+
+type LoginThrottleService struct {
+	byLogin throttler
+	byIP    throttler
+}
+
+func NewLoginThrottleService(byLogin, byIP throttler) *LoginThrottleService {
+	return &LoginThrottleService{
+		byLogin: byLogin,
+		byIP:    byIP,
+	}
+}
+
+// Check reserves an attempt for the login and the IP. An attempt is counted
+// as failed until it is reported otherwise, so that concurrent attempts
+// can't get past the throttle before the first of them has failed.
+func (s *LoginThrottleService) Check(ctx context.Context, login, ip string) (time.Duration, error) {
+	loginRetry, err := s.byLogin.Check(ctx, login)
+	if err != nil || loginRetry > 0 {
+		return loginRetry, err
+	}
+	ipRetry, err := s.byIP.Check(ctx, ip)
+	if err != nil || ipRetry > 0 {
+		return ipRetry, errors.Join(err, s.byLogin.Release(ctx, login))
+	}
+	return 0, nil
+}
+
+// Success clears the failures of the login and gives the attempt back to
+// the IP.
+func (s *LoginThrottleService) Success(ctx context.Context, login, ip string) error {
+	return errors.Join(s.byLogin.Reset(ctx, login), s.byIP.Release(ctx, ip))
+}
+
+// Release gives back an attempt whose credentials couldn't be checked.
+func (s *LoginThrottleService) Release(ctx context.Context, login, ip string) error {
+	return errors.Join(s.byLogin.Release(ctx, login), s.byIP.Release(ctx, ip))
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testLogin = "test"
+	testIP    = "127.0.0.1"
+)
+
+func TestLoginThrottleService_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mByLogin := mocks.NewMockthrottler(ctrl)
+	mByIP := mocks.NewMockthrottler(ctrl)
+
+	s := NewLoginThrottleService(mByLogin, mByIP)
+
+	t.Run("valid test", func(t *testing.T) {
+		mByLogin.EXPECT().Check(gomock.Any(), testLogin).Return(time.Duration(0), nil)
+		mByIP.EXPECT().Check(gomock.Any(), testIP).Return(time.Duration(0), nil)
+
+		retry, err := s.Check(context.Background(), testLogin, testIP)
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+	})
+
+	t.Run("login throttled", func(t *testing.T) {
+		mByLogin.EXPECT().Check(gomock.Any(), testLogin).Return(time.Second, nil)
+
+		retry, err := s.Check(context.Background(), testLogin, testIP)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, retry)
+	})
+
+	t.Run("ip throttled", func(t *testing.T) {
+		mByLogin.EXPECT().Check(gomock.Any(), testLogin).Return(time.Duration(0), nil)
+		mByIP.EXPECT().Check(gomock.Any(), testIP).Return(time.Minute, nil)
+		mByLogin.EXPECT().Release(gomock.Any(), testLogin).Return(nil)
+
+		retry, err := s.Check(context.Background(), testLogin, testIP)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Minute, retry)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mByLogin.EXPECT().Check(gomock.Any(), testLogin).Return(time.Duration(0), errTest)
+
+		_, err := s.Check(context.Background(), testLogin, testIP)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestLoginThrottleService_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mByLogin := mocks.NewMockthrottler(ctrl)
+	mByIP := mocks.NewMockthrottler(ctrl)
+
+	s := NewLoginThrottleService(mByLogin, mByIP)
+
+	t.Run("valid test", func(t *testing.T) {
+		mByLogin.EXPECT().Reset(gomock.Any(), testLogin).Return(nil)
+		mByIP.EXPECT().Release(gomock.Any(), testIP).Return(nil)
+
+		err := s.Success(context.Background(), testLogin, testIP)
+		assert.NoError(t, err)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mByLogin.EXPECT().Reset(gomock.Any(), testLogin).Return(errTest)
+		mByIP.EXPECT().Release(gomock.Any(), testIP).Return(nil)
+
+		err := s.Success(context.Background(), testLogin, testIP)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestLoginThrottleService_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mByLogin := mocks.NewMockthrottler(ctrl)
+	mByIP := mocks.NewMockthrottler(ctrl)
+
+	s := NewLoginThrottleService(mByLogin, mByIP)
+
+	t.Run("valid test", func(t *testing.T) {
+		mByLogin.EXPECT().Release(gomock.Any(), testLogin).Return(nil)
+		mByIP.EXPECT().Release(gomock.Any(), testIP).Return(nil)
+
+		err := s.Release(context.Background(), testLogin, testIP)
+		assert.NoError(t, err)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mByLogin.EXPECT().Release(gomock.Any(), testLogin).Return(nil)
+		mByIP.EXPECT().Release(gomock.Any(), testIP).Return(errTest)
+
+		err := s.Release(context.Background(), testLogin, testIP)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: loginthrottleservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// Mockthrottler is a mock of throttler interface.
+type Mockthrottler struct {
+	ctrl     *gomock.Controller
+	recorder *MockthrottlerMockRecorder
+}
+
+// MockthrottlerMockRecorder is the mock recorder for Mockthrottler.
+type MockthrottlerMockRecorder struct {
+	mock *Mockthrottler
+}
+
+// NewMockthrottler creates a new mock instance.
+func NewMockthrottler(ctrl *gomock.Controller) *Mockthrottler {
+	mock := &Mockthrottler{ctrl: ctrl}
+	mock.recorder = &MockthrottlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockthrottler) EXPECT() *MockthrottlerMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *Mockthrottler) Check(arg0 context.Context, arg1 string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0, arg1)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockthrottlerMockRecorder) Check(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*Mockthrottler)(nil).Check), arg0, arg1)
+}
+
+// Release mocks base method.
+func (m *Mockthrottler) Release(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockthrottlerMockRecorder) Release(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*Mockthrottler)(nil).Release), arg0, arg1)
+}
+
+// Reset mocks base method.
+func (m *Mockthrottler) Reset(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockthrottlerMockRecorder) Reset(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*Mockthrottler)(nil).Reset), arg0, arg1)
+}
@@ -35,33 +35,35 @@ func (m *MockorderStorager) EXPECT() *MockorderStoragerMockRecorder {
 	return m.recorder
 }
 
-// AddOrder mocks base method.
-func (m *MockorderStorager) AddOrder(arg0 context.Context, arg1 *models.Order) error {
+// AddOrdersBatch mocks base method.
+func (m *MockorderStorager) AddOrdersBatch(arg0 context.Context, arg1 models.UserID, arg2 []string, arg3 int) (map[string]models.UserID, []string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddOrder", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "AddOrdersBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]models.UserID)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// AddOrder indicates an expected call of AddOrder.
-func (mr *MockorderStoragerMockRecorder) AddOrder(arg0, arg1 interface{}) *gomock.Call {
+// AddOrdersBatch indicates an expected call of AddOrdersBatch.
+func (mr *MockorderStoragerMockRecorder) AddOrdersBatch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockorderStorager)(nil).AddOrder), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrdersBatch", reflect.TypeOf((*MockorderStorager)(nil).AddOrdersBatch), arg0, arg1, arg2, arg3)
 }
 
-// GetOrderByNum mocks base method.
-func (m *MockorderStorager) GetOrderByNum(arg0 context.Context, arg1 string) (*models.OrderDB, error) {
+// GetOrderDetailByNum mocks base method.
+func (m *MockorderStorager) GetOrderDetailByNum(arg0 context.Context, arg1 string) (*models.OrderDetail, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetOrderByNum", arg0, arg1)
-	ret0, _ := ret[0].(*models.OrderDB)
+	ret := m.ctrl.Call(m, "GetOrderDetailByNum", arg0, arg1)
+	ret0, _ := ret[0].(*models.OrderDetail)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetOrderByNum indicates an expected call of GetOrderByNum.
-func (mr *MockorderStoragerMockRecorder) GetOrderByNum(arg0, arg1 interface{}) *gomock.Call {
+// GetOrderDetailByNum indicates an expected call of GetOrderDetailByNum.
+func (mr *MockorderStoragerMockRecorder) GetOrderDetailByNum(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByNum", reflect.TypeOf((*MockorderStorager)(nil).GetOrderByNum), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderDetailByNum", reflect.TypeOf((*MockorderStorager)(nil).GetOrderDetailByNum), arg0, arg1)
 }
 
 // GetOrdersByUserID mocks base method.
@@ -79,6 +81,21 @@ func (mr *MockorderStoragerMockRecorder) GetOrdersByUserID(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUserID", reflect.TypeOf((*MockorderStorager)(nil).GetOrdersByUserID), arg0, arg1)
 }
 
+// GetOrdersPageByUserID mocks base method.
+func (m *MockorderStorager) GetOrdersPageByUserID(arg0 context.Context, arg1 models.UserID, arg2 models.Page) ([]*models.OrderDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersPageByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.OrderDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersPageByUserID indicates an expected call of GetOrdersPageByUserID.
+func (mr *MockorderStoragerMockRecorder) GetOrdersPageByUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersPageByUserID", reflect.TypeOf((*MockorderStorager)(nil).GetOrdersPageByUserID), arg0, arg1, arg2)
+}
+
 // MockerrNoOrder is a mock of errNoOrder interface.
 type MockerrNoOrder struct {
 	ctrl     *gomock.Controller
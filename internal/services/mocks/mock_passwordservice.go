@@ -0,0 +1,147 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: passwordservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockpasswordStorager is a mock of passwordStorager interface.
+type MockpasswordStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordStoragerMockRecorder
+}
+
+// MockpasswordStoragerMockRecorder is the mock recorder for MockpasswordStorager.
+type MockpasswordStoragerMockRecorder struct {
+	mock *MockpasswordStorager
+}
+
+// NewMockpasswordStorager creates a new mock instance.
+func NewMockpasswordStorager(ctrl *gomock.Controller) *MockpasswordStorager {
+	mock := &MockpasswordStorager{ctrl: ctrl}
+	mock.recorder = &MockpasswordStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordStorager) EXPECT() *MockpasswordStoragerMockRecorder {
+	return m.recorder
+}
+
+// AddResetToken mocks base method.
+func (m *MockpasswordStorager) AddResetToken(arg0 context.Context, arg1 *models.ResetToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddResetToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddResetToken indicates an expected call of AddResetToken.
+func (mr *MockpasswordStoragerMockRecorder) AddResetToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResetToken", reflect.TypeOf((*MockpasswordStorager)(nil).AddResetToken), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockpasswordStorager) GetUserByID(arg0 context.Context, arg1 models.UserID) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockpasswordStoragerMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockpasswordStorager)(nil).GetUserByID), arg0, arg1)
+}
+
+// GetUserByLogin mocks base method.
+func (m *MockpasswordStorager) GetUserByLogin(arg0 context.Context, arg1 string) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockpasswordStoragerMockRecorder) GetUserByLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockpasswordStorager)(nil).GetUserByLogin), arg0, arg1)
+}
+
+// ResetPassword mocks base method.
+func (m *MockpasswordStorager) ResetPassword(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockpasswordStoragerMockRecorder) ResetPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockpasswordStorager)(nil).ResetPassword), arg0, arg1, arg2, arg3)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockpasswordStorager) UpdatePassword(arg0 context.Context, arg1 models.UserID, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockpasswordStoragerMockRecorder) UpdatePassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockpasswordStorager)(nil).UpdatePassword), arg0, arg1, arg2, arg3)
+}
+
+// MockresetNotifier is a mock of resetNotifier interface.
+type MockresetNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockresetNotifierMockRecorder
+}
+
+// MockresetNotifierMockRecorder is the mock recorder for MockresetNotifier.
+type MockresetNotifierMockRecorder struct {
+	mock *MockresetNotifier
+}
+
+// NewMockresetNotifier creates a new mock instance.
+func NewMockresetNotifier(ctrl *gomock.Controller) *MockresetNotifier {
+	mock := &MockresetNotifier{ctrl: ctrl}
+	mock.recorder = &MockresetNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockresetNotifier) EXPECT() *MockresetNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyPasswordReset mocks base method.
+func (m *MockresetNotifier) NotifyPasswordReset(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyPasswordReset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyPasswordReset indicates an expected call of NotifyPasswordReset.
+func (mr *MockresetNotifierMockRecorder) NotifyPasswordReset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPasswordReset", reflect.TypeOf((*MockresetNotifier)(nil).NotifyPasswordReset), arg0, arg1, arg2, arg3)
+}
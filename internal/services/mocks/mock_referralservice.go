@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: referralservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockreferralStorager is a mock of referralStorager interface.
+type MockreferralStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MockreferralStoragerMockRecorder
+}
+
+// MockreferralStoragerMockRecorder is the mock recorder for MockreferralStorager.
+type MockreferralStoragerMockRecorder struct {
+	mock *MockreferralStorager
+}
+
+// NewMockreferralStorager creates a new mock instance.
+func NewMockreferralStorager(ctrl *gomock.Controller) *MockreferralStorager {
+	mock := &MockreferralStorager{ctrl: ctrl}
+	mock.recorder = &MockreferralStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreferralStorager) EXPECT() *MockreferralStoragerMockRecorder {
+	return m.recorder
+}
+
+// GetInviteesByReferrerID mocks base method.
+func (m *MockreferralStorager) GetInviteesByReferrerID(arg0 context.Context, arg1 models.UserID) ([]*models.Invitee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInviteesByReferrerID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Invitee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInviteesByReferrerID indicates an expected call of GetInviteesByReferrerID.
+func (mr *MockreferralStoragerMockRecorder) GetInviteesByReferrerID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInviteesByReferrerID", reflect.TypeOf((*MockreferralStorager)(nil).GetInviteesByReferrerID), arg0, arg1)
+}
+
+// GetReferralCodeByUserID mocks base method.
+func (m *MockreferralStorager) GetReferralCodeByUserID(arg0 context.Context, arg1 models.UserID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReferralCodeByUserID", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReferralCodeByUserID indicates an expected call of GetReferralCodeByUserID.
+func (mr *MockreferralStoragerMockRecorder) GetReferralCodeByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReferralCodeByUserID", reflect.TypeOf((*MockreferralStorager)(nil).GetReferralCodeByUserID), arg0, arg1)
+}
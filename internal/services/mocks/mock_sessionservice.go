@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sessionservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MocksessionStorager is a mock of sessionStorager interface.
+type MocksessionStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionStoragerMockRecorder
+}
+
+// MocksessionStoragerMockRecorder is the mock recorder for MocksessionStorager.
+type MocksessionStoragerMockRecorder struct {
+	mock *MocksessionStorager
+}
+
+// NewMocksessionStorager creates a new mock instance.
+func NewMocksessionStorager(ctrl *gomock.Controller) *MocksessionStorager {
+	mock := &MocksessionStorager{ctrl: ctrl}
+	mock.recorder = &MocksessionStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionStorager) EXPECT() *MocksessionStoragerMockRecorder {
+	return m.recorder
+}
+
+// GetSessionsValidAfter mocks base method.
+func (m *MocksessionStorager) GetSessionsValidAfter(arg0 context.Context, arg1 models.UserID) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsValidAfter", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsValidAfter indicates an expected call of GetSessionsValidAfter.
+func (mr *MocksessionStoragerMockRecorder) GetSessionsValidAfter(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsValidAfter", reflect.TypeOf((*MocksessionStorager)(nil).GetSessionsValidAfter), arg0, arg1)
+}
+
+// MocksessionJWT is a mock of sessionJWT interface.
+type MocksessionJWT struct {
+	ctrl     *gomock.Controller
+	recorder *MocksessionJWTMockRecorder
+}
+
+// MocksessionJWTMockRecorder is the mock recorder for MocksessionJWT.
+type MocksessionJWTMockRecorder struct {
+	mock *MocksessionJWT
+}
+
+// NewMocksessionJWT creates a new mock instance.
+func NewMocksessionJWT(ctrl *gomock.Controller) *MocksessionJWT {
+	mock := &MocksessionJWT{ctrl: ctrl}
+	mock.recorder = &MocksessionJWTMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocksessionJWT) EXPECT() *MocksessionJWTMockRecorder {
+	return m.recorder
+}
+
+// ParseSessionFromAuthHeader mocks base method.
+func (m *MocksessionJWT) ParseSessionFromAuthHeader(arg0 string) (models.UserID, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseSessionFromAuthHeader", arg0)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseSessionFromAuthHeader indicates an expected call of ParseSessionFromAuthHeader.
+func (mr *MocksessionJWTMockRecorder) ParseSessionFromAuthHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseSessionFromAuthHeader", reflect.TypeOf((*MocksessionJWT)(nil).ParseSessionFromAuthHeader), arg0)
+}
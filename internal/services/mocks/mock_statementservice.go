@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: statementservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MockstatementStorager is a mock of statementStorager interface.
+type MockstatementStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MockstatementStoragerMockRecorder
+}
+
+// MockstatementStoragerMockRecorder is the mock recorder for MockstatementStorager.
+type MockstatementStoragerMockRecorder struct {
+	mock *MockstatementStorager
+}
+
+// NewMockstatementStorager creates a new mock instance.
+func NewMockstatementStorager(ctrl *gomock.Controller) *MockstatementStorager {
+	mock := &MockstatementStorager{ctrl: ctrl}
+	mock.recorder = &MockstatementStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstatementStorager) EXPECT() *MockstatementStoragerMockRecorder {
+	return m.recorder
+}
+
+// StreamStatement mocks base method.
+func (m *MockstatementStorager) StreamStatement(arg0 context.Context, arg1 models.UserID, arg2, arg3 time.Time, arg4 func(float64) error, arg5 func(*models.StatementEntry) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamStatement", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamStatement indicates an expected call of StreamStatement.
+func (mr *MockstatementStoragerMockRecorder) StreamStatement(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamStatement", reflect.TypeOf((*MockstatementStorager)(nil).StreamStatement), arg0, arg1, arg2, arg3, arg4, arg5)
+}
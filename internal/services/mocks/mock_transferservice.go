@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: transferservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MocktransferStorager is a mock of transferStorager interface.
+type MocktransferStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktransferStoragerMockRecorder
+}
+
+// MocktransferStoragerMockRecorder is the mock recorder for MocktransferStorager.
+type MocktransferStoragerMockRecorder struct {
+	mock *MocktransferStorager
+}
+
+// NewMocktransferStorager creates a new mock instance.
+func NewMocktransferStorager(ctrl *gomock.Controller) *MocktransferStorager {
+	mock := &MocktransferStorager{ctrl: ctrl}
+	mock.recorder = &MocktransferStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktransferStorager) EXPECT() *MocktransferStoragerMockRecorder {
+	return m.recorder
+}
+
+// AddTransfer mocks base method.
+func (m *MocktransferStorager) AddTransfer(arg0 context.Context, arg1 *models.Transfer, arg2 float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTransfer", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTransfer indicates an expected call of AddTransfer.
+func (mr *MocktransferStoragerMockRecorder) AddTransfer(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTransfer", reflect.TypeOf((*MocktransferStorager)(nil).AddTransfer), arg0, arg1, arg2)
+}
+
+// GetTransfersByUserID mocks base method.
+func (m *MocktransferStorager) GetTransfersByUserID(arg0 context.Context, arg1 models.UserID) ([]*models.TransferRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfersByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.TransferRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersByUserID indicates an expected call of GetTransfersByUserID.
+func (mr *MocktransferStoragerMockRecorder) GetTransfersByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersByUserID", reflect.TypeOf((*MocktransferStorager)(nil).GetTransfersByUserID), arg0, arg1)
+}
+
+// GetTransfersPageByUserID mocks base method.
+func (m *MocktransferStorager) GetTransfersPageByUserID(arg0 context.Context, arg1 models.UserID, arg2 models.Page) ([]*models.TransferRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfersPageByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.TransferRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersPageByUserID indicates an expected call of GetTransfersPageByUserID.
+func (mr *MocktransferStoragerMockRecorder) GetTransfersPageByUserID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersPageByUserID", reflect.TypeOf((*MocktransferStorager)(nil).GetTransfersPageByUserID), arg0, arg1, arg2)
+}
+
+// MockrecipientStorager is a mock of recipientStorager interface.
+type MockrecipientStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MockrecipientStoragerMockRecorder
+}
+
+// MockrecipientStoragerMockRecorder is the mock recorder for MockrecipientStorager.
+type MockrecipientStoragerMockRecorder struct {
+	mock *MockrecipientStorager
+}
+
+// NewMockrecipientStorager creates a new mock instance.
+func NewMockrecipientStorager(ctrl *gomock.Controller) *MockrecipientStorager {
+	mock := &MockrecipientStorager{ctrl: ctrl}
+	mock.recorder = &MockrecipientStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrecipientStorager) EXPECT() *MockrecipientStoragerMockRecorder {
+	return m.recorder
+}
+
+// GetUserByLogin mocks base method.
+func (m *MockrecipientStorager) GetUserByLogin(arg0 context.Context, arg1 string) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockrecipientStoragerMockRecorder) GetUserByLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockrecipientStorager)(nil).GetUserByLogin), arg0, arg1)
+}
@@ -0,0 +1,265 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: twofactorservice.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/rycln/loyalsys/internal/models"
+)
+
+// MocktwoFactorStorager is a mock of twoFactorStorager interface.
+type MocktwoFactorStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktwoFactorStoragerMockRecorder
+}
+
+// MocktwoFactorStoragerMockRecorder is the mock recorder for MocktwoFactorStorager.
+type MocktwoFactorStoragerMockRecorder struct {
+	mock *MocktwoFactorStorager
+}
+
+// NewMocktwoFactorStorager creates a new mock instance.
+func NewMocktwoFactorStorager(ctrl *gomock.Controller) *MocktwoFactorStorager {
+	mock := &MocktwoFactorStorager{ctrl: ctrl}
+	mock.recorder = &MocktwoFactorStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktwoFactorStorager) EXPECT() *MocktwoFactorStoragerMockRecorder {
+	return m.recorder
+}
+
+// EnableTOTP mocks base method.
+func (m *MocktwoFactorStorager) EnableTOTP(arg0 context.Context, arg1 models.UserID, arg2 int64, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTOTP", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTOTP indicates an expected call of EnableTOTP.
+func (mr *MocktwoFactorStoragerMockRecorder) EnableTOTP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTOTP", reflect.TypeOf((*MocktwoFactorStorager)(nil).EnableTOTP), arg0, arg1, arg2, arg3)
+}
+
+// GetTOTP mocks base method.
+func (m *MocktwoFactorStorager) GetTOTP(arg0 context.Context, arg1 models.UserID) (*models.TOTPDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTP", arg0, arg1)
+	ret0, _ := ret[0].(*models.TOTPDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTP indicates an expected call of GetTOTP.
+func (mr *MocktwoFactorStoragerMockRecorder) GetTOTP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTP", reflect.TypeOf((*MocktwoFactorStorager)(nil).GetTOTP), arg0, arg1)
+}
+
+// SaveTOTPSecret mocks base method.
+func (m *MocktwoFactorStorager) SaveTOTPSecret(arg0 context.Context, arg1 models.UserID, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTPSecret", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTPSecret indicates an expected call of SaveTOTPSecret.
+func (mr *MocktwoFactorStoragerMockRecorder) SaveTOTPSecret(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPSecret", reflect.TypeOf((*MocktwoFactorStorager)(nil).SaveTOTPSecret), arg0, arg1, arg2)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MocktwoFactorStorager) UseRecoveryCode(arg0 context.Context, arg1 models.UserID, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MocktwoFactorStoragerMockRecorder) UseRecoveryCode(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MocktwoFactorStorager)(nil).UseRecoveryCode), arg0, arg1, arg2)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MocktwoFactorStorager) UseTOTPStep(arg0 context.Context, arg1 models.UserID, arg2 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MocktwoFactorStoragerMockRecorder) UseTOTPStep(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MocktwoFactorStorager)(nil).UseTOTPStep), arg0, arg1, arg2)
+}
+
+// MocktwoFactorUserStorager is a mock of twoFactorUserStorager interface.
+type MocktwoFactorUserStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MocktwoFactorUserStoragerMockRecorder
+}
+
+// MocktwoFactorUserStoragerMockRecorder is the mock recorder for MocktwoFactorUserStorager.
+type MocktwoFactorUserStoragerMockRecorder struct {
+	mock *MocktwoFactorUserStorager
+}
+
+// NewMocktwoFactorUserStorager creates a new mock instance.
+func NewMocktwoFactorUserStorager(ctrl *gomock.Controller) *MocktwoFactorUserStorager {
+	mock := &MocktwoFactorUserStorager{ctrl: ctrl}
+	mock.recorder = &MocktwoFactorUserStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktwoFactorUserStorager) EXPECT() *MocktwoFactorUserStoragerMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MocktwoFactorUserStorager) GetUserByID(arg0 context.Context, arg1 models.UserID) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MocktwoFactorUserStoragerMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MocktwoFactorUserStorager)(nil).GetUserByID), arg0, arg1)
+}
+
+// MocktotpGenerator is a mock of totpGenerator interface.
+type MocktotpGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MocktotpGeneratorMockRecorder
+}
+
+// MocktotpGeneratorMockRecorder is the mock recorder for MocktotpGenerator.
+type MocktotpGeneratorMockRecorder struct {
+	mock *MocktotpGenerator
+}
+
+// NewMocktotpGenerator creates a new mock instance.
+func NewMocktotpGenerator(ctrl *gomock.Controller) *MocktotpGenerator {
+	mock := &MocktotpGenerator{ctrl: ctrl}
+	mock.recorder = &MocktotpGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktotpGenerator) EXPECT() *MocktotpGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateSecret mocks base method.
+func (m *MocktotpGenerator) GenerateSecret() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSecret")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSecret indicates an expected call of GenerateSecret.
+func (mr *MocktotpGeneratorMockRecorder) GenerateSecret() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSecret", reflect.TypeOf((*MocktotpGenerator)(nil).GenerateSecret))
+}
+
+// ProvisioningURI mocks base method.
+func (m *MocktotpGenerator) ProvisioningURI(arg0, arg1 string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProvisioningURI", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ProvisioningURI indicates an expected call of ProvisioningURI.
+func (mr *MocktotpGeneratorMockRecorder) ProvisioningURI(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProvisioningURI", reflect.TypeOf((*MocktotpGenerator)(nil).ProvisioningURI), arg0, arg1)
+}
+
+// Validate mocks base method.
+func (m *MocktotpGenerator) Validate(arg0, arg1 string) (int64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MocktotpGeneratorMockRecorder) Validate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MocktotpGenerator)(nil).Validate), arg0, arg1)
+}
+
+// MockerrNoTOTP is a mock of errNoTOTP interface.
+type MockerrNoTOTP struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrNoTOTPMockRecorder
+}
+
+// MockerrNoTOTPMockRecorder is the mock recorder for MockerrNoTOTP.
+type MockerrNoTOTPMockRecorder struct {
+	mock *MockerrNoTOTP
+}
+
+// NewMockerrNoTOTP creates a new mock instance.
+func NewMockerrNoTOTP(ctrl *gomock.Controller) *MockerrNoTOTP {
+	mock := &MockerrNoTOTP{ctrl: ctrl}
+	mock.recorder = &MockerrNoTOTPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrNoTOTP) EXPECT() *MockerrNoTOTPMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrNoTOTP) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrNoTOTPMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrNoTOTP)(nil).Error))
+}
+
+// IsErrNoTOTP mocks base method.
+func (m *MockerrNoTOTP) IsErrNoTOTP() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNoTOTP")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNoTOTP indicates an expected call of IsErrNoTOTP.
+func (mr *MockerrNoTOTPMockRecorder) IsErrNoTOTP() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNoTOTP", reflect.TypeOf((*MockerrNoTOTP)(nil).IsErrNoTOTP))
+}
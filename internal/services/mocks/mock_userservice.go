@@ -50,6 +50,21 @@ func (mr *MockuserStoragerMockRecorder) AddUser(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockuserStorager)(nil).AddUser), arg0, arg1)
 }
 
+// AddUserWithReferral mocks base method.
+func (m *MockuserStorager) AddUserWithReferral(arg0 context.Context, arg1 *models.UserDB, arg2 *models.Referral) (models.UserID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserWithReferral", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.UserID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddUserWithReferral indicates an expected call of AddUserWithReferral.
+func (mr *MockuserStoragerMockRecorder) AddUserWithReferral(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserWithReferral", reflect.TypeOf((*MockuserStorager)(nil).AddUserWithReferral), arg0, arg1, arg2)
+}
+
 // GetUserByLogin mocks base method.
 func (m *MockuserStorager) GetUserByLogin(arg0 context.Context, arg1 string) (*models.UserDB, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +80,35 @@ func (mr *MockuserStoragerMockRecorder) GetUserByLogin(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockuserStorager)(nil).GetUserByLogin), arg0, arg1)
 }
 
+// GetUserByReferralCode mocks base method.
+func (m *MockuserStorager) GetUserByReferralCode(arg0 context.Context, arg1 string) (*models.UserDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByReferralCode", arg0, arg1)
+	ret0, _ := ret[0].(*models.UserDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByReferralCode indicates an expected call of GetUserByReferralCode.
+func (mr *MockuserStoragerMockRecorder) GetUserByReferralCode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByReferralCode", reflect.TypeOf((*MockuserStorager)(nil).GetUserByReferralCode), arg0, arg1)
+}
+
+// RehashPassword mocks base method.
+func (m *MockuserStorager) RehashPassword(arg0 context.Context, arg1 models.UserID, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RehashPassword", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RehashPassword indicates an expected call of RehashPassword.
+func (mr *MockuserStoragerMockRecorder) RehashPassword(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RehashPassword", reflect.TypeOf((*MockuserStorager)(nil).RehashPassword), arg0, arg1, arg2, arg3)
+}
+
 // MockpasswordHasher is a mock of passwordHasher interface.
 type MockpasswordHasher struct {
 	ctrl     *gomock.Controller
@@ -116,3 +160,156 @@ func (mr *MockpasswordHasherMockRecorder) Hash(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockpasswordHasher)(nil).Hash), arg0)
 }
+
+// NeedsRehash mocks base method.
+func (m *MockpasswordHasher) NeedsRehash(arg0 string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockpasswordHasherMockRecorder) NeedsRehash(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockpasswordHasher)(nil).NeedsRehash), arg0)
+}
+
+// MockpasswordPolicy is a mock of passwordPolicy interface.
+type MockpasswordPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockpasswordPolicyMockRecorder
+}
+
+// MockpasswordPolicyMockRecorder is the mock recorder for MockpasswordPolicy.
+type MockpasswordPolicyMockRecorder struct {
+	mock *MockpasswordPolicy
+}
+
+// NewMockpasswordPolicy creates a new mock instance.
+func NewMockpasswordPolicy(ctrl *gomock.Controller) *MockpasswordPolicy {
+	mock := &MockpasswordPolicy{ctrl: ctrl}
+	mock.recorder = &MockpasswordPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpasswordPolicy) EXPECT() *MockpasswordPolicyMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockpasswordPolicy) Check(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockpasswordPolicyMockRecorder) Check(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockpasswordPolicy)(nil).Check), arg0)
+}
+
+// MockerrNoUser is a mock of errNoUser interface.
+type MockerrNoUser struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrNoUserMockRecorder
+}
+
+// MockerrNoUserMockRecorder is the mock recorder for MockerrNoUser.
+type MockerrNoUserMockRecorder struct {
+	mock *MockerrNoUser
+}
+
+// NewMockerrNoUser creates a new mock instance.
+func NewMockerrNoUser(ctrl *gomock.Controller) *MockerrNoUser {
+	mock := &MockerrNoUser{ctrl: ctrl}
+	mock.recorder = &MockerrNoUserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrNoUser) EXPECT() *MockerrNoUserMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrNoUser) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrNoUserMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrNoUser)(nil).Error))
+}
+
+// IsErrNoUser mocks base method.
+func (m *MockerrNoUser) IsErrNoUser() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrNoUser")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrNoUser indicates an expected call of IsErrNoUser.
+func (mr *MockerrNoUserMockRecorder) IsErrNoUser() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrNoUser", reflect.TypeOf((*MockerrNoUser)(nil).IsErrNoUser))
+}
+
+// MockerrReferralCodeConflict is a mock of errReferralCodeConflict interface.
+type MockerrReferralCodeConflict struct {
+	ctrl     *gomock.Controller
+	recorder *MockerrReferralCodeConflictMockRecorder
+}
+
+// MockerrReferralCodeConflictMockRecorder is the mock recorder for MockerrReferralCodeConflict.
+type MockerrReferralCodeConflictMockRecorder struct {
+	mock *MockerrReferralCodeConflict
+}
+
+// NewMockerrReferralCodeConflict creates a new mock instance.
+func NewMockerrReferralCodeConflict(ctrl *gomock.Controller) *MockerrReferralCodeConflict {
+	mock := &MockerrReferralCodeConflict{ctrl: ctrl}
+	mock.recorder = &MockerrReferralCodeConflictMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockerrReferralCodeConflict) EXPECT() *MockerrReferralCodeConflictMockRecorder {
+	return m.recorder
+}
+
+// Error mocks base method.
+func (m *MockerrReferralCodeConflict) Error() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Error")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Error indicates an expected call of Error.
+func (mr *MockerrReferralCodeConflictMockRecorder) Error() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockerrReferralCodeConflict)(nil).Error))
+}
+
+// IsErrReferralCodeConflict mocks base method.
+func (m *MockerrReferralCodeConflict) IsErrReferralCodeConflict() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsErrReferralCodeConflict")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsErrReferralCodeConflict indicates an expected call of IsErrReferralCodeConflict.
+func (mr *MockerrReferralCodeConflictMockRecorder) IsErrReferralCodeConflict() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsErrReferralCodeConflict", reflect.TypeOf((*MockerrReferralCodeConflict)(nil).IsErrReferralCodeConflict))
+}
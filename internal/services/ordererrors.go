@@ -6,6 +6,8 @@ var (
 	ErrWrongNum      = errors.New("luhn algorithm validation failed")
 	ErrOrderExists   = errors.New("order already registered by user")
 	ErrOrderConflict = errors.New("order already registered by other user")
+	ErrOrderNotFound = errors.New("order not found")
+	ErrTooManyOrders = errors.New("too many orders awaiting accrual")
 )
 
 type errWrongNum struct {
@@ -73,3 +75,47 @@ func newErrOrderConflict(err error) error {
 		err: err,
 	}
 }
+
+type errOrderNotFound struct {
+	err error
+}
+
+func (err *errOrderNotFound) Error() string {
+	return err.err.Error()
+}
+
+func (err *errOrderNotFound) Unwrap() error {
+	return err.err
+}
+
+func (err *errOrderNotFound) IsErrOrderNotFound() bool {
+	return true
+}
+
+func newErrOrderNotFound(err error) error {
+	return &errOrderNotFound{
+		err: err,
+	}
+}
+
+type errTooManyOrders struct {
+	err error
+}
+
+func (err *errTooManyOrders) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTooManyOrders) Unwrap() error {
+	return err.err
+}
+
+func (err *errTooManyOrders) IsErrTooManyOrders() bool {
+	return true
+}
+
+func newErrTooManyOrders(err error) error {
+	return &errTooManyOrders{
+		err: err,
+	}
+}
@@ -10,17 +10,22 @@ import (
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
 type orderStorager interface {
-	AddOrder(context.Context, *models.Order) error
-	GetOrderByNum(context.Context, string) (*models.OrderDB, error)
+	AddOrdersBatch(context.Context, models.UserID, []string, int) (map[string]models.UserID, []string, error)
 	GetOrdersByUserID(context.Context, models.UserID) ([]*models.OrderDB, error)
+	GetOrdersPageByUserID(context.Context, models.UserID, models.Page) ([]*models.OrderDB, error)
+	GetOrderDetailByNum(context.Context, string) (*models.OrderDetail, error)
 }
 
 type OrderService struct {
-	strg orderStorager
+	strg         orderStorager
+	pendingLimit int
 }
 
-func NewOrderService(strg orderStorager) *OrderService {
-	return &OrderService{strg: strg}
+func NewOrderService(strg orderStorager, pendingLimit int) *OrderService {
+	return &OrderService{
+		strg:         strg,
+		pendingLimit: pendingLimit,
+	}
 }
 
 type errNoOrder interface {
@@ -28,28 +33,85 @@ type errNoOrder interface {
 	IsErrNoOrder() bool
 }
 
+// SaveOrder goes through the batch insert, which applies the pending order
+// cap in the same transaction, so concurrent uploads can't exceed it.
 func (s *OrderService) SaveOrder(ctx context.Context, order *models.Order) error {
 	err := goluhn.Validate(order.Number)
 	if err != nil {
 		return newErrWrongNum(ErrWrongNum)
 	}
-	checkOrder, err := s.strg.GetOrderByNum(ctx, order.Number)
-	if e, ok := err.(errNoOrder); ok && e.IsErrNoOrder() {
-		err := s.strg.AddOrder(ctx, order)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
+	existing, over, err := s.strg.AddOrdersBatch(ctx, order.UserID, []string{order.Number}, s.pendingLimit)
 	if err != nil {
 		return err
 	}
-	if checkOrder.UserID == order.UserID {
+	if len(over) > 0 {
+		return newErrTooManyOrders(ErrTooManyOrders)
+	}
+	owner, ok := existing[order.Number]
+	if !ok {
+		return nil
+	}
+	if owner == order.UserID {
 		return newErrOrderExists(ErrOrderExists)
 	}
 	return newErrOrderConflict(ErrOrderConflict)
 }
 
+func (s *OrderService) SaveOrdersBatch(ctx context.Context, uid models.UserID, nums []string) ([]*models.OrderBatchResult, error) {
+	results := make([]*models.OrderBatchResult, len(nums))
+	seen := make(map[string]bool, len(nums))
+	var valid []string
+	for i, num := range nums {
+		results[i] = &models.OrderBatchResult{
+			Number: num,
+			Status: models.BatchAccepted,
+		}
+		if goluhn.Validate(num) != nil {
+			results[i].Status = models.BatchWrongNum
+			continue
+		}
+		// A repeated number stays accepted, so the status resolved below
+		// for the number applies to all of its occurrences.
+		if seen[num] {
+			continue
+		}
+		seen[num] = true
+		valid = append(valid, num)
+	}
+	if len(valid) == 0 {
+		return results, nil
+	}
+	// Only new numbers count towards the pending order cap: the storage
+	// looks the numbers up before it applies the cap.
+	existing, over, err := s.strg.AddOrdersBatch(ctx, uid, valid, s.pendingLimit)
+	if err != nil {
+		return nil, err
+	}
+	overLimit := make(map[string]bool, len(over))
+	for _, num := range over {
+		overLimit[num] = true
+	}
+	for _, result := range results {
+		if result.Status != models.BatchAccepted {
+			continue
+		}
+		if overLimit[result.Number] {
+			result.Status = models.BatchTooManyOrders
+			continue
+		}
+		owner, ok := existing[result.Number]
+		if !ok {
+			continue
+		}
+		if owner == uid {
+			result.Status = models.BatchExists
+		} else {
+			result.Status = models.BatchConflict
+		}
+	}
+	return results, nil
+}
+
 func (s *OrderService) GetUserOrders(ctx context.Context, uid models.UserID) ([]*models.OrderDB, error) {
 	orders, err := s.strg.GetOrdersByUserID(ctx, uid)
 	if err != nil {
@@ -57,3 +119,27 @@ func (s *OrderService) GetUserOrders(ctx context.Context, uid models.UserID) ([]
 	}
 	return orders, nil
 }
+
+// GetUserOrdersPage also reports whether more orders follow the page.
+func (s *OrderService) GetUserOrdersPage(ctx context.Context, uid models.UserID, page models.Page) ([]*models.OrderDB, bool, error) {
+	orders, err := s.strg.GetOrdersPageByUserID(ctx, uid, lookahead(page))
+	if err != nil {
+		return nil, false, err
+	}
+	orders, more := trimPage(orders, page)
+	return orders, more, nil
+}
+
+func (s *OrderService) GetUserOrder(ctx context.Context, uid models.UserID, number string) (*models.OrderDetail, error) {
+	order, err := s.strg.GetOrderDetailByNum(ctx, number)
+	if e, ok := err.(errNoOrder); ok && e.IsErrNoOrder() {
+		return nil, newErrOrderNotFound(ErrOrderNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != uid {
+		return nil, newErrOrderNotFound(ErrOrderNotFound)
+	}
+	return order, nil
+}
@@ -11,23 +11,23 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const testPendingLimit = 3
+
 func TestOrderService_SaveOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mStrg := mocks.NewMockorderStorager(ctrl)
-	s := NewOrderService(mStrg)
+	s := NewOrderService(mStrg, testPendingLimit)
 
-	mErrNoOrder := mocks.NewMockerrNoOrder(ctrl)
+	testOrder := &models.Order{
+		Number: validLuhnString,
+		UserID: testUserID,
+	}
+	testNums := []string{validLuhnString}
 
 	t.Run("valid test", func(t *testing.T) {
-		testOrder := &models.Order{
-			Number: validLuhnString,
-			UserID: testUserID,
-		}
-		mErrNoOrder.EXPECT().IsErrNoOrder().Return(true)
-		mStrg.EXPECT().GetOrderByNum(gomock.Any(), testOrder.Number).Return(nil, mErrNoOrder)
-		mStrg.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil)
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, testNums, testPendingLimit).Return(map[string]models.UserID{}, nil, nil)
 
 		err := s.SaveOrder(context.Background(), testOrder)
 		assert.NoError(t, err)
@@ -43,53 +43,29 @@ func TestOrderService_SaveOrder(t *testing.T) {
 		assert.ErrorIs(t, err, ErrWrongNum)
 	})
 
-	t.Run("AddOrder error", func(t *testing.T) {
-		testOrder := &models.Order{
-			Number: validLuhnString,
-			UserID: testUserID,
-		}
-		mErrNoOrder.EXPECT().IsErrNoOrder().Return(true)
-		mStrg.EXPECT().GetOrderByNum(gomock.Any(), testOrder.Number).Return(nil, mErrNoOrder)
-		mStrg.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(errTest)
+	t.Run("AddOrdersBatch error", func(t *testing.T) {
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, testNums, testPendingLimit).Return(nil, nil, errTest)
 
 		err := s.SaveOrder(context.Background(), testOrder)
 		assert.Equal(t, err, errTest)
 	})
 
-	t.Run("GetOrderByNum error", func(t *testing.T) {
-		testOrder := &models.Order{
-			Number: validLuhnString,
-			UserID: testUserID,
-		}
-		mStrg.EXPECT().GetOrderByNum(gomock.Any(), testOrder.Number).Return(nil, errTest)
+	t.Run("too many pending orders", func(t *testing.T) {
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, testNums, testPendingLimit).Return(map[string]models.UserID{}, testNums, nil)
 
 		err := s.SaveOrder(context.Background(), testOrder)
-		assert.Equal(t, err, errTest)
+		assert.ErrorIs(t, err, ErrTooManyOrders)
 	})
 
 	t.Run("order exists error", func(t *testing.T) {
-		testOrder := &models.Order{
-			Number: validLuhnString,
-			UserID: testUserID,
-		}
-		testOrderDB := &models.OrderDB{
-			UserID: testUserID,
-		}
-		mStrg.EXPECT().GetOrderByNum(gomock.Any(), testOrder.Number).Return(testOrderDB, nil)
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, testNums, testPendingLimit).Return(map[string]models.UserID{validLuhnString: testUserID}, nil, nil)
 
 		err := s.SaveOrder(context.Background(), testOrder)
 		assert.ErrorIs(t, err, ErrOrderExists)
 	})
 
 	t.Run("order conflict error", func(t *testing.T) {
-		testOrder := &models.Order{
-			Number: validLuhnString,
-			UserID: testUserID,
-		}
-		testOrderDB := &models.OrderDB{
-			UserID: testOtherUserID,
-		}
-		mStrg.EXPECT().GetOrderByNum(gomock.Any(), testOrder.Number).Return(testOrderDB, nil)
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, testNums, testPendingLimit).Return(map[string]models.UserID{validLuhnString: testOtherUserID}, nil, nil)
 
 		err := s.SaveOrder(context.Background(), testOrder)
 		assert.ErrorIs(t, err, ErrOrderConflict)
@@ -101,7 +77,7 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 	defer ctrl.Finish()
 
 	mStrg := mocks.NewMockorderStorager(ctrl)
-	s := NewOrderService(mStrg)
+	s := NewOrderService(mStrg, testPendingLimit)
 
 	t.Run("valid test", func(t *testing.T) {
 		testOrders := []*models.OrderDB{
@@ -133,3 +109,167 @@ func TestOrderService_GetUserOrders(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestOrderService_GetUserOrdersPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorderStorager(ctrl)
+	s := NewOrderService(mStrg, testPendingLimit)
+
+	testOrders := []*models.OrderDB{
+		{Number: "123", Status: models.StatusNew},
+		{Number: "456", Status: models.StatusNew},
+		{Number: "789", Status: models.StatusNew},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetOrdersPageByUserID(gomock.Any(), testUserID, models.Page{Limit: 3, Offset: 4}).Return(testOrders, nil)
+
+		orders, more, err := s.GetUserOrdersPage(context.Background(), testUserID, models.Page{Limit: 2, Offset: 4})
+		assert.NoError(t, err)
+		assert.True(t, more)
+		assert.Equal(t, testOrders[:2], orders)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		mStrg.EXPECT().GetOrdersPageByUserID(gomock.Any(), testUserID, models.Page{Limit: 4, Offset: 0}).Return(testOrders, nil)
+
+		orders, more, err := s.GetUserOrdersPage(context.Background(), testUserID, models.Page{Limit: 3, Offset: 0})
+		assert.NoError(t, err)
+		assert.False(t, more)
+		assert.Equal(t, testOrders, orders)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetOrdersPageByUserID(gomock.Any(), testUserID, gomock.Any()).Return(nil, errTest)
+
+		_, _, err := s.GetUserOrdersPage(context.Background(), testUserID, models.Page{Limit: 2})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestOrderService_SaveOrdersBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorderStorager(ctrl)
+	s := NewOrderService(mStrg, testPendingLimit)
+
+	t.Run("valid test", func(t *testing.T) {
+		testNums := []string{validLuhnString, "12345", "79927398713", "12345678903", validLuhnString}
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, []string{validLuhnString, "79927398713", "12345678903"}, testPendingLimit).Return(map[string]models.UserID{
+			"79927398713": testUserID,
+			"12345678903": testOtherUserID,
+		}, nil, nil)
+
+		results, err := s.SaveOrdersBatch(context.Background(), testUserID, testNums)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.OrderBatchResult{
+			{Number: validLuhnString, Status: models.BatchAccepted},
+			{Number: "12345", Status: models.BatchWrongNum},
+			{Number: "79927398713", Status: models.BatchExists},
+			{Number: "12345678903", Status: models.BatchConflict},
+			{Number: validLuhnString, Status: models.BatchAccepted},
+		}, results)
+	})
+
+	t.Run("repeated numbers", func(t *testing.T) {
+		testNums := []string{"12345678903", validLuhnString, "79927398713", "12345678903", "79927398713"}
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, []string{"12345678903", validLuhnString, "79927398713"}, testPendingLimit).Return(map[string]models.UserID{
+			"12345678903": testOtherUserID,
+		}, []string{"79927398713"}, nil)
+
+		results, err := s.SaveOrdersBatch(context.Background(), testUserID, testNums)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.OrderBatchResult{
+			{Number: "12345678903", Status: models.BatchConflict},
+			{Number: validLuhnString, Status: models.BatchAccepted},
+			{Number: "79927398713", Status: models.BatchTooManyOrders},
+			{Number: "12345678903", Status: models.BatchConflict},
+			{Number: "79927398713", Status: models.BatchTooManyOrders},
+		}, results)
+	})
+
+	t.Run("no valid numbers", func(t *testing.T) {
+		results, err := s.SaveOrdersBatch(context.Background(), testUserID, []string{"12345"})
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.OrderBatchResult{
+			{Number: "12345", Status: models.BatchWrongNum},
+		}, results)
+	})
+
+	t.Run("too many pending orders", func(t *testing.T) {
+		testNums := []string{validLuhnString, "79927398713", "12345678903"}
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, testNums, testPendingLimit).Return(map[string]models.UserID{
+			"79927398713": testUserID,
+		}, []string{"12345678903"}, nil)
+
+		results, err := s.SaveOrdersBatch(context.Background(), testUserID, testNums)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.OrderBatchResult{
+			{Number: validLuhnString, Status: models.BatchAccepted},
+			{Number: "79927398713", Status: models.BatchExists},
+			{Number: "12345678903", Status: models.BatchTooManyOrders},
+		}, results)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().AddOrdersBatch(gomock.Any(), testUserID, gomock.Any(), testPendingLimit).Return(nil, nil, errTest)
+
+		_, err := s.SaveOrdersBatch(context.Background(), testUserID, []string{validLuhnString})
+		assert.Error(t, err)
+	})
+}
+
+func TestOrderService_GetUserOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockorderStorager(ctrl)
+	s := NewOrderService(mStrg, testPendingLimit)
+
+	testOrder := &models.OrderDetail{
+		Number:    validLuhnString,
+		UserID:    testUserID,
+		Status:    models.StatusNew,
+		CreatedAt: time.Now().String(),
+		History: []*models.OrderStatusChange{
+			{
+				Status:    models.StatusNew,
+				ChangedAt: time.Now().String(),
+			},
+		},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetOrderDetailByNum(gomock.Any(), validLuhnString).Return(testOrder, nil)
+
+		order, err := s.GetUserOrder(context.Background(), testUserID, validLuhnString)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrder, order)
+	})
+
+	t.Run("other user order", func(t *testing.T) {
+		mStrg.EXPECT().GetOrderDetailByNum(gomock.Any(), validLuhnString).Return(testOrder, nil)
+
+		_, err := s.GetUserOrder(context.Background(), testOtherUserID, validLuhnString)
+		assert.ErrorIs(t, err, ErrOrderNotFound)
+	})
+
+	t.Run("no order", func(t *testing.T) {
+		mErrNoOrder := mocks.NewMockerrNoOrder(ctrl)
+		mErrNoOrder.EXPECT().IsErrNoOrder().Return(true)
+		mStrg.EXPECT().GetOrderDetailByNum(gomock.Any(), validLuhnString).Return(nil, mErrNoOrder)
+
+		_, err := s.GetUserOrder(context.Background(), testUserID, validLuhnString)
+		assert.ErrorIs(t, err, ErrOrderNotFound)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetOrderDetailByNum(gomock.Any(), validLuhnString).Return(nil, errTest)
+
+		_, err := s.GetUserOrder(context.Background(), testUserID, validLuhnString)
+		assert.Equal(t, errTest, err)
+	})
+}
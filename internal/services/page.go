@@ -0,0 +1,17 @@
+package services
+
+import "github.com/rycln/loyalsys/internal/models"
+
+// lookahead asks storage for one item past the page to learn whether
+// another page follows without a separate count query.
+func lookahead(page models.Page) models.Page {
+	page.Limit++
+	return page
+}
+
+func trimPage[T any](items []T, page models.Page) ([]T, bool) {
+	if len(items) > page.Limit {
+		return items[:page.Limit], true
+	}
+	return items, false
+}
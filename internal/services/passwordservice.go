@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+const resetTokenLength = 32
+
+type passwordStorager interface {
+	GetUserByID(context.Context, models.UserID) (*models.UserDB, error)
+	GetUserByLogin(context.Context, string) (*models.UserDB, error)
+	UpdatePassword(context.Context, models.UserID, string, time.Time) error
+	AddResetToken(context.Context, *models.ResetToken) error
+	ResetPassword(context.Context, string, string, time.Time) (models.UserID, error)
+}
+
+type resetNotifier interface {
+	NotifyPasswordReset(context.Context, string, string, time.Time) error
+}
+
+type PasswordService struct {
+	strg     passwordStorager
+	hasher   passwordHasher
+	policy   passwordPolicy
+	notifier resetNotifier
+	resetTTL time.Duration
+	timeout  time.Duration
+	pending  sync.WaitGroup
+}
+
+func NewPasswordService(strg passwordStorager, hasher passwordHasher, policy passwordPolicy, notifier resetNotifier, resetTTL, timeout time.Duration) *PasswordService {
+	return &PasswordService{
+		strg:     strg,
+		hasher:   hasher,
+		policy:   policy,
+		notifier: notifier,
+		resetTTL: resetTTL,
+		timeout:  timeout,
+	}
+}
+
+func (s *PasswordService) ChangePassword(ctx context.Context, uid models.UserID, change *models.PasswordChange) error {
+	userDB, err := s.strg.GetUserByID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	err = s.hasher.Compare(userDB.PasswordHash, change.OldPassword)
+	if err != nil {
+		return err
+	}
+	err = s.policy.Check(change.NewPassword)
+	if err != nil {
+		return err
+	}
+	hash, err := s.hasher.Hash(change.NewPassword)
+	if err != nil {
+		return err
+	}
+	return s.strg.UpdatePassword(ctx, uid, hash, sessionsCutoff())
+}
+
+// RequestReset succeeds for unknown logins too, so the endpoint can't be
+// used to find out which logins are registered. The token is issued and
+// sent in the background, so that a known login doesn't take longer to
+// answer either. The request context is not used there: it ends with the
+// request.
+func (s *PasswordService) RequestReset(ctx context.Context, login string) error {
+	userDB, err := s.strg.GetUserByLogin(ctx, login)
+	if e, ok := err.(errNoUser); ok && e.IsErrNoUser() {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+
+		err := s.issueResetToken(ctx, userDB)
+		if err != nil {
+			logger.Log.Warn("Password reset failed", zap.Int64("user_id", int64(userDB.ID)), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// Wait blocks until the resets requested so far are issued or ctx is done.
+func (s *PasswordService) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+func (s *PasswordService) issueResetToken(ctx context.Context, userDB *models.UserDB) error {
+	token, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+	resetToken := &models.ResetToken{
+		UserID:    userDB.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(s.resetTTL),
+	}
+	err = s.strg.AddResetToken(ctx, resetToken)
+	if err != nil {
+		return err
+	}
+	return s.notifier.NotifyPasswordReset(ctx, userDB.Login, token, resetToken.ExpiresAt)
+}
+
+func (s *PasswordService) ConfirmReset(ctx context.Context, confirm *models.PasswordResetConfirm) error {
+	err := s.policy.Check(confirm.NewPassword)
+	if err != nil {
+		return err
+	}
+	hash, err := s.hasher.Hash(confirm.NewPassword)
+	if err != nil {
+		return err
+	}
+	_, err = s.strg.ResetPassword(ctx, hashToken(confirm.Token), hash, sessionsCutoff())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func generateResetToken() (string, error) {
+	buf := make([]byte, resetTokenLength)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResetTTL = time.Duration(30) * time.Minute
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockpasswordStorager(ctrl)
+	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mPolicy := mocks.NewMockpasswordPolicy(ctrl)
+	mNotifier := mocks.NewMockresetNotifier(ctrl)
+
+	s := NewPasswordService(mStrg, mHasher, mPolicy, mNotifier, testResetTTL, time.Second)
+
+	testChange := &models.PasswordChange{
+		OldPassword: "old_secret",
+		NewPassword: "new_secret",
+	}
+	testUserDB := &models.UserDB{
+		ID:           testUserID,
+		PasswordHash: testPasswordHash,
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(testUserDB, nil)
+		mHasher.EXPECT().Compare(testPasswordHash, testChange.OldPassword).Return(nil)
+		mPolicy.EXPECT().Check(testChange.NewPassword).Return(nil)
+		mHasher.EXPECT().Hash(testChange.NewPassword).Return("new_hash", nil)
+		mStrg.EXPECT().UpdatePassword(gomock.Any(), testUserID, "new_hash", gomock.Any()).Return(nil)
+
+		err := s.ChangePassword(context.Background(), testUserID, testChange)
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong old password", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(testUserDB, nil)
+		mHasher.EXPECT().Compare(testPasswordHash, testChange.OldPassword).Return(errTest)
+
+		err := s.ChangePassword(context.Background(), testUserID, testChange)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(testUserDB, nil)
+		mHasher.EXPECT().Compare(testPasswordHash, testChange.OldPassword).Return(nil)
+		mPolicy.EXPECT().Check(testChange.NewPassword).Return(errTest)
+
+		err := s.ChangePassword(context.Background(), testUserID, testChange)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(nil, errTest)
+
+		err := s.ChangePassword(context.Background(), testUserID, testChange)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestPasswordService_RequestReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockpasswordStorager(ctrl)
+	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mPolicy := mocks.NewMockpasswordPolicy(ctrl)
+	mNotifier := mocks.NewMockresetNotifier(ctrl)
+
+	s := NewPasswordService(mStrg, mHasher, mPolicy, mNotifier, testResetTTL, time.Second)
+
+	testUserDB := &models.UserDB{
+		ID:    testUserID,
+		Login: testLogin,
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		var stored *models.ResetToken
+		var sent string
+		mStrg.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(testUserDB, nil)
+		mStrg.EXPECT().AddResetToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, token *models.ResetToken) error {
+			stored = token
+			return nil
+		})
+		mNotifier.EXPECT().NotifyPasswordReset(gomock.Any(), testLogin, gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _, token string, _ time.Time) error {
+			sent = token
+			return nil
+		})
+
+		err := s.RequestReset(context.Background(), testLogin)
+		assert.NoError(t, err)
+		require.NoError(t, s.Wait(context.Background()))
+		assert.Equal(t, testUserID, stored.UserID)
+		assert.Equal(t, hashToken(sent), stored.TokenHash)
+		assert.NotEqual(t, sent, stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(testResetTTL), stored.ExpiresAt, time.Second)
+	})
+
+	t.Run("unknown login", func(t *testing.T) {
+		mErr := mocks.NewMockerrNoUser(ctrl)
+		mErr.EXPECT().IsErrNoUser().Return(true)
+		mStrg.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(nil, mErr)
+
+		err := s.RequestReset(context.Background(), testLogin)
+		assert.NoError(t, err)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(nil, errTest)
+
+		err := s.RequestReset(context.Background(), testLogin)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("issue error", func(t *testing.T) {
+		mStrg.EXPECT().GetUserByLogin(gomock.Any(), testLogin).Return(testUserDB, nil)
+		mStrg.EXPECT().AddResetToken(gomock.Any(), gomock.Any()).Return(errTest)
+
+		err := s.RequestReset(context.Background(), testLogin)
+		assert.NoError(t, err)
+		require.NoError(t, s.Wait(context.Background()))
+	})
+}
+
+func TestPasswordService_ConfirmReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockpasswordStorager(ctrl)
+	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mPolicy := mocks.NewMockpasswordPolicy(ctrl)
+	mNotifier := mocks.NewMockresetNotifier(ctrl)
+
+	s := NewPasswordService(mStrg, mHasher, mPolicy, mNotifier, testResetTTL, time.Second)
+
+	testConfirm := &models.PasswordResetConfirm{
+		Token:       "token",
+		NewPassword: "new_secret",
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mPolicy.EXPECT().Check(testConfirm.NewPassword).Return(nil)
+		mHasher.EXPECT().Hash(testConfirm.NewPassword).Return("new_hash", nil)
+		mStrg.EXPECT().ResetPassword(gomock.Any(), hashToken(testConfirm.Token), "new_hash", gomock.Any()).Return(testUserID, nil)
+
+		err := s.ConfirmReset(context.Background(), testConfirm)
+		assert.NoError(t, err)
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		mPolicy.EXPECT().Check(testConfirm.NewPassword).Return(errTest)
+
+		err := s.ConfirmReset(context.Background(), testConfirm)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mPolicy.EXPECT().Check(testConfirm.NewPassword).Return(nil)
+		mHasher.EXPECT().Hash(testConfirm.NewPassword).Return("new_hash", nil)
+		mStrg.EXPECT().ResetPassword(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(models.UserID(0), errTest)
+
+		err := s.ConfirmReset(context.Background(), testConfirm)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type referralStorager interface {
+	GetReferralCodeByUserID(context.Context, models.UserID) (string, error)
+	GetInviteesByReferrerID(context.Context, models.UserID) ([]*models.Invitee, error)
+}
+
+type ReferralService struct {
+	strg referralStorager
+}
+
+func NewReferralService(strg referralStorager) *ReferralService {
+	return &ReferralService{strg: strg}
+}
+
+func (s *ReferralService) GetUserReferrals(ctx context.Context, uid models.UserID) (*models.Referrals, error) {
+	code, err := s.strg.GetReferralCodeByUserID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	invitees, err := s.strg.GetInviteesByReferrerID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	referrals := &models.Referrals{
+		Code:     code,
+		Invitees: []*models.Invitee{},
+	}
+	for _, invitee := range invitees {
+		if invitee.Rewarded {
+			referrals.Earned += invitee.Bonus
+		}
+		referrals.Invitees = append(referrals.Invitees, invitee)
+	}
+	return referrals, nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferralService_GetUserReferrals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockreferralStorager(ctrl)
+
+	s := NewReferralService(mStrg)
+
+	t.Run("valid test", func(t *testing.T) {
+		testInvitees := []*models.Invitee{
+			{
+				Login:        "first",
+				Bonus:        100,
+				Rewarded:     true,
+				RegisteredAt: time.Now().String(),
+			},
+			{
+				Login:        "second",
+				Bonus:        100,
+				Rewarded:     false,
+				RegisteredAt: time.Now().String(),
+			},
+		}
+
+		mStrg.EXPECT().GetReferralCodeByUserID(context.Background(), testUserID).Return(testReferralCode, nil)
+		mStrg.EXPECT().GetInviteesByReferrerID(context.Background(), testUserID).Return(testInvitees, nil)
+
+		referrals, err := s.GetUserReferrals(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, testReferralCode, referrals.Code)
+		assert.Equal(t, float64(100), referrals.Earned)
+		assert.Equal(t, testInvitees, referrals.Invitees)
+	})
+
+	t.Run("no invitees", func(t *testing.T) {
+		mStrg.EXPECT().GetReferralCodeByUserID(context.Background(), testUserID).Return(testReferralCode, nil)
+		mStrg.EXPECT().GetInviteesByReferrerID(context.Background(), testUserID).Return(nil, nil)
+
+		referrals, err := s.GetUserReferrals(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Empty(t, referrals.Invitees)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetReferralCodeByUserID(context.Background(), testUserID).Return("", errTest)
+
+		_, err := s.GetUserReferrals(context.Background(), testUserID)
+		assert.Error(t, err)
+	})
+}
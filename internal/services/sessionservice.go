@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type sessionStorager interface {
+	GetSessionsValidAfter(context.Context, models.UserID) (time.Time, error)
+}
+
+type sessionJWT interface {
+	ParseSessionFromAuthHeader(string) (models.UserID, time.Time, error)
+}
+
+type SessionService struct {
+	strg sessionStorager
+	jwt  sessionJWT
+}
+
+func NewSessionService(strg sessionStorager, jwt sessionJWT) *SessionService {
+	return &SessionService{
+		strg: strg,
+		jwt:  jwt,
+	}
+}
+
+// ValidateSession rejects tokens issued before the user's last password
+// change. Token timestamps have second precision, so tokens issued within
+// the same second as the change stay valid.
+func (s *SessionService) ValidateSession(ctx context.Context, header string) error {
+	uid, issuedAt, err := s.jwt.ParseSessionFromAuthHeader(header)
+	if err != nil {
+		return newErrSessionRevoked(err)
+	}
+	validAfter, err := s.strg.GetSessionsValidAfter(ctx, uid)
+	if e, ok := err.(errNoUser); ok && e.IsErrNoUser() {
+		return newErrSessionRevoked(ErrSessionRevoked)
+	}
+	if err != nil {
+		return err
+	}
+	if issuedAt.Before(validAfter) {
+		return newErrSessionRevoked(ErrSessionRevoked)
+	}
+	return nil
+}
+
+func sessionsCutoff() time.Time {
+	return time.Now().Truncate(time.Second)
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAuthHeader = "Bearer abc.def.ghi"
+
+func TestSessionService_ValidateSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocksessionStorager(ctrl)
+	mJWT := mocks.NewMocksessionJWT(ctrl)
+
+	s := NewSessionService(mStrg, mJWT)
+
+	issuedAt := time.Now().Truncate(time.Second)
+
+	t.Run("valid test", func(t *testing.T) {
+		mJWT.EXPECT().ParseSessionFromAuthHeader(testAuthHeader).Return(testUserID, issuedAt, nil)
+		mStrg.EXPECT().GetSessionsValidAfter(gomock.Any(), testUserID).Return(issuedAt, nil)
+
+		err := s.ValidateSession(context.Background(), testAuthHeader)
+		assert.NoError(t, err)
+	})
+
+	t.Run("password never changed", func(t *testing.T) {
+		mJWT.EXPECT().ParseSessionFromAuthHeader(testAuthHeader).Return(testUserID, issuedAt, nil)
+		mStrg.EXPECT().GetSessionsValidAfter(gomock.Any(), testUserID).Return(time.Time{}, nil)
+
+		err := s.ValidateSession(context.Background(), testAuthHeader)
+		assert.NoError(t, err)
+	})
+
+	t.Run("revoked session", func(t *testing.T) {
+		mJWT.EXPECT().ParseSessionFromAuthHeader(testAuthHeader).Return(testUserID, issuedAt, nil)
+		mStrg.EXPECT().GetSessionsValidAfter(gomock.Any(), testUserID).Return(issuedAt.Add(time.Second), nil)
+
+		err := s.ValidateSession(context.Background(), testAuthHeader)
+		assert.ErrorIs(t, err, ErrSessionRevoked)
+	})
+
+	t.Run("no user", func(t *testing.T) {
+		mErr := mocks.NewMockerrNoUser(ctrl)
+		mErr.EXPECT().IsErrNoUser().Return(true)
+		mJWT.EXPECT().ParseSessionFromAuthHeader(testAuthHeader).Return(testUserID, issuedAt, nil)
+		mStrg.EXPECT().GetSessionsValidAfter(gomock.Any(), testUserID).Return(time.Time{}, mErr)
+
+		err := s.ValidateSession(context.Background(), testAuthHeader)
+		assert.ErrorIs(t, err, ErrSessionRevoked)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mJWT.EXPECT().ParseSessionFromAuthHeader(testAuthHeader).Return(testUserID, issuedAt, nil)
+		mStrg.EXPECT().GetSessionsValidAfter(gomock.Any(), testUserID).Return(time.Time{}, errTest)
+
+		err := s.ValidateSession(context.Background(), testAuthHeader)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
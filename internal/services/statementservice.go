@@ -0,0 +1,46 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type statementStorager interface {
+	StreamStatement(context.Context, models.UserID, time.Time, time.Time, func(float64) error, func(*models.StatementEntry) error) error
+}
+
+type StatementService struct {
+	strg statementStorager
+}
+
+func NewStatementService(strg statementStorager) *StatementService {
+	return &StatementService{strg: strg}
+}
+
+// StreamStatement passes the opening balance to opening and then every entry
+// of the period with the running balance to fn. It returns the closing
+// balance.
+func (s *StatementService) StreamStatement(ctx context.Context, uid models.UserID, from, to time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) (float64, error) {
+	var balance float64
+	err := s.strg.StreamStatement(ctx, uid, from, to, func(before float64) error {
+		balance = roundCents(before)
+		return opening(balance)
+	}, func(entry *models.StatementEntry) error {
+		balance = roundCents(balance + entry.Amount)
+		entry.Balance = balance
+		return fn(entry)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return balance, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
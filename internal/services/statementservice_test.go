@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementService_StreamStatement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockstatementStorager(ctrl)
+
+	s := NewStatementService(mStrg)
+
+	testFrom := time.Now().Add(-time.Hour)
+	testTo := time.Now()
+
+	testEntries := []*models.StatementEntry{
+		{Type: models.EntryAccrual, Amount: 10.1},
+		{Type: models.EntryWithdrawal, Amount: -5.2},
+		{Type: models.EntryTransferIn, Amount: 0.3},
+	}
+
+	streamStatement := func(_ context.Context, _ models.UserID, _, _ time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) error {
+		if err := opening(100.004); err != nil {
+			return err
+		}
+		for _, entry := range testEntries {
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().StreamStatement(context.Background(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(streamStatement)
+
+		var opening float64
+		var balances []float64
+		closing, err := s.StreamStatement(context.Background(), testUserID, testFrom, testTo, func(balance float64) error {
+			opening = balance
+			return nil
+		}, func(entry *models.StatementEntry) error {
+			balances = append(balances, entry.Balance)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(100), opening)
+		assert.Equal(t, []float64{110.1, 104.9, 105.2}, balances)
+		assert.Equal(t, 105.2, closing)
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		mStrg.EXPECT().StreamStatement(context.Background(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).DoAndReturn(streamStatement)
+
+		_, err := s.StreamStatement(context.Background(), testUserID, testFrom, testTo, func(float64) error {
+			return nil
+		}, func(*models.StatementEntry) error {
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().StreamStatement(context.Background(), testUserID, testFrom, testTo, gomock.Any(), gomock.Any()).Return(errTest)
+
+		_, err := s.StreamStatement(context.Background(), testUserID, testFrom, testTo, func(float64) error {
+			return nil
+		}, func(*models.StatementEntry) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
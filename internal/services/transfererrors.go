@@ -0,0 +1,75 @@
+package services
+
+import "errors"
+
+var (
+	ErrInvalidTransfer  = errors.New("invalid transfer")
+	ErrUnknownRecipient = errors.New("recipient does not exist")
+	ErrSelfTransfer     = errors.New("transfer to yourself")
+)
+
+type errInvalidTransfer struct {
+	err error
+}
+
+func (err *errInvalidTransfer) Error() string {
+	return err.err.Error()
+}
+
+func (err *errInvalidTransfer) Unwrap() error {
+	return err.err
+}
+
+func (err *errInvalidTransfer) IsErrInvalidTransfer() bool {
+	return true
+}
+
+func newErrInvalidTransfer(err error) error {
+	return &errInvalidTransfer{
+		err: err,
+	}
+}
+
+type errUnknownRecipient struct {
+	err error
+}
+
+func (err *errUnknownRecipient) Error() string {
+	return err.err.Error()
+}
+
+func (err *errUnknownRecipient) Unwrap() error {
+	return err.err
+}
+
+func (err *errUnknownRecipient) IsErrUnknownRecipient() bool {
+	return true
+}
+
+func newErrUnknownRecipient(err error) error {
+	return &errUnknownRecipient{
+		err: err,
+	}
+}
+
+type errSelfTransfer struct {
+	err error
+}
+
+func (err *errSelfTransfer) Error() string {
+	return err.err.Error()
+}
+
+func (err *errSelfTransfer) Unwrap() error {
+	return err.err
+}
+
+func (err *errSelfTransfer) IsErrSelfTransfer() bool {
+	return true
+}
+
+func newErrSelfTransfer(err error) error {
+	return &errSelfTransfer{
+		err: err,
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+type transferStorager interface {
+	AddTransfer(context.Context, *models.Transfer, float64) error
+	GetTransfersByUserID(context.Context, models.UserID) ([]*models.TransferRecord, error)
+	GetTransfersPageByUserID(context.Context, models.UserID, models.Page) ([]*models.TransferRecord, error)
+}
+
+type recipientStorager interface {
+	GetUserByLogin(context.Context, string) (*models.UserDB, error)
+}
+
+type TransferService struct {
+	strg       transferStorager
+	users      recipientStorager
+	dailyLimit float64
+}
+
+func NewTransferService(strg transferStorager, users recipientStorager, dailyLimit float64) *TransferService {
+	return &TransferService{
+		strg:       strg,
+		users:      users,
+		dailyLimit: dailyLimit,
+	}
+}
+
+func (s *TransferService) TransferProcessing(ctx context.Context, transfer *models.Transfer) error {
+	if transfer.Sum <= 0 || transfer.Recipient == "" || transfer.IdempotencyKey == "" {
+		return newErrInvalidTransfer(ErrInvalidTransfer)
+	}
+	recipient, err := s.users.GetUserByLogin(ctx, transfer.Recipient)
+	if e, ok := err.(errNoUser); ok && e.IsErrNoUser() {
+		return newErrUnknownRecipient(ErrUnknownRecipient)
+	}
+	if err != nil {
+		return err
+	}
+	if recipient.ID == transfer.SenderID {
+		return newErrSelfTransfer(ErrSelfTransfer)
+	}
+	transfer.RecipientID = recipient.ID
+	err = s.strg.AddTransfer(ctx, transfer, s.dailyLimit)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *TransferService) GetUserTransfers(ctx context.Context, uid models.UserID) ([]*models.TransferRecord, error) {
+	transfers, err := s.strg.GetTransfersByUserID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+func (s *TransferService) GetUserTransfersPage(ctx context.Context, uid models.UserID, page models.Page) ([]*models.TransferRecord, bool, error) {
+	transfers, err := s.strg.GetTransfersPageByUserID(ctx, uid, lookahead(page))
+	if err != nil {
+		return nil, false, err
+	}
+	transfers, more := trimPage(transfers, page)
+	return transfers, more, nil
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const testDailyLimit = float64(100)
+
+func TestTransferService_TransferProcessing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktransferStorager(ctrl)
+	mUsers := mocks.NewMockrecipientStorager(ctrl)
+
+	s := NewTransferService(mStrg, mUsers, testDailyLimit)
+
+	testRecipient := &models.UserDB{
+		ID:    testOtherUserID,
+		Login: "recipient",
+	}
+
+	newTestTransfer := func() *models.Transfer {
+		return &models.Transfer{
+			SenderID:       testUserID,
+			Recipient:      testRecipient.Login,
+			Sum:            10,
+			IdempotencyKey: "key",
+		}
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		testTransfer := newTestTransfer()
+
+		mUsers.EXPECT().GetUserByLogin(context.Background(), testRecipient.Login).Return(testRecipient, nil)
+		mStrg.EXPECT().AddTransfer(context.Background(), testTransfer, testDailyLimit).Return(nil)
+
+		err := s.TransferProcessing(context.Background(), testTransfer)
+		assert.NoError(t, err)
+		assert.Equal(t, testOtherUserID, testTransfer.RecipientID)
+	})
+
+	t.Run("invalid sum", func(t *testing.T) {
+		testTransfer := newTestTransfer()
+		testTransfer.Sum = -1
+
+		err := s.TransferProcessing(context.Background(), testTransfer)
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("no idempotency key", func(t *testing.T) {
+		testTransfer := newTestTransfer()
+		testTransfer.IdempotencyKey = ""
+
+		err := s.TransferProcessing(context.Background(), testTransfer)
+		assert.ErrorIs(t, err, ErrInvalidTransfer)
+	})
+
+	t.Run("unknown recipient", func(t *testing.T) {
+		testTransfer := newTestTransfer()
+
+		mErr := mocks.NewMockerrNoUser(ctrl)
+		mErr.EXPECT().IsErrNoUser().Return(true)
+		mUsers.EXPECT().GetUserByLogin(context.Background(), testRecipient.Login).Return(nil, mErr)
+
+		err := s.TransferProcessing(context.Background(), testTransfer)
+		assert.ErrorIs(t, err, ErrUnknownRecipient)
+	})
+
+	t.Run("self transfer", func(t *testing.T) {
+		testTransfer := newTestTransfer()
+
+		mUsers.EXPECT().GetUserByLogin(context.Background(), testRecipient.Login).Return(&models.UserDB{ID: testUserID}, nil)
+
+		err := s.TransferProcessing(context.Background(), testTransfer)
+		assert.ErrorIs(t, err, ErrSelfTransfer)
+	})
+
+	t.Run("add transfer error", func(t *testing.T) {
+		testTransfer := newTestTransfer()
+
+		mUsers.EXPECT().GetUserByLogin(context.Background(), testRecipient.Login).Return(testRecipient, nil)
+		mStrg.EXPECT().AddTransfer(context.Background(), testTransfer, testDailyLimit).Return(errTest)
+
+		err := s.TransferProcessing(context.Background(), testTransfer)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransferService_GetUserTransfers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktransferStorager(ctrl)
+	mUsers := mocks.NewMockrecipientStorager(ctrl)
+
+	s := NewTransferService(mStrg, mUsers, testDailyLimit)
+
+	t.Run("valid test", func(t *testing.T) {
+		testTransfers := []*models.TransferRecord{
+			{
+				Direction:    models.TransferOut,
+				Counterparty: "recipient",
+				Sum:          10,
+				ProcessedAt:  time.Now().String(),
+			},
+		}
+
+		mStrg.EXPECT().GetTransfersByUserID(context.Background(), testUserID).Return(testTransfers, nil)
+
+		transfers, err := s.GetUserTransfers(context.Background(), testUserID)
+		assert.Equal(t, testTransfers, transfers)
+		assert.NoError(t, err)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetTransfersByUserID(context.Background(), testUserID).Return(nil, errTest)
+
+		_, err := s.GetUserTransfers(context.Background(), testUserID)
+		assert.Error(t, err)
+	})
+}
+
+func TestTransferService_GetUserTransfersPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktransferStorager(ctrl)
+	mUsers := mocks.NewMockrecipientStorager(ctrl)
+
+	s := NewTransferService(mStrg, mUsers, testDailyLimit)
+
+	testTransfers := []*models.TransferRecord{
+		{Direction: models.TransferOut, Counterparty: "recipient", Sum: 10},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetTransfersPageByUserID(gomock.Any(), testUserID, models.Page{Limit: 11, Offset: 0}).Return(testTransfers, nil)
+
+		transfers, more, err := s.GetUserTransfersPage(context.Background(), testUserID, models.Page{Limit: 10})
+		assert.NoError(t, err)
+		assert.False(t, more)
+		assert.Equal(t, testTransfers, transfers)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetTransfersPageByUserID(gomock.Any(), testUserID, gomock.Any()).Return(nil, errTest)
+
+		_, _, err := s.GetUserTransfersPage(context.Background(), testUserID, models.Page{Limit: 10})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,75 @@
+package services
+
+import "errors"
+
+var (
+	ErrTOTPNotEnrolled = errors.New("totp is not enrolled")
+	ErrWrongTOTPCode   = errors.New("wrong totp or recovery code")
+	ErrTOTPRequired    = errors.New("totp code is required")
+)
+
+type errTOTPNotEnrolled struct {
+	err error
+}
+
+func (err *errTOTPNotEnrolled) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTOTPNotEnrolled) Unwrap() error {
+	return err.err
+}
+
+func (err *errTOTPNotEnrolled) IsErrTOTPNotEnrolled() bool {
+	return true
+}
+
+func newErrTOTPNotEnrolled(err error) error {
+	return &errTOTPNotEnrolled{
+		err: err,
+	}
+}
+
+type errWrongTOTPCode struct {
+	err error
+}
+
+func (err *errWrongTOTPCode) Error() string {
+	return err.err.Error()
+}
+
+func (err *errWrongTOTPCode) Unwrap() error {
+	return err.err
+}
+
+func (err *errWrongTOTPCode) IsErrWrongTOTPCode() bool {
+	return true
+}
+
+func newErrWrongTOTPCode(err error) error {
+	return &errWrongTOTPCode{
+		err: err,
+	}
+}
+
+type errTOTPRequired struct {
+	err error
+}
+
+func (err *errTOTPRequired) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTOTPRequired) Unwrap() error {
+	return err.err
+}
+
+func (err *errTOTPRequired) IsErrTOTPRequired() bool {
+	return true
+}
+
+func newErrTOTPRequired(err error) error {
+	return &errTOTPRequired{
+		err: err,
+	}
+}
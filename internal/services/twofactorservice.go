@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"strings"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+const (
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+type twoFactorStorager interface {
+	GetTOTP(context.Context, models.UserID) (*models.TOTPDB, error)
+	SaveTOTPSecret(context.Context, models.UserID, string) error
+	EnableTOTP(context.Context, models.UserID, int64, []string) error
+	UseTOTPStep(context.Context, models.UserID, int64) (bool, error)
+	UseRecoveryCode(context.Context, models.UserID, string) (bool, error)
+}
+
+type twoFactorUserStorager interface {
+	GetUserByID(context.Context, models.UserID) (*models.UserDB, error)
+}
+
+type totpGenerator interface {
+	GenerateSecret() (string, error)
+	ProvisioningURI(string, string) string
+	Validate(string, string) (int64, bool)
+}
+
+type errNoTOTP interface {
+	error
+	IsErrNoTOTP() bool
+}
+
+type TwoFactorService struct {
+	strg      twoFactorStorager
+	users     twoFactorUserStorager
+	totp      totpGenerator
+	threshold float64
+}
+
+func NewTwoFactorService(strg twoFactorStorager, users twoFactorUserStorager, totp totpGenerator, threshold float64) *TwoFactorService {
+	return &TwoFactorService{
+		strg:      strg,
+		users:     users,
+		totp:      totp,
+		threshold: threshold,
+	}
+}
+
+// BeginEnrollment provisions a new secret. It replaces a pending one, so a
+// user who lost the QR code can start over until the enrolment is confirmed.
+func (s *TwoFactorService) BeginEnrollment(ctx context.Context, uid models.UserID) (*models.TOTPEnrollment, error) {
+	userDB, err := s.users.GetUserByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := s.totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	err = s.strg.SaveTOTPSecret(ctx, uid, secret)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: s.totp.ProvisioningURI(userDB.Login, secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables 2FA once the user proves the authenticator is
+// set up and returns recovery codes. They are only stored hashed, so this
+// is the only time they can be shown.
+func (s *TwoFactorService) ConfirmEnrollment(ctx context.Context, uid models.UserID, code string) ([]string, error) {
+	totp, err := s.strg.GetTOTP(ctx, uid)
+	if e, ok := err.(errNoTOTP); ok && e.IsErrNoTOTP() {
+		return nil, newErrTOTPNotEnrolled(ErrTOTPNotEnrolled)
+	}
+	if err != nil {
+		return nil, err
+	}
+	step, ok := s.totp.Validate(totp.Secret, code)
+	if !ok {
+		return nil, newErrWrongTOTPCode(ErrWrongTOTPCode)
+	}
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	err = s.strg.EnableTOTP(ctx, uid, step, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *TwoFactorService) IsEnabled(ctx context.Context, uid models.UserID) (bool, error) {
+	totp, err := s.strg.GetTOTP(ctx, uid)
+	if e, ok := err.(errNoTOTP); ok && e.IsErrNoTOTP() {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return totp.Enabled, nil
+}
+
+// VerifyLogin accepts a TOTP code or an unused recovery code.
+func (s *TwoFactorService) VerifyLogin(ctx context.Context, uid models.UserID, code string) error {
+	totp, err := s.getEnabledTOTP(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if totp == nil {
+		return newErrTOTPNotEnrolled(ErrTOTPNotEnrolled)
+	}
+	ok, err := s.useCode(ctx, totp, code)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	ok, err = s.strg.UseRecoveryCode(ctx, uid, hashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return newErrWrongTOTPCode(ErrWrongTOTPCode)
+	}
+	return nil
+}
+
+// VerifyStepUp requires a fresh TOTP code for amounts above the threshold
+// from users who have 2FA enabled. Recovery codes are not accepted here.
+func (s *TwoFactorService) VerifyStepUp(ctx context.Context, uid models.UserID, amount float64, code string) error {
+	if amount <= s.threshold {
+		return nil
+	}
+	totp, err := s.getEnabledTOTP(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if totp == nil {
+		return nil
+	}
+	if code == "" {
+		return newErrTOTPRequired(ErrTOTPRequired)
+	}
+	ok, err := s.useCode(ctx, totp, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return newErrWrongTOTPCode(ErrWrongTOTPCode)
+	}
+	return nil
+}
+
+func (s *TwoFactorService) getEnabledTOTP(ctx context.Context, uid models.UserID) (*models.TOTPDB, error) {
+	totp, err := s.strg.GetTOTP(ctx, uid)
+	if e, ok := err.(errNoTOTP); ok && e.IsErrNoTOTP() {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !totp.Enabled {
+		return nil, nil
+	}
+	return totp, nil
+}
+
+// useCode marks the matched time step as used, so a code can't be replayed.
+func (s *TwoFactorService) useCode(ctx context.Context, totp *models.TOTPDB, code string) (bool, error) {
+	step, ok := s.totp.Validate(totp.Secret, code)
+	if !ok {
+		return false, nil
+	}
+	return s.strg.UseTOTPStep(ctx, totp.UserID, step)
+}
+
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeLength)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf))[:recoveryCodeLength]
+	return code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
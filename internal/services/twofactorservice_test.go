@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/services/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testTOTPSecret    = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	testTOTPCode      = "123456"
+	testTOTPStep      = int64(100)
+	testStepThreshold = 1000
+)
+
+var (
+	testEnabledTOTP = &models.TOTPDB{
+		UserID:  testUserID,
+		Secret:  testTOTPSecret,
+		Enabled: true,
+	}
+	testPendingTOTP = &models.TOTPDB{
+		UserID: testUserID,
+		Secret: testTOTPSecret,
+	}
+)
+
+func TestTwoFactorService_BeginEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktwoFactorStorager(ctrl)
+	mUsers := mocks.NewMocktwoFactorUserStorager(ctrl)
+	mTOTP := mocks.NewMocktotpGenerator(ctrl)
+
+	s := NewTwoFactorService(mStrg, mUsers, mTOTP, testStepThreshold)
+
+	t.Run("valid test", func(t *testing.T) {
+		mUsers.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(&models.UserDB{ID: testUserID, Login: testLogin}, nil)
+		mTOTP.EXPECT().GenerateSecret().Return(testTOTPSecret, nil)
+		mStrg.EXPECT().SaveTOTPSecret(gomock.Any(), testUserID, testTOTPSecret).Return(nil)
+		mTOTP.EXPECT().ProvisioningURI(testLogin, testTOTPSecret).Return("otpauth://totp/test")
+
+		enrollment, err := s.BeginEnrollment(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.TOTPEnrollment{
+			Secret:          testTOTPSecret,
+			ProvisioningURI: "otpauth://totp/test",
+		}, enrollment)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mUsers.EXPECT().GetUserByID(gomock.Any(), testUserID).Return(&models.UserDB{ID: testUserID, Login: testLogin}, nil)
+		mTOTP.EXPECT().GenerateSecret().Return(testTOTPSecret, nil)
+		mStrg.EXPECT().SaveTOTPSecret(gomock.Any(), testUserID, testTOTPSecret).Return(errTest)
+
+		_, err := s.BeginEnrollment(context.Background(), testUserID)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestTwoFactorService_ConfirmEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktwoFactorStorager(ctrl)
+	mUsers := mocks.NewMocktwoFactorUserStorager(ctrl)
+	mTOTP := mocks.NewMocktotpGenerator(ctrl)
+
+	s := NewTwoFactorService(mStrg, mUsers, mTOTP, testStepThreshold)
+
+	t.Run("valid test", func(t *testing.T) {
+		var storedHashes []string
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testPendingTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, testTOTPCode).Return(testTOTPStep, true)
+		mStrg.EXPECT().EnableTOTP(gomock.Any(), testUserID, testTOTPStep, gomock.Any()).DoAndReturn(func(_ context.Context, _ models.UserID, _ int64, hashes []string) error {
+			storedHashes = hashes
+			return nil
+		})
+
+		codes, err := s.ConfirmEnrollment(context.Background(), testUserID, testTOTPCode)
+		require.NoError(t, err)
+		assert.Len(t, codes, recoveryCodeCount)
+		require.Len(t, storedHashes, recoveryCodeCount)
+		for i, code := range codes {
+			assert.Len(t, code, recoveryCodeLength+1)
+			assert.Equal(t, hashToken(normalizeRecoveryCode(code)), storedHashes[i])
+		}
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mErr := mocks.NewMockerrNoTOTP(ctrl)
+		mErr.EXPECT().IsErrNoTOTP().Return(true)
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(nil, mErr)
+
+		_, err := s.ConfirmEnrollment(context.Background(), testUserID, testTOTPCode)
+		assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testPendingTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, testTOTPCode).Return(int64(0), false)
+
+		_, err := s.ConfirmEnrollment(context.Background(), testUserID, testTOTPCode)
+		assert.ErrorIs(t, err, ErrWrongTOTPCode)
+	})
+}
+
+func TestTwoFactorService_IsEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktwoFactorStorager(ctrl)
+	mUsers := mocks.NewMocktwoFactorUserStorager(ctrl)
+	mTOTP := mocks.NewMocktotpGenerator(ctrl)
+
+	s := NewTwoFactorService(mStrg, mUsers, mTOTP, testStepThreshold)
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+
+		enabled, err := s.IsEnabled(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.True(t, enabled)
+	})
+
+	t.Run("pending enrollment", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testPendingTOTP, nil)
+
+		enabled, err := s.IsEnabled(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mErr := mocks.NewMockerrNoTOTP(ctrl)
+		mErr.EXPECT().IsErrNoTOTP().Return(true)
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(nil, mErr)
+
+		enabled, err := s.IsEnabled(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.False(t, enabled)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(nil, errTest)
+
+		_, err := s.IsEnabled(context.Background(), testUserID)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestTwoFactorService_VerifyLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktwoFactorStorager(ctrl)
+	mUsers := mocks.NewMocktwoFactorUserStorager(ctrl)
+	mTOTP := mocks.NewMocktotpGenerator(ctrl)
+
+	s := NewTwoFactorService(mStrg, mUsers, mTOTP, testStepThreshold)
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, testTOTPCode).Return(testTOTPStep, true)
+		mStrg.EXPECT().UseTOTPStep(gomock.Any(), testUserID, testTOTPStep).Return(true, nil)
+
+		err := s.VerifyLogin(context.Background(), testUserID, testTOTPCode)
+		assert.NoError(t, err)
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, "ABCDE-FGHIJ").Return(int64(0), false)
+		mStrg.EXPECT().UseRecoveryCode(gomock.Any(), testUserID, hashToken("abcdefghij")).Return(true, nil)
+
+		err := s.VerifyLogin(context.Background(), testUserID, "ABCDE-FGHIJ")
+		assert.NoError(t, err)
+	})
+
+	t.Run("replayed code", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, testTOTPCode).Return(testTOTPStep, true)
+		mStrg.EXPECT().UseTOTPStep(gomock.Any(), testUserID, testTOTPStep).Return(false, nil)
+		mStrg.EXPECT().UseRecoveryCode(gomock.Any(), testUserID, gomock.Any()).Return(false, nil)
+
+		err := s.VerifyLogin(context.Background(), testUserID, testTOTPCode)
+		assert.ErrorIs(t, err, ErrWrongTOTPCode)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testPendingTOTP, nil)
+
+		err := s.VerifyLogin(context.Background(), testUserID, testTOTPCode)
+		assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+	})
+}
+
+func TestTwoFactorService_VerifyStepUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMocktwoFactorStorager(ctrl)
+	mUsers := mocks.NewMocktwoFactorUserStorager(ctrl)
+	mTOTP := mocks.NewMocktotpGenerator(ctrl)
+
+	s := NewTwoFactorService(mStrg, mUsers, mTOTP, testStepThreshold)
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, testTOTPCode).Return(testTOTPStep, true)
+		mStrg.EXPECT().UseTOTPStep(gomock.Any(), testUserID, testTOTPStep).Return(true, nil)
+
+		err := s.VerifyStepUp(context.Background(), testUserID, testStepThreshold+1, testTOTPCode)
+		assert.NoError(t, err)
+	})
+
+	t.Run("below threshold", func(t *testing.T) {
+		err := s.VerifyStepUp(context.Background(), testUserID, testStepThreshold, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("2fa disabled", func(t *testing.T) {
+		mErr := mocks.NewMockerrNoTOTP(ctrl)
+		mErr.EXPECT().IsErrNoTOTP().Return(true)
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(nil, mErr)
+
+		err := s.VerifyStepUp(context.Background(), testUserID, testStepThreshold+1, "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("code required", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+
+		err := s.VerifyStepUp(context.Background(), testUserID, testStepThreshold+1, "")
+		assert.ErrorIs(t, err, ErrTOTPRequired)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(testEnabledTOTP, nil)
+		mTOTP.EXPECT().Validate(testTOTPSecret, testTOTPCode).Return(int64(0), false)
+
+		err := s.VerifyStepUp(context.Background(), testUserID, testStepThreshold+1, testTOTPCode)
+		assert.ErrorIs(t, err, ErrWrongTOTPCode)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetTOTP(gomock.Any(), testUserID).Return(nil, errTest)
+
+		err := s.VerifyStepUp(context.Background(), testUserID, testStepThreshold+1, testTOTPCode)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,52 @@
+package services
+
+import "errors"
+
+var (
+	ErrWrongReferralCode = errors.New("referral code does not exist")
+	ErrSessionRevoked    = errors.New("session was revoked")
+)
+
+type errWrongReferralCode struct {
+	err error
+}
+
+func (err *errWrongReferralCode) Error() string {
+	return err.err.Error()
+}
+
+func (err *errWrongReferralCode) Unwrap() error {
+	return err.err
+}
+
+func (err *errWrongReferralCode) IsErrWrongReferralCode() bool {
+	return true
+}
+
+func newErrWrongReferralCode(err error) error {
+	return &errWrongReferralCode{
+		err: err,
+	}
+}
+
+type errSessionRevoked struct {
+	err error
+}
+
+func (err *errSessionRevoked) Error() string {
+	return err.err.Error()
+}
+
+func (err *errSessionRevoked) Unwrap() error {
+	return err.err
+}
+
+func (err *errSessionRevoked) IsErrSessionRevoked() bool {
+	return true
+}
+
+func newErrSessionRevoked(err error) error {
+	return &errSessionRevoked{
+		err: err,
+	}
+}
@@ -2,35 +2,99 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base32"
 
+	"github.com/rycln/loyalsys/internal/logger"
 	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
 
+const (
+	referralCodeLength   = 10
+	referralCodeAttempts = 3
+	dummyPassword        = "loyalsys-dummy-password"
+)
+
 type userStorager interface {
 	AddUser(context.Context, *models.UserDB) (models.UserID, error)
+	AddUserWithReferral(context.Context, *models.UserDB, *models.Referral) (models.UserID, error)
 	GetUserByLogin(context.Context, string) (*models.UserDB, error)
+	GetUserByReferralCode(context.Context, string) (*models.UserDB, error)
+	RehashPassword(context.Context, models.UserID, string, string) error
 }
 
 type passwordHasher interface {
 	Hash(string) (string, error)
 	Compare(string, string) error
+	NeedsRehash(string) bool
+}
+
+type passwordPolicy interface {
+	Check(string) error
+}
+
+type errNoUser interface {
+	error
+	IsErrNoUser() bool
+}
+
+type errReferralCodeConflict interface {
+	error
+	IsErrReferralCodeConflict() bool
+}
+
+type ReferralBonus struct {
+	Referrer float64
+	Referee  float64
 }
 
 type UserService struct {
-	strg   userStorager
-	hasher passwordHasher
+	strg      userStorager
+	hasher    passwordHasher
+	policy    passwordPolicy
+	bonus     ReferralBonus
+	dummyHash string
 }
 
-func NewUserService(strg userStorager, hasher passwordHasher) *UserService {
-	return &UserService{
-		strg:   strg,
-		hasher: hasher,
+// NewUserService hashes the dummy password up front, so that the first
+// unknown login doesn't take a hash longer than the ones after it.
+func NewUserService(strg userStorager, hasher passwordHasher, policy passwordPolicy, bonus ReferralBonus) (*UserService, error) {
+	dummyHash, err := hasher.Hash(dummyPassword)
+	if err != nil {
+		return nil, err
 	}
+	return &UserService{
+		strg:      strg,
+		hasher:    hasher,
+		policy:    policy,
+		bonus:     bonus,
+		dummyHash: dummyHash,
+	}, nil
 }
 
 func (s *UserService) CreateUser(ctx context.Context, user *models.User) (models.UserID, error) {
+	err := s.policy.Check(user.Password)
+	if err != nil {
+		return 0, err
+	}
+	var referral *models.Referral
+	if user.ReferralCode != "" {
+		referrer, err := s.strg.GetUserByReferralCode(ctx, user.ReferralCode)
+		if e, ok := err.(errNoUser); ok && e.IsErrNoUser() {
+			return 0, newErrWrongReferralCode(ErrWrongReferralCode)
+		}
+		if err != nil {
+			return 0, err
+		}
+		referral = &models.Referral{
+			ReferrerID:    referrer.ID,
+			ReferrerBonus: s.bonus.Referrer,
+			RefereeBonus:  s.bonus.Referee,
+		}
+	}
 	hash, err := s.hasher.Hash(user.Password)
 	if err != nil {
 		return 0, err
@@ -39,15 +103,37 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) (models
 		Login:        user.Login,
 		PasswordHash: hash,
 	}
-	uid, err := s.strg.AddUser(ctx, userDB)
-	if err != nil {
-		return 0, err
+	// A fresh code is drawn when the random one is already taken, so the
+	// collision is never reported as a taken login.
+	for attempt := 1; ; attempt++ {
+		userDB.ReferralCode, err = generateReferralCode()
+		if err != nil {
+			return 0, err
+		}
+		uid, err := s.addUser(ctx, userDB, referral)
+		if e, ok := err.(errReferralCodeConflict); ok && e.IsErrReferralCodeConflict() && attempt < referralCodeAttempts {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		return uid, nil
 	}
-	return uid, nil
+}
+
+func (s *UserService) addUser(ctx context.Context, userDB *models.UserDB, referral *models.Referral) (models.UserID, error) {
+	if referral == nil {
+		return s.strg.AddUser(ctx, userDB)
+	}
+	return s.strg.AddUserWithReferral(ctx, userDB, referral)
 }
 
 func (s *UserService) UserAuth(ctx context.Context, user *models.User) (models.UserID, error) {
 	userDB, err := s.strg.GetUserByLogin(ctx, user.Login)
+	if e, ok := err.(errNoUser); ok && e.IsErrNoUser() {
+		s.compareDummy(user.Password)
+		return 0, err
+	}
 	if err != nil {
 		return 0, err
 	}
@@ -55,5 +141,36 @@ func (s *UserService) UserAuth(ctx context.Context, user *models.User) (models.U
 	if err != nil {
 		return 0, err
 	}
+	if s.hasher.NeedsRehash(userDB.PasswordHash) {
+		s.rehash(ctx, userDB, user.Password)
+	}
 	return models.UserID(userDB.ID), nil
 }
+
+// rehash upgrades the stored hash to the preferred algorithm and parameters.
+// Failures are only logged: the user has already been authenticated.
+func (s *UserService) rehash(ctx context.Context, userDB *models.UserDB, password string) {
+	hash, err := s.hasher.Hash(password)
+	if err == nil {
+		err = s.strg.RehashPassword(ctx, userDB.ID, userDB.PasswordHash, hash)
+	}
+	if err != nil {
+		logger.Log.Warn("Password rehash failed", zap.Int64("user_id", int64(userDB.ID)), zap.Error(err))
+	}
+}
+
+// compareDummy spends the same time on an unknown login as on a wrong
+// password, so the two cases can't be told apart by response timing.
+func (s *UserService) compareDummy(password string) {
+	_ = s.hasher.Compare(s.dummyHash, password)
+}
+
+func generateReferralCode() (string, error) {
+	buf := make([]byte, referralCodeLength)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	code := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)
+	return code[:referralCodeLength], nil
+}
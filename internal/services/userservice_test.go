@@ -9,9 +9,38 @@ import (
 	"github.com/rycln/loyalsys/internal/models"
 	"github.com/rycln/loyalsys/internal/services/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-const testPasswordHash = "abcdefg"
+const (
+	testPasswordHash = "abcdefg"
+	testReferralCode = "ABCDEFGHIJ"
+	testDummyHash    = "dummy"
+)
+
+var testReferralBonus = ReferralBonus{
+	Referrer: 100,
+	Referee:  50,
+}
+
+func newTestUserService(t *testing.T, strg userStorager, hasher *mocks.MockpasswordHasher, policy passwordPolicy) *UserService {
+	t.Helper()
+	hasher.EXPECT().Hash(dummyPassword).Return(testDummyHash, nil)
+	s, err := NewUserService(strg, hasher, policy, testReferralBonus)
+	require.NoError(t, err)
+	return s
+}
+
+func TestNewUserService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mHasher.EXPECT().Hash(dummyPassword).Return("", errTest)
+
+	_, err := NewUserService(mocks.NewMockuserStorager(ctrl), mHasher, mocks.NewMockpasswordPolicy(ctrl), testReferralBonus)
+	assert.ErrorIs(t, err, errTest)
+}
 
 func TestUserService_CreateUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -19,6 +48,7 @@ func TestUserService_CreateUser(t *testing.T) {
 
 	mStrg := mocks.NewMockuserStorager(ctrl)
 	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mPolicy := mocks.NewMockpasswordPolicy(ctrl)
 
 	t.Run("valid test", func(t *testing.T) {
 		testUser := &models.User{
@@ -26,10 +56,11 @@ func TestUserService_CreateUser(t *testing.T) {
 			Password: "secret",
 		}
 
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
 		mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(testUserID, nil)
 		mHasher.EXPECT().Hash(testUser.Password).Return(testPasswordHash, nil)
 
-		s := NewUserService(mStrg, mHasher)
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
 		uid, err := s.CreateUser(context.Background(), testUser)
 		assert.NoError(t, err)
 		assert.Equal(t, testUserID, uid)
@@ -41,34 +72,137 @@ func TestUserService_CreateUser(t *testing.T) {
 			Password: "secret",
 		}
 
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
 		mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).Return(models.UserID(0), errTest)
 		mHasher.EXPECT().Hash(testUser.Password).Return(testPasswordHash, nil)
 
-		s := NewUserService(mStrg, mHasher)
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
 		_, err := s.CreateUser(context.Background(), testUser)
 		assert.Error(t, err)
 	})
 
+	t.Run("referral code collision", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    "test",
+			Password: "secret",
+		}
+
+		mErr := mocks.NewMockerrReferralCodeConflict(ctrl)
+		mErr.EXPECT().IsErrReferralCodeConflict().Return(true)
+
+		var codes []string
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
+		mHasher.EXPECT().Hash(testUser.Password).Return(testPasswordHash, nil)
+		gomock.InOrder(
+			mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *models.UserDB) (models.UserID, error) {
+				codes = append(codes, u.ReferralCode)
+				return 0, mErr
+			}),
+			mStrg.EXPECT().AddUser(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *models.UserDB) (models.UserID, error) {
+				codes = append(codes, u.ReferralCode)
+				return testUserID, nil
+			}),
+		)
+
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
+		uid, err := s.CreateUser(context.Background(), testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+		assert.Len(t, codes, 2)
+		assert.NotEqual(t, codes[0], codes[1])
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    "test",
+			Password: "secret",
+		}
+
+		mPolicy.EXPECT().Check(testUser.Password).Return(errTest)
+
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
+		_, err := s.CreateUser(context.Background(), testUser)
+		assert.ErrorIs(t, err, errTest)
+	})
+
 	t.Run("password hash failed", func(t *testing.T) {
 		testUser := &models.User{
 			Login:    "test",
 			Password: "wrong_password",
 		}
 
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
 		mHasher.EXPECT().Hash(testUser.Password).Return("", errTest)
 
-		s := NewUserService(mStrg, mHasher)
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
 		_, err := s.CreateUser(context.Background(), testUser)
 		assert.Error(t, err)
 	})
 }
 
+func TestUserService_CreateUser_WithReferral(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockuserStorager(ctrl)
+	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mPolicy := mocks.NewMockpasswordPolicy(ctrl)
+
+	s := newTestUserService(t, mStrg, mHasher, mPolicy)
+
+	testUser := &models.User{
+		Login:        "test",
+		Password:     "secret",
+		ReferralCode: testReferralCode,
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		testReferrer := &models.UserDB{
+			ID:           testOtherUserID,
+			ReferralCode: testReferralCode,
+		}
+		testReferral := &models.Referral{
+			ReferrerID:    testOtherUserID,
+			ReferrerBonus: testReferralBonus.Referrer,
+			RefereeBonus:  testReferralBonus.Referee,
+		}
+
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
+		mStrg.EXPECT().GetUserByReferralCode(gomock.Any(), testReferralCode).Return(testReferrer, nil)
+		mHasher.EXPECT().Hash(testUser.Password).Return(testPasswordHash, nil)
+		mStrg.EXPECT().AddUserWithReferral(gomock.Any(), gomock.Any(), testReferral).Return(testUserID, nil)
+
+		uid, err := s.CreateUser(context.Background(), testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+	})
+
+	t.Run("wrong referral code", func(t *testing.T) {
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
+		mErr := mocks.NewMockerrNoUser(ctrl)
+		mErr.EXPECT().IsErrNoUser().Return(true)
+		mStrg.EXPECT().GetUserByReferralCode(gomock.Any(), testReferralCode).Return(nil, mErr)
+
+		_, err := s.CreateUser(context.Background(), testUser)
+		assert.ErrorIs(t, err, ErrWrongReferralCode)
+	})
+
+	t.Run("GetUserByReferralCode error", func(t *testing.T) {
+		mPolicy.EXPECT().Check(testUser.Password).Return(nil)
+		mStrg.EXPECT().GetUserByReferralCode(gomock.Any(), testReferralCode).Return(nil, errTest)
+
+		_, err := s.CreateUser(context.Background(), testUser)
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
 func TestUserService_UserAuth(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mStrg := mocks.NewMockuserStorager(ctrl)
 	mHasher := mocks.NewMockpasswordHasher(ctrl)
+	mPolicy := mocks.NewMockpasswordPolicy(ctrl)
 
 	t.Run("valid test", func(t *testing.T) {
 		testUser := &models.User{
@@ -83,8 +217,55 @@ func TestUserService_UserAuth(t *testing.T) {
 
 		mStrg.EXPECT().GetUserByLogin(context.Background(), testUser.Login).Return(testUserDB, nil)
 		mHasher.EXPECT().Compare(testUserDB.PasswordHash, testUser.Password).Return(nil)
+		mHasher.EXPECT().NeedsRehash(testUserDB.PasswordHash).Return(false)
+
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
+		uid, err := s.UserAuth(context.Background(), testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+	})
+
+	t.Run("rehash on login", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    "test",
+			Password: "secret",
+		}
+
+		testUserDB := &models.UserDB{
+			ID:           testUserID,
+			PasswordHash: testPasswordHash,
+		}
+
+		mStrg.EXPECT().GetUserByLogin(context.Background(), testUser.Login).Return(testUserDB, nil)
+		mHasher.EXPECT().Compare(testUserDB.PasswordHash, testUser.Password).Return(nil)
+		mHasher.EXPECT().NeedsRehash(testUserDB.PasswordHash).Return(true)
+		mHasher.EXPECT().Hash(testUser.Password).Return("new_hash", nil)
+		mStrg.EXPECT().RehashPassword(gomock.Any(), testUserID, testPasswordHash, "new_hash").Return(nil)
+
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
+		uid, err := s.UserAuth(context.Background(), testUser)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+	})
+
+	t.Run("rehash error does not fail login", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    "test",
+			Password: "secret",
+		}
+
+		testUserDB := &models.UserDB{
+			ID:           testUserID,
+			PasswordHash: testPasswordHash,
+		}
+
+		mStrg.EXPECT().GetUserByLogin(context.Background(), testUser.Login).Return(testUserDB, nil)
+		mHasher.EXPECT().Compare(testUserDB.PasswordHash, testUser.Password).Return(nil)
+		mHasher.EXPECT().NeedsRehash(testUserDB.PasswordHash).Return(true)
+		mHasher.EXPECT().Hash(testUser.Password).Return("new_hash", nil)
+		mStrg.EXPECT().RehashPassword(gomock.Any(), testUserID, testPasswordHash, "new_hash").Return(errTest)
 
-		s := NewUserService(mStrg, mHasher)
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
 		uid, err := s.UserAuth(context.Background(), testUser)
 		assert.NoError(t, err)
 		assert.Equal(t, testUserID, uid)
@@ -98,11 +279,30 @@ func TestUserService_UserAuth(t *testing.T) {
 
 		mStrg.EXPECT().GetUserByLogin(context.Background(), testUser.Login).Return(nil, errors.New("test err"))
 
-		s := NewUserService(mStrg, mHasher)
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
 		_, err := s.UserAuth(context.Background(), testUser)
 		assert.Error(t, err)
 	})
 
+	t.Run("no user compares dummy hash", func(t *testing.T) {
+		testUser := &models.User{
+			Login:    "test",
+			Password: "secret",
+		}
+
+		mErr := mocks.NewMockerrNoUser(ctrl)
+		mErr.EXPECT().IsErrNoUser().Return(true).Times(2)
+
+		mStrg.EXPECT().GetUserByLogin(context.Background(), testUser.Login).Return(nil, mErr).Times(2)
+		mHasher.EXPECT().Compare(testDummyHash, testUser.Password).Return(errTest).Times(2)
+
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
+		_, err := s.UserAuth(context.Background(), testUser)
+		assert.ErrorIs(t, err, mErr)
+		_, err = s.UserAuth(context.Background(), testUser)
+		assert.ErrorIs(t, err, mErr)
+	})
+
 	t.Run("password hash is not the same", func(t *testing.T) {
 		testUser := &models.User{
 			Login:    "test",
@@ -115,7 +315,7 @@ func TestUserService_UserAuth(t *testing.T) {
 		mStrg.EXPECT().GetUserByLogin(context.Background(), testUser.Login).Return(testUserDB, nil)
 		mHasher.EXPECT().Compare(testUserDB.PasswordHash, testUser.Password).Return(errTest)
 
-		s := NewUserService(mStrg, mHasher)
+		s := newTestUserService(t, mStrg, mHasher, mPolicy)
 		_, err := s.UserAuth(context.Background(), testUser)
 		assert.Error(t, err)
 	})
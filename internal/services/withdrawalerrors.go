@@ -3,8 +3,8 @@ package services
 import "errors"
 
 var (
-	ErrWrongOrderNum     = errors.New("luhn algorithm validation failed")
-	ErrNotEnoughCurrency = errors.New("not enough currency")
+	ErrWrongOrderNum        = errors.New("luhn algorithm validation failed")
+	ErrInvalidWithdrawalSum = errors.New("withdrawal sum must be positive")
 )
 
 type errWrongOrderNum struct {
@@ -29,24 +29,24 @@ func newErrWrongOrderNum(err error) error {
 	}
 }
 
-type errNotEnoughCurrency struct {
+type errInvalidWithdrawalSum struct {
 	err error
 }
 
-func (err *errNotEnoughCurrency) Error() string {
+func (err *errInvalidWithdrawalSum) Error() string {
 	return err.err.Error()
 }
 
-func (err *errNotEnoughCurrency) Unwrap() error {
+func (err *errInvalidWithdrawalSum) Unwrap() error {
 	return err.err
 }
 
-func (err *errNotEnoughCurrency) IsErrNotEnoughCurrency() bool {
+func (err *errInvalidWithdrawalSum) IsErrInvalidWithdrawalSum() bool {
 	return true
 }
 
-func newErrNotEnoughCurrency(err error) error {
-	return &errNotEnoughCurrency{
+func newErrInvalidWithdrawalSum(err error) error {
+	return &errInvalidWithdrawalSum{
 		err: err,
 	}
 }
@@ -11,40 +11,32 @@ import (
 
 type withdrawalStorager interface {
 	GetWithdrawalsByUserID(context.Context, models.UserID) ([]*models.Withdrawal, error)
+	GetWithdrawalsPageByUserID(context.Context, models.UserID, models.Page) ([]*models.Withdrawal, error)
 	AddWithdrawal(context.Context, *models.Withdrawal) error
 }
 
-type balanceServicer interface {
-	GetUserBalance(context.Context, models.UserID) (*models.Balance, error)
-}
-
 type WithdrawalService struct {
-	strg    withdrawalStorager
-	balance balanceServicer
+	strg withdrawalStorager
 }
 
-func NewWithdrawalService(strg withdrawalStorager, balance balanceServicer) *WithdrawalService {
+func NewWithdrawalService(strg withdrawalStorager) *WithdrawalService {
 	return &WithdrawalService{
-		strg:    strg,
-		balance: balance,
+		strg: strg,
 	}
 }
 
+// WithdrawalProcessing leaves the balance check to the storage, which makes
+// it under the same lock as the debit. A sum that isn't positive is refused
+// up front, as it would credit the balance instead.
 func (s *WithdrawalService) WithdrawalProcessing(ctx context.Context, withdrawal *models.Withdrawal) error {
+	if withdrawal.Sum <= 0 {
+		return newErrInvalidWithdrawalSum(ErrInvalidWithdrawalSum)
+	}
 	err := goluhn.Validate(withdrawal.Order)
 	if err != nil {
 		return newErrWrongOrderNum(ErrWrongOrderNum)
 	}
 
-	balance, err := s.balance.GetUserBalance(ctx, withdrawal.UserID)
-	if err != nil {
-		return err
-	}
-
-	if balance.Current < withdrawal.Sum {
-		return newErrNotEnoughCurrency(ErrNotEnoughCurrency)
-	}
-
 	err = s.strg.AddWithdrawal(ctx, withdrawal)
 	if err != nil {
 		return err
@@ -59,3 +51,12 @@ func (s *WithdrawalService) GetUserWithdrawals(ctx context.Context, uid models.U
 	}
 	return withdrawals, nil
 }
+
+func (s *WithdrawalService) GetUserWithdrawalsPage(ctx context.Context, uid models.UserID, page models.Page) ([]*models.Withdrawal, bool, error) {
+	withdrawals, err := s.strg.GetWithdrawalsPageByUserID(ctx, uid, lookahead(page))
+	if err != nil {
+		return nil, false, err
+	}
+	withdrawals, more := trimPage(withdrawals, page)
+	return withdrawals, more, nil
+}
@@ -16,8 +16,7 @@ func TestWithdrawalService_GetUserWithdrawals(t *testing.T) {
 	defer ctrl.Finish()
 
 	mStrg := mocks.NewMockwithdrawalStorager(ctrl)
-	mBalance := mocks.NewMockbalanceServicer(ctrl)
-	s := NewWithdrawalService(mStrg, mBalance)
+	s := NewWithdrawalService(mStrg)
 
 	t.Run("valid test", func(t *testing.T) {
 		testWithdrawals := []*models.Withdrawal{
@@ -52,20 +51,42 @@ func TestWithdrawalService_GetUserWithdrawals(t *testing.T) {
 	})
 }
 
-func TestWithdrawalService_WithdrawalProcessing(t *testing.T) {
+func TestWithdrawalService_GetUserWithdrawalsPage(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mStrg := mocks.NewMockwithdrawalStorager(ctrl)
-	mBalance := mocks.NewMockbalanceServicer(ctrl)
-	s := NewWithdrawalService(mStrg, mBalance)
+	s := NewWithdrawalService(mStrg)
 
-	testBalance := &models.Balance{
-		UserID:    testUserID,
-		Current:   10,
-		Withdrawn: 20,
+	testWithdrawals := []*models.Withdrawal{
+		{ID: 1, Order: "123", UserID: testUserID, Sum: 10},
+		{ID: 2, Order: "456", UserID: testUserID, Sum: 5},
 	}
 
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetWithdrawalsPageByUserID(gomock.Any(), testUserID, models.Page{Limit: 2, Offset: 1}).Return(testWithdrawals, nil)
+
+		withdrawals, more, err := s.GetUserWithdrawalsPage(context.Background(), testUserID, models.Page{Limit: 1, Offset: 1})
+		assert.NoError(t, err)
+		assert.True(t, more)
+		assert.Equal(t, testWithdrawals[:1], withdrawals)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStrg.EXPECT().GetWithdrawalsPageByUserID(gomock.Any(), testUserID, gomock.Any()).Return(nil, errTest)
+
+		_, _, err := s.GetUserWithdrawalsPage(context.Background(), testUserID, models.Page{Limit: 1})
+		assert.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestWithdrawalService_WithdrawalProcessing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStrg := mocks.NewMockwithdrawalStorager(ctrl)
+	s := NewWithdrawalService(mStrg)
+
 	t.Run("valid test", func(t *testing.T) {
 		testWithdrawal := &models.Withdrawal{
 			ID:          1,
@@ -75,7 +96,6 @@ func TestWithdrawalService_WithdrawalProcessing(t *testing.T) {
 			ProcessedAt: time.Now().String(),
 		}
 
-		mBalance.EXPECT().GetUserBalance(context.Background(), testWithdrawal.UserID).Return(testBalance, nil)
 		mStrg.EXPECT().AddWithdrawal(context.Background(), testWithdrawal).Return(nil)
 
 		err := s.WithdrawalProcessing(context.Background(), testWithdrawal)
@@ -95,34 +115,19 @@ func TestWithdrawalService_WithdrawalProcessing(t *testing.T) {
 		assert.ErrorIs(t, err, ErrWrongOrderNum)
 	})
 
-	t.Run("balance error", func(t *testing.T) {
-		testWithdrawal := &models.Withdrawal{
-			ID:          1,
-			Order:       validLuhnString,
-			UserID:      testUserID,
-			Sum:         10,
-			ProcessedAt: time.Now().String(),
-		}
-
-		mBalance.EXPECT().GetUserBalance(context.Background(), testWithdrawal.UserID).Return(nil, errTest)
-
-		err := s.WithdrawalProcessing(context.Background(), testWithdrawal)
-		assert.Error(t, err)
-	})
+	t.Run("non-positive sum", func(t *testing.T) {
+		for _, sum := range []float64{0, -10} {
+			testWithdrawal := &models.Withdrawal{
+				ID:          1,
+				Order:       validLuhnString,
+				UserID:      testUserID,
+				Sum:         sum,
+				ProcessedAt: time.Now().String(),
+			}
 
-	t.Run("not enough currency", func(t *testing.T) {
-		testWithdrawal := &models.Withdrawal{
-			ID:          1,
-			Order:       validLuhnString,
-			UserID:      testUserID,
-			Sum:         20,
-			ProcessedAt: time.Now().String(),
+			err := s.WithdrawalProcessing(context.Background(), testWithdrawal)
+			assert.ErrorIs(t, err, ErrInvalidWithdrawalSum)
 		}
-
-		mBalance.EXPECT().GetUserBalance(context.Background(), testWithdrawal.UserID).Return(testBalance, nil)
-
-		err := s.WithdrawalProcessing(context.Background(), testWithdrawal)
-		assert.ErrorIs(t, err, ErrNotEnoughCurrency)
 	})
 
 	t.Run("add withdrawal error", func(t *testing.T) {
@@ -134,10 +139,9 @@ func TestWithdrawalService_WithdrawalProcessing(t *testing.T) {
 			ProcessedAt: time.Now().String(),
 		}
 
-		mBalance.EXPECT().GetUserBalance(context.Background(), testWithdrawal.UserID).Return(testBalance, nil)
 		mStrg.EXPECT().AddWithdrawal(context.Background(), testWithdrawal).Return(errTest)
 
 		err := s.WithdrawalProcessing(context.Background(), testWithdrawal)
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, errTest)
 	})
 }
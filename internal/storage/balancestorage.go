@@ -2,23 +2,24 @@ package storage
 
 import (
 	"context"
-	"database/sql"
 
 	"github.com/rycln/loyalsys/internal/models"
 )
 
 type BalanceStorage struct {
-	db *sql.DB
+	db     DB
+	tenant models.TenantID
 }
 
-func NewBalanceStorage(db *sql.DB) *BalanceStorage {
+func NewBalanceStorage(db DB, tenant models.TenantID) *BalanceStorage {
 	return &BalanceStorage{
-		db: db,
+		db:     db,
+		tenant: tenant,
 	}
 }
 
 func (s *BalanceStorage) GetBalanceByUserID(ctx context.Context, uid models.UserID) (*models.Balance, error) {
-	row := s.db.QueryRowContext(ctx, sqlGetBalanceByUserID, uid)
+	row := s.db.QueryRow(ctx, sqlGetBalanceByUserID, uid, s.tenant)
 	var totalAccrual, totalWithdrawn float64
 	err := row.Scan(&totalAccrual, &totalWithdrawn)
 	if err != nil {
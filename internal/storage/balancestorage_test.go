@@ -16,7 +16,7 @@ func TestBalanceStorage_GetBalanceByUserID(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewBalanceStorage(db)
+	strg := NewBalanceStorage(newMockDB(db), testTenant)
 
 	testBalance := &models.Balance{
 		UserID:    testUserID,
@@ -28,7 +28,7 @@ func TestBalanceStorage_GetBalanceByUserID(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"accrual", "withdrawn"}).AddRow(testBalance.Current+testBalance.Withdrawn, testBalance.Withdrawn)
-		mock.ExpectQuery(expectedQuery).WithArgs(testBalance.UserID).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testBalance.UserID, testTenant).WillReturnRows(rows)
 
 		balance, err := strg.GetBalanceByUserID(context.Background(), testUserID)
 		assert.NoError(t, err)
@@ -37,7 +37,7 @@ func TestBalanceStorage_GetBalanceByUserID(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testBalance.UserID).WillReturnError(errTest)
+		mock.ExpectQuery(expectedQuery).WithArgs(testBalance.UserID, testTenant).WillReturnError(errTest)
 
 		_, err := strg.GetBalanceByUserID(context.Background(), testUserID)
 		assert.Error(t, err)
@@ -1,15 +1,139 @@
 package storage
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/rycln/loyalsys/internal/models"
 )
 
 const (
-	testUserID = models.UserID(1)
+	testUserID       = models.UserID(1)
+	testOtherUserID  = models.UserID(2)
+	testReferralCode = "ABCDEFGHIJ"
+	testTenant       = models.TenantID("brand")
+	testOtherTenant  = models.TenantID("other_brand")
 )
 
 var (
 	errTest = errors.New("test error")
 )
+
+// mockDB runs the queries of a storage on a go-sqlmock database, so the
+// tests can set expectations on the statements a storage runs on the pool.
+type mockDB struct {
+	mockConn
+	db *sql.DB
+}
+
+func newMockDB(db *sql.DB) *mockDB {
+	return &mockDB{mockConn: mockConn{q: db}, db: db}
+}
+
+func (m *mockDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return m.BeginTx(ctx, pgx.TxOptions{})
+}
+
+// BeginTx ignores the options, as sqlmock can't expect them.
+func (m *mockDB) BeginTx(ctx context.Context, _ pgx.TxOptions) (pgx.Tx, error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &mockTx{mockConn: mockConn{q: tx}, tx: tx}, nil
+}
+
+type sqlQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// mockConn holds the query methods shared by mockDB and mockTx.
+type mockConn struct {
+	q sqlQuerier
+}
+
+func (c mockConn) Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
+	res, err := c.q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", n)), nil
+}
+
+func (c mockConn) Query(ctx context.Context, query string, args ...any) (pgx.Rows, error) {
+	rows, err := c.q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mockRows{rows: rows}, nil
+}
+
+func (c mockConn) QueryRow(ctx context.Context, query string, args ...any) pgx.Row {
+	return mockRow{row: c.q.QueryRowContext(ctx, query, args...)}
+}
+
+type mockRow struct {
+	row *sql.Row
+}
+
+// Scan reports a missing row the way pgx does.
+func (r mockRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return pgx.ErrNoRows
+	}
+	return err
+}
+
+// mockRows implements the part of pgx.Rows the storages use; the rest of
+// the interface is left nil.
+type mockRows struct {
+	pgx.Rows
+	rows *sql.Rows
+}
+
+func (r *mockRows) Next() bool {
+	return r.rows.Next()
+}
+
+func (r *mockRows) Scan(dest ...any) error {
+	return r.rows.Scan(dest...)
+}
+
+func (r *mockRows) Err() error {
+	return r.rows.Err()
+}
+
+func (r *mockRows) Close() {
+	r.rows.Close()
+}
+
+// unusedTx leaves the methods of pgx.Tx that mockTx doesn't implement nil
+// one level deeper than mockConn, so mockConn's query methods win.
+type unusedTx struct {
+	pgx.Tx
+}
+
+type mockTx struct {
+	mockConn
+	unusedTx
+	tx *sql.Tx
+}
+
+func (tx *mockTx) Commit(context.Context) error {
+	return tx.tx.Commit()
+}
+
+func (tx *mockTx) Rollback(context.Context) error {
+	return tx.tx.Rollback()
+}
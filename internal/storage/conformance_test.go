@@ -0,0 +1,51 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/rycln/loyalsys/internal/migrator"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+	"github.com/rycln/loyalsys/internal/storage/storagetest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance runs the storage conformance suite against Postgres. It
+// runs only when TEST_DATABASE_URI is set.
+func TestConformance(t *testing.T) {
+	databaseURI := os.Getenv("TEST_DATABASE_URI")
+	if databaseURI == "" {
+		t.Skip("TEST_DATABASE_URI is not set")
+	}
+	ctx := context.Background()
+	pool, err := storage.NewPool(ctx, storage.NewDSN(databaseURI), storage.PoolConfig{
+		MaxConns:        4,
+		MaxConnIdleTime: time.Minute,
+		MaxConnLifetime: time.Hour,
+	})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	// goose needs database/sql, so only the migrator goes through stdlib.
+	db := stdlib.OpenDBFromPool(pool)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := migrator.New(db)
+	require.NoError(t, err)
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+
+	storagetest.Run(t, func(_ *testing.T, tenant models.TenantID) storagetest.Repositories {
+		return storagetest.Repositories{
+			Users:       storage.NewUserStorage(pool, tenant),
+			Orders:      storage.NewOrderSyncStorage(storage.NewOrderStorage(pool, tenant)),
+			Withdrawals: storage.NewWithdrawalStorage(pool, tenant),
+			Balance:     storage.NewBalanceStorage(pool, tenant),
+			Transfers:   storage.NewTransferStorage(pool, tenant),
+			TwoFactor:   storage.NewTwoFactorStorage(pool, tenant),
+		}
+	})
+}
@@ -1,28 +1,98 @@
 package storage
 
 import (
-	"database/sql"
+	"context"
+	"fmt"
+	"sync/atomic"
 	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rycln/loyalsys/internal/redact"
 )
 
-const (
-	maxOpenConns    = 0 //unlimited
-	maxIdleConns    = 10
-	maxIdleTime     = time.Duration(3) * time.Minute
-	maxConnLifetime = 0 //unlimited
-)
+// PoolConfig limits the database connection pool.
+type PoolConfig struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnIdleTime time.Duration
+	MaxConnLifetime time.Duration
+}
+
+// DSN holds the database URI, which can be replaced at runtime. String
+// masks the password, so a DSN is safe to log.
+type DSN struct {
+	uri atomic.Pointer[string]
+}
+
+func NewDSN(uri string) *DSN {
+	dsn := &DSN{}
+	dsn.Set(uri)
+	return dsn
+}
+
+func (dsn *DSN) Set(uri string) {
+	dsn.uri.Store(&uri)
+}
+
+func (dsn *DSN) URI() string {
+	return *dsn.uri.Load()
+}
+
+func (dsn *DSN) String() string {
+	return redact.DSN(dsn.URI())
+}
 
-func NewDB(uri string) (*sql.DB, error) {
-	database, err := sql.Open("pgx", uri)
+// NewPool creates the database connection pool shared by all storages, so
+// the configured limits cap every connection the service opens.
+// Connections are opened lazily and read the DSN when dialing, so a
+// reloaded DSN applies to new connections.
+func NewPool(ctx context.Context, dsn *DSN, cfg PoolConfig) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn.URI())
 	if err != nil {
 		return nil, err
 	}
-	database.SetMaxOpenConns(maxOpenConns)
-	database.SetMaxIdleConns(maxIdleConns)
-	database.SetConnMaxIdleTime(maxIdleTime)
-	database.SetConnMaxLifetime(maxConnLifetime)
+	poolCfg.MaxConns = cfg.MaxConns
+	poolCfg.MinConns = cfg.MinConns
+	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
+	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	poolCfg.BeforeConnect = func(_ context.Context, cc *pgx.ConnConfig) error {
+		next, err := pgxpool.ParseConfig(dsn.URI())
+		if err != nil {
+			return err
+		}
+		*cc = *next.ConnConfig
+		return nil
+	}
+	return pgxpool.NewWithConfig(ctx, poolCfg)
+}
 
-	return database, nil
+// DB is the part of pgxpool.Pool the storages run their queries on.
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+// timestamp scans a timestamptz into the string the API returns, RFC 3339
+// with nanoseconds, and NULL into an empty string.
+type timestamp string
+
+func (ts *timestamp) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*ts = ""
+	case time.Time:
+		*ts = timestamp(v.Format(time.RFC3339Nano))
+	case string:
+		*ts = timestamp(v)
+	case []byte:
+		*ts = timestamp(v)
+	default:
+		return fmt.Errorf("can't scan %T into a timestamp", src)
+	}
+	return nil
 }
@@ -0,0 +1,29 @@
+package storage
+
+import "errors"
+
+var (
+	ErrIdempotencyConflict = errors.New("idempotency key reused with a different request")
+)
+
+type errIdempotencyConflict struct {
+	err error
+}
+
+func (err *errIdempotencyConflict) Error() string {
+	return err.err.Error()
+}
+
+func (err *errIdempotencyConflict) Unwrap() error {
+	return err.err
+}
+
+func (err *errIdempotencyConflict) IsErrIdempotencyConflict() bool {
+	return true
+}
+
+func newErrIdempotencyConflict(err error) error {
+	return &errIdempotencyConflict{
+		err: err,
+	}
+}
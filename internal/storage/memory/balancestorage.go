@@ -0,0 +1,27 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type BalanceStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewBalanceStorage(db *DB, tenant models.TenantID) *BalanceStorage {
+	return &BalanceStorage{db: db, tenant: tenant}
+}
+
+func (s *BalanceStorage) GetBalanceByUserID(_ context.Context, uid models.UserID) (*models.Balance, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	accrual, withdrawn := s.db.balance(s.tenant, uid)
+	return &models.Balance{
+		UserID:    uid,
+		Current:   accrual - withdrawn,
+		Withdrawn: withdrawn,
+	}, nil
+}
@@ -0,0 +1,23 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage/memory"
+	"github.com/rycln/loyalsys/internal/storage/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	db := memory.NewDB()
+	storagetest.Run(t, func(_ *testing.T, tenant models.TenantID) storagetest.Repositories {
+		return storagetest.Repositories{
+			Users:       memory.NewUserStorage(db, tenant),
+			Orders:      memory.NewOrderStorage(db, tenant),
+			Withdrawals: memory.NewWithdrawalStorage(db, tenant),
+			Balance:     memory.NewBalanceStorage(db, tenant),
+			Transfers:   memory.NewTransferStorage(db, tenant),
+			TwoFactor:   memory.NewTwoFactorStorage(db, tenant),
+		}
+	})
+}
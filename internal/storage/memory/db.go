@@ -0,0 +1,200 @@
+// Package memory is a storage backend that keeps everything in process
+// memory. It has the semantics of the Postgres backend, including unique
+// constraints and ordering, and is meant for local runs, demos and tests.
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+// ErrUniqueViolation is returned where Postgres would report a unique
+// constraint violation that the storage doesn't translate.
+var ErrUniqueViolation = errors.New("unique constraint violation")
+
+type user struct {
+	id                 models.UserID
+	tenant             models.TenantID
+	login              string
+	passwordHash       string
+	referralCode       string
+	sessionsValidAfter time.Time
+}
+
+type order struct {
+	id            int64
+	tenant        models.TenantID
+	number        string
+	userID        models.UserID
+	status        string
+	accrual       float64
+	createdAt     time.Time
+	processedAt   time.Time
+	lastCheckedAt time.Time
+	checkCount    int
+	history       []*models.OrderStatusChange
+}
+
+type withdrawal struct {
+	id             int64
+	tenant         models.TenantID
+	number         string
+	userID         models.UserID
+	sum            float64
+	idempotencyKey string
+	processedAt    time.Time
+}
+
+type referral struct {
+	referrerID    models.UserID
+	refereeID     models.UserID
+	referrerBonus float64
+	refereeBonus  float64
+	createdAt     time.Time
+	rewardedAt    time.Time
+}
+
+type transfer struct {
+	id             int64
+	senderID       models.UserID
+	recipientID    models.UserID
+	sum            float64
+	idempotencyKey string
+	createdAt      time.Time
+}
+
+type resetToken struct {
+	userID    models.UserID
+	expiresAt time.Time
+}
+
+type totp struct {
+	secret        string
+	enabled       bool
+	lastUsedStep  int64
+	recoveryCodes map[string]bool
+}
+
+// DB holds the data of all tenants. Every storage operation runs under its
+// lock, which gives the isolation of the Postgres transactions it replaces.
+type DB struct {
+	mu          sync.Mutex
+	seq         int64
+	users       []*user
+	orders      []*order
+	withdrawals []*withdrawal
+	referrals   []*referral
+	transfers   []*transfer
+	resetTokens map[string]*resetToken
+	totp        map[models.UserID]*totp
+	now         func() time.Time
+}
+
+func NewDB() *DB {
+	return &DB{
+		resetTokens: make(map[string]*resetToken),
+		totp:        make(map[models.UserID]*totp),
+		now:         time.Now,
+	}
+}
+
+// SchemaVersion reports the version of the schema, which the memory
+// backend doesn't have.
+func (db *DB) SchemaVersion(context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (db *DB) nextID() int64 {
+	db.seq++
+	return db.seq
+}
+
+func (db *DB) userByID(tenant models.TenantID, uid models.UserID) *user {
+	for _, u := range db.users {
+		if u.id == uid && u.tenant == tenant {
+			return u
+		}
+	}
+	return nil
+}
+
+func (db *DB) userByLogin(tenant models.TenantID, login string) *user {
+	for _, u := range db.users {
+		if u.login == login && u.tenant == tenant {
+			return u
+		}
+	}
+	return nil
+}
+
+func (db *DB) orderByNum(tenant models.TenantID, number string) *order {
+	for _, o := range db.orders {
+		if o.number == number && o.tenant == tenant {
+			return o
+		}
+	}
+	return nil
+}
+
+// balance sums what the user has earned and withdrawn, like the balance
+// query of the Postgres backend.
+func (db *DB) balance(tenant models.TenantID, uid models.UserID) (accrual, withdrawn float64) {
+	for _, o := range db.orders {
+		if o.userID == uid && o.tenant == tenant {
+			accrual += o.accrual
+		}
+	}
+	for _, r := range db.referrals {
+		if r.rewardedAt.IsZero() {
+			continue
+		}
+		if r.referrerID == uid {
+			accrual += r.referrerBonus
+		}
+		if r.refereeID == uid {
+			accrual += r.refereeBonus
+		}
+	}
+	for _, t := range db.transfers {
+		if t.recipientID == uid {
+			accrual += t.sum
+		}
+		if t.senderID == uid {
+			accrual -= t.sum
+		}
+	}
+	for _, w := range db.withdrawals {
+		if w.userID == uid && w.tenant == tenant {
+			withdrawn += w.sum
+		}
+	}
+	return accrual, withdrawn
+}
+
+func isConclusive(status string) bool {
+	return status == models.StatusInvalid || status == models.StatusProcessed
+}
+
+// formatTime renders timestamps the way the Postgres storages scan a
+// timestamptz into a string.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func paginate[T any](rows []T, page models.Page) []T {
+	if page.Offset >= len(rows) {
+		return make([]T, 0)
+	}
+	rows = rows[page.Offset:]
+	if len(rows) > page.Limit {
+		rows = rows[:page.Limit]
+	}
+	return rows
+}
@@ -0,0 +1,268 @@
+package memory
+
+// The error types mirror those of the Postgres storage, so callers can't
+// tell the backends apart. They wrap the sentinel errors of package storage.
+
+type errLoginConflict struct {
+	err error
+}
+
+func (err *errLoginConflict) Error() string {
+	return err.err.Error()
+}
+
+func (err *errLoginConflict) Unwrap() error {
+	return err.err
+}
+
+func (err *errLoginConflict) IsErrLoginConflict() bool {
+	return true
+}
+
+func newErrLoginConflict(err error) error {
+	return &errLoginConflict{
+		err: err,
+	}
+}
+
+type errReferralCodeConflict struct {
+	err error
+}
+
+func (err *errReferralCodeConflict) Error() string {
+	return err.err.Error()
+}
+
+func (err *errReferralCodeConflict) Unwrap() error {
+	return err.err
+}
+
+func (err *errReferralCodeConflict) IsErrReferralCodeConflict() bool {
+	return true
+}
+
+func newErrReferralCodeConflict(err error) error {
+	return &errReferralCodeConflict{
+		err: err,
+	}
+}
+
+type errNoUser struct {
+	err error
+}
+
+func (err *errNoUser) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoUser) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoUser) IsErrNoUser() bool {
+	return true
+}
+
+func newErrNoUser(err error) error {
+	return &errNoUser{
+		err: err,
+	}
+}
+
+type errInvalidResetToken struct {
+	err error
+}
+
+func (err *errInvalidResetToken) Error() string {
+	return err.err.Error()
+}
+
+func (err *errInvalidResetToken) Unwrap() error {
+	return err.err
+}
+
+func (err *errInvalidResetToken) IsErrInvalidResetToken() bool {
+	return true
+}
+
+func newErrInvalidResetToken(err error) error {
+	return &errInvalidResetToken{
+		err: err,
+	}
+}
+
+type errNoOrder struct {
+	err error
+}
+
+func (err *errNoOrder) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoOrder) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoOrder) IsErrNoOrder() bool {
+	return true
+}
+
+func newErrNoOrder(err error) error {
+	return &errNoOrder{
+		err: err,
+	}
+}
+
+type errNoWithdrawal struct {
+	err error
+}
+
+func (err *errNoWithdrawal) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoWithdrawal) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoWithdrawal) IsErrNoWithdrawal() bool {
+	return true
+}
+
+func newErrNoWithdrawal(err error) error {
+	return &errNoWithdrawal{
+		err: err,
+	}
+}
+
+type errNotEnoughCurrency struct {
+	err error
+}
+
+func (err *errNotEnoughCurrency) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNotEnoughCurrency) Unwrap() error {
+	return err.err
+}
+
+func (err *errNotEnoughCurrency) IsErrNotEnoughCurrency() bool {
+	return true
+}
+
+func newErrNotEnoughCurrency(err error) error {
+	return &errNotEnoughCurrency{
+		err: err,
+	}
+}
+
+type errTransferLimitExceeded struct {
+	err error
+}
+
+func (err *errTransferLimitExceeded) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTransferLimitExceeded) Unwrap() error {
+	return err.err
+}
+
+func (err *errTransferLimitExceeded) IsErrTransferLimitExceeded() bool {
+	return true
+}
+
+func newErrTransferLimitExceeded(err error) error {
+	return &errTransferLimitExceeded{
+		err: err,
+	}
+}
+
+type errNoTransfer struct {
+	err error
+}
+
+func (err *errNoTransfer) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoTransfer) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoTransfer) IsErrNoTransfer() bool {
+	return true
+}
+
+func newErrNoTransfer(err error) error {
+	return &errNoTransfer{
+		err: err,
+	}
+}
+
+type errNoTOTP struct {
+	err error
+}
+
+func (err *errNoTOTP) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoTOTP) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoTOTP) IsErrNoTOTP() bool {
+	return true
+}
+
+func newErrNoTOTP(err error) error {
+	return &errNoTOTP{
+		err: err,
+	}
+}
+
+type errTOTPEnabled struct {
+	err error
+}
+
+func (err *errTOTPEnabled) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTOTPEnabled) Unwrap() error {
+	return err.err
+}
+
+func (err *errTOTPEnabled) IsErrTOTPEnabled() bool {
+	return true
+}
+
+func newErrTOTPEnabled(err error) error {
+	return &errTOTPEnabled{
+		err: err,
+	}
+}
+
+type errIdempotencyConflict struct {
+	err error
+}
+
+func (err *errIdempotencyConflict) Error() string {
+	return err.err.Error()
+}
+
+func (err *errIdempotencyConflict) Unwrap() error {
+	return err.err
+}
+
+func (err *errIdempotencyConflict) IsErrIdempotencyConflict() bool {
+	return true
+}
+
+func newErrIdempotencyConflict(err error) error {
+	return &errIdempotencyConflict{
+		err: err,
+	}
+}
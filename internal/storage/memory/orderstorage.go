@@ -0,0 +1,261 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+)
+
+type OrderStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewOrderStorage(db *DB, tenant models.TenantID) *OrderStorage {
+	return &OrderStorage{db: db, tenant: tenant}
+}
+
+func (s *OrderStorage) AddOrder(_ context.Context, order *models.Order) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.orderByNum(s.tenant, order.Number) != nil {
+		return ErrUniqueViolation
+	}
+	s.addOrder(order.Number, order.UserID)
+	return nil
+}
+
+func (s *OrderStorage) addOrder(number string, uid models.UserID) {
+	now := s.db.now()
+	s.db.orders = append(s.db.orders, &order{
+		id:        s.db.nextID(),
+		tenant:    s.tenant,
+		number:    number,
+		userID:    uid,
+		status:    models.StatusNew,
+		createdAt: now,
+		history: []*models.OrderStatusChange{{
+			Status:    models.StatusNew,
+			ChangedAt: formatTime(now),
+		}},
+	})
+}
+
+func (s *OrderStorage) AddOrdersBatch(_ context.Context, uid models.UserID, nums []string, limit int) (map[string]models.UserID, []string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	capacity := len(nums)
+	if limit > 0 {
+		capacity = max(limit-s.countPending(uid), 0)
+	}
+	existing := make(map[string]models.UserID)
+	inserted := make(map[string]bool, len(nums))
+	var over []string
+	for _, num := range nums {
+		if inserted[num] {
+			continue
+		}
+		if o := s.db.orderByNum(s.tenant, num); o != nil {
+			existing[num] = o.userID
+			continue
+		}
+		if capacity == 0 {
+			over = append(over, num)
+			inserted[num] = true
+			continue
+		}
+		s.addOrder(num, uid)
+		inserted[num] = true
+		capacity--
+	}
+	return existing, over, nil
+}
+
+func (s *OrderStorage) GetOrderByNum(_ context.Context, number string) (*models.OrderDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	o := s.db.orderByNum(s.tenant, number)
+	if o == nil {
+		return nil, newErrNoOrder(storage.ErrNoOrder)
+	}
+	return o.orderDB(), nil
+}
+
+func (s *OrderStorage) GetOrderDetailByNum(_ context.Context, number string) (*models.OrderDetail, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	o := s.db.orderByNum(s.tenant, number)
+	if o == nil {
+		return nil, newErrNoOrder(storage.ErrNoOrder)
+	}
+	history := make([]*models.OrderStatusChange, len(o.history))
+	for i, change := range o.history {
+		c := *change
+		history[i] = &c
+	}
+	return &models.OrderDetail{
+		ID:            o.id,
+		Number:        o.number,
+		UserID:        o.userID,
+		Status:        o.status,
+		Accrual:       o.accrual,
+		CreatedAt:     formatTime(o.createdAt),
+		ProcessedAt:   formatTime(o.processedAt),
+		LastCheckedAt: formatTime(o.lastCheckedAt),
+		CheckCount:    o.checkCount,
+		History:       history,
+	}, nil
+}
+
+func (s *OrderStorage) GetOrdersByUserID(_ context.Context, uid models.UserID) ([]*models.OrderDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	orders := s.userOrders(uid)
+	if len(orders) == 0 {
+		return nil, newErrNoOrder(storage.ErrNoOrder)
+	}
+	return orders, nil
+}
+
+func (s *OrderStorage) GetOrdersPageByUserID(_ context.Context, uid models.UserID, page models.Page) ([]*models.OrderDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return paginate(s.userOrders(uid), page), nil
+}
+
+// userOrders lists the orders of the user, newest first.
+func (s *OrderStorage) userOrders(uid models.UserID) []*models.OrderDB {
+	var owned []*order
+	for _, o := range s.db.orders {
+		if o.userID == uid && o.tenant == s.tenant {
+			owned = append(owned, o)
+		}
+	}
+	slices.SortStableFunc(owned, func(a, b *order) int {
+		if c := b.createdAt.Compare(a.createdAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.id, a.id)
+	})
+	orders := make([]*models.OrderDB, len(owned))
+	for i, o := range owned {
+		orders[i] = &models.OrderDB{
+			Number:    o.number,
+			Status:    o.status,
+			Accrual:   o.accrual,
+			CreatedAt: formatTime(o.createdAt),
+		}
+	}
+	return orders
+}
+
+func (s *OrderStorage) CountPendingOrders(_ context.Context, uid models.UserID) (int, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return s.countPending(uid), nil
+}
+
+func (s *OrderStorage) countPending(uid models.UserID) int {
+	var count int
+	for _, o := range s.db.orders {
+		if o.userID == uid && o.tenant == s.tenant && !isConclusive(o.status) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *OrderStorage) GetInconclusiveOrderNums(context.Context) ([]string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var nums []string
+	for _, o := range s.db.orders {
+		if o.tenant == s.tenant && !isConclusive(o.status) {
+			nums = append(nums, o.number)
+		}
+	}
+	return nums, nil
+}
+
+func (s *OrderStorage) GetStaleOrderNums(_ context.Context, before time.Time) ([]string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var nums []string
+	for _, o := range s.db.orders {
+		if o.tenant != s.tenant || isConclusive(o.status) {
+			continue
+		}
+		checkedAt := o.lastCheckedAt
+		if checkedAt.IsZero() {
+			checkedAt = o.createdAt
+		}
+		if checkedAt.Before(before) {
+			nums = append(nums, o.number)
+		}
+	}
+	return nums, nil
+}
+
+// UpdateOrdersBatch stores accrual results, the last one of every order
+// like the Postgres backend does. Orders that are already invalid or
+// processed are left as they are. A status change is added to the order
+// history, and a processed order rewards the referral of its owner.
+func (s *OrderStorage) UpdateOrdersBatch(_ context.Context, orders []*models.OrderDB) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	last := make(map[string]*models.OrderDB, len(orders))
+	for _, update := range orders {
+		last[update.Number] = update
+	}
+	now := s.db.now()
+	for _, update := range orders {
+		if last[update.Number] != update {
+			continue
+		}
+		o := s.db.orderByNum(s.tenant, update.Number)
+		if o == nil || isConclusive(o.status) {
+			continue
+		}
+		if o.status != update.Status {
+			o.history = append(o.history, &models.OrderStatusChange{
+				Status:    update.Status,
+				Accrual:   update.Accrual,
+				ChangedAt: formatTime(now),
+			})
+		}
+		o.status = update.Status
+		o.accrual = update.Accrual
+		o.lastCheckedAt = now
+		o.checkCount++
+		if isConclusive(o.status) && o.processedAt.IsZero() {
+			o.processedAt = now
+		}
+		if o.status == models.StatusProcessed {
+			s.rewardReferral(o.userID, now)
+		}
+	}
+	return nil
+}
+
+func (s *OrderStorage) rewardReferral(referee models.UserID, now time.Time) {
+	for _, r := range s.db.referrals {
+		if r.refereeID == referee && r.rewardedAt.IsZero() {
+			r.rewardedAt = now
+		}
+	}
+}
+
+func (o *order) orderDB() *models.OrderDB {
+	return &models.OrderDB{
+		ID:        o.id,
+		Number:    o.number,
+		UserID:    o.userID,
+		Status:    o.status,
+		Accrual:   o.accrual,
+		CreatedAt: formatTime(o.createdAt),
+	}
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+)
+
+type ReferralStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewReferralStorage(db *DB, tenant models.TenantID) *ReferralStorage {
+	return &ReferralStorage{db: db, tenant: tenant}
+}
+
+func (s *ReferralStorage) GetReferralCodeByUserID(_ context.Context, uid models.UserID) (string, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	u := s.db.userByID(s.tenant, uid)
+	if u == nil {
+		return "", newErrNoUser(storage.ErrNoUser)
+	}
+	return u.referralCode, nil
+}
+
+// GetInviteesByReferrerID lists the users the referrer invited, the latest
+// first.
+func (s *ReferralStorage) GetInviteesByReferrerID(_ context.Context, uid models.UserID) ([]*models.Invitee, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	var referrals []*referral
+	for _, r := range s.db.referrals {
+		if r.referrerID == uid && s.db.userByID(s.tenant, r.refereeID) != nil {
+			referrals = append(referrals, r)
+		}
+	}
+	slices.SortStableFunc(referrals, func(a, b *referral) int {
+		return b.createdAt.Compare(a.createdAt)
+	})
+	var invitees []*models.Invitee
+	for _, r := range referrals {
+		invitees = append(invitees, &models.Invitee{
+			Login:        s.db.userByID(s.tenant, r.refereeID).login,
+			Bonus:        r.referrerBonus,
+			Rewarded:     !r.rewardedAt.IsZero(),
+			RegisteredAt: formatTime(r.createdAt),
+		})
+	}
+	return invitees, nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import "github.com/rycln/loyalsys/internal/storage"
+
+var (
+	_ storage.UserRepository       = (*UserStorage)(nil)
+	_ storage.OrderSyncRepository  = (*OrderStorage)(nil)
+	_ storage.WithdrawalRepository = (*WithdrawalStorage)(nil)
+	_ storage.BalanceRepository    = (*BalanceStorage)(nil)
+	_ storage.ReferralRepository   = (*ReferralStorage)(nil)
+	_ storage.TransferRepository   = (*TransferStorage)(nil)
+	_ storage.StatementRepository  = (*StatementStorage)(nil)
+	_ storage.TwoFactorRepository  = (*TwoFactorStorage)(nil)
+)
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type StatementStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewStatementStorage(db *DB, tenant models.TenantID) *StatementStorage {
+	return &StatementStorage{db: db, tenant: tenant}
+}
+
+type ledgerEntry struct {
+	date      time.Time
+	typ       string
+	reference string
+	amount    float64
+}
+
+// StreamStatement reads the opening balance and the entries from one copy
+// of the ledger, like the Postgres backend reads them in one transaction.
+func (s *StatementStorage) StreamStatement(ctx context.Context, uid models.UserID, from, to time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) error {
+	s.db.mu.Lock()
+	ledger := s.ledger(uid)
+	s.db.mu.Unlock()
+	var balance float64
+	for _, e := range ledger {
+		if e.date.Before(from) {
+			balance += e.amount
+		}
+	}
+	if err := opening(balance); err != nil {
+		return err
+	}
+	slices.SortStableFunc(ledger, func(a, b *ledgerEntry) int {
+		if c := a.date.Compare(b.date); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.typ, b.typ); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.reference, b.reference)
+	})
+	var entry models.StatementEntry
+	for _, e := range ledger {
+		if e.date.Before(from) || !e.date.Before(to) {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		entry = models.StatementEntry{
+			Date:      formatTime(e.date),
+			Type:      e.typ,
+			Reference: e.reference,
+			Amount:    e.amount,
+		}
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ledger collects every balance change of the user, like the ledger query
+// of the Postgres backend.
+func (s *StatementStorage) ledger(uid models.UserID) []*ledgerEntry {
+	var ledger []*ledgerEntry
+	for _, o := range s.db.orders {
+		if o.userID != uid || o.tenant != s.tenant || o.accrual <= 0 {
+			continue
+		}
+		date := o.processedAt
+		if date.IsZero() {
+			date = o.createdAt
+		}
+		ledger = append(ledger, &ledgerEntry{date, models.EntryAccrual, o.number, o.accrual})
+	}
+	for _, w := range s.db.withdrawals {
+		if w.userID == uid && w.tenant == s.tenant {
+			ledger = append(ledger, &ledgerEntry{w.processedAt, models.EntryWithdrawal, w.number, -w.sum})
+		}
+	}
+	for _, r := range s.db.referrals {
+		if r.rewardedAt.IsZero() {
+			continue
+		}
+		if u := s.db.userByID(s.tenant, r.refereeID); r.referrerID == uid && u != nil {
+			ledger = append(ledger, &ledgerEntry{r.rewardedAt, models.EntryReferralBonus, u.login, r.referrerBonus})
+		}
+		if u := s.db.userByID(s.tenant, r.referrerID); r.refereeID == uid && u != nil {
+			ledger = append(ledger, &ledgerEntry{r.rewardedAt, models.EntryReferralBonus, u.login, r.refereeBonus})
+		}
+	}
+	for _, t := range s.db.transfers {
+		if u := s.db.userByID(s.tenant, t.senderID); t.recipientID == uid && u != nil {
+			ledger = append(ledger, &ledgerEntry{t.createdAt, models.EntryTransferIn, u.login, t.sum})
+		}
+		if u := s.db.userByID(s.tenant, t.recipientID); t.senderID == uid && u != nil {
+			ledger = append(ledger, &ledgerEntry{t.createdAt, models.EntryTransferOut, u.login, -t.sum})
+		}
+	}
+	return ledger
+}
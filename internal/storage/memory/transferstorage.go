@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+)
+
+type TransferStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewTransferStorage(db *DB, tenant models.TenantID) *TransferStorage {
+	return &TransferStorage{db: db, tenant: tenant}
+}
+
+// AddTransfer moves points between users unless the sender already made a
+// transfer with the same idempotency key. A replay that doesn't match the
+// stored transfer is a conflict. A positive dailyLimit caps what
+// the sender can transfer since midnight.
+func (s *TransferStorage) AddTransfer(_ context.Context, t *models.Transfer, dailyLimit float64) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.userByID(s.tenant, t.SenderID) == nil {
+		return newErrNoUser(storage.ErrNoUser)
+	}
+	for _, existing := range s.db.transfers {
+		if existing.senderID == t.SenderID && existing.idempotencyKey == t.IdempotencyKey {
+			if existing.recipientID != t.RecipientID || existing.sum != t.Sum {
+				return newErrIdempotencyConflict(storage.ErrIdempotencyConflict)
+			}
+			return nil
+		}
+	}
+	accrual, withdrawn := s.db.balance(s.tenant, t.SenderID)
+	if accrual-withdrawn < t.Sum {
+		return newErrNotEnoughCurrency(storage.ErrNotEnoughCurrency)
+	}
+	now := s.db.now()
+	if dailyLimit > 0 {
+		year, month, day := now.Date()
+		midnight := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+		var dailySum float64
+		for _, existing := range s.db.transfers {
+			if existing.senderID == t.SenderID && !existing.createdAt.Before(midnight) {
+				dailySum += existing.sum
+			}
+		}
+		if dailySum+t.Sum > dailyLimit {
+			return newErrTransferLimitExceeded(storage.ErrTransferLimitExceeded)
+		}
+	}
+	if s.db.userByID(s.tenant, t.RecipientID) == nil {
+		return newErrNoUser(storage.ErrNoUser)
+	}
+	s.db.transfers = append(s.db.transfers, &transfer{
+		id:             s.db.nextID(),
+		senderID:       t.SenderID,
+		recipientID:    t.RecipientID,
+		sum:            t.Sum,
+		idempotencyKey: t.IdempotencyKey,
+		createdAt:      now,
+	})
+	return nil
+}
+
+func (s *TransferStorage) GetTransfersByUserID(_ context.Context, uid models.UserID) ([]*models.TransferRecord, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	transfers := s.userTransfers(uid)
+	if len(transfers) == 0 {
+		return nil, newErrNoTransfer(storage.ErrNoTransfer)
+	}
+	return transfers, nil
+}
+
+func (s *TransferStorage) GetTransfersPageByUserID(_ context.Context, uid models.UserID, page models.Page) ([]*models.TransferRecord, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return paginate(s.userTransfers(uid), page), nil
+}
+
+// userTransfers lists the transfers the user sent or received, newest
+// first.
+func (s *TransferStorage) userTransfers(uid models.UserID) []*models.TransferRecord {
+	var own []*transfer
+	for _, t := range s.db.transfers {
+		if t.senderID == uid || t.recipientID == uid {
+			own = append(own, t)
+		}
+	}
+	slices.SortStableFunc(own, func(a, b *transfer) int {
+		if c := b.createdAt.Compare(a.createdAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.id, a.id)
+	})
+	transfers := make([]*models.TransferRecord, 0, len(own))
+	for _, t := range own {
+		direction, counterparty := models.TransferIn, t.senderID
+		if t.senderID == uid {
+			direction, counterparty = models.TransferOut, t.recipientID
+		}
+		u := s.db.userByID(s.tenant, counterparty)
+		if u == nil {
+			continue
+		}
+		transfers = append(transfers, &models.TransferRecord{
+			Direction:    direction,
+			Counterparty: u.login,
+			Sum:          t.sum,
+			ProcessedAt:  formatTime(t.createdAt),
+		})
+	}
+	return transfers
+}
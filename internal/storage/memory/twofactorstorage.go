@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+)
+
+type TwoFactorStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewTwoFactorStorage(db *DB, tenant models.TenantID) *TwoFactorStorage {
+	return &TwoFactorStorage{db: db, tenant: tenant}
+}
+
+// userTOTP returns the TOTP settings of a user of the tenant.
+func (s *TwoFactorStorage) userTOTP(uid models.UserID) (*totp, bool) {
+	if s.db.userByID(s.tenant, uid) == nil {
+		return nil, false
+	}
+	t, ok := s.db.totp[uid]
+	return t, ok
+}
+
+func (s *TwoFactorStorage) GetTOTP(_ context.Context, uid models.UserID) (*models.TOTPDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	t, ok := s.userTOTP(uid)
+	if !ok {
+		return nil, newErrNoTOTP(storage.ErrNoTOTP)
+	}
+	return &models.TOTPDB{UserID: uid, Secret: t.secret, Enabled: t.enabled}, nil
+}
+
+// SaveTOTPSecret stores a new secret unless two-factor authentication is
+// already enabled.
+func (s *TwoFactorStorage) SaveTOTPSecret(_ context.Context, uid models.UserID, secret string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.userByID(s.tenant, uid) == nil {
+		return newErrNoUser(storage.ErrNoUser)
+	}
+	if t, ok := s.db.totp[uid]; ok && t.enabled {
+		return newErrTOTPEnabled(storage.ErrTOTPEnabled)
+	}
+	s.db.totp[uid] = &totp{secret: secret}
+	return nil
+}
+
+func (s *TwoFactorStorage) EnableTOTP(_ context.Context, uid models.UserID, step int64, codeHashes []string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	t, ok := s.userTOTP(uid)
+	if !ok || t.enabled {
+		return newErrTOTPEnabled(storage.ErrTOTPEnabled)
+	}
+	t.enabled = true
+	t.lastUsedStep = step
+	t.recoveryCodes = make(map[string]bool, len(codeHashes))
+	for _, hash := range codeHashes {
+		t.recoveryCodes[hash] = false
+	}
+	return nil
+}
+
+// UseTOTPStep reports whether the step is newer than the last used one, so
+// a code can't be replayed.
+func (s *TwoFactorStorage) UseTOTPStep(_ context.Context, uid models.UserID, step int64) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	t, ok := s.userTOTP(uid)
+	if !ok || t.lastUsedStep >= step {
+		return false, nil
+	}
+	t.lastUsedStep = step
+	return true, nil
+}
+
+func (s *TwoFactorStorage) UseRecoveryCode(_ context.Context, uid models.UserID, codeHash string) (bool, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	t, ok := s.userTOTP(uid)
+	if !ok {
+		return false, nil
+	}
+	used, ok := t.recoveryCodes[codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	t.recoveryCodes[codeHash] = true
+	return true, nil
+}
@@ -0,0 +1,170 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+)
+
+type UserStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewUserStorage(db *DB, tenant models.TenantID) *UserStorage {
+	return &UserStorage{db: db, tenant: tenant}
+}
+
+func (s *UserStorage) AddUser(_ context.Context, user *models.UserDB) (models.UserID, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return s.addUser(user)
+}
+
+func (s *UserStorage) AddUserWithReferral(_ context.Context, user *models.UserDB, ref *models.Referral) (models.UserID, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	uid, err := s.addUser(user)
+	if err != nil {
+		return 0, err
+	}
+	s.db.referrals = append(s.db.referrals, &referral{
+		referrerID:    ref.ReferrerID,
+		refereeID:     uid,
+		referrerBonus: ref.ReferrerBonus,
+		refereeBonus:  ref.RefereeBonus,
+		createdAt:     s.db.now(),
+	})
+	return uid, nil
+}
+
+// addUser enforces the unique logins per tenant and the globally unique
+// referral codes of the users table.
+func (s *UserStorage) addUser(u *models.UserDB) (models.UserID, error) {
+	for _, existing := range s.db.users {
+		if existing.tenant == s.tenant && existing.login == u.Login {
+			return 0, newErrLoginConflict(storage.ErrLoginConflict)
+		}
+		if existing.referralCode == u.ReferralCode {
+			return 0, newErrReferralCodeConflict(storage.ErrReferralCodeConflict)
+		}
+	}
+	uid := models.UserID(s.db.nextID())
+	s.db.users = append(s.db.users, &user{
+		id:           uid,
+		tenant:       s.tenant,
+		login:        u.Login,
+		passwordHash: u.PasswordHash,
+		referralCode: u.ReferralCode,
+	})
+	return uid, nil
+}
+
+func (s *UserStorage) GetUserByLogin(_ context.Context, login string) (*models.UserDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	u := s.db.userByLogin(s.tenant, login)
+	if u == nil {
+		return nil, newErrNoUser(storage.ErrNoUser)
+	}
+	return &models.UserDB{ID: u.id, Login: u.login, PasswordHash: u.passwordHash}, nil
+}
+
+func (s *UserStorage) GetUserByReferralCode(_ context.Context, code string) (*models.UserDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	for _, u := range s.db.users {
+		if u.referralCode == code && u.tenant == s.tenant {
+			return &models.UserDB{ID: u.id, Login: u.login, PasswordHash: u.passwordHash, ReferralCode: u.referralCode}, nil
+		}
+	}
+	return nil, newErrNoUser(storage.ErrNoUser)
+}
+
+func (s *UserStorage) GetUserByID(_ context.Context, uid models.UserID) (*models.UserDB, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	u := s.db.userByID(s.tenant, uid)
+	if u == nil {
+		return nil, newErrNoUser(storage.ErrNoUser)
+	}
+	return &models.UserDB{ID: u.id, Login: u.login, PasswordHash: u.passwordHash}, nil
+}
+
+func (s *UserStorage) UpdatePassword(_ context.Context, uid models.UserID, hash string, validAfter time.Time) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	u := s.db.userByID(s.tenant, uid)
+	if u == nil {
+		return newErrNoUser(storage.ErrNoUser)
+	}
+	u.passwordHash = hash
+	u.sessionsValidAfter = validAfter
+	return nil
+}
+
+// RehashPassword replaces the hash only if it wasn't changed since it was
+// read, so a concurrent password change is never overwritten.
+func (s *UserStorage) RehashPassword(_ context.Context, uid models.UserID, oldHash, newHash string) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	u := s.db.userByID(s.tenant, uid)
+	if u != nil && u.passwordHash == oldHash {
+		u.passwordHash = newHash
+	}
+	return nil
+}
+
+func (s *UserStorage) GetSessionsValidAfter(_ context.Context, uid models.UserID) (time.Time, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	u := s.db.userByID(s.tenant, uid)
+	if u == nil {
+		return time.Time{}, newErrNoUser(storage.ErrNoUser)
+	}
+	return u.sessionsValidAfter, nil
+}
+
+func (s *UserStorage) AddResetToken(_ context.Context, token *models.ResetToken) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.userByID(s.tenant, token.UserID) == nil {
+		return newErrNoUser(storage.ErrNoUser)
+	}
+	s.deleteResetTokens(token.UserID)
+	if _, ok := s.db.resetTokens[token.TokenHash]; ok {
+		return ErrUniqueViolation
+	}
+	s.db.resetTokens[token.TokenHash] = &resetToken{
+		userID:    token.UserID,
+		expiresAt: token.ExpiresAt,
+	}
+	return nil
+}
+
+func (s *UserStorage) ResetPassword(_ context.Context, tokenHash, hash string, validAfter time.Time) (models.UserID, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	token, ok := s.db.resetTokens[tokenHash]
+	if !ok || !token.expiresAt.After(s.db.now()) {
+		return 0, newErrInvalidResetToken(storage.ErrInvalidResetToken)
+	}
+	u := s.db.userByID(s.tenant, token.userID)
+	if u == nil {
+		return 0, newErrInvalidResetToken(storage.ErrInvalidResetToken)
+	}
+	u.passwordHash = hash
+	u.sessionsValidAfter = validAfter
+	s.deleteResetTokens(u.id)
+	return u.id, nil
+}
+
+func (s *UserStorage) deleteResetTokens(uid models.UserID) {
+	for hash, token := range s.db.resetTokens {
+		if token.userID == uid {
+			delete(s.db.resetTokens, hash)
+		}
+	}
+}
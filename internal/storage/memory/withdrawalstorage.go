@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+)
+
+type WithdrawalStorage struct {
+	db     *DB
+	tenant models.TenantID
+}
+
+func NewWithdrawalStorage(db *DB, tenant models.TenantID) *WithdrawalStorage {
+	return &WithdrawalStorage{db: db, tenant: tenant}
+}
+
+// AddWithdrawal debits the user unless the user already made a withdrawal
+// with the same idempotency key. A replay that doesn't match the stored
+// withdrawal is a conflict.
+func (s *WithdrawalStorage) AddWithdrawal(_ context.Context, w *models.Withdrawal) error {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	if s.db.userByID(s.tenant, w.UserID) == nil {
+		return newErrNoUser(storage.ErrNoUser)
+	}
+	for _, existing := range s.db.withdrawals {
+		if w.IdempotencyKey != "" && existing.tenant == s.tenant && existing.userID == w.UserID && existing.idempotencyKey == w.IdempotencyKey {
+			if existing.number != w.Order || existing.sum != w.Sum {
+				return newErrIdempotencyConflict(storage.ErrIdempotencyConflict)
+			}
+			return nil
+		}
+	}
+	accrual, withdrawn := s.db.balance(s.tenant, w.UserID)
+	if accrual-withdrawn < w.Sum {
+		return newErrNotEnoughCurrency(storage.ErrNotEnoughCurrency)
+	}
+	for _, existing := range s.db.withdrawals {
+		if existing.tenant == s.tenant && existing.number == w.Order {
+			return ErrUniqueViolation
+		}
+	}
+	s.db.withdrawals = append(s.db.withdrawals, &withdrawal{
+		id:             s.db.nextID(),
+		tenant:         s.tenant,
+		number:         w.Order,
+		userID:         w.UserID,
+		sum:            w.Sum,
+		idempotencyKey: w.IdempotencyKey,
+		processedAt:    s.db.now(),
+	})
+	return nil
+}
+
+func (s *WithdrawalStorage) GetWithdrawalsByUserID(_ context.Context, uid models.UserID) ([]*models.Withdrawal, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	withdrawals := s.userWithdrawals(uid)
+	if len(withdrawals) == 0 {
+		return nil, newErrNoWithdrawal(storage.ErrNoWithdrawal)
+	}
+	return withdrawals, nil
+}
+
+func (s *WithdrawalStorage) GetWithdrawalsPageByUserID(_ context.Context, uid models.UserID, page models.Page) ([]*models.Withdrawal, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	return paginate(s.userWithdrawals(uid), page), nil
+}
+
+// userWithdrawals lists the withdrawals of the user, newest first.
+func (s *WithdrawalStorage) userWithdrawals(uid models.UserID) []*models.Withdrawal {
+	var owned []*withdrawal
+	for _, w := range s.db.withdrawals {
+		if w.userID == uid && w.tenant == s.tenant {
+			owned = append(owned, w)
+		}
+	}
+	slices.SortStableFunc(owned, func(a, b *withdrawal) int {
+		if c := b.processedAt.Compare(a.processedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.id, a.id)
+	})
+	withdrawals := make([]*models.Withdrawal, len(owned))
+	for i, w := range owned {
+		withdrawals[i] = &models.Withdrawal{
+			ID:          w.id,
+			Order:       w.number,
+			UserID:      uid,
+			Sum:         w.sum,
+			ProcessedAt: formatTime(w.processedAt),
+		}
+	}
+	return withdrawals
+}
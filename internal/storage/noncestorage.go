@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+const noncePruneInterval = 1024
+
+// NonceStorage remembers the nonces of signed requests of a tenant in the
+// database, so a request replayed to another replica is caught too.
+type NonceStorage struct {
+	db     DB
+	tenant models.TenantID
+	calls  atomic.Int64
+}
+
+func NewNonceStorage(db DB, tenant models.TenantID) *NonceStorage {
+	return &NonceStorage{
+		db:     db,
+		tenant: tenant,
+	}
+}
+
+// Use records the nonce until expiresAt. It reports false if the nonce was
+// already used and hasn't expired yet; an expired one is taken over.
+func (s *NonceStorage) Use(ctx context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	if s.calls.Add(1)%noncePruneInterval == 0 {
+		_, err := s.db.Exec(ctx, sqlDeleteExpiredNonces)
+		if err != nil {
+			return false, err
+		}
+	}
+	tag, err := s.db.Exec(ctx, sqlUseNonce, s.tenant, nonce, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNonce = "b7f3c1d2"
+
+func TestNonceStorage_Use(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewNonceStorage(newMockDB(db), testTenant)
+
+	testExpiresAt := time.Now().Add(time.Minute)
+
+	t.Run("new nonce", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlUseNonce)).WithArgs(testTenant, testNonce, testExpiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := strg.Use(context.Background(), testNonce, testExpiresAt)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("used nonce", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlUseNonce)).WithArgs(testTenant, testNonce, testExpiresAt).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		ok, err := strg.Use(context.Background(), testNonce, testExpiresAt)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("prune expired nonces", func(t *testing.T) {
+		strg.calls.Store(noncePruneInterval - 1)
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteExpiredNonces)).WillReturnResult(sqlmock.NewResult(0, 5))
+		mock.ExpectExec(regexp.QuoteMeta(sqlUseNonce)).WithArgs(testTenant, testNonce, testExpiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := strg.Use(context.Background(), testNonce, testExpiresAt)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectExec(regexp.QuoteMeta(sqlUseNonce)).WithArgs(testTenant, testNonce, testExpiresAt).WillReturnError(errTest)
+
+		_, err := strg.Use(context.Background(), testNonce, testExpiresAt)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
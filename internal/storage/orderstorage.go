@@ -2,23 +2,28 @@ package storage
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
 	"github.com/rycln/loyalsys/internal/models"
 )
 
+const orderBatchChunkSize = 1000
+
 type OrderStorage struct {
-	db *sql.DB
+	db     DB
+	tenant models.TenantID
 }
 
-func NewOrderStorage(db *sql.DB) *OrderStorage {
-	return &OrderStorage{db: db}
+func NewOrderStorage(db DB, tenant models.TenantID) *OrderStorage {
+	return &OrderStorage{db: db, tenant: tenant}
 }
 
 func (s *OrderStorage) AddOrder(ctx context.Context, order *models.Order) error {
-	_, err := s.db.ExecContext(ctx, sqlAddOrder, order.Number, order.UserID)
+	_, err := s.db.Exec(ctx, sqlAddOrder, order.Number, order.UserID, s.tenant)
 	if err != nil {
 		return err
 	}
@@ -26,10 +31,10 @@ func (s *OrderStorage) AddOrder(ctx context.Context, order *models.Order) error
 }
 
 func (s *OrderStorage) GetOrderByNum(ctx context.Context, number string) (*models.OrderDB, error) {
-	row := s.db.QueryRowContext(ctx, sqlGetOrderByNum, number)
+	row := s.db.QueryRow(ctx, sqlGetOrderByNum, number, s.tenant)
 	var orderDB models.OrderDB
-	err := row.Scan(&orderDB.ID, &orderDB.Number, &orderDB.UserID, &orderDB.Status, &orderDB.Accrual, &orderDB.CreatedAt)
-	if errors.Is(err, sql.ErrNoRows) {
+	err := row.Scan(&orderDB.ID, &orderDB.Number, &orderDB.UserID, &orderDB.Status, &orderDB.Accrual, (*timestamp)(&orderDB.CreatedAt))
+	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, newErrNoOrder(ErrNoOrder)
 	}
 	if err != nil {
@@ -38,8 +43,39 @@ func (s *OrderStorage) GetOrderByNum(ctx context.Context, number string) (*model
 	return &orderDB, nil
 }
 
+func (s *OrderStorage) GetOrderDetailByNum(ctx context.Context, number string) (*models.OrderDetail, error) {
+	row := s.db.QueryRow(ctx, sqlGetOrderDetailByNum, number, s.tenant)
+	var order models.OrderDetail
+	err := row.Scan(&order.ID, &order.Number, &order.UserID, &order.Status, &order.Accrual, (*timestamp)(&order.CreatedAt), (*timestamp)(&order.ProcessedAt), (*timestamp)(&order.LastCheckedAt), &order.CheckCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, newErrNoOrder(ErrNoOrder)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(ctx, sqlGetOrderStatusHistory, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	order.History = []*models.OrderStatusChange{}
+	for rows.Next() {
+		var change models.OrderStatusChange
+		err = rows.Scan(&change.Status, &change.Accrual, (*timestamp)(&change.ChangedAt))
+		if err != nil {
+			return nil, err
+		}
+		order.History = append(order.History, &change)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 func (s *OrderStorage) GetOrdersByUserID(ctx context.Context, uid models.UserID) ([]*models.OrderDB, error) {
-	rows, err := s.db.QueryContext(ctx, sqlGetOrdersByUserID, uid)
+	rows, err := s.db.Query(ctx, sqlGetOrdersByUserID, uid, s.tenant)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +83,7 @@ func (s *OrderStorage) GetOrdersByUserID(ctx context.Context, uid models.UserID)
 	var orders []*models.OrderDB
 	for rows.Next() {
 		var order models.OrderDB
-		err = rows.Scan(&order.Number, &order.Status, &order.Accrual, &order.CreatedAt)
+		err = rows.Scan(&order.Number, &order.Status, &order.Accrual, (*timestamp)(&order.CreatedAt))
 		if err != nil {
 			return nil, err
 		}
@@ -63,8 +99,50 @@ func (s *OrderStorage) GetOrdersByUserID(ctx context.Context, uid models.UserID)
 	return orders, nil
 }
 
+func (s *OrderStorage) GetOrdersPageByUserID(ctx context.Context, uid models.UserID, page models.Page) ([]*models.OrderDB, error) {
+	rows, err := s.db.Query(ctx, sqlGetOrdersPageByUserID, uid, page.Limit, page.Offset, s.tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	orders := make([]*models.OrderDB, 0, page.Limit)
+	for rows.Next() {
+		var order models.OrderDB
+		err = rows.Scan(&order.Number, &order.Status, &order.Accrual, (*timestamp)(&order.CreatedAt))
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, &order)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+func (s *OrderStorage) CountPendingOrders(ctx context.Context, uid models.UserID) (int, error) {
+	row := s.db.QueryRow(ctx, sqlCountPendingOrders, uid, s.tenant)
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (s *OrderStorage) GetInconclusiveOrderNums(ctx context.Context) ([]string, error) {
-	rows, err := s.db.QueryContext(ctx, sqlGetInconclusiveOrderNums)
+	return s.queryOrderNums(ctx, sqlGetInconclusiveOrderNums, s.tenant)
+}
+
+// GetStaleOrderNums lists the inconclusive orders that got no accrual
+// result since before, counting from creation if they never got one.
+func (s *OrderStorage) GetStaleOrderNums(ctx context.Context, before time.Time) ([]string, error) {
+	return s.queryOrderNums(ctx, sqlGetStaleOrderNums, s.tenant, before)
+}
+
+func (s *OrderStorage) queryOrderNums(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := s.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,23 +163,140 @@ func (s *OrderStorage) GetInconclusiveOrderNums(ctx context.Context) ([]string,
 	return nums, nil
 }
 
-func (s *OrderStorage) UpdateOrdersBatch(ctx context.Context, orders []*models.OrderDB) error {
-	tx, err := s.db.BeginTx(ctx, nil)
+// AddOrdersBatch inserts the numbers that are not uploaded yet and returns
+// the owners of the others. With a positive limit, the user row is locked
+// and only as many new numbers are inserted as the user may still have
+// pending; the rest are returned as over the limit.
+func (s *OrderStorage) AddOrdersBatch(ctx context.Context, uid models.UserID, nums []string, limit int) (map[string]models.UserID, []string, error) {
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	defer tx.Rollback()
+	defer tx.Rollback(ctx)
+	if limit > 0 {
+		var lockedID models.UserID
+		err = tx.QueryRow(ctx, sqlLockUser, uid, s.tenant).Scan(&lockedID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	existing := make(map[string]models.UserID)
+	for start := 0; start < len(nums); start += orderBatchChunkSize {
+		chunk := nums[start:min(start+orderBatchChunkSize, len(nums))]
+		err = s.getOrderOwners(ctx, tx, chunk, existing)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	fresh := make([]string, 0, len(nums))
+	seen := make(map[string]bool, len(nums))
+	for _, num := range nums {
+		if _, ok := existing[num]; ok || seen[num] {
+			continue
+		}
+		seen[num] = true
+		fresh = append(fresh, num)
+	}
+	var over []string
+	if limit > 0 {
+		var pending int
+		err = tx.QueryRow(ctx, sqlCountPendingOrders, uid, s.tenant).Scan(&pending)
+		if err != nil {
+			return nil, nil, err
+		}
+		capacity := max(limit-pending, 0)
+		if len(fresh) > capacity {
+			over = fresh[capacity:]
+			fresh = fresh[:capacity]
+		}
+	}
+	for start := 0; start < len(fresh); start += orderBatchChunkSize {
+		chunk := fresh[start:min(start+orderBatchChunkSize, len(fresh))]
+		err = s.addOrdersChunk(ctx, tx, uid, chunk, existing)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return existing, over, nil
+}
 
-	stmt, err := tx.PrepareContext(ctx, sqlUpdateOrdersBatch)
+// addOrdersChunk inserts the numbers, looking up the owners of those that
+// another upload inserted in the meantime.
+func (s *OrderStorage) addOrdersChunk(ctx context.Context, tx pgx.Tx, uid models.UserID, nums []string, existing map[string]models.UserID) error {
+	args := make([]any, 0, len(nums)+2)
+	args = append(args, uid, s.tenant)
+	for _, num := range nums {
+		args = append(args, num)
+	}
+	rows, err := tx.Query(ctx, buildAddOrdersBatchQuery(len(nums)), args...)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
+	defer rows.Close()
+	inserted := make(map[string]bool, len(nums))
+	for rows.Next() {
+		var num string
+		err = rows.Scan(&num)
+		if err != nil {
+			return err
+		}
+		inserted[num] = true
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	var rest []string
+	for _, num := range nums {
+		if !inserted[num] {
+			rest = append(rest, num)
+		}
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	return s.getOrderOwners(ctx, tx, rest, existing)
+}
 
-	for _, order := range orders {
-		if _, err := stmt.ExecContext(ctx, order.Status, order.Accrual, order.Number); err != nil {
+func (s *OrderStorage) getOrderOwners(ctx context.Context, tx pgx.Tx, nums []string, existing map[string]models.UserID) error {
+	args := make([]any, 0, len(nums)+1)
+	args = append(args, s.tenant)
+	for _, num := range nums {
+		args = append(args, num)
+	}
+	owners, err := tx.Query(ctx, buildGetOrderOwnersQuery(len(nums)), args...)
+	if err != nil {
+		return err
+	}
+	defer owners.Close()
+	for owners.Next() {
+		var num string
+		var owner models.UserID
+		err = owners.Scan(&num, &owner)
+		if err != nil {
 			return err
 		}
+		existing[num] = owner
+	}
+	return owners.Err()
+}
+
+func buildAddOrdersBatchQuery(n int) string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("($%d, $1, $2)", i+3)
+	}
+	return sqlAddOrdersBatchPrefix + strings.Join(values, ", ") + sqlAddOrdersBatchSuffix
+}
+
+func buildGetOrderOwnersQuery(n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", i+2)
 	}
-	return tx.Commit()
+	return sqlGetOrderOwnersPrefix + strings.Join(params, ", ") + sqlGetOrderOwnersSuffix
 }
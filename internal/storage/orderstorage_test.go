@@ -2,13 +2,12 @@ package storage
 
 import (
 	"context"
-	"database/sql"
 	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
 	"github.com/rycln/loyalsys/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,7 +25,7 @@ func TestOrderStorage_AddOrder(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewOrderStorage(db)
+	strg := NewOrderStorage(newMockDB(db), testTenant)
 
 	testOrder := &models.Order{
 		Number: testOrderNum,
@@ -36,7 +35,7 @@ func TestOrderStorage_AddOrder(t *testing.T) {
 	expectedQuery := regexp.QuoteMeta(sqlAddOrder)
 
 	t.Run("valid test", func(t *testing.T) {
-		mock.ExpectExec(expectedQuery).WithArgs(testOrder.Number, testOrder.UserID).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(expectedQuery).WithArgs(testOrder.Number, testOrder.UserID, testTenant).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := strg.AddOrder(context.Background(), testOrder)
 		assert.NoError(t, err)
@@ -44,7 +43,7 @@ func TestOrderStorage_AddOrder(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectExec(expectedQuery).WithArgs(testOrder.Number, testOrder.UserID).WillReturnError(errTest)
+		mock.ExpectExec(expectedQuery).WithArgs(testOrder.Number, testOrder.UserID, testTenant).WillReturnError(errTest)
 
 		err := strg.AddOrder(context.Background(), testOrder)
 		assert.Error(t, err)
@@ -57,7 +56,7 @@ func TestOrderStorage_GetOrderByNum(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewOrderStorage(db)
+	strg := NewOrderStorage(newMockDB(db), testTenant)
 
 	testOrder := &models.OrderDB{
 		ID:        testOrderID,
@@ -72,7 +71,7 @@ func TestOrderStorage_GetOrderByNum(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"id", "number", "user_id", "status", "accrual", "created_at"}).AddRow(testOrder.ID, testOrder.Number, testOrder.UserID, testOrder.Status, testOrder.Accrual, testOrder.CreatedAt)
-		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnRows(rows)
 
 		orderDB, err := strg.GetOrderByNum(context.Background(), testOrder.Number)
 		assert.NoError(t, err)
@@ -81,7 +80,7 @@ func TestOrderStorage_GetOrderByNum(t *testing.T) {
 	})
 
 	t.Run("no order error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnError(pgx.ErrNoRows)
 
 		_, err := strg.GetOrderByNum(context.Background(), testOrder.Number)
 		assert.ErrorIs(t, err, ErrNoOrder)
@@ -89,12 +88,113 @@ func TestOrderStorage_GetOrderByNum(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number).WillReturnError(errTest)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnError(errTest)
 
 		_, err := strg.GetOrderByNum(context.Background(), testOrder.Number)
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		otherStrg := NewOrderStorage(newMockDB(db), testOtherTenant)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testOtherTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := otherStrg.GetOrderByNum(context.Background(), testOrder.Number)
+		assert.ErrorIs(t, err, ErrNoOrder)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrderStorage_GetOrderDetailByNum(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrderStorage(newMockDB(db), testTenant)
+
+	testProcessedAt := time.Now().String()
+	testOrder := &models.OrderDetail{
+		ID:            testOrderID,
+		Number:        testOrderNum,
+		UserID:        testUserID,
+		Status:        models.StatusProcessed,
+		Accrual:       500,
+		CreatedAt:     testCreatedAt.String(),
+		ProcessedAt:   testProcessedAt,
+		LastCheckedAt: testProcessedAt,
+		CheckCount:    2,
+		History: []*models.OrderStatusChange{
+			{
+				Status:    models.StatusNew,
+				ChangedAt: testCreatedAt.String(),
+			},
+			{
+				Status:    models.StatusProcessed,
+				Accrual:   500,
+				ChangedAt: testProcessedAt,
+			},
+		},
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetOrderDetailByNum)
+	expectedHistoryQuery := regexp.QuoteMeta(sqlGetOrderStatusHistory)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "number", "user_id", "status", "accrual", "created_at", "processed_at", "last_checked_at", "check_count"}).
+			AddRow(testOrder.ID, testOrder.Number, testOrder.UserID, testOrder.Status, testOrder.Accrual, testOrder.CreatedAt, testOrder.ProcessedAt, testOrder.LastCheckedAt, testOrder.CheckCount)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnRows(rows)
+		historyRows := mock.NewRows([]string{"status", "accrual", "changed_at"})
+		for _, change := range testOrder.History {
+			historyRows.AddRow(change.Status, change.Accrual, change.ChangedAt)
+		}
+		mock.ExpectQuery(expectedHistoryQuery).WithArgs(testOrder.ID).WillReturnRows(historyRows)
+
+		order, err := strg.GetOrderDetailByNum(context.Background(), testOrder.Number)
+		assert.NoError(t, err)
+		assert.Equal(t, testOrder, order)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not processed order", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "number", "user_id", "status", "accrual", "created_at", "processed_at", "last_checked_at", "check_count"}).
+			AddRow(testOrder.ID, testOrder.Number, testOrder.UserID, models.StatusNew, 0, testOrder.CreatedAt, nil, nil, 0)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnRows(rows)
+		mock.ExpectQuery(expectedHistoryQuery).WithArgs(testOrder.ID).WillReturnRows(mock.NewRows([]string{"status", "accrual", "changed_at"}))
+
+		order, err := strg.GetOrderDetailByNum(context.Background(), testOrder.Number)
+		assert.NoError(t, err)
+		assert.Empty(t, order.ProcessedAt)
+		assert.Empty(t, order.LastCheckedAt)
+		assert.Empty(t, order.History)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no order error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := strg.GetOrderDetailByNum(context.Background(), testOrder.Number)
+		assert.ErrorIs(t, err, ErrNoOrder)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("history error", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "number", "user_id", "status", "accrual", "created_at", "processed_at", "last_checked_at", "check_count"}).
+			AddRow(testOrder.ID, testOrder.Number, testOrder.UserID, testOrder.Status, testOrder.Accrual, testOrder.CreatedAt, testOrder.ProcessedAt, testOrder.LastCheckedAt, testOrder.CheckCount)
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnRows(rows)
+		mock.ExpectQuery(expectedHistoryQuery).WithArgs(testOrder.ID).WillReturnError(errTest)
+
+		_, err := strg.GetOrderDetailByNum(context.Background(), testOrder.Number)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testOrder.Number, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetOrderDetailByNum(context.Background(), testOrder.Number)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestOrderStorage_GetOrdersByUserID(t *testing.T) {
@@ -102,7 +202,7 @@ func TestOrderStorage_GetOrdersByUserID(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewOrderStorage(db)
+	strg := NewOrderStorage(newMockDB(db), testTenant)
 
 	testOrder := &models.OrderDB{
 		Number:    testOrderNum,
@@ -116,7 +216,7 @@ func TestOrderStorage_GetOrdersByUserID(t *testing.T) {
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"number", "status", "accrual", "created_at"}).
 			AddRow(testOrder.Number, testOrder.Status, testOrder.Accrual, testOrder.CreatedAt)
-		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
 
 		orderDB, err := strg.GetOrdersByUserID(context.Background(), testUserID)
 		assert.NoError(t, err)
@@ -125,7 +225,7 @@ func TestOrderStorage_GetOrdersByUserID(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnError(errTest)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(errTest)
 
 		_, err := strg.GetOrdersByUserID(context.Background(), testUserID)
 		assert.Error(t, err)
@@ -142,12 +242,87 @@ func TestOrderStorage_GetOrdersByUserID(t *testing.T) {
 	})
 }
 
+func TestOrderStorage_GetOrdersPageByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrderStorage(newMockDB(db), testTenant)
+
+	testOrder := &models.OrderDB{
+		Number:    testOrderNum,
+		Status:    "some status",
+		Accrual:   0,
+		CreatedAt: testCreatedAt.String(),
+	}
+	testPage := models.Page{Limit: 10, Offset: 20}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetOrdersPageByUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"number", "status", "accrual", "created_at"}).
+			AddRow(testOrder.Number, testOrder.Status, testOrder.Accrual, testOrder.CreatedAt)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnRows(rows)
+
+		orders, err := strg.GetOrdersPageByUserID(context.Background(), testUserID, testPage)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.OrderDB{testOrder}, orders)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		rows := mock.NewRows([]string{"number", "status", "accrual", "created_at"})
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnRows(rows)
+
+		orders, err := strg.GetOrdersPageByUserID(context.Background(), testUserID, testPage)
+		assert.NoError(t, err)
+		assert.Empty(t, orders)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetOrdersPageByUserID(context.Background(), testUserID, testPage)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrderStorage_CountPendingOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrderStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlCountPendingOrders)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"count"}).AddRow(3)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		count, err := strg.CountPendingOrders(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(errTest)
+
+		_, err := strg.CountPendingOrders(context.Background(), testUserID)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestOrderStorage_GetInconclusiveOrderNums(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewOrderStorage(db)
+	strg := NewOrderStorage(newMockDB(db), testTenant)
 
 	orderNums := []string{
 		"123",
@@ -158,7 +333,7 @@ func TestOrderStorage_GetInconclusiveOrderNums(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"number"}).AddRow(orderNums[0]).AddRow(orderNums[1])
-		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testTenant).WillReturnRows(rows)
 
 		nums, err := strg.GetInconclusiveOrderNums(context.Background())
 		assert.NoError(t, err)
@@ -167,7 +342,7 @@ func TestOrderStorage_GetInconclusiveOrderNums(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WillReturnError(errTest)
+		mock.ExpectQuery(expectedQuery).WithArgs(testTenant).WillReturnError(errTest)
 
 		_, err := strg.GetInconclusiveOrderNums(context.Background())
 		assert.Error(t, err)
@@ -175,70 +350,129 @@ func TestOrderStorage_GetInconclusiveOrderNums(t *testing.T) {
 	})
 }
 
-func TestOrderStorage_UpdateOrdersBatch(t *testing.T) {
+func TestOrderStorage_GetStaleOrderNums(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewOrderStorage(db)
+	strg := NewOrderStorage(newMockDB(db), testTenant)
 
-	testOrders := []*models.OrderDB{
-		{
-			Number:    "123",
-			Status:    "some status",
-			Accrual:   10,
-			CreatedAt: time.Now().String(),
-		},
-		{
-			Number:    "456",
-			Status:    "some status",
-			Accrual:   10,
-			CreatedAt: time.Now().String(),
-		},
-	}
+	before := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	expectedQuery := regexp.QuoteMeta(sqlGetStaleOrderNums)
 
-	expectedQuery := regexp.QuoteMeta(sqlUpdateOrdersBatch)
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"number"}).AddRow("123")
+		mock.ExpectQuery(expectedQuery).WithArgs(testTenant, before).WillReturnRows(rows)
+
+		nums, err := strg.GetStaleOrderNums(context.Background(), before)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"123"}, nums)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testTenant, before).WillReturnError(errTest)
+
+		_, err := strg.GetStaleOrderNums(context.Background(), before)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestOrderStorage_AddOrdersBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewOrderStorage(newMockDB(db), testTenant)
+
+	testNums := []string{"123", "456", "789"}
+
+	expectedOwners := regexp.QuoteMeta(buildGetOrderOwnersQuery(len(testNums)))
 
 	t.Run("valid test", func(t *testing.T) {
 		mock.ExpectBegin()
-		mockStmt := mock.ExpectPrepare(expectedQuery)
-		for _, testOrder := range testOrders {
-			mockStmt.ExpectExec().WithArgs(testOrder.Status, testOrder.Accrual, testOrder.Number).WillReturnResult(sqlmock.NewResult(1, 1))
-		}
+		mock.ExpectQuery(expectedOwners).WithArgs(testTenant, "123", "456", "789").WillReturnRows(sqlmock.NewRows([]string{"number", "user_id"}).AddRow("789", testOtherUserID))
+		mock.ExpectQuery(regexp.QuoteMeta(buildAddOrdersBatchQuery(2))).WithArgs(testUserID, testTenant, "123", "456").WillReturnRows(sqlmock.NewRows([]string{"number"}).AddRow("123"))
+		mock.ExpectQuery(regexp.QuoteMeta(buildGetOrderOwnersQuery(1))).WithArgs(testTenant, "456").WillReturnRows(sqlmock.NewRows([]string{"number", "user_id"}).AddRow("456", testOtherUserID))
 		mock.ExpectCommit()
 
-		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		existing, over, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 0)
 		assert.NoError(t, err)
+		assert.Equal(t, map[string]models.UserID{"456": testOtherUserID, "789": testOtherUserID}, existing)
+		assert.Empty(t, over)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("begin error", func(t *testing.T) {
-		mock.ExpectBegin().WillReturnError(errTest)
+	t.Run("all inserted", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedOwners).WithArgs(testTenant, "123", "456", "789").WillReturnRows(sqlmock.NewRows([]string{"number", "user_id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(buildAddOrdersBatchQuery(3))).WithArgs(testUserID, testTenant, "123", "456", "789").WillReturnRows(sqlmock.NewRows([]string{"number"}).AddRow("123").AddRow("456").AddRow("789"))
+		mock.ExpectCommit()
 
-		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
-		assert.Error(t, err)
+		existing, over, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 0)
+		assert.NoError(t, err)
+		assert.Empty(t, existing)
+		assert.Empty(t, over)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("prepare error", func(t *testing.T) {
+	t.Run("limit", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectPrepare(expectedQuery).WillReturnError(errTest)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testUserID))
+		mock.ExpectQuery(expectedOwners).WithArgs(testTenant, "123", "456", "789").WillReturnRows(sqlmock.NewRows([]string{"number", "user_id"}).AddRow("123", testUserID))
+		mock.ExpectQuery(regexp.QuoteMeta(sqlCountPendingOrders)).WithArgs(testUserID, testTenant).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta(buildAddOrdersBatchQuery(1))).WithArgs(testUserID, testTenant, "456").WillReturnRows(sqlmock.NewRows([]string{"number"}).AddRow("456"))
+		mock.ExpectCommit()
+
+		existing, over, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]models.UserID{"123": testUserID}, existing)
+		assert.Equal(t, []string{"789"}, over)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("limit reached", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(testUserID))
+		mock.ExpectQuery(expectedOwners).WithArgs(testTenant, "123", "456", "789").WillReturnRows(sqlmock.NewRows([]string{"number", "user_id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(sqlCountPendingOrders)).WithArgs(testUserID, testTenant).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectCommit()
+
+		existing, over, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 3)
+		assert.NoError(t, err)
+		assert.Empty(t, existing)
+		assert.Equal(t, testNums, over)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).WillReturnError(errTest)
 		mock.ExpectRollback()
 
-		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		_, _, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 3)
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("exec error", func(t *testing.T) {
+	t.Run("insert error", func(t *testing.T) {
 		mock.ExpectBegin()
-		mockStmt := mock.ExpectPrepare(expectedQuery)
+		mock.ExpectQuery(expectedOwners).WillReturnRows(sqlmock.NewRows([]string{"number", "user_id"}))
+		mock.ExpectQuery(regexp.QuoteMeta(buildAddOrdersBatchQuery(3))).WillReturnError(errTest)
+		mock.ExpectRollback()
 
-		mockStmt.ExpectExec().WithArgs(testOrders[0].Status, testOrders[0].Accrual, testOrders[0].Number).WillReturnError(errTest)
+		_, _, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 0)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 
+	t.Run("owners error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedOwners).WillReturnError(errTest)
 		mock.ExpectRollback()
 
-		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		_, _, err := strg.AddOrdersBatch(context.Background(), testUserID, testNums, 0)
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
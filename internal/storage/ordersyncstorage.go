@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+// OrderSyncStorage is the order storage of the accrual sync worker. Status
+// updates are copied into a temporary table and applied to all orders with a
+// single statement. Invalid and processed orders are final and don't change.
+type OrderSyncStorage struct {
+	*OrderStorage
+}
+
+func NewOrderSyncStorage(orders *OrderStorage) *OrderSyncStorage {
+	return &OrderSyncStorage{OrderStorage: orders}
+}
+
+func (s *OrderSyncStorage) UpdateOrdersBatch(ctx context.Context, orders []*models.OrderDB) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, sqlCreateOrderUpdates)
+	if err != nil {
+		return err
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"order_updates"}, []string{"number", "status", "accrual"}, pgx.CopyFromRows(orderUpdateRows(orders)))
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, sqlApplyOrderUpdates, s.tenant)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// orderUpdateRows keeps the last update of every order, as the same order
+// may be fetched twice before its first result is stored.
+func orderUpdateRows(orders []*models.OrderDB) [][]any {
+	last := make(map[string]int, len(orders))
+	rows := make([][]any, 0, len(orders))
+	for _, order := range orders {
+		row := []any{order.Number, order.Status, order.Accrual}
+		if i, ok := last[order.Number]; ok {
+			rows[i] = row
+			continue
+		}
+		last[order.Number] = len(rows)
+		rows = append(rows, row)
+	}
+	return rows
+}
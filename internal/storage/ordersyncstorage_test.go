@@ -0,0 +1,249 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testDatabaseURIEnv = "TEST_DATABASE_URI"
+	benchOrders        = 10000
+)
+
+// sqlUpdateOrderPerRow is the update the sync worker used to run for every
+// order, kept as the baseline of BenchmarkUpdateOrdersBatch.
+const sqlUpdateOrderPerRow = `
+	WITH prev AS (
+		SELECT 
+			id, 
+			status 
+		FROM orders 
+		WHERE number = $3 AND tenant_id = $4 
+		FOR UPDATE
+	), updated AS (
+		UPDATE orders 
+		SET 
+			status = $1::VARCHAR, 
+			accrual = $2, 
+			last_checked_at = CURRENT_TIMESTAMP, 
+			check_count = orders.check_count + 1, 
+			processed_at = CASE 
+				WHEN $1::VARCHAR IN ('INVALID', 'PROCESSED') THEN COALESCE(orders.processed_at, CURRENT_TIMESTAMP) 
+				ELSE orders.processed_at 
+			END 
+		FROM prev 
+		WHERE orders.id = prev.id 
+		RETURNING orders.id
+	) 
+	INSERT INTO order_status_history (order_id, status, accrual) 
+	SELECT prev.id, $1::VARCHAR, $2 
+	FROM prev 
+	WHERE prev.status IS DISTINCT FROM $1::VARCHAR
+`
+
+type fakePool struct {
+	DB
+	tx  *fakeTx
+	err error
+}
+
+func (p *fakePool) Begin(context.Context) (pgx.Tx, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	return p.tx, nil
+}
+
+type fakeTx struct {
+	pgx.Tx
+	execs      []string
+	execArgs   [][]any
+	copied     [][]any
+	execErr    error
+	copyErr    error
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	tx.execs = append(tx.execs, sql)
+	tx.execArgs = append(tx.execArgs, args)
+	return pgconn.CommandTag{}, tx.execErr
+}
+
+func (tx *fakeTx) CopyFrom(ctx context.Context, _ pgx.Identifier, _ []string, src pgx.CopyFromSource) (int64, error) {
+	if tx.copyErr != nil {
+		return 0, tx.copyErr
+	}
+	for src.Next() {
+		row, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		tx.copied = append(tx.copied, row)
+	}
+	return int64(len(tx.copied)), nil
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	if !tx.committed {
+		tx.rolledBack = true
+	}
+	return nil
+}
+
+func TestOrderSyncStorage_UpdateOrdersBatch(t *testing.T) {
+	testOrders := []*models.OrderDB{
+		{Number: "123", Status: "PROCESSING"},
+		{Number: "456", Status: models.StatusProcessed, Accrual: 10},
+		{Number: "123", Status: "INVALID"},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		tx := &fakeTx{}
+		strg := NewOrderSyncStorage(NewOrderStorage(&fakePool{tx: tx}, testTenant))
+
+		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{sqlCreateOrderUpdates, sqlApplyOrderUpdates}, tx.execs)
+		assert.Equal(t, []any{testTenant}, tx.execArgs[1])
+		assert.Equal(t, [][]any{
+			{"123", "INVALID", float64(0)},
+			{"456", models.StatusProcessed, float64(10)},
+		}, tx.copied)
+		assert.True(t, tx.committed)
+	})
+
+	t.Run("no orders", func(t *testing.T) {
+		strg := NewOrderSyncStorage(NewOrderStorage(&fakePool{err: errTest}, testTenant))
+
+		err := strg.UpdateOrdersBatch(context.Background(), nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("begin error", func(t *testing.T) {
+		strg := NewOrderSyncStorage(NewOrderStorage(&fakePool{err: errTest}, testTenant))
+
+		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		assert.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("copy error", func(t *testing.T) {
+		tx := &fakeTx{copyErr: errTest}
+		strg := NewOrderSyncStorage(NewOrderStorage(&fakePool{tx: tx}, testTenant))
+
+		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		assert.ErrorIs(t, err, errTest)
+		assert.True(t, tx.rolledBack)
+	})
+
+	t.Run("exec error", func(t *testing.T) {
+		tx := &fakeTx{execErr: errTest}
+		strg := NewOrderSyncStorage(NewOrderStorage(&fakePool{tx: tx}, testTenant))
+
+		err := strg.UpdateOrdersBatch(context.Background(), testOrders)
+		assert.ErrorIs(t, err, errTest)
+		assert.True(t, tx.rolledBack)
+	})
+}
+
+// BenchmarkUpdateOrdersBatch compares storing 10k accrual results one
+// statement per order with the COPY based update. It runs only when
+// TEST_DATABASE_URI points to a migrated database.
+func BenchmarkUpdateOrdersBatch(b *testing.B) {
+	databaseURI := os.Getenv(testDatabaseURIEnv)
+	if databaseURI == "" {
+		b.Skipf("%s is not set", testDatabaseURIEnv)
+	}
+	ctx := context.Background()
+	pool, err := NewPool(ctx, NewDSN(databaseURI), PoolConfig{
+		MaxConns:        4,
+		MaxConnIdleTime: time.Minute,
+		MaxConnLifetime: time.Hour,
+	})
+	require.NoError(b, err)
+	defer pool.Close()
+
+	suffix := time.Now().UnixNano()
+	tenant := models.TenantID(fmt.Sprintf("bench-%d", suffix))
+	uid, err := NewUserStorage(pool, tenant).AddUser(ctx, &models.UserDB{
+		Login:        "bench",
+		PasswordHash: "bench",
+		ReferralCode: fmt.Sprintf("BENCH%d", suffix),
+	})
+	require.NoError(b, err)
+	defer func() {
+		_, err := pool.Exec(ctx, "DELETE FROM order_status_history WHERE order_id IN (SELECT id FROM orders WHERE tenant_id = $1)", tenant)
+		assert.NoError(b, err)
+		_, err = pool.Exec(ctx, "DELETE FROM orders WHERE tenant_id = $1", tenant)
+		assert.NoError(b, err)
+		_, err = pool.Exec(ctx, "DELETE FROM users WHERE tenant_id = $1", tenant)
+		assert.NoError(b, err)
+	}()
+
+	nums := make([]string, benchOrders)
+	for i := range nums {
+		nums[i] = fmt.Sprintf("%d%05d", suffix, i)
+	}
+	orderStrg := NewOrderStorage(pool, tenant)
+	_, _, err = orderStrg.AddOrdersBatch(ctx, uid, nums, 0)
+	require.NoError(b, err)
+
+	// Every round flips the status, so each update also writes history.
+	statuses := []string{"PROCESSING", "REGISTERED"}
+	round := 0
+	nextOrders := func() []*models.OrderDB {
+		round++
+		orders := make([]*models.OrderDB, len(nums))
+		for i, num := range nums {
+			orders[i] = &models.OrderDB{Number: num, Status: statuses[round%len(statuses)]}
+		}
+		return orders
+	}
+
+	b.Run("per row", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			err := updateOrdersPerRow(ctx, pool, tenant, nextOrders())
+			require.NoError(b, err)
+		}
+		b.ReportMetric(float64(benchOrders*b.N)/b.Elapsed().Seconds(), "orders/s")
+	})
+
+	b.Run("copy", func(b *testing.B) {
+		strg := NewOrderSyncStorage(orderStrg)
+		for i := 0; i < b.N; i++ {
+			err := strg.UpdateOrdersBatch(ctx, nextOrders())
+			require.NoError(b, err)
+		}
+		b.ReportMetric(float64(benchOrders*b.N)/b.Elapsed().Seconds(), "orders/s")
+	})
+}
+
+func updateOrdersPerRow(ctx context.Context, db DB, tenant models.TenantID, orders []*models.OrderDB) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, order := range orders {
+		if _, err := tx.Exec(ctx, sqlUpdateOrderPerRow, order.Status, order.Accrual, order.Number, tenant); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
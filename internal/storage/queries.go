@@ -1,8 +1,8 @@
 package storage
 
 const sqlAddUser = `
-	INSERT INTO users (login, password_hash) 
-	VALUES ($1, $2) 
+	INSERT INTO users (login, password_hash, referral_code, tenant_id) 
+	VALUES ($1, $2, $3, $4) 
 	RETURNING id
 `
 
@@ -12,7 +12,41 @@ const sqlGetUserByLogin = `
 		login, 
 		password_hash 
 	FROM users 
-	WHERE login = $1
+	WHERE login = $1 AND tenant_id = $2
+`
+
+const sqlGetUserByReferralCode = `
+	SELECT 
+		id, 
+		login, 
+		password_hash, 
+		referral_code 
+	FROM users 
+	WHERE referral_code = $1 AND tenant_id = $2
+`
+
+const sqlAddReferral = `
+	INSERT INTO referrals (referrer_id, referee_id, referrer_bonus, referee_bonus) 
+	VALUES ($1, $2, $3, $4)
+`
+
+const sqlGetReferralCodeByUserID = `
+	SELECT 
+		referral_code 
+	FROM users 
+	WHERE id = $1 AND tenant_id = $2
+`
+
+const sqlGetInviteesByReferrerID = `
+	SELECT 
+		users.login, 
+		referrals.referrer_bonus, 
+		referrals.rewarded_at IS NOT NULL AS rewarded, 
+		referrals.created_at 
+	FROM referrals 
+	JOIN users ON users.id = referrals.referee_id 
+	WHERE referrals.referrer_id = $1 AND users.tenant_id = $2 
+	ORDER BY referrals.created_at DESC
 `
 
 const sqlGetOrderByNum = `
@@ -24,27 +58,138 @@ const sqlGetOrderByNum = `
 		accrual, 
 		created_at 
 	FROM orders 
-	WHERE number = $1
+	WHERE number = $1 AND tenant_id = $2
 `
 
 const sqlAddOrder = `
-	INSERT INTO orders (number, user_id) 
-	VALUES ($1, $2)
+	WITH inserted AS (
+		INSERT INTO orders (number, user_id, tenant_id) 
+		VALUES ($1, $2, $3) 
+		RETURNING id, status
+	) 
+	INSERT INTO order_status_history (order_id, status) 
+	SELECT id, status FROM inserted
+`
+
+const sqlAddOrdersBatchPrefix = `
+	WITH inserted AS (
+		INSERT INTO orders (number, user_id, tenant_id) 
+		VALUES `
+
+const sqlAddOrdersBatchSuffix = ` 
+		ON CONFLICT (tenant_id, number) DO NOTHING 
+		RETURNING id, number, status
+	), history AS (
+		INSERT INTO order_status_history (order_id, status) 
+		SELECT id, status FROM inserted
+	) 
+	SELECT number FROM inserted
+`
+
+const sqlGetOrderOwnersPrefix = `
+	SELECT 
+		number, 
+		user_id 
+	FROM orders 
+	WHERE tenant_id = $1 AND number IN (`
+
+const sqlGetOrderOwnersSuffix = `)
+`
+
+const sqlCountPendingOrders = `
+	SELECT 
+		COUNT(*) 
+	FROM orders 
+	WHERE user_id = $1 AND tenant_id = $2 AND status NOT IN ('INVALID', 'PROCESSED')
 `
 
 const sqlGetInconclusiveOrderNums = `
 	SELECT 
 		number 
 	FROM orders 
-	WHERE status NOT IN ('INVALID', 'PROCESSED')
+	WHERE tenant_id = $1 AND status NOT IN ('INVALID', 'PROCESSED')
+`
+
+const sqlGetStaleOrderNums = `
+	SELECT 
+		number 
+	FROM orders 
+	WHERE tenant_id = $1 AND status NOT IN ('INVALID', 'PROCESSED') AND COALESCE(last_checked_at, created_at) < $2
+`
+
+const sqlCreateOrderUpdates = `
+	CREATE TEMP TABLE order_updates (
+		number VARCHAR(255) NOT NULL, 
+		status VARCHAR(255) NOT NULL, 
+		accrual DECIMAL(10, 2) NOT NULL 
+	) ON COMMIT DROP
 `
 
-const sqlUpdateOrdersBatch = `
-	UPDATE orders 
+const sqlApplyOrderUpdates = `
+	WITH prev AS (
+		SELECT 
+			orders.id, 
+			orders.user_id, 
+			orders.status AS prev_status, 
+			order_updates.status, 
+			order_updates.accrual 
+		FROM orders 
+		JOIN order_updates ON order_updates.number = orders.number 
+		WHERE orders.tenant_id = $1 
+			AND orders.status NOT IN ('INVALID', 'PROCESSED') 
+		ORDER BY orders.id 
+		FOR UPDATE OF orders
+	), updated AS (
+		UPDATE orders 
+		SET 
+			status = prev.status, 
+			accrual = prev.accrual, 
+			last_checked_at = CURRENT_TIMESTAMP, 
+			check_count = orders.check_count + 1, 
+			processed_at = CASE 
+				WHEN prev.status IN ('INVALID', 'PROCESSED') THEN COALESCE(orders.processed_at, CURRENT_TIMESTAMP) 
+				ELSE orders.processed_at 
+			END 
+		FROM prev 
+		WHERE orders.id = prev.id
+	), history AS (
+		INSERT INTO order_status_history (order_id, status, accrual) 
+		SELECT id, status, accrual 
+		FROM prev 
+		WHERE prev_status IS DISTINCT FROM status
+	) 
+	UPDATE referrals 
 	SET 
-		status = $1, 
-		accrual = $2 
-	WHERE number = $3
+		rewarded_at = CURRENT_TIMESTAMP 
+	FROM prev 
+	WHERE referrals.referee_id = prev.user_id 
+		AND prev.status = 'PROCESSED' 
+		AND referrals.rewarded_at IS NULL
+`
+
+const sqlGetOrderDetailByNum = `
+	SELECT 
+		id, 
+		number, 
+		user_id, 
+		status, 
+		accrual, 
+		created_at, 
+		processed_at, 
+		last_checked_at, 
+		check_count 
+	FROM orders 
+	WHERE number = $1 AND tenant_id = $2
+`
+
+const sqlGetOrderStatusHistory = `
+	SELECT 
+		status, 
+		accrual, 
+		changed_at 
+	FROM order_status_history 
+	WHERE order_id = $1 
+	ORDER BY changed_at, id
 `
 
 const sqlGetOrdersByUserID = `
@@ -54,10 +199,22 @@ const sqlGetOrdersByUserID = `
 		accrual, 
 		created_at 
 	FROM orders 
-	WHERE user_id = $1 
+	WHERE user_id = $1 AND tenant_id = $2 
 	ORDER BY created_at DESC
 `
 
+const sqlGetOrdersPageByUserID = `
+	SELECT 
+		number, 
+		status, 
+		accrual, 
+		created_at 
+	FROM orders 
+	WHERE user_id = $1 AND tenant_id = $4 
+	ORDER BY created_at DESC, id DESC 
+	LIMIT $2 OFFSET $3
+`
+
 const sqlGetWithdrawalsByUserID = `
 	SELECT 
 		id, 
@@ -65,20 +222,317 @@ const sqlGetWithdrawalsByUserID = `
 		sum, 
 		processed_at 
 	FROM withdrawals 
-	WHERE user_id = $1 
+	WHERE user_id = $1 AND tenant_id = $2 
 	ORDER BY processed_at DESC
 `
 
+const sqlGetWithdrawalsPageByUserID = `
+	SELECT 
+		id, 
+		number, 
+		sum, 
+		processed_at 
+	FROM withdrawals 
+	WHERE user_id = $1 AND tenant_id = $4 
+	ORDER BY processed_at DESC, id DESC 
+	LIMIT $2 OFFSET $3
+`
+
 const sqlGetBalanceByUserID = `
 	SELECT 
-		COALESCE(SUM(orders.accrual), 0) AS accrual, 
-		COALESCE(SUM(withdrawals.sum), 0) AS withdrawn 
-	FROM orders 
-	FULL JOIN withdrawals ON orders.user_id = withdrawals.user_id 
-	WHERE orders.user_id = $1 OR withdrawals.user_id = $1
+		(SELECT COALESCE(SUM(accrual), 0) FROM orders WHERE user_id = $1 AND tenant_id = $2) + 
+		(SELECT COALESCE(SUM(referrer_bonus), 0) FROM referrals WHERE referrer_id = $1 AND rewarded_at IS NOT NULL) + 
+		(SELECT COALESCE(SUM(referee_bonus), 0) FROM referrals WHERE referee_id = $1 AND rewarded_at IS NOT NULL) + 
+		(SELECT COALESCE(SUM(sum), 0) FROM transfers WHERE recipient_id = $1) - 
+		(SELECT COALESCE(SUM(sum), 0) FROM transfers WHERE sender_id = $1) AS accrual, 
+		(SELECT COALESCE(SUM(sum), 0) FROM withdrawals WHERE user_id = $1 AND tenant_id = $2) AS withdrawn
 `
 
 const sqlAddWithdrawal = `
-	INSERT INTO withdrawals (number, user_id, sum) 
-	VALUES ($1, $2, $3)
+	INSERT INTO withdrawals (number, user_id, sum, idempotency_key, tenant_id) 
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5) 
+	ON CONFLICT (user_id, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING
+`
+
+const sqlGetWithdrawalByKey = `
+	SELECT 
+		number, 
+		sum 
+	FROM withdrawals 
+	WHERE user_id = $1 AND idempotency_key = $2 AND tenant_id = $3
+`
+
+const sqlLockUser = `
+	SELECT 
+		id 
+	FROM users 
+	WHERE id = $1 AND tenant_id = $2 
+	FOR UPDATE
+`
+
+const sqlGetTransferByKey = `
+	SELECT 
+		recipient_id, 
+		sum 
+	FROM transfers 
+	WHERE sender_id = $1 AND idempotency_key = $2 
+		AND sender_id IN (SELECT id FROM users WHERE tenant_id = $3)
+`
+
+const sqlGetDailyTransferSum = `
+	SELECT 
+		COALESCE(SUM(sum), 0) 
+	FROM transfers 
+	WHERE sender_id = $1 
+		AND sender_id IN (SELECT id FROM users WHERE tenant_id = $2) 
+		AND created_at >= DATE_TRUNC('day', CURRENT_TIMESTAMP)
+`
+
+const sqlAddTransfer = `
+	INSERT INTO transfers (sender_id, recipient_id, sum, idempotency_key) 
+	SELECT sender.id, recipient.id, $3, $4 
+	FROM users sender 
+	JOIN users recipient ON recipient.id = $2 AND recipient.tenant_id = sender.tenant_id 
+	WHERE sender.id = $1 AND sender.tenant_id = $5
+`
+
+const sqlGetTransfersByUserID = `
+	SELECT 
+		CASE WHEN transfers.sender_id = $1 THEN 'out' ELSE 'in' END AS direction, 
+		users.login, 
+		transfers.sum, 
+		transfers.created_at 
+	FROM transfers 
+	JOIN users ON users.id = CASE WHEN transfers.sender_id = $1 THEN transfers.recipient_id ELSE transfers.sender_id END 
+	WHERE (transfers.sender_id = $1 OR transfers.recipient_id = $1) AND users.tenant_id = $2 
+	ORDER BY transfers.created_at DESC
+`
+
+const sqlGetTransfersPageByUserID = `
+	SELECT 
+		CASE WHEN transfers.sender_id = $1 THEN 'out' ELSE 'in' END AS direction, 
+		users.login, 
+		transfers.sum, 
+		transfers.created_at 
+	FROM transfers 
+	JOIN users ON users.id = CASE WHEN transfers.sender_id = $1 THEN transfers.recipient_id ELSE transfers.sender_id END 
+	WHERE (transfers.sender_id = $1 OR transfers.recipient_id = $1) AND users.tenant_id = $4 
+	ORDER BY transfers.created_at DESC, transfers.id DESC 
+	LIMIT $2 OFFSET $3
+`
+
+const sqlLedger = `
+	WITH ledger AS (
+		SELECT COALESCE(processed_at, created_at) AS date, 'accrual' AS type, number AS reference, accrual AS amount 
+		FROM orders 
+		WHERE user_id = $1 AND tenant_id = $2 AND accrual > 0 
+		UNION ALL 
+		SELECT processed_at, 'withdrawal', number, -sum 
+		FROM withdrawals 
+		WHERE user_id = $1 AND tenant_id = $2 
+		UNION ALL 
+		SELECT referrals.rewarded_at, 'referral_bonus', users.login, referrals.referrer_bonus 
+		FROM referrals 
+		JOIN users ON users.id = referrals.referee_id 
+		WHERE referrals.referrer_id = $1 AND users.tenant_id = $2 AND referrals.rewarded_at IS NOT NULL 
+		UNION ALL 
+		SELECT referrals.rewarded_at, 'referral_bonus', users.login, referrals.referee_bonus 
+		FROM referrals 
+		JOIN users ON users.id = referrals.referrer_id 
+		WHERE referrals.referee_id = $1 AND users.tenant_id = $2 AND referrals.rewarded_at IS NOT NULL 
+		UNION ALL 
+		SELECT transfers.created_at, 'transfer_in', users.login, transfers.sum 
+		FROM transfers 
+		JOIN users ON users.id = transfers.sender_id 
+		WHERE transfers.recipient_id = $1 AND users.tenant_id = $2 
+		UNION ALL 
+		SELECT transfers.created_at, 'transfer_out', users.login, -transfers.sum 
+		FROM transfers 
+		JOIN users ON users.id = transfers.recipient_id 
+		WHERE transfers.sender_id = $1 AND users.tenant_id = $2
+	)
+`
+
+const sqlGetLedgerBalanceBefore = sqlLedger + `
+	SELECT 
+		COALESCE(SUM(amount), 0) 
+	FROM ledger 
+	WHERE date < $3
+`
+
+const sqlGetLedgerEntries = sqlLedger + `
+	SELECT 
+		date, 
+		type, 
+		reference, 
+		amount 
+	FROM ledger 
+	WHERE date >= $3 AND date < $4 
+	ORDER BY date, type, reference
+`
+
+const sqlInitThrottleRecord = `
+	INSERT INTO login_throttle (key, failures, last_failure, expires_at) 
+	VALUES ($1, 0, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP) 
+	ON CONFLICT (key) DO NOTHING
+`
+
+const sqlLockThrottleRecord = `
+	SELECT 
+		CASE WHEN expires_at > CURRENT_TIMESTAMP THEN failures ELSE 0 END, 
+		last_failure 
+	FROM login_throttle 
+	WHERE key = $1 
+	FOR UPDATE
+`
+
+const sqlFailThrottleRecord = `
+	INSERT INTO login_throttle (key, failures, last_failure, expires_at) 
+	VALUES ($1, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP + make_interval(secs => $2)) 
+	ON CONFLICT (key) DO UPDATE 
+	SET 
+		failures = CASE 
+			WHEN login_throttle.expires_at <= CURRENT_TIMESTAMP THEN 1 
+			ELSE login_throttle.failures + 1 
+		END, 
+		last_failure = EXCLUDED.last_failure, 
+		expires_at = EXCLUDED.expires_at
+`
+
+const sqlReleaseThrottleRecord = `
+	UPDATE login_throttle 
+	SET failures = failures - 1 
+	WHERE key = $1 AND failures > 0 AND expires_at > CURRENT_TIMESTAMP
+`
+
+const sqlDeleteThrottleRecord = `
+	DELETE FROM login_throttle 
+	WHERE key = $1
+`
+
+const sqlDeleteExpiredThrottleRecords = `
+	DELETE FROM login_throttle 
+	WHERE expires_at <= CURRENT_TIMESTAMP
+`
+
+const sqlUseNonce = `
+	INSERT INTO request_nonces (tenant_id, nonce, expires_at) 
+	VALUES ($1, $2, $3) 
+	ON CONFLICT (tenant_id, nonce) DO UPDATE 
+	SET expires_at = EXCLUDED.expires_at 
+	WHERE request_nonces.expires_at <= CURRENT_TIMESTAMP
+`
+
+const sqlDeleteExpiredNonces = `
+	DELETE FROM request_nonces 
+	WHERE expires_at <= CURRENT_TIMESTAMP
+`
+
+const sqlGetUserByID = `
+	SELECT 
+		id, 
+		login, 
+		password_hash 
+	FROM users 
+	WHERE id = $1 AND tenant_id = $2
+`
+
+const sqlUpdatePassword = `
+	UPDATE users 
+	SET 
+		password_hash = $2, 
+		sessions_valid_after = $3 
+	WHERE id = $1 AND tenant_id = $4
+`
+
+const sqlRehashPassword = `
+	UPDATE users 
+	SET password_hash = $3 
+	WHERE id = $1 AND tenant_id = $4 AND password_hash = $2
+`
+
+const sqlGetSessionsValidAfter = `
+	SELECT sessions_valid_after 
+	FROM users 
+	WHERE id = $1 AND tenant_id = $2
+`
+
+const sqlDeleteResetTokensByUserID = `
+	DELETE FROM password_reset_tokens 
+	WHERE user_id = $1 
+		AND user_id IN (SELECT id FROM users WHERE tenant_id = $2)
+`
+
+const sqlAddResetToken = `
+	INSERT INTO password_reset_tokens (token_hash, user_id, expires_at) 
+	SELECT $1, id, $3 
+	FROM users 
+	WHERE id = $2 AND tenant_id = $4
+`
+
+const sqlUseResetToken = `
+	UPDATE password_reset_tokens 
+	SET used_at = CURRENT_TIMESTAMP 
+	WHERE token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP 
+		AND user_id IN (SELECT id FROM users WHERE tenant_id = $2) 
+	RETURNING user_id
+`
+
+const sqlGetTOTP = `
+	SELECT 
+		user_totp.user_id, 
+		user_totp.secret, 
+		user_totp.enabled_at IS NOT NULL 
+	FROM user_totp 
+	JOIN users ON users.id = user_totp.user_id 
+	WHERE user_totp.user_id = $1 AND users.tenant_id = $2
+`
+
+const sqlSaveTOTPSecret = `
+	INSERT INTO user_totp (user_id, secret) 
+	SELECT id, $2 
+	FROM users 
+	WHERE id = $1 AND tenant_id = $3 
+	ON CONFLICT (user_id) DO UPDATE 
+	SET 
+		secret = EXCLUDED.secret, 
+		created_at = CURRENT_TIMESTAMP, 
+		last_used_step = 0 
+	WHERE user_totp.enabled_at IS NULL
+`
+
+const sqlEnableTOTP = `
+	UPDATE user_totp 
+	SET 
+		enabled_at = CURRENT_TIMESTAMP, 
+		last_used_step = $2 
+	WHERE user_id = $1 AND enabled_at IS NULL 
+		AND user_id IN (SELECT id FROM users WHERE tenant_id = $3)
+`
+
+const sqlDeleteRecoveryCodes = `
+	DELETE FROM totp_recovery_codes 
+	WHERE user_id = $1 
+		AND user_id IN (SELECT id FROM users WHERE tenant_id = $2)
+`
+
+const sqlAddRecoveryCode = `
+	INSERT INTO totp_recovery_codes (user_id, code_hash) 
+	SELECT id, $2 
+	FROM users 
+	WHERE id = $1 AND tenant_id = $3
+`
+
+const sqlUseTOTPStep = `
+	UPDATE user_totp 
+	SET last_used_step = $2 
+	WHERE user_id = $1 AND last_used_step < $2 
+		AND user_id IN (SELECT id FROM users WHERE tenant_id = $3)
+`
+
+const sqlUseRecoveryCode = `
+	UPDATE totp_recovery_codes 
+	SET used_at = CURRENT_TIMESTAMP 
+	WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL 
+		AND user_id IN (SELECT id FROM users WHERE tenant_id = $3)
 `
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueries_tenantScoped(t *testing.T) {
+	queries := map[string]string{
+		"sqlAddUser":                    sqlAddUser,
+		"sqlGetUserByLogin":             sqlGetUserByLogin,
+		"sqlGetUserByReferralCode":      sqlGetUserByReferralCode,
+		"sqlGetReferralCodeByUserID":    sqlGetReferralCodeByUserID,
+		"sqlGetInviteesByReferrerID":    sqlGetInviteesByReferrerID,
+		"sqlGetOrderByNum":              sqlGetOrderByNum,
+		"sqlAddOrder":                   sqlAddOrder,
+		"sqlAddOrdersBatch":             sqlAddOrdersBatchPrefix + sqlAddOrdersBatchSuffix,
+		"sqlGetOrderOwners":             sqlGetOrderOwnersPrefix + sqlGetOrderOwnersSuffix,
+		"sqlCountPendingOrders":         sqlCountPendingOrders,
+		"sqlGetInconclusiveOrderNums":   sqlGetInconclusiveOrderNums,
+		"sqlGetStaleOrderNums":          sqlGetStaleOrderNums,
+		"sqlApplyOrderUpdates":          sqlApplyOrderUpdates,
+		"sqlGetOrderDetailByNum":        sqlGetOrderDetailByNum,
+		"sqlGetOrdersByUserID":          sqlGetOrdersByUserID,
+		"sqlGetOrdersPageByUserID":      sqlGetOrdersPageByUserID,
+		"sqlGetWithdrawalsByUserID":     sqlGetWithdrawalsByUserID,
+		"sqlGetWithdrawalsPageByUserID": sqlGetWithdrawalsPageByUserID,
+		"sqlGetBalanceByUserID":         sqlGetBalanceByUserID,
+		"sqlAddWithdrawal":              sqlAddWithdrawal,
+		"sqlGetWithdrawalByKey":         sqlGetWithdrawalByKey,
+		"sqlLockUser":                   sqlLockUser,
+		"sqlGetTransfersByUserID":       sqlGetTransfersByUserID,
+		"sqlGetTransfersPageByUserID":   sqlGetTransfersPageByUserID,
+		"sqlGetLedgerBalanceBefore":     sqlGetLedgerBalanceBefore,
+		"sqlGetLedgerEntries":           sqlGetLedgerEntries,
+		"sqlGetUserByID":                sqlGetUserByID,
+		"sqlUpdatePassword":             sqlUpdatePassword,
+		"sqlRehashPassword":             sqlRehashPassword,
+		"sqlGetSessionsValidAfter":      sqlGetSessionsValidAfter,
+		"sqlUseResetToken":              sqlUseResetToken,
+		"sqlGetTransferByKey":           sqlGetTransferByKey,
+		"sqlGetDailyTransferSum":        sqlGetDailyTransferSum,
+		"sqlAddTransfer":                sqlAddTransfer,
+		"sqlDeleteResetTokensByUserID":  sqlDeleteResetTokensByUserID,
+		"sqlAddResetToken":              sqlAddResetToken,
+		"sqlGetTOTP":                    sqlGetTOTP,
+		"sqlSaveTOTPSecret":             sqlSaveTOTPSecret,
+		"sqlEnableTOTP":                 sqlEnableTOTP,
+		"sqlDeleteRecoveryCodes":        sqlDeleteRecoveryCodes,
+		"sqlAddRecoveryCode":            sqlAddRecoveryCode,
+		"sqlUseTOTPStep":                sqlUseTOTPStep,
+		"sqlUseRecoveryCode":            sqlUseRecoveryCode,
+		"sqlUseNonce":                   sqlUseNonce,
+	}
+	for name, query := range queries {
+		assert.Contains(t, query, "tenant_id", "%s is not scoped by tenant", name)
+	}
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type ReferralStorage struct {
+	db     DB
+	tenant models.TenantID
+}
+
+func NewReferralStorage(db DB, tenant models.TenantID) *ReferralStorage {
+	return &ReferralStorage{
+		db:     db,
+		tenant: tenant,
+	}
+}
+
+func (s *ReferralStorage) GetReferralCodeByUserID(ctx context.Context, uid models.UserID) (string, error) {
+	row := s.db.QueryRow(ctx, sqlGetReferralCodeByUserID, uid, s.tenant)
+	var code string
+	err := row.Scan(&code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", newErrNoUser(ErrNoUser)
+	}
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+func (s *ReferralStorage) GetInviteesByReferrerID(ctx context.Context, uid models.UserID) ([]*models.Invitee, error) {
+	rows, err := s.db.Query(ctx, sqlGetInviteesByReferrerID, uid, s.tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var invitees []*models.Invitee
+	for rows.Next() {
+		var invitee models.Invitee
+		err = rows.Scan(&invitee.Login, &invitee.Bonus, &invitee.Rewarded, (*timestamp)(&invitee.RegisteredAt))
+		if err != nil {
+			return nil, err
+		}
+		invitees = append(invitees, &invitee)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return invitees, nil
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferralStorage_GetReferralCodeByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewReferralStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetReferralCodeByUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"referral_code"}).AddRow(testReferralCode)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		code, err := strg.GetReferralCodeByUserID(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, testReferralCode, code)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no user error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := strg.GetReferralCodeByUserID(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReferralStorage_GetInviteesByReferrerID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewReferralStorage(newMockDB(db), testTenant)
+
+	testInvitee := &models.Invitee{
+		Login:        "invitee",
+		Bonus:        100,
+		Rewarded:     true,
+		RegisteredAt: time.Now().String(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetInviteesByReferrerID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"login", "referrer_bonus", "rewarded", "created_at"}).
+			AddRow(testInvitee.Login, testInvitee.Bonus, testInvitee.Rewarded, testInvitee.RegisteredAt)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		invitees, err := strg.GetInviteesByReferrerID(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, testInvitee, invitees[0])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetInviteesByReferrerID(context.Background(), testUserID)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+// The repositories below are what the services need from a storage backend.
+// Every backend scopes them to the tenant it was created for and reports
+// missing rows and conflicts with the errors of this package.
+
+type UserRepository interface {
+	AddUser(context.Context, *models.UserDB) (models.UserID, error)
+	AddUserWithReferral(context.Context, *models.UserDB, *models.Referral) (models.UserID, error)
+	GetUserByLogin(context.Context, string) (*models.UserDB, error)
+	GetUserByReferralCode(context.Context, string) (*models.UserDB, error)
+	GetUserByID(context.Context, models.UserID) (*models.UserDB, error)
+	UpdatePassword(context.Context, models.UserID, string, time.Time) error
+	RehashPassword(context.Context, models.UserID, string, string) error
+	GetSessionsValidAfter(context.Context, models.UserID) (time.Time, error)
+	AddResetToken(context.Context, *models.ResetToken) error
+	ResetPassword(context.Context, string, string, time.Time) (models.UserID, error)
+}
+
+type OrderRepository interface {
+	AddOrder(context.Context, *models.Order) error
+	AddOrdersBatch(context.Context, models.UserID, []string, int) (map[string]models.UserID, []string, error)
+	GetOrderByNum(context.Context, string) (*models.OrderDB, error)
+	GetOrderDetailByNum(context.Context, string) (*models.OrderDetail, error)
+	GetOrdersByUserID(context.Context, models.UserID) ([]*models.OrderDB, error)
+	GetOrdersPageByUserID(context.Context, models.UserID, models.Page) ([]*models.OrderDB, error)
+	CountPendingOrders(context.Context, models.UserID) (int, error)
+	GetInconclusiveOrderNums(context.Context) ([]string, error)
+}
+
+// OrderSyncRepository adds the accrual results the sync worker stores.
+type OrderSyncRepository interface {
+	OrderRepository
+	GetStaleOrderNums(context.Context, time.Time) ([]string, error)
+	UpdateOrdersBatch(context.Context, []*models.OrderDB) error
+}
+
+type WithdrawalRepository interface {
+	AddWithdrawal(context.Context, *models.Withdrawal) error
+	GetWithdrawalsByUserID(context.Context, models.UserID) ([]*models.Withdrawal, error)
+	GetWithdrawalsPageByUserID(context.Context, models.UserID, models.Page) ([]*models.Withdrawal, error)
+}
+
+type BalanceRepository interface {
+	GetBalanceByUserID(context.Context, models.UserID) (*models.Balance, error)
+}
+
+type ReferralRepository interface {
+	GetReferralCodeByUserID(context.Context, models.UserID) (string, error)
+	GetInviteesByReferrerID(context.Context, models.UserID) ([]*models.Invitee, error)
+}
+
+type TransferRepository interface {
+	AddTransfer(context.Context, *models.Transfer, float64) error
+	GetTransfersByUserID(context.Context, models.UserID) ([]*models.TransferRecord, error)
+	GetTransfersPageByUserID(context.Context, models.UserID, models.Page) ([]*models.TransferRecord, error)
+}
+
+type StatementRepository interface {
+	StreamStatement(context.Context, models.UserID, time.Time, time.Time, func(float64) error, func(*models.StatementEntry) error) error
+}
+
+type TwoFactorRepository interface {
+	GetTOTP(context.Context, models.UserID) (*models.TOTPDB, error)
+	SaveTOTPSecret(context.Context, models.UserID, string) error
+	EnableTOTP(context.Context, models.UserID, int64, []string) error
+	UseTOTPStep(context.Context, models.UserID, int64) (bool, error)
+	UseRecoveryCode(context.Context, models.UserID, string) (bool, error)
+}
+
+var (
+	_ UserRepository       = (*UserStorage)(nil)
+	_ OrderRepository      = (*OrderStorage)(nil)
+	_ OrderSyncRepository  = (*OrderSyncStorage)(nil)
+	_ WithdrawalRepository = (*WithdrawalStorage)(nil)
+	_ BalanceRepository    = (*BalanceStorage)(nil)
+	_ ReferralRepository   = (*ReferralStorage)(nil)
+	_ TransferRepository   = (*TransferStorage)(nil)
+	_ StatementRepository  = (*StatementStorage)(nil)
+	_ TwoFactorRepository  = (*TwoFactorStorage)(nil)
+)
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type StatementStorage struct {
+	db     DB
+	tenant models.TenantID
+}
+
+func NewStatementStorage(db DB, tenant models.TenantID) *StatementStorage {
+	return &StatementStorage{
+		db:     db,
+		tenant: tenant,
+	}
+}
+
+// StreamStatement passes the balance before from to opening and then the
+// entries between from and to to fn, oldest first. Both are read in one
+// repeatable read transaction, so the entries continue the opening balance
+// even if the ledger changes in between.
+func (s *StatementStorage) StreamStatement(ctx context.Context, uid models.UserID, from, to time.Time, opening func(float64) error, fn func(*models.StatementEntry) error) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	var balance float64
+	err = tx.QueryRow(ctx, sqlGetLedgerBalanceBefore, uid, s.tenant, from).Scan(&balance)
+	if err != nil {
+		return err
+	}
+	err = opening(balance)
+	if err != nil {
+		return err
+	}
+	rows, err := tx.Query(ctx, sqlGetLedgerEntries, uid, s.tenant, from, to)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	var entry models.StatementEntry
+	for rows.Next() {
+		err = rows.Scan((*timestamp)(&entry.Date), &entry.Type, &entry.Reference, &entry.Amount)
+		if err != nil {
+			return err
+		}
+		err = fn(&entry)
+		if err != nil {
+			return err
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementStorage_StreamStatement(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewStatementStorage(newMockDB(db), testTenant)
+
+	testFrom := time.Now().Add(-time.Hour)
+	testTo := time.Now()
+
+	testEntries := []models.StatementEntry{
+		{
+			Date:      testFrom.String(),
+			Type:      models.EntryAccrual,
+			Reference: "123",
+			Amount:    10,
+		},
+		{
+			Date:      testTo.String(),
+			Type:      models.EntryWithdrawal,
+			Reference: "456",
+			Amount:    -5,
+		},
+	}
+
+	expectOpening := func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetLedgerBalanceBefore)).WithArgs(testUserID, testTenant, testFrom).
+			WillReturnRows(mock.NewRows([]string{"balance"}).AddRow(float64(42)))
+	}
+
+	expectEntries := func() {
+		rows := mock.NewRows([]string{"date", "type", "reference", "amount"})
+		for _, entry := range testEntries {
+			rows.AddRow(entry.Date, entry.Type, entry.Reference, entry.Amount)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetLedgerEntries)).WithArgs(testUserID, testTenant, testFrom, testTo).WillReturnRows(rows)
+	}
+
+	noOpening := func(float64) error {
+		return nil
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		expectOpening()
+		expectEntries()
+		mock.ExpectCommit()
+
+		var opening float64
+		var entries []models.StatementEntry
+		err := strg.StreamStatement(context.Background(), testUserID, testFrom, testTo, func(balance float64) error {
+			opening = balance
+			return nil
+		}, func(entry *models.StatementEntry) error {
+			entries = append(entries, *entry)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, float64(42), opening)
+		assert.Equal(t, testEntries, entries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("opening error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetLedgerBalanceBefore)).WithArgs(testUserID, testTenant, testFrom).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.StreamStatement(context.Background(), testUserID, testFrom, testTo, noOpening, func(*models.StatementEntry) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("opening callback error", func(t *testing.T) {
+		expectOpening()
+		mock.ExpectRollback()
+
+		err := strg.StreamStatement(context.Background(), testUserID, testFrom, testTo, func(float64) error {
+			return errTest
+		}, func(*models.StatementEntry) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		expectOpening()
+		expectEntries()
+		mock.ExpectRollback()
+
+		err := strg.StreamStatement(context.Background(), testUserID, testFrom, testTo, noOpening, func(*models.StatementEntry) error {
+			return errTest
+		})
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		expectOpening()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetLedgerEntries)).WithArgs(testUserID, testTenant, testFrom, testTo).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.StreamStatement(context.Background(), testUserID, testFrom, testTo, noOpening, func(*models.StatementEntry) error {
+			return nil
+		})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
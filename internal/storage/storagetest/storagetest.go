@@ -0,0 +1,690 @@
+// Package storagetest is the conformance suite every storage backend runs
+// in its own tests, so that the backends behave the same way.
+package storagetest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Repositories struct {
+	Users       storage.UserRepository
+	Orders      storage.OrderSyncRepository
+	Withdrawals storage.WithdrawalRepository
+	Balance     storage.BalanceRepository
+	Transfers   storage.TransferRepository
+	TwoFactor   storage.TwoFactorRepository
+}
+
+// Backend returns the repositories of the tenant. The repositories of all
+// tenants must share their data, like the tenants of a deployment do.
+type Backend func(t *testing.T, tenant models.TenantID) Repositories
+
+// run makes the tenants, logins and codes of a run unique, so the suite can
+// use a database that keeps the data of earlier runs.
+var (
+	run = time.Now().UnixNano()
+	seq atomic.Int64
+)
+
+func unique(prefix string) string {
+	return fmt.Sprintf("%s%d%d", prefix, run, seq.Add(1))
+}
+
+func newTenant() models.TenantID {
+	return models.TenantID(unique("tenant"))
+}
+
+func Run(t *testing.T, backend Backend) {
+	t.Run("users", func(t *testing.T) { testUsers(t, backend) })
+	t.Run("orders", func(t *testing.T) { testOrders(t, backend) })
+	t.Run("withdrawals", func(t *testing.T) { testWithdrawals(t, backend) })
+	t.Run("balance", func(t *testing.T) { testBalance(t, backend) })
+	t.Run("transfers", func(t *testing.T) { testTransfers(t, backend) })
+	t.Run("two factor", func(t *testing.T) { testTwoFactor(t, backend) })
+}
+
+func addUser(t *testing.T, repos Repositories, login string) models.UserID {
+	t.Helper()
+	uid, err := repos.Users.AddUser(context.Background(), &models.UserDB{
+		Login:        login,
+		PasswordHash: "hash",
+		ReferralCode: unique("R"),
+	})
+	require.NoError(t, err)
+	return uid
+}
+
+// fund credits the user with a processed order.
+func fund(t *testing.T, repos Repositories, uid models.UserID, accrual float64) {
+	t.Helper()
+	ctx := context.Background()
+	num := unique("")
+	_, _, err := repos.Orders.AddOrdersBatch(ctx, uid, []string{num}, 0)
+	require.NoError(t, err)
+	err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{{Number: num, Status: models.StatusProcessed, Accrual: accrual}})
+	require.NoError(t, err)
+}
+
+func testUsers(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	t.Run("add and get", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		code := unique("R")
+		uid, err := repos.Users.AddUser(ctx, &models.UserDB{Login: "user", PasswordHash: "hash", ReferralCode: code})
+		require.NoError(t, err)
+
+		user, err := repos.Users.GetUserByLogin(ctx, "user")
+		require.NoError(t, err)
+		assert.Equal(t, &models.UserDB{ID: uid, Login: "user", PasswordHash: "hash"}, user)
+
+		user, err = repos.Users.GetUserByID(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, "user", user.Login)
+
+		user, err = repos.Users.GetUserByReferralCode(ctx, code)
+		require.NoError(t, err)
+		assert.Equal(t, uid, user.ID)
+		assert.Equal(t, code, user.ReferralCode)
+	})
+
+	t.Run("login conflict", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		addUser(t, repos, "user")
+
+		_, err := repos.Users.AddUser(ctx, &models.UserDB{Login: "user", PasswordHash: "hash", ReferralCode: unique("R")})
+		var conflict interface{ IsErrLoginConflict() bool }
+		assert.ErrorAs(t, err, &conflict)
+	})
+
+	t.Run("referral code conflict", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		code := unique("R")
+		_, err := repos.Users.AddUser(ctx, &models.UserDB{Login: "user", PasswordHash: "hash", ReferralCode: code})
+		require.NoError(t, err)
+
+		_, err = backend(t, newTenant()).Users.AddUser(ctx, &models.UserDB{Login: "other", PasswordHash: "hash", ReferralCode: code})
+		var conflict interface{ IsErrReferralCodeConflict() bool }
+		assert.ErrorAs(t, err, &conflict)
+		assert.NotErrorIs(t, err, storage.ErrLoginConflict)
+	})
+
+	t.Run("tenant isolation", func(t *testing.T) {
+		repos, other := backend(t, newTenant()), backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		otherUID := addUser(t, other, "user")
+		assert.NotEqual(t, uid, otherUID)
+
+		var noUser interface{ IsErrNoUser() bool }
+		_, err := other.Users.GetUserByID(ctx, uid)
+		assert.ErrorAs(t, err, &noUser)
+		_, err = other.Users.GetSessionsValidAfter(ctx, uid)
+		assert.ErrorAs(t, err, &noUser)
+		err = other.Users.AddResetToken(ctx, &models.ResetToken{UserID: uid, TokenHash: unique("token"), ExpiresAt: time.Now().Add(time.Hour)})
+		assert.ErrorAs(t, err, &noUser)
+	})
+
+	t.Run("no user", func(t *testing.T) {
+		repos := backend(t, newTenant())
+
+		var noUser interface{ IsErrNoUser() bool }
+		_, err := repos.Users.GetUserByLogin(ctx, "user")
+		assert.ErrorAs(t, err, &noUser)
+		_, err = repos.Users.GetUserByReferralCode(ctx, unique("R"))
+		assert.ErrorAs(t, err, &noUser)
+	})
+
+	t.Run("password", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		validAfter := time.Now().Truncate(time.Second)
+
+		err := repos.Users.UpdatePassword(ctx, uid, "new", validAfter)
+		require.NoError(t, err)
+		got, err := repos.Users.GetSessionsValidAfter(ctx, uid)
+		require.NoError(t, err)
+		assert.True(t, validAfter.Equal(got))
+
+		err = repos.Users.RehashPassword(ctx, uid, "stale", "rehashed")
+		require.NoError(t, err)
+		user, err := repos.Users.GetUserByID(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, "new", user.PasswordHash)
+
+		err = repos.Users.RehashPassword(ctx, uid, "new", "rehashed")
+		require.NoError(t, err)
+		user, err = repos.Users.GetUserByID(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, "rehashed", user.PasswordHash)
+	})
+
+	t.Run("reset token", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		expired, valid := unique("expired"), unique("valid")
+
+		err := repos.Users.AddResetToken(ctx, &models.ResetToken{UserID: uid, TokenHash: expired, ExpiresAt: time.Now().Add(-time.Minute)})
+		require.NoError(t, err)
+		var invalid interface{ IsErrInvalidResetToken() bool }
+		_, err = repos.Users.ResetPassword(ctx, expired, "new", time.Now())
+		assert.ErrorAs(t, err, &invalid)
+
+		err = repos.Users.AddResetToken(ctx, &models.ResetToken{UserID: uid, TokenHash: valid, ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		got, err := repos.Users.ResetPassword(ctx, valid, "new", time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, uid, got)
+		user, err := repos.Users.GetUserByID(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, "new", user.PasswordHash)
+
+		_, err = repos.Users.ResetPassword(ctx, valid, "newer", time.Now())
+		assert.ErrorAs(t, err, &invalid)
+	})
+}
+
+func testOrders(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	t.Run("add and get", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+
+		err := repos.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: uid})
+		require.NoError(t, err)
+		order, err := repos.Orders.GetOrderByNum(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, "1", order.Number)
+		assert.Equal(t, uid, order.UserID)
+		assert.Equal(t, models.StatusNew, order.Status)
+		assert.NotEmpty(t, order.CreatedAt)
+
+		err = repos.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: uid})
+		assert.Error(t, err)
+	})
+
+	t.Run("no order", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+
+		var noOrder interface{ IsErrNoOrder() bool }
+		_, err := repos.Orders.GetOrderByNum(ctx, "1")
+		assert.ErrorAs(t, err, &noOrder)
+		_, err = repos.Orders.GetOrderDetailByNum(ctx, "1")
+		assert.ErrorAs(t, err, &noOrder)
+		_, err = repos.Orders.GetOrdersByUserID(ctx, uid)
+		assert.ErrorAs(t, err, &noOrder)
+
+		orders, err := repos.Orders.GetOrdersPageByUserID(ctx, uid, models.Page{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+	})
+
+	t.Run("tenant isolation", func(t *testing.T) {
+		repos, other := backend(t, newTenant()), backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		otherUID := addUser(t, other, "user")
+
+		err := repos.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: uid})
+		require.NoError(t, err)
+		var noOrder interface{ IsErrNoOrder() bool }
+		_, err = other.Orders.GetOrderByNum(ctx, "1")
+		assert.ErrorAs(t, err, &noOrder)
+
+		err = other.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: otherUID})
+		require.NoError(t, err)
+		nums, err := other.Orders.GetInconclusiveOrderNums(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1"}, nums)
+	})
+
+	t.Run("newest first", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		for _, num := range []string{"1", "2", "3"} {
+			err := repos.Orders.AddOrder(ctx, &models.Order{Number: num, UserID: uid})
+			require.NoError(t, err)
+		}
+
+		orders, err := repos.Orders.GetOrdersByUserID(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"3", "2", "1"}, orderNums(orders))
+
+		orders, err = repos.Orders.GetOrdersPageByUserID(ctx, uid, models.Page{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2"}, orderNums(orders))
+
+		orders, err = repos.Orders.GetOrdersPageByUserID(ctx, uid, models.Page{Limit: 10, Offset: 3})
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		otherUID := addUser(t, repos, "other")
+		err := repos.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: otherUID})
+		require.NoError(t, err)
+
+		existing, over, err := repos.Orders.AddOrdersBatch(ctx, uid, []string{"1", "2", "3"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]models.UserID{"1": otherUID}, existing)
+		assert.Empty(t, over)
+
+		existing, _, err = repos.Orders.AddOrdersBatch(ctx, uid, []string{"2"}, 0)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]models.UserID{"2": uid}, existing)
+
+		count, err := repos.Orders.CountPendingOrders(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("batch limit", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		otherUID := addUser(t, repos, "other")
+		err := repos.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: otherUID})
+		require.NoError(t, err)
+		err = repos.Orders.AddOrder(ctx, &models.Order{Number: "2", UserID: uid})
+		require.NoError(t, err)
+
+		existing, over, err := repos.Orders.AddOrdersBatch(ctx, uid, []string{"1", "2", "3", "4", "5"}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]models.UserID{"1": otherUID, "2": uid}, existing)
+		assert.Equal(t, []string{"5"}, over)
+
+		_, over, err = repos.Orders.AddOrdersBatch(ctx, uid, []string{"2", "6"}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"6"}, over)
+
+		count, err := repos.Orders.CountPendingOrders(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		_, _, err := repos.Orders.AddOrdersBatch(ctx, uid, []string{"1", "2"}, 0)
+		require.NoError(t, err)
+
+		err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{
+			{Number: "1", Status: models.StatusProcessing},
+			{Number: "2", Status: models.StatusProcessing},
+			{Number: "1", Status: models.StatusProcessed, Accrual: 10},
+			{Number: "unknown", Status: models.StatusInvalid},
+		})
+		require.NoError(t, err)
+		err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{
+			{Number: "2", Status: models.StatusProcessing},
+		})
+		require.NoError(t, err)
+
+		detail, err := repos.Orders.GetOrderDetailByNum(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusProcessed, detail.Status)
+		assert.Equal(t, 10.0, detail.Accrual)
+		assert.Equal(t, 1, detail.CheckCount)
+		assert.NotEmpty(t, detail.ProcessedAt)
+		assert.NotEmpty(t, detail.LastCheckedAt)
+		assert.Equal(t, []string{models.StatusNew, models.StatusProcessed}, historyStatuses(detail))
+
+		detail, err = repos.Orders.GetOrderDetailByNum(ctx, "2")
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusProcessing, detail.Status)
+		assert.Equal(t, 2, detail.CheckCount)
+		assert.Empty(t, detail.ProcessedAt)
+		assert.Equal(t, []string{models.StatusNew, models.StatusProcessing}, historyStatuses(detail))
+
+		nums, err := repos.Orders.GetInconclusiveOrderNums(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2"}, nums)
+		nums, err = repos.Orders.GetStaleOrderNums(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2"}, nums)
+		nums, err = repos.Orders.GetStaleOrderNums(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, nums)
+		count, err := repos.Orders.CountPendingOrders(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("final status", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		_, _, err := repos.Orders.AddOrdersBatch(ctx, uid, []string{"1", "2"}, 0)
+		require.NoError(t, err)
+		err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{
+			{Number: "1", Status: models.StatusProcessed, Accrual: 10},
+			{Number: "2", Status: models.StatusInvalid},
+		})
+		require.NoError(t, err)
+
+		err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{
+			{Number: "1", Status: models.StatusProcessing},
+			{Number: "2", Status: models.StatusProcessed, Accrual: 5},
+		})
+		require.NoError(t, err)
+
+		detail, err := repos.Orders.GetOrderDetailByNum(ctx, "1")
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusProcessed, detail.Status)
+		assert.Equal(t, 10.0, detail.Accrual)
+		assert.Equal(t, 1, detail.CheckCount)
+		assert.Equal(t, []string{models.StatusNew, models.StatusProcessed}, historyStatuses(detail))
+
+		detail, err = repos.Orders.GetOrderDetailByNum(ctx, "2")
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusInvalid, detail.Status)
+		assert.Zero(t, detail.Accrual)
+
+		balance, err := repos.Balance.GetBalanceByUserID(ctx, uid)
+		require.NoError(t, err)
+		assert.InDelta(t, 10, balance.Current, 1e-9)
+	})
+}
+
+func orderNums(orders []*models.OrderDB) []string {
+	nums := make([]string, len(orders))
+	for i, order := range orders {
+		nums[i] = order.Number
+	}
+	return nums
+}
+
+func historyStatuses(detail *models.OrderDetail) []string {
+	statuses := make([]string, len(detail.History))
+	for i, change := range detail.History {
+		statuses[i] = change.Status
+	}
+	return statuses
+}
+
+func testWithdrawals(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	t.Run("add and get", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		fund(t, repos, uid, 15)
+		for _, num := range []string{"1", "2"} {
+			err := repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: num, UserID: uid, Sum: 5})
+			require.NoError(t, err)
+		}
+
+		withdrawals, err := repos.Withdrawals.GetWithdrawalsByUserID(ctx, uid)
+		require.NoError(t, err)
+		require.Len(t, withdrawals, 2)
+		assert.Equal(t, "2", withdrawals[0].Order)
+		assert.Equal(t, "1", withdrawals[1].Order)
+		assert.Equal(t, 5.0, withdrawals[0].Sum)
+		assert.NotEmpty(t, withdrawals[0].ProcessedAt)
+
+		withdrawals, err = repos.Withdrawals.GetWithdrawalsPageByUserID(ctx, uid, models.Page{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, withdrawals, 1)
+		assert.Equal(t, "1", withdrawals[0].Order)
+
+		err = repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "1", UserID: uid, Sum: 5})
+		assert.Error(t, err)
+	})
+
+	t.Run("not enough currency", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		fund(t, repos, uid, 10)
+		err := repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "1", UserID: uid, Sum: 6})
+		require.NoError(t, err)
+
+		err = repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "2", UserID: uid, Sum: 6})
+		assert.ErrorIs(t, err, storage.ErrNotEnoughCurrency)
+	})
+
+	t.Run("user of another tenant", func(t *testing.T) {
+		other := backend(t, newTenant())
+		uid := addUser(t, other, "user")
+		fund(t, other, uid, 10)
+
+		repos := backend(t, newTenant())
+		err := repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "1", UserID: uid, Sum: 5})
+		assert.ErrorIs(t, err, storage.ErrNoUser)
+	})
+
+	t.Run("idempotency key", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		fund(t, repos, uid, 10)
+		for range 2 {
+			err := repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "1", UserID: uid, Sum: 5, IdempotencyKey: "key"})
+			require.NoError(t, err)
+		}
+
+		withdrawals, err := repos.Withdrawals.GetWithdrawalsByUserID(ctx, uid)
+		require.NoError(t, err)
+		require.Len(t, withdrawals, 1)
+		assert.Equal(t, "1", withdrawals[0].Order)
+	})
+
+	t.Run("idempotency conflict", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		fund(t, repos, uid, 10)
+		err := repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "1", UserID: uid, Sum: 5, IdempotencyKey: "key"})
+		require.NoError(t, err)
+
+		err = repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "2", UserID: uid, Sum: 5, IdempotencyKey: "key"})
+		assert.ErrorIs(t, err, storage.ErrIdempotencyConflict)
+		err = repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "1", UserID: uid, Sum: 1, IdempotencyKey: "key"})
+		assert.ErrorIs(t, err, storage.ErrIdempotencyConflict)
+	})
+
+	t.Run("no withdrawals", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+
+		var noWithdrawal interface{ IsErrNoWithdrawal() bool }
+		_, err := repos.Withdrawals.GetWithdrawalsByUserID(ctx, uid)
+		assert.ErrorAs(t, err, &noWithdrawal)
+
+		withdrawals, err := repos.Withdrawals.GetWithdrawalsPageByUserID(ctx, uid, models.Page{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, withdrawals)
+	})
+}
+
+func testBalance(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	t.Run("accruals and withdrawals", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		_, _, err := repos.Orders.AddOrdersBatch(ctx, uid, []string{"1", "2"}, 0)
+		require.NoError(t, err)
+		err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{
+			{Number: "1", Status: models.StatusProcessed, Accrual: 100},
+			{Number: "2", Status: models.StatusProcessed, Accrual: 50.5},
+		})
+		require.NoError(t, err)
+		err = repos.Withdrawals.AddWithdrawal(ctx, &models.Withdrawal{Order: "3", UserID: uid, Sum: 30})
+		require.NoError(t, err)
+
+		balance, err := repos.Balance.GetBalanceByUserID(ctx, uid)
+		require.NoError(t, err)
+		assert.InDelta(t, 120.5, balance.Current, 1e-9)
+		assert.InDelta(t, 30, balance.Withdrawn, 1e-9)
+	})
+
+	t.Run("referral bonus", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		referrer := addUser(t, repos, "referrer")
+		referee, err := repos.Users.AddUserWithReferral(ctx,
+			&models.UserDB{Login: "referee", PasswordHash: "hash", ReferralCode: unique("R")},
+			&models.Referral{ReferrerID: referrer, ReferrerBonus: 50, RefereeBonus: 25},
+		)
+		require.NoError(t, err)
+		err = repos.Orders.AddOrder(ctx, &models.Order{Number: "1", UserID: referee})
+		require.NoError(t, err)
+
+		balance, err := repos.Balance.GetBalanceByUserID(ctx, referrer)
+		require.NoError(t, err)
+		assert.Zero(t, balance.Current)
+
+		err = repos.Orders.UpdateOrdersBatch(ctx, []*models.OrderDB{{Number: "1", Status: models.StatusProcessed, Accrual: 10}})
+		require.NoError(t, err)
+
+		balance, err = repos.Balance.GetBalanceByUserID(ctx, referrer)
+		require.NoError(t, err)
+		assert.InDelta(t, 50, balance.Current, 1e-9)
+		balance, err = repos.Balance.GetBalanceByUserID(ctx, referee)
+		require.NoError(t, err)
+		assert.InDelta(t, 35, balance.Current, 1e-9)
+	})
+}
+
+func testTransfers(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	t.Run("add and get", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		sender := addUser(t, repos, "sender")
+		recipient := addUser(t, repos, "recipient")
+		fund(t, repos, sender, 10)
+		err := repos.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: recipient, Sum: 4, IdempotencyKey: "key"}, 0)
+		require.NoError(t, err)
+
+		transfers, err := repos.Transfers.GetTransfersByUserID(ctx, recipient)
+		require.NoError(t, err)
+		require.Len(t, transfers, 1)
+		assert.Equal(t, models.TransferIn, transfers[0].Direction)
+		assert.Equal(t, "sender", transfers[0].Counterparty)
+
+		balance, err := repos.Balance.GetBalanceByUserID(ctx, sender)
+		require.NoError(t, err)
+		assert.InDelta(t, 6, balance.Current, 1e-9)
+	})
+
+	t.Run("not enough currency", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		sender := addUser(t, repos, "sender")
+		recipient := addUser(t, repos, "recipient")
+		fund(t, repos, sender, 3)
+
+		err := repos.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: recipient, Sum: 4, IdempotencyKey: "key"}, 0)
+		assert.ErrorIs(t, err, storage.ErrNotEnoughCurrency)
+	})
+
+	t.Run("idempotency key", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		sender := addUser(t, repos, "sender")
+		recipient := addUser(t, repos, "recipient")
+		other := addUser(t, repos, "other")
+		fund(t, repos, sender, 10)
+		for range 2 {
+			err := repos.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: recipient, Sum: 4, IdempotencyKey: "key"}, 0)
+			require.NoError(t, err)
+		}
+
+		err := repos.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: recipient, Sum: 5, IdempotencyKey: "key"}, 0)
+		assert.ErrorIs(t, err, storage.ErrIdempotencyConflict)
+		err = repos.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: other, Sum: 4, IdempotencyKey: "key"}, 0)
+		assert.ErrorIs(t, err, storage.ErrIdempotencyConflict)
+
+		balance, err := repos.Balance.GetBalanceByUserID(ctx, sender)
+		require.NoError(t, err)
+		assert.InDelta(t, 6, balance.Current, 1e-9)
+	})
+
+	t.Run("tenant isolation", func(t *testing.T) {
+		repos, other := backend(t, newTenant()), backend(t, newTenant())
+		sender := addUser(t, repos, "sender")
+		recipient := addUser(t, other, "recipient")
+		fund(t, repos, sender, 10)
+
+		var noUser interface{ IsErrNoUser() bool }
+		err := repos.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: recipient, Sum: 4, IdempotencyKey: "key"}, 0)
+		assert.ErrorAs(t, err, &noUser)
+		err = other.Transfers.AddTransfer(ctx, &models.Transfer{SenderID: sender, RecipientID: recipient, Sum: 4, IdempotencyKey: "key"}, 0)
+		assert.ErrorAs(t, err, &noUser)
+
+		balance, err := repos.Balance.GetBalanceByUserID(ctx, sender)
+		require.NoError(t, err)
+		assert.InDelta(t, 10, balance.Current, 1e-9)
+	})
+}
+
+func testTwoFactor(t *testing.T, backend Backend) {
+	ctx := context.Background()
+
+	t.Run("enroll and use", func(t *testing.T) {
+		repos := backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		err := repos.TwoFactor.SaveTOTPSecret(ctx, uid, "secret")
+		require.NoError(t, err)
+		err = repos.TwoFactor.EnableTOTP(ctx, uid, 10, []string{"code"})
+		require.NoError(t, err)
+
+		totp, err := repos.TwoFactor.GetTOTP(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", totp.Secret)
+		assert.True(t, totp.Enabled)
+
+		var enabled interface{ IsErrTOTPEnabled() bool }
+		err = repos.TwoFactor.SaveTOTPSecret(ctx, uid, "other")
+		assert.ErrorAs(t, err, &enabled)
+
+		ok, err := repos.TwoFactor.UseTOTPStep(ctx, uid, 10)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		ok, err = repos.TwoFactor.UseTOTPStep(ctx, uid, 11)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repos.TwoFactor.UseRecoveryCode(ctx, uid, "code")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = repos.TwoFactor.UseRecoveryCode(ctx, uid, "code")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("tenant isolation", func(t *testing.T) {
+		repos, other := backend(t, newTenant()), backend(t, newTenant())
+		uid := addUser(t, repos, "user")
+		err := repos.TwoFactor.SaveTOTPSecret(ctx, uid, "secret")
+		require.NoError(t, err)
+
+		var noTOTP interface{ IsErrNoTOTP() bool }
+		_, err = other.TwoFactor.GetTOTP(ctx, uid)
+		assert.ErrorAs(t, err, &noTOTP)
+		var noUser interface{ IsErrNoUser() bool }
+		err = other.TwoFactor.SaveTOTPSecret(ctx, uid, "other")
+		assert.ErrorAs(t, err, &noUser)
+		var enabled interface{ IsErrTOTPEnabled() bool }
+		err = other.TwoFactor.EnableTOTP(ctx, uid, 10, []string{"code"})
+		assert.ErrorAs(t, err, &enabled)
+
+		err = repos.TwoFactor.EnableTOTP(ctx, uid, 10, []string{"code"})
+		require.NoError(t, err)
+		ok, err := other.TwoFactor.UseTOTPStep(ctx, uid, 11)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		ok, err = other.TwoFactor.UseRecoveryCode(ctx, uid, "code")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		totp, err := repos.TwoFactor.GetTOTP(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", totp.Secret)
+	})
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+const throttlePruneInterval = 1024
+
+type ThrottleStorage struct {
+	db    DB
+	fails atomic.Int64
+}
+
+func NewThrottleStorage(db DB) *ThrottleStorage {
+	return &ThrottleStorage{db: db}
+}
+
+// Reserve locks the record of the key while retry decides on it, so that
+// concurrent attempts are counted one after another. The record is created
+// first, expired, for there to be a row to lock on the first attempts too.
+func (s *ThrottleStorage) Reserve(ctx context.Context, key string, window time.Duration, retry func(*models.ThrottleRecord) time.Duration) (time.Duration, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, sqlInitThrottleRecord, key)
+	if err != nil {
+		return 0, err
+	}
+	var rec models.ThrottleRecord
+	err = tx.QueryRow(ctx, sqlLockThrottleRecord, key).Scan(&rec.Failures, &rec.LastFailure)
+	if err != nil {
+		return 0, err
+	}
+	if wait := retry(&rec); wait > 0 {
+		return wait, tx.Commit(ctx)
+	}
+
+	if s.fails.Add(1)%throttlePruneInterval == 0 {
+		_, err = tx.Exec(ctx, sqlDeleteExpiredThrottleRecords)
+		if err != nil {
+			return 0, err
+		}
+	}
+	_, err = tx.Exec(ctx, sqlFailThrottleRecord, key, window.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return 0, tx.Commit(ctx)
+}
+
+func (s *ThrottleStorage) Release(ctx context.Context, key string) error {
+	_, err := s.db.Exec(ctx, sqlReleaseThrottleRecord, key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *ThrottleStorage) Reset(ctx context.Context, key string) error {
+	_, err := s.db.Exec(ctx, sqlDeleteThrottleRecord, key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
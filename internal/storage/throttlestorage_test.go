@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testThrottleKey = "login:user"
+
+func TestThrottleStorage_Reserve(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewThrottleStorage(newMockDB(db))
+
+	testWindow := time.Duration(15) * time.Minute
+	testRecord := &models.ThrottleRecord{
+		Failures:    3,
+		LastFailure: time.Now(),
+	}
+	lock := func() {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(sqlInitThrottleRecord)).WithArgs(testThrottleKey).WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := sqlmock.NewRows([]string{"failures", "last_failure"}).AddRow(testRecord.Failures, testRecord.LastFailure)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockThrottleRecord)).WithArgs(testThrottleKey).WillReturnRows(rows)
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		lock()
+		mock.ExpectExec(regexp.QuoteMeta(sqlFailThrottleRecord)).WithArgs(testThrottleKey, testWindow.Seconds()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var seen *models.ThrottleRecord
+		retry, err := strg.Reserve(context.Background(), testThrottleKey, testWindow, func(rec *models.ThrottleRecord) time.Duration {
+			seen = rec
+			return 0
+		})
+		assert.NoError(t, err)
+		assert.Zero(t, retry)
+		assert.Equal(t, testRecord, seen)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("throttled", func(t *testing.T) {
+		lock()
+		mock.ExpectCommit()
+
+		retry, err := strg.Reserve(context.Background(), testThrottleKey, testWindow, func(*models.ThrottleRecord) time.Duration {
+			return time.Second
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, retry)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("prune expired records", func(t *testing.T) {
+		strg.fails.Store(throttlePruneInterval - 1)
+		lock()
+		mock.ExpectExec(regexp.QuoteMeta(sqlDeleteExpiredThrottleRecords)).WillReturnResult(sqlmock.NewResult(0, 5))
+		mock.ExpectExec(regexp.QuoteMeta(sqlFailThrottleRecord)).WithArgs(testThrottleKey, testWindow.Seconds()).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		_, err := strg.Reserve(context.Background(), testThrottleKey, testWindow, func(*models.ThrottleRecord) time.Duration {
+			return 0
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		lock()
+		mock.ExpectExec(regexp.QuoteMeta(sqlFailThrottleRecord)).WithArgs(testThrottleKey, testWindow.Seconds()).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		_, err := strg.Reserve(context.Background(), testThrottleKey, testWindow, func(*models.ThrottleRecord) time.Duration {
+			return 0
+		})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestThrottleStorage_Release(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewThrottleStorage(newMockDB(db))
+
+	expectedQuery := regexp.QuoteMeta(sqlReleaseThrottleRecord)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testThrottleKey).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.Release(context.Background(), testThrottleKey)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testThrottleKey).WillReturnError(errTest)
+
+		err := strg.Release(context.Background(), testThrottleKey)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestThrottleStorage_Reset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewThrottleStorage(newMockDB(db))
+
+	expectedQuery := regexp.QuoteMeta(sqlDeleteThrottleRecord)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testThrottleKey).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.Reset(context.Background(), testThrottleKey)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testThrottleKey).WillReturnError(errTest)
+
+		err := strg.Reset(context.Background(), testThrottleKey)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,75 @@
+package storage
+
+import "errors"
+
+var (
+	ErrNotEnoughCurrency     = errors.New("not enough currency")
+	ErrTransferLimitExceeded = errors.New("daily transfer limit exceeded")
+	ErrNoTransfer            = errors.New("no transfers")
+)
+
+type errNotEnoughCurrency struct {
+	err error
+}
+
+func (err *errNotEnoughCurrency) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNotEnoughCurrency) Unwrap() error {
+	return err.err
+}
+
+func (err *errNotEnoughCurrency) IsErrNotEnoughCurrency() bool {
+	return true
+}
+
+func newErrNotEnoughCurrency(err error) error {
+	return &errNotEnoughCurrency{
+		err: err,
+	}
+}
+
+type errTransferLimitExceeded struct {
+	err error
+}
+
+func (err *errTransferLimitExceeded) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTransferLimitExceeded) Unwrap() error {
+	return err.err
+}
+
+func (err *errTransferLimitExceeded) IsErrTransferLimitExceeded() bool {
+	return true
+}
+
+func newErrTransferLimitExceeded(err error) error {
+	return &errTransferLimitExceeded{
+		err: err,
+	}
+}
+
+type errNoTransfer struct {
+	err error
+}
+
+func (err *errNoTransfer) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoTransfer) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoTransfer) IsErrNoTransfer() bool {
+	return true
+}
+
+func newErrNoTransfer(err error) error {
+	return &errNoTransfer{
+		err: err,
+	}
+}
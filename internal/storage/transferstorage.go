@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type TransferStorage struct {
+	db     DB
+	tenant models.TenantID
+}
+
+func NewTransferStorage(db DB, tenant models.TenantID) *TransferStorage {
+	return &TransferStorage{
+		db:     db,
+		tenant: tenant,
+	}
+}
+
+// AddTransfer moves points between users of the tenant under a lock on the
+// sender's row.
+// Replaying an idempotency key is a no-op if the transfer matches the stored
+// one and a conflict otherwise.
+func (s *TransferStorage) AddTransfer(ctx context.Context, transfer *models.Transfer, dailyLimit float64) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	var lockedID models.UserID
+	err = tx.QueryRow(ctx, sqlLockUser, transfer.SenderID, s.tenant).Scan(&lockedID)
+	if err != nil {
+		return err
+	}
+	var recipientID models.UserID
+	var sum float64
+	err = tx.QueryRow(ctx, sqlGetTransferByKey, transfer.SenderID, transfer.IdempotencyKey, s.tenant).Scan(&recipientID, &sum)
+	if err == nil {
+		if recipientID != transfer.RecipientID || sum != transfer.Sum {
+			return newErrIdempotencyConflict(ErrIdempotencyConflict)
+		}
+		return tx.Commit(ctx)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	var totalAccrual, totalWithdrawn float64
+	err = tx.QueryRow(ctx, sqlGetBalanceByUserID, transfer.SenderID, s.tenant).Scan(&totalAccrual, &totalWithdrawn)
+	if err != nil {
+		return err
+	}
+	if totalAccrual-totalWithdrawn < transfer.Sum {
+		return newErrNotEnoughCurrency(ErrNotEnoughCurrency)
+	}
+	if dailyLimit > 0 {
+		var dailySum float64
+		err = tx.QueryRow(ctx, sqlGetDailyTransferSum, transfer.SenderID, s.tenant).Scan(&dailySum)
+		if err != nil {
+			return err
+		}
+		if dailySum+transfer.Sum > dailyLimit {
+			return newErrTransferLimitExceeded(ErrTransferLimitExceeded)
+		}
+	}
+	tag, err := tx.Exec(ctx, sqlAddTransfer, transfer.SenderID, transfer.RecipientID, transfer.Sum, transfer.IdempotencyKey, s.tenant)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *TransferStorage) GetTransfersByUserID(ctx context.Context, uid models.UserID) ([]*models.TransferRecord, error) {
+	rows, err := s.db.Query(ctx, sqlGetTransfersByUserID, uid, s.tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var transfers []*models.TransferRecord
+	for rows.Next() {
+		var transfer models.TransferRecord
+		err = rows.Scan(&transfer.Direction, &transfer.Counterparty, &transfer.Sum, (*timestamp)(&transfer.ProcessedAt))
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, &transfer)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	if transfers == nil {
+		return nil, newErrNoTransfer(ErrNoTransfer)
+	}
+	return transfers, nil
+}
+
+func (s *TransferStorage) GetTransfersPageByUserID(ctx context.Context, uid models.UserID, page models.Page) ([]*models.TransferRecord, error) {
+	rows, err := s.db.Query(ctx, sqlGetTransfersPageByUserID, uid, page.Limit, page.Offset, s.tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	transfers := make([]*models.TransferRecord, 0, page.Limit)
+	for rows.Next() {
+		var transfer models.TransferRecord
+		err = rows.Scan(&transfer.Direction, &transfer.Counterparty, &transfer.Sum, (*timestamp)(&transfer.ProcessedAt))
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, &transfer)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
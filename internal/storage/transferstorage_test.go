@@ -0,0 +1,265 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIdempotencyKey = "key"
+	testDailyLimit     = float64(100)
+)
+
+func TestTransferStorage_AddTransfer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTransferStorage(newMockDB(db), testTenant)
+
+	testTransfer := &models.Transfer{
+		SenderID:       testUserID,
+		RecipientID:    testOtherUserID,
+		Sum:            10,
+		IdempotencyKey: testIdempotencyKey,
+	}
+
+	expectLock := func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).
+			WillReturnRows(mock.NewRows([]string{"id"}).AddRow(testUserID))
+	}
+
+	expectNoReplay := func() {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetTransferByKey)).WithArgs(testUserID, testIdempotencyKey, testTenant).
+			WillReturnError(pgx.ErrNoRows)
+	}
+
+	expectReplay := func(recipientID models.UserID, sum float64) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetTransferByKey)).WithArgs(testUserID, testIdempotencyKey, testTenant).
+			WillReturnRows(mock.NewRows([]string{"recipient_id", "sum"}).AddRow(recipientID, sum))
+	}
+
+	expectBalance := func(accrual, withdrawn float64) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetBalanceByUserID)).WithArgs(testUserID, testTenant).
+			WillReturnRows(mock.NewRows([]string{"accrual", "withdrawn"}).AddRow(accrual, withdrawn))
+	}
+
+	expectDailySum := func(sum float64) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetDailyTransferSum)).WithArgs(testUserID, testTenant).
+			WillReturnRows(mock.NewRows([]string{"sum"}).AddRow(sum))
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		expectLock()
+		expectNoReplay()
+		expectBalance(30, 10)
+		expectDailySum(0)
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddTransfer)).
+			WithArgs(testTransfer.SenderID, testTransfer.RecipientID, testTransfer.Sum, testTransfer.IdempotencyKey, testTenant).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("idempotent replay", func(t *testing.T) {
+		expectLock()
+		expectReplay(testOtherUserID, testTransfer.Sum)
+		mock.ExpectCommit()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("idempotency conflict", func(t *testing.T) {
+		expectLock()
+		expectReplay(testOtherUserID, testTransfer.Sum+1)
+		mock.ExpectRollback()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.ErrorIs(t, err, ErrIdempotencyConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("replay lookup error", func(t *testing.T) {
+		expectLock()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetTransferByKey)).WithArgs(testUserID, testIdempotencyKey, testTenant).
+			WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not enough currency", func(t *testing.T) {
+		expectLock()
+		expectNoReplay()
+		expectBalance(15, 10)
+		mock.ExpectRollback()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.ErrorIs(t, err, ErrNotEnoughCurrency)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("daily limit exceeded", func(t *testing.T) {
+		expectLock()
+		expectNoReplay()
+		expectBalance(30, 10)
+		expectDailySum(95)
+		mock.ExpectRollback()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.ErrorIs(t, err, ErrTransferLimitExceeded)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no daily limit", func(t *testing.T) {
+		expectLock()
+		expectNoReplay()
+		expectBalance(30, 10)
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddTransfer)).
+			WithArgs(testTransfer.SenderID, testTransfer.RecipientID, testTransfer.Sum, testTransfer.IdempotencyKey, testTenant).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, 0)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("recipient of another tenant", func(t *testing.T) {
+		expectLock()
+		expectNoReplay()
+		expectBalance(30, 10)
+		expectDailySum(0)
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddTransfer)).
+			WithArgs(testTransfer.SenderID, testTransfer.RecipientID, testTransfer.Sum, testTransfer.IdempotencyKey, testTenant).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		expectLock()
+		expectNoReplay()
+		expectBalance(30, 10)
+		expectDailySum(0)
+		mock.ExpectExec(regexp.QuoteMeta(sqlAddTransfer)).
+			WithArgs(testTransfer.SenderID, testTransfer.RecipientID, testTransfer.Sum, testTransfer.IdempotencyKey, testTenant).
+			WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.AddTransfer(context.Background(), testTransfer, testDailyLimit)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTransferStorage_GetTransfersByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTransferStorage(newMockDB(db), testTenant)
+
+	testTransfer := &models.TransferRecord{
+		Direction:    models.TransferOut,
+		Counterparty: "recipient",
+		Sum:          10,
+		ProcessedAt:  time.Now().String(),
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetTransfersByUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"direction", "login", "sum", "created_at"}).
+			AddRow(testTransfer.Direction, testTransfer.Counterparty, testTransfer.Sum, testTransfer.ProcessedAt)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		transfers, err := strg.GetTransfersByUserID(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, testTransfer, transfers[0])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetTransfersByUserID(context.Background(), testUserID)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		rows := mock.NewRows([]string{"direction", "login", "sum", "created_at"})
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		_, err := strg.GetTransfersByUserID(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoTransfer)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTransferStorage_GetTransfersPageByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTransferStorage(newMockDB(db), testTenant)
+
+	testTransfer := &models.TransferRecord{
+		Direction:    models.TransferIn,
+		Counterparty: "sender",
+		Sum:          10,
+		ProcessedAt:  time.Now().String(),
+	}
+	testPage := models.Page{Limit: 10, Offset: 20}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetTransfersPageByUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"direction", "login", "sum", "created_at"}).
+			AddRow(testTransfer.Direction, testTransfer.Counterparty, testTransfer.Sum, testTransfer.ProcessedAt)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnRows(rows)
+
+		transfers, err := strg.GetTransfersPageByUserID(context.Background(), testUserID, testPage)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.TransferRecord{testTransfer}, transfers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		rows := mock.NewRows([]string{"direction", "login", "sum", "created_at"})
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnRows(rows)
+
+		transfers, err := strg.GetTransfersPageByUserID(context.Background(), testUserID, testPage)
+		assert.NoError(t, err)
+		assert.Empty(t, transfers)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetTransfersPageByUserID(context.Background(), testUserID, testPage)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,52 @@
+package storage
+
+import "errors"
+
+var (
+	ErrNoTOTP      = errors.New("totp is not enrolled")
+	ErrTOTPEnabled = errors.New("totp is already enabled")
+)
+
+type errNoTOTP struct {
+	err error
+}
+
+func (err *errNoTOTP) Error() string {
+	return err.err.Error()
+}
+
+func (err *errNoTOTP) Unwrap() error {
+	return err.err
+}
+
+func (err *errNoTOTP) IsErrNoTOTP() bool {
+	return true
+}
+
+func newErrNoTOTP(err error) error {
+	return &errNoTOTP{
+		err: err,
+	}
+}
+
+type errTOTPEnabled struct {
+	err error
+}
+
+func (err *errTOTPEnabled) Error() string {
+	return err.err.Error()
+}
+
+func (err *errTOTPEnabled) Unwrap() error {
+	return err.err
+}
+
+func (err *errTOTPEnabled) IsErrTOTPEnabled() bool {
+	return true
+}
+
+func newErrTOTPEnabled(err error) error {
+	return &errTOTPEnabled{
+		err: err,
+	}
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type TwoFactorStorage struct {
+	db     DB
+	tenant models.TenantID
+}
+
+func NewTwoFactorStorage(db DB, tenant models.TenantID) *TwoFactorStorage {
+	return &TwoFactorStorage{
+		db:     db,
+		tenant: tenant,
+	}
+}
+
+func (s *TwoFactorStorage) GetTOTP(ctx context.Context, uid models.UserID) (*models.TOTPDB, error) {
+	row := s.db.QueryRow(ctx, sqlGetTOTP, uid, s.tenant)
+	var totp models.TOTPDB
+	err := row.Scan(&totp.UserID, &totp.Secret, &totp.Enabled)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, newErrNoTOTP(ErrNoTOTP)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &totp, nil
+}
+
+func (s *TwoFactorStorage) SaveTOTPSecret(ctx context.Context, uid models.UserID, secret string) error {
+	tag, err := s.db.Exec(ctx, sqlSaveTOTPSecret, uid, secret, s.tenant)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		// Nothing is saved if TOTP is already enabled or the user belongs
+		// to another tenant.
+		_, err = s.GetTOTP(ctx, uid)
+		if errors.Is(err, ErrNoTOTP) {
+			return newErrNoUser(ErrNoUser)
+		}
+		if err != nil {
+			return err
+		}
+		return newErrTOTPEnabled(ErrTOTPEnabled)
+	}
+	return nil
+}
+
+func (s *TwoFactorStorage) EnableTOTP(ctx context.Context, uid models.UserID, step int64, codeHashes []string) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	tag, err := tx.Exec(ctx, sqlEnableTOTP, uid, step, s.tenant)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return newErrTOTPEnabled(ErrTOTPEnabled)
+	}
+	_, err = tx.Exec(ctx, sqlDeleteRecoveryCodes, uid, s.tenant)
+	if err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		_, err = tx.Exec(ctx, sqlAddRecoveryCode, uid, hash, s.tenant)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *TwoFactorStorage) UseTOTPStep(ctx context.Context, uid models.UserID, step int64) (bool, error) {
+	tag, err := s.db.Exec(ctx, sqlUseTOTPStep, uid, step, s.tenant)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (s *TwoFactorStorage) UseRecoveryCode(ctx context.Context, uid models.UserID, codeHash string) (bool, error) {
+	tag, err := s.db.Exec(ctx, sqlUseRecoveryCode, uid, codeHash, s.tenant)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTwoFactorStorage_GetTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTwoFactorStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetTOTP)
+
+	t.Run("valid test", func(t *testing.T) {
+		testTOTP := &models.TOTPDB{
+			UserID:  testUserID,
+			Secret:  testTOTPSecret,
+			Enabled: true,
+		}
+		rows := mock.NewRows([]string{"user_id", "secret", "enabled"}).AddRow(testTOTP.UserID, testTOTP.Secret, testTOTP.Enabled)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		totp, err := strg.GetTOTP(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, testTOTP, totp)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := strg.GetTOTP(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoTOTP)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetTOTP(context.Background(), testUserID)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTwoFactorStorage_SaveTOTPSecret(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTwoFactorStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlSaveTOTPSecret)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, testTOTPSecret, testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.SaveTOTPSecret(context.Background(), testUserID, testTOTPSecret)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, testTOTPSecret, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		rows := mock.NewRows([]string{"user_id", "secret", "enabled"}).AddRow(testUserID, testTOTPSecret, true)
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetTOTP)).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		err := strg.SaveTOTPSecret(context.Background(), testUserID, testTOTPSecret)
+		assert.ErrorIs(t, err, ErrTOTPEnabled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no user", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, testTOTPSecret, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetTOTP)).WithArgs(testUserID, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		err := strg.SaveTOTPSecret(context.Background(), testUserID, testTOTPSecret)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTwoFactorStorage_EnableTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTwoFactorStorage(newMockDB(db), testTenant)
+
+	testHashes := []string{"hash1", "hash2"}
+	expectedEnableQuery := regexp.QuoteMeta(sqlEnableTOTP)
+	expectedDeleteQuery := regexp.QuoteMeta(sqlDeleteRecoveryCodes)
+	expectedAddQuery := regexp.QuoteMeta(sqlAddRecoveryCode)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedEnableQuery).WithArgs(testUserID, int64(100), testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(expectedDeleteQuery).WithArgs(testUserID, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		for _, hash := range testHashes {
+			mock.ExpectExec(expectedAddQuery).WithArgs(testUserID, hash, testTenant).WillReturnResult(sqlmock.NewResult(1, 1))
+		}
+		mock.ExpectCommit()
+
+		err := strg.EnableTOTP(context.Background(), testUserID, 100, testHashes)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedEnableQuery).WithArgs(testUserID, int64(100), testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := strg.EnableTOTP(context.Background(), testUserID, 100, testHashes)
+		assert.ErrorIs(t, err, ErrTOTPEnabled)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedEnableQuery).WithArgs(testUserID, int64(100), testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(expectedDeleteQuery).WithArgs(testUserID, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(expectedAddQuery).WithArgs(testUserID, testHashes[0], testTenant).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.EnableTOTP(context.Background(), testUserID, 100, testHashes)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTwoFactorStorage_UseTOTPStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTwoFactorStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlUseTOTPStep)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, int64(101), testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := strg.UseTOTPStep(context.Background(), testUserID, 101)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("replayed step", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, int64(101), testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		ok, err := strg.UseTOTPStep(context.Background(), testUserID, 101)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestTwoFactorStorage_UseRecoveryCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewTwoFactorStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlUseRecoveryCode)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "hash", testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		ok, err := strg.UseRecoveryCode(context.Background(), testUserID, "hash")
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "hash", testTenant).WillReturnError(errTest)
+
+		_, err := strg.UseRecoveryCode(context.Background(), testUserID, "hash")
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -5,8 +5,10 @@ import (
 )
 
 var (
-	ErrLoginConflict = errors.New("login already registered")
-	ErrNoUser        = errors.New("user does not exist")
+	ErrLoginConflict        = errors.New("login already registered")
+	ErrReferralCodeConflict = errors.New("referral code already taken")
+	ErrNoUser               = errors.New("user does not exist")
+	ErrInvalidResetToken    = errors.New("password reset token is invalid, expired or already used")
 )
 
 type errLoginConflict struct {
@@ -31,6 +33,28 @@ func newErrLoginConflict(err error) error {
 	}
 }
 
+type errReferralCodeConflict struct {
+	err error
+}
+
+func (err *errReferralCodeConflict) Error() string {
+	return err.err.Error()
+}
+
+func (err *errReferralCodeConflict) Unwrap() error {
+	return err.err
+}
+
+func (err *errReferralCodeConflict) IsErrReferralCodeConflict() bool {
+	return true
+}
+
+func newErrReferralCodeConflict(err error) error {
+	return &errReferralCodeConflict{
+		err: err,
+	}
+}
+
 type errNoUser struct {
 	err error
 }
@@ -52,3 +76,25 @@ func newErrNoUser(err error) error {
 		err: err,
 	}
 }
+
+type errInvalidResetToken struct {
+	err error
+}
+
+func (err *errInvalidResetToken) Error() string {
+	return err.err.Error()
+}
+
+func (err *errInvalidResetToken) Unwrap() error {
+	return err.err
+}
+
+func (err *errInvalidResetToken) IsErrInvalidResetToken() bool {
+	return true
+}
+
+func newErrInvalidResetToken(err error) error {
+	return &errInvalidResetToken{
+		err: err,
+	}
+}
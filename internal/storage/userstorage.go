@@ -2,42 +2,110 @@ package storage
 
 import (
 	"context"
-	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/rycln/loyalsys/internal/models"
 )
 
+const (
+	constraintUsersLogin        = "users_tenant_id_login_key"
+	constraintUsersReferralCode = "users_referral_code_key"
+)
+
 type UserStorage struct {
-	db *sql.DB
+	db     DB
+	tenant models.TenantID
 }
 
-func NewUserStorage(db *sql.DB) *UserStorage {
-	return &UserStorage{db: db}
+func NewUserStorage(db DB, tenant models.TenantID) *UserStorage {
+	return &UserStorage{db: db, tenant: tenant}
 }
 
 func (s *UserStorage) AddUser(ctx context.Context, user *models.UserDB) (models.UserID, error) {
-	row := s.db.QueryRowContext(ctx, sqlAddUser, user.Login, user.PasswordHash)
+	row := s.db.QueryRow(ctx, sqlAddUser, user.Login, user.PasswordHash, user.ReferralCode, s.tenant)
 	var uid models.UserID
 	err := row.Scan(&uid)
 	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
-			return 0, newErrLoginConflict(ErrLoginConflict)
-		}
-		return 0, err
+		return 0, addUserError(err)
 	}
 	return uid, nil
 }
 
+// addUserError tells a taken login from a colliding referral code, which
+// the caller can regenerate.
+func addUserError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgerrcode.UniqueViolation {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case constraintUsersLogin:
+		return newErrLoginConflict(ErrLoginConflict)
+	case constraintUsersReferralCode:
+		return newErrReferralCodeConflict(ErrReferralCodeConflict)
+	}
+	return err
+}
+
 func (s *UserStorage) GetUserByLogin(ctx context.Context, login string) (*models.UserDB, error) {
-	row := s.db.QueryRowContext(ctx, sqlGetUserByLogin, login)
+	row := s.db.QueryRow(ctx, sqlGetUserByLogin, login, s.tenant)
+	var userDB models.UserDB
+	err := row.Scan(&userDB.ID, &userDB.Login, &userDB.PasswordHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, newErrNoUser(ErrNoUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &userDB, nil
+}
+
+func (s *UserStorage) AddUserWithReferral(ctx context.Context, user *models.UserDB, referral *models.Referral) (models.UserID, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	row := tx.QueryRow(ctx, sqlAddUser, user.Login, user.PasswordHash, user.ReferralCode, s.tenant)
+	var uid models.UserID
+	err = row.Scan(&uid)
+	if err != nil {
+		return 0, addUserError(err)
+	}
+	_, err = tx.Exec(ctx, sqlAddReferral, referral.ReferrerID, uid, referral.ReferrerBonus, referral.RefereeBonus)
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uid, nil
+}
+
+func (s *UserStorage) GetUserByReferralCode(ctx context.Context, code string) (*models.UserDB, error) {
+	row := s.db.QueryRow(ctx, sqlGetUserByReferralCode, code, s.tenant)
+	var userDB models.UserDB
+	err := row.Scan(&userDB.ID, &userDB.Login, &userDB.PasswordHash, &userDB.ReferralCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, newErrNoUser(ErrNoUser)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &userDB, nil
+}
+
+func (s *UserStorage) GetUserByID(ctx context.Context, uid models.UserID) (*models.UserDB, error) {
+	row := s.db.QueryRow(ctx, sqlGetUserByID, uid, s.tenant)
 	var userDB models.UserDB
 	err := row.Scan(&userDB.ID, &userDB.Login, &userDB.PasswordHash)
-	if errors.Is(err, sql.ErrNoRows) {
+	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, newErrNoUser(ErrNoUser)
 	}
 	if err != nil {
@@ -45,3 +113,87 @@ func (s *UserStorage) GetUserByLogin(ctx context.Context, login string) (*models
 	}
 	return &userDB, nil
 }
+
+func (s *UserStorage) UpdatePassword(ctx context.Context, uid models.UserID, hash string, validAfter time.Time) error {
+	tag, err := s.db.Exec(ctx, sqlUpdatePassword, uid, hash, validAfter, s.tenant)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+	return nil
+}
+
+// RehashPassword replaces the hash only if it wasn't changed since it was
+// read, so a concurrent password change is never overwritten.
+func (s *UserStorage) RehashPassword(ctx context.Context, uid models.UserID, oldHash, newHash string) error {
+	_, err := s.db.Exec(ctx, sqlRehashPassword, uid, oldHash, newHash, s.tenant)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *UserStorage) GetSessionsValidAfter(ctx context.Context, uid models.UserID) (time.Time, error) {
+	row := s.db.QueryRow(ctx, sqlGetSessionsValidAfter, uid, s.tenant)
+	var validAfter pgtype.Timestamptz
+	err := row.Scan(&validAfter)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, newErrNoUser(ErrNoUser)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return validAfter.Time, nil
+}
+
+func (s *UserStorage) AddResetToken(ctx context.Context, token *models.ResetToken) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	_, err = tx.Exec(ctx, sqlDeleteResetTokensByUserID, token.UserID, s.tenant)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, sqlAddResetToken, token.TokenHash, token.UserID, token.ExpiresAt, s.tenant)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return newErrNoUser(ErrNoUser)
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *UserStorage) ResetPassword(ctx context.Context, tokenHash, hash string, validAfter time.Time) (models.UserID, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+	row := tx.QueryRow(ctx, sqlUseResetToken, tokenHash, s.tenant)
+	var uid models.UserID
+	err = row.Scan(&uid)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, newErrInvalidResetToken(ErrInvalidResetToken)
+	}
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(ctx, sqlUpdatePassword, uid, hash, validAfter, s.tenant)
+	if err != nil {
+		return 0, err
+	}
+	_, err = tx.Exec(ctx, sqlDeleteResetTokensByUserID, uid, s.tenant)
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uid, nil
+}
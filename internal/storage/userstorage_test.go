@@ -2,14 +2,14 @@ package storage
 
 import (
 	"context"
-	"database/sql"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
-	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/rycln/loyalsys/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,18 +20,19 @@ func TestUserStorage_AddUser(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewUserStorage(db)
+	strg := NewUserStorage(newMockDB(db), testTenant)
 
 	testUser := &models.UserDB{
 		Login:        "test",
 		PasswordHash: "hashed_password",
+		ReferralCode: testReferralCode,
 	}
 
 	expectedQuery := regexp.QuoteMeta(sqlAddUser)
 
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"id"}).AddRow(testUserID)
-		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
 
 		uid, err := strg.AddUser(context.Background(), testUser)
 		assert.NoError(t, err)
@@ -41,20 +42,49 @@ func TestUserStorage_AddUser(t *testing.T) {
 
 	t.Run("conflict error", func(t *testing.T) {
 		var pgErr = &pgconn.PgError{
-			Code: pgerrcode.IntegrityConstraintViolation,
+			Code:           pgerrcode.UniqueViolation,
+			ConstraintName: constraintUsersLogin,
 		}
 
 		rows := mock.NewRows([]string{"id"}).AddRow(testUserID).RowError(0, pgErr)
-		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
 
 		_, err = strg.AddUser(context.Background(), testUser)
 		assert.ErrorIs(t, err, ErrLoginConflict)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("referral code conflict error", func(t *testing.T) {
+		var pgErr = &pgconn.PgError{
+			Code:           pgerrcode.UniqueViolation,
+			ConstraintName: constraintUsersReferralCode,
+		}
+
+		rows := mock.NewRows([]string{"id"}).AddRow(testUserID).RowError(0, pgErr)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
+
+		_, err = strg.AddUser(context.Background(), testUser)
+		assert.ErrorIs(t, err, ErrReferralCodeConflict)
+		assert.NotErrorIs(t, err, ErrLoginConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("other constraint error", func(t *testing.T) {
+		var pgErr = &pgconn.PgError{
+			Code: pgerrcode.NotNullViolation,
+		}
+
+		rows := mock.NewRows([]string{"id"}).AddRow(testUserID).RowError(0, pgErr)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
+
+		_, err = strg.AddUser(context.Background(), testUser)
+		assert.NotErrorIs(t, err, ErrLoginConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("some error", func(t *testing.T) {
 		rows := mock.NewRows([]string{"id"}).AddRow(testUserID).RowError(0, errTest)
-		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
 
 		_, err = strg.AddUser(context.Background(), testUser)
 		assert.Error(t, err)
@@ -62,12 +92,76 @@ func TestUserStorage_AddUser(t *testing.T) {
 	})
 }
 
+func TestUserStorage_AddUserWithReferral(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	testUser := &models.UserDB{
+		Login:        "test",
+		PasswordHash: "hashed_password",
+		ReferralCode: testReferralCode,
+	}
+
+	testReferral := &models.Referral{
+		ReferrerID:    testOtherUserID,
+		ReferrerBonus: 100,
+		RefereeBonus:  50,
+	}
+
+	expectedUserQuery := regexp.QuoteMeta(sqlAddUser)
+	expectedReferralQuery := regexp.QuoteMeta(sqlAddReferral)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id"}).AddRow(testUserID)
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedUserQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
+		mock.ExpectExec(expectedReferralQuery).WithArgs(testReferral.ReferrerID, testUserID, testReferral.ReferrerBonus, testReferral.RefereeBonus).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		uid, err := strg.AddUserWithReferral(context.Background(), testUser, testReferral)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("conflict error", func(t *testing.T) {
+		var pgErr = &pgconn.PgError{
+			Code:           pgerrcode.UniqueViolation,
+			ConstraintName: constraintUsersLogin,
+		}
+
+		rows := mock.NewRows([]string{"id"}).AddRow(testUserID).RowError(0, pgErr)
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedUserQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
+		mock.ExpectRollback()
+
+		_, err = strg.AddUserWithReferral(context.Background(), testUser, testReferral)
+		assert.ErrorIs(t, err, ErrLoginConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("add referral error", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id"}).AddRow(testUserID)
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedUserQuery).WithArgs(testUser.Login, testUser.PasswordHash, testUser.ReferralCode, testTenant).WillReturnRows(rows)
+		mock.ExpectExec(expectedReferralQuery).WithArgs(testReferral.ReferrerID, testUserID, testReferral.ReferrerBonus, testReferral.RefereeBonus).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		_, err := strg.AddUserWithReferral(context.Background(), testUser, testReferral)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestUserStorage_GetUserByLogin(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewUserStorage(db)
+	strg := NewUserStorage(newMockDB(db), testTenant)
 
 	testUser := &models.UserDB{
 		ID:           testUserID,
@@ -79,7 +173,7 @@ func TestUserStorage_GetUserByLogin(t *testing.T) {
 
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"id", "login", "password_hash"}).AddRow(testUser.ID, testUser.Login, testUser.PasswordHash)
-		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testTenant).WillReturnRows(rows)
 
 		userDB, err := strg.GetUserByLogin(context.Background(), testUser.Login)
 		assert.NoError(t, err)
@@ -88,7 +182,7 @@ func TestUserStorage_GetUserByLogin(t *testing.T) {
 	})
 
 	t.Run("no user error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testTenant).WillReturnError(pgx.ErrNoRows)
 
 		_, err := strg.GetUserByLogin(context.Background(), testUser.Login)
 		assert.ErrorIs(t, err, ErrNoUser)
@@ -96,10 +190,287 @@ func TestUserStorage_GetUserByLogin(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login).WillReturnError(errTest)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testTenant).WillReturnError(errTest)
 
 		_, err := strg.GetUserByLogin(context.Background(), testUser.Login)
 		assert.Error(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		otherStrg := NewUserStorage(newMockDB(db), testOtherTenant)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.Login, testOtherTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := otherStrg.GetUserByLogin(context.Background(), testUser.Login)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_GetUserByReferralCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	testUser := &models.UserDB{
+		ID:           testUserID,
+		Login:        "test",
+		PasswordHash: "hashed_password",
+		ReferralCode: testReferralCode,
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetUserByReferralCode)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "login", "password_hash", "referral_code"}).AddRow(testUser.ID, testUser.Login, testUser.PasswordHash, testUser.ReferralCode)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.ReferralCode, testTenant).WillReturnRows(rows)
+
+		userDB, err := strg.GetUserByReferralCode(context.Background(), testUser.ReferralCode)
+		assert.NoError(t, err)
+		assert.Equal(t, testUser, userDB)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no user error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.ReferralCode, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := strg.GetUserByReferralCode(context.Background(), testUser.ReferralCode)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_GetUserByID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	testUser := &models.UserDB{
+		ID:           testUserID,
+		Login:        "test",
+		PasswordHash: "hashed_password",
+	}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetUserByID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "login", "password_hash"}).AddRow(testUser.ID, testUser.Login, testUser.PasswordHash)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.ID, testTenant).WillReturnRows(rows)
+
+		userDB, err := strg.GetUserByID(context.Background(), testUser.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, testUser, userDB)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no user error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUser.ID, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := strg.GetUserByID(context.Background(), testUser.ID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_UpdatePassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	testValidAfter := time.Now().Truncate(time.Second)
+	expectedQuery := regexp.QuoteMeta(sqlUpdatePassword)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "hashed_password", testValidAfter, testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.UpdatePassword(context.Background(), testUserID, "hashed_password", testValidAfter)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no user error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "hashed_password", testValidAfter, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := strg.UpdatePassword(context.Background(), testUserID, "hashed_password", testValidAfter)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "hashed_password", testValidAfter, testTenant).WillReturnError(errTest)
+
+		err := strg.UpdatePassword(context.Background(), testUserID, "hashed_password", testValidAfter)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_RehashPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlRehashPassword)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "old_hash", "new_hash", testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := strg.RehashPassword(context.Background(), testUserID, "old_hash", "new_hash")
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectExec(expectedQuery).WithArgs(testUserID, "old_hash", "new_hash", testTenant).WillReturnError(errTest)
+
+		err := strg.RehashPassword(context.Background(), testUserID, "old_hash", "new_hash")
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_GetSessionsValidAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	expectedQuery := regexp.QuoteMeta(sqlGetSessionsValidAfter)
+
+	t.Run("valid test", func(t *testing.T) {
+		testValidAfter := time.Now().Truncate(time.Second)
+		rows := mock.NewRows([]string{"sessions_valid_after"}).AddRow(testValidAfter)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		validAfter, err := strg.GetSessionsValidAfter(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.Equal(t, testValidAfter, validAfter)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("never changed", func(t *testing.T) {
+		rows := mock.NewRows([]string{"sessions_valid_after"}).AddRow(nil)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
+
+		validAfter, err := strg.GetSessionsValidAfter(context.Background(), testUserID)
+		assert.NoError(t, err)
+		assert.True(t, validAfter.IsZero())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no user error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(pgx.ErrNoRows)
+
+		_, err := strg.GetSessionsValidAfter(context.Background(), testUserID)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_AddResetToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	testToken := &models.ResetToken{
+		UserID:    testUserID,
+		TokenHash: "token_hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	expectedDeleteQuery := regexp.QuoteMeta(sqlDeleteResetTokensByUserID)
+	expectedAddQuery := regexp.QuoteMeta(sqlAddResetToken)
+
+	t.Run("valid test", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedDeleteQuery).WithArgs(testToken.UserID, testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(expectedAddQuery).WithArgs(testToken.TokenHash, testToken.UserID, testToken.ExpiresAt, testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := strg.AddResetToken(context.Background(), testToken)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("user of another tenant", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedDeleteQuery).WithArgs(testToken.UserID, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(expectedAddQuery).WithArgs(testToken.TokenHash, testToken.UserID, testToken.ExpiresAt, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := strg.AddResetToken(context.Background(), testToken)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(expectedDeleteQuery).WithArgs(testToken.UserID, testTenant).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(expectedAddQuery).WithArgs(testToken.TokenHash, testToken.UserID, testToken.ExpiresAt, testTenant).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.AddResetToken(context.Background(), testToken)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUserStorage_ResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewUserStorage(newMockDB(db), testTenant)
+
+	testValidAfter := time.Now().Truncate(time.Second)
+	expectedUseQuery := regexp.QuoteMeta(sqlUseResetToken)
+	expectedUpdateQuery := regexp.QuoteMeta(sqlUpdatePassword)
+	expectedDeleteQuery := regexp.QuoteMeta(sqlDeleteResetTokensByUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"user_id"}).AddRow(testUserID)
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedUseQuery).WithArgs("token_hash", testTenant).WillReturnRows(rows)
+		mock.ExpectExec(expectedUpdateQuery).WithArgs(testUserID, "hashed_password", testValidAfter, testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(expectedDeleteQuery).WithArgs(testUserID, testTenant).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		uid, err := strg.ResetPassword(context.Background(), "token_hash", "hashed_password", testValidAfter)
+		assert.NoError(t, err)
+		assert.Equal(t, testUserID, uid)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedUseQuery).WithArgs("token_hash", testTenant).WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		_, err := strg.ResetPassword(context.Background(), "token_hash", "hashed_password", testValidAfter)
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		rows := mock.NewRows([]string{"user_id"}).AddRow(testUserID)
+		mock.ExpectBegin()
+		mock.ExpectQuery(expectedUseQuery).WithArgs("token_hash", testTenant).WillReturnRows(rows)
+		mock.ExpectExec(expectedUpdateQuery).WithArgs(testUserID, "hashed_password", testValidAfter, testTenant).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		_, err := strg.ResetPassword(context.Background(), "token_hash", "hashed_password", testValidAfter)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
@@ -2,31 +2,73 @@ package storage
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rycln/loyalsys/internal/models"
 )
 
 type WithdrawalStorage struct {
-	db *sql.DB
+	db     DB
+	tenant models.TenantID
 }
 
-func NewWithdrawalStorage(db *sql.DB) *WithdrawalStorage {
+func NewWithdrawalStorage(db DB, tenant models.TenantID) *WithdrawalStorage {
 	return &WithdrawalStorage{
-		db: db,
+		db:     db,
+		tenant: tenant,
 	}
 }
 
+// AddWithdrawal debits the user under a lock on the user's row, so
+// concurrent withdrawals can't overdraw the balance. A user missing from the
+// tenant is ErrNoUser. Replaying an idempotency key is a no-op if the
+// withdrawal matches the stored one and a conflict otherwise.
 func (s *WithdrawalStorage) AddWithdrawal(ctx context.Context, withdrawal *models.Withdrawal) error {
-	_, err := s.db.ExecContext(ctx, sqlAddWithdrawal, withdrawal.Order, withdrawal.UserID, withdrawal.Sum)
+	tx, err := s.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
-	return nil
+	defer tx.Rollback(ctx)
+	var lockedID models.UserID
+	err = tx.QueryRow(ctx, sqlLockUser, withdrawal.UserID, s.tenant).Scan(&lockedID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return newErrNoUser(ErrNoUser)
+	}
+	if err != nil {
+		return err
+	}
+	if withdrawal.IdempotencyKey != "" {
+		var number string
+		var sum float64
+		err = tx.QueryRow(ctx, sqlGetWithdrawalByKey, withdrawal.UserID, withdrawal.IdempotencyKey, s.tenant).Scan(&number, &sum)
+		if err == nil {
+			if number != withdrawal.Order || sum != withdrawal.Sum {
+				return newErrIdempotencyConflict(ErrIdempotencyConflict)
+			}
+			return tx.Commit(ctx)
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+	var totalAccrual, totalWithdrawn float64
+	err = tx.QueryRow(ctx, sqlGetBalanceByUserID, withdrawal.UserID, s.tenant).Scan(&totalAccrual, &totalWithdrawn)
+	if err != nil {
+		return err
+	}
+	if totalAccrual-totalWithdrawn < withdrawal.Sum {
+		return newErrNotEnoughCurrency(ErrNotEnoughCurrency)
+	}
+	_, err = tx.Exec(ctx, sqlAddWithdrawal, withdrawal.Order, withdrawal.UserID, withdrawal.Sum, withdrawal.IdempotencyKey, s.tenant)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (s *WithdrawalStorage) GetWithdrawalsByUserID(ctx context.Context, uid models.UserID) ([]*models.Withdrawal, error) {
-	rows, err := s.db.QueryContext(ctx, sqlGetWithdrawalsByUserID, uid)
+	rows, err := s.db.Query(ctx, sqlGetWithdrawalsByUserID, uid, s.tenant)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +77,7 @@ func (s *WithdrawalStorage) GetWithdrawalsByUserID(ctx context.Context, uid mode
 	for rows.Next() {
 		var withdrawal models.Withdrawal
 		withdrawal.UserID = uid
-		err = rows.Scan(&withdrawal.ID, &withdrawal.Order, &withdrawal.Sum, &withdrawal.ProcessedAt)
+		err = rows.Scan(&withdrawal.ID, &withdrawal.Order, &withdrawal.Sum, (*timestamp)(&withdrawal.ProcessedAt))
 		if err != nil {
 			return nil, err
 		}
@@ -50,3 +92,26 @@ func (s *WithdrawalStorage) GetWithdrawalsByUserID(ctx context.Context, uid mode
 	}
 	return withdrawals, nil
 }
+
+func (s *WithdrawalStorage) GetWithdrawalsPageByUserID(ctx context.Context, uid models.UserID, page models.Page) ([]*models.Withdrawal, error) {
+	rows, err := s.db.Query(ctx, sqlGetWithdrawalsPageByUserID, uid, page.Limit, page.Offset, s.tenant)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	withdrawals := make([]*models.Withdrawal, 0, page.Limit)
+	for rows.Next() {
+		var withdrawal models.Withdrawal
+		withdrawal.UserID = uid
+		err = rows.Scan(&withdrawal.ID, &withdrawal.Order, &withdrawal.Sum, (*timestamp)(&withdrawal.ProcessedAt))
+		if err != nil {
+			return nil, err
+		}
+		withdrawals = append(withdrawals, &withdrawal)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	return withdrawals, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5"
 	"github.com/rycln/loyalsys/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,7 +26,7 @@ func TestWithdrawalStorage_GetWithdrawalsByUserID(t *testing.T) {
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewWithdrawalStorage(db)
+	strg := NewWithdrawalStorage(newMockDB(db), testTenant)
 
 	testWithdrawal := &models.Withdrawal{
 		ID:          testWithdrawalID,
@@ -40,7 +41,7 @@ func TestWithdrawalStorage_GetWithdrawalsByUserID(t *testing.T) {
 	t.Run("valid test", func(t *testing.T) {
 		rows := mock.NewRows([]string{"id", "order", "sum", "processed_at"}).
 			AddRow(testWithdrawal.ID, testWithdrawal.Order, testWithdrawal.Sum, testWithdrawal.ProcessedAt)
-		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
 
 		withdrawalsDB, err := strg.GetWithdrawalsByUserID(context.Background(), testUserID)
 		assert.NoError(t, err)
@@ -49,7 +50,7 @@ func TestWithdrawalStorage_GetWithdrawalsByUserID(t *testing.T) {
 	})
 
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnError(errTest)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnError(errTest)
 
 		_, err := strg.GetWithdrawalsByUserID(context.Background(), testUserID)
 		assert.Error(t, err)
@@ -58,7 +59,7 @@ func TestWithdrawalStorage_GetWithdrawalsByUserID(t *testing.T) {
 
 	t.Run("empty response", func(t *testing.T) {
 		rows := mock.NewRows([]string{"id", "order", "sum", "processed_at"})
-		mock.ExpectQuery(expectedQuery).WithArgs(testUserID).WillReturnRows(rows)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testTenant).WillReturnRows(rows)
 
 		_, err := strg.GetWithdrawalsByUserID(context.Background(), testUserID)
 		assert.ErrorIs(t, err, ErrNoWithdrawal)
@@ -66,31 +67,166 @@ func TestWithdrawalStorage_GetWithdrawalsByUserID(t *testing.T) {
 	})
 }
 
+func TestWithdrawalStorage_GetWithdrawalsPageByUserID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	strg := NewWithdrawalStorage(newMockDB(db), testTenant)
+
+	testWithdrawal := &models.Withdrawal{
+		ID:          testWithdrawalID,
+		Order:       testWithdrawalOrder,
+		UserID:      testUserID,
+		Sum:         testWithdrawalSum,
+		ProcessedAt: testProcessedAt,
+	}
+	testPage := models.Page{Limit: 10, Offset: 20}
+
+	expectedQuery := regexp.QuoteMeta(sqlGetWithdrawalsPageByUserID)
+
+	t.Run("valid test", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "order", "sum", "processed_at"}).
+			AddRow(testWithdrawal.ID, testWithdrawal.Order, testWithdrawal.Sum, testWithdrawal.ProcessedAt)
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnRows(rows)
+
+		withdrawals, err := strg.GetWithdrawalsPageByUserID(context.Background(), testUserID, testPage)
+		assert.NoError(t, err)
+		assert.Equal(t, []*models.Withdrawal{testWithdrawal}, withdrawals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		rows := mock.NewRows([]string{"id", "order", "sum", "processed_at"})
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnRows(rows)
+
+		withdrawals, err := strg.GetWithdrawalsPageByUserID(context.Background(), testUserID, testPage)
+		assert.NoError(t, err)
+		assert.Empty(t, withdrawals)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mock.ExpectQuery(expectedQuery).WithArgs(testUserID, testPage.Limit, testPage.Offset, testTenant).WillReturnError(errTest)
+
+		_, err := strg.GetWithdrawalsPageByUserID(context.Background(), testUserID, testPage)
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestWithdrawalStorage_AddWithdrawal(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 	defer db.Close()
 
-	strg := NewWithdrawalStorage(db)
+	strg := NewWithdrawalStorage(newMockDB(db), testTenant)
 
 	testWithdrawal := &models.Withdrawal{
 		Order:  testWithdrawalOrder,
 		UserID: testUserID,
 		Sum:    testWithdrawalSum,
 	}
+	keyed := *testWithdrawal
+	keyed.IdempotencyKey = "key"
 
 	expectedQuery := regexp.QuoteMeta(sqlAddWithdrawal)
 
+	expectLock := func() {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).
+			WillReturnRows(mock.NewRows([]string{"id"}).AddRow(testUserID))
+	}
+
+	expectReplay := func(number string, sum float64) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetWithdrawalByKey)).WithArgs(testUserID, "key", testTenant).
+			WillReturnRows(mock.NewRows([]string{"number", "sum"}).AddRow(number, sum))
+	}
+
+	expectBalance := func(accrual, withdrawn float64) {
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetBalanceByUserID)).WithArgs(testUserID, testTenant).
+			WillReturnRows(mock.NewRows([]string{"accrual", "withdrawn"}).AddRow(accrual, withdrawn))
+	}
+
 	t.Run("valid test", func(t *testing.T) {
-		mock.ExpectExec(expectedQuery).WithArgs(testWithdrawal.Order, testWithdrawal.UserID, testWithdrawal.Sum).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLock()
+		expectBalance(testWithdrawalSum, 0)
+		mock.ExpectExec(expectedQuery).WithArgs(testWithdrawal.Order, testWithdrawal.UserID, testWithdrawal.Sum, "", testTenant).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
 
 		err := strg.AddWithdrawal(context.Background(), testWithdrawal)
 		assert.NoError(t, err)
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
+	t.Run("idempotency key", func(t *testing.T) {
+		expectLock()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlGetWithdrawalByKey)).WithArgs(testUserID, "key", testTenant).
+			WillReturnError(pgx.ErrNoRows)
+		expectBalance(testWithdrawalSum, 0)
+		mock.ExpectExec(expectedQuery).WithArgs(testWithdrawal.Order, testWithdrawal.UserID, testWithdrawal.Sum, "key", testTenant).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := strg.AddWithdrawal(context.Background(), &keyed)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("idempotent replay", func(t *testing.T) {
+		expectLock()
+		expectReplay(testWithdrawalOrder, testWithdrawalSum)
+		mock.ExpectCommit()
+
+		err := strg.AddWithdrawal(context.Background(), &keyed)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("idempotency conflict", func(t *testing.T) {
+		expectLock()
+		expectReplay("other", testWithdrawalSum)
+		mock.ExpectRollback()
+
+		err := strg.AddWithdrawal(context.Background(), &keyed)
+		assert.ErrorIs(t, err, ErrIdempotencyConflict)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not enough currency", func(t *testing.T) {
+		expectLock()
+		expectBalance(testWithdrawalSum, 1)
+		mock.ExpectRollback()
+
+		err := strg.AddWithdrawal(context.Background(), testWithdrawal)
+		assert.ErrorIs(t, err, ErrNotEnoughCurrency)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		err := strg.AddWithdrawal(context.Background(), testWithdrawal)
+		assert.ErrorIs(t, err, ErrNoUser)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("lock error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(sqlLockUser)).WithArgs(testUserID, testTenant).WillReturnError(errTest)
+		mock.ExpectRollback()
+
+		err := strg.AddWithdrawal(context.Background(), testWithdrawal)
+		assert.ErrorIs(t, err, errTest)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	t.Run("some error", func(t *testing.T) {
-		mock.ExpectExec(expectedQuery).WithArgs(testWithdrawal.Order, testWithdrawal.UserID, testWithdrawal.Sum).WillReturnError(errTest)
+		expectLock()
+		expectBalance(testWithdrawalSum, 0)
+		mock.ExpectExec(expectedQuery).WithArgs(testWithdrawal.Order, testWithdrawal.UserID, testWithdrawal.Sum, "", testTenant).WillReturnError(errTest)
+		mock.ExpectRollback()
 
 		err := strg.AddWithdrawal(context.Background(), testWithdrawal)
 		assert.Error(t, err)
@@ -0,0 +1,104 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix     = "$argon2id$"
+	argon2SaltLength   = 16
+	argon2KeyLength    = 32
+	argon2HashSections = 6
+)
+
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+type Argon2idHasher struct {
+	params Argon2Params
+}
+
+func NewArgon2idHasher(params Argon2Params) *Argon2idHasher {
+	return &Argon2idHasher{
+		params: params,
+	}
+}
+
+// Hash returns the hash in PHC string format:
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, argon2KeyLength)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Compare(hashed, plain string) error {
+	params, salt, key, err := parseArgon2idHash(hashed)
+	if err != nil {
+		return err
+	}
+	other := argon2.IDKey([]byte(plain), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return newErrWrongPassword(ErrWrongPassword)
+	}
+	return nil
+}
+
+func (h *Argon2idHasher) Supports(hashed string) bool {
+	return strings.HasPrefix(hashed, argon2idPrefix)
+}
+
+func (h *Argon2idHasher) NeedsRehash(hashed string) bool {
+	params, _, key, err := parseArgon2idHash(hashed)
+	if err != nil {
+		return true
+	}
+	return params != h.params || len(key) != argon2KeyLength
+}
+
+func parseArgon2idHash(hashed string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+	sections := strings.Split(hashed, "$")
+	if len(sections) != argon2HashSections || "$"+sections[1]+"$" != argon2idPrefix {
+		return params, nil, nil, ErrUnknownHashFormat
+	}
+	var version int
+	_, err := fmt.Sscanf(sections[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownHashFormat
+	}
+	_, err = fmt.Sscanf(sections[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil {
+		return params, nil, nil, ErrUnknownHashFormat
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(sections[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHashFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(sections[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnknownHashFormat
+	}
+	return params, salt, key, nil
+}
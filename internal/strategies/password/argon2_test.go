@@ -0,0 +1,76 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testArgon2Params = Argon2Params{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+}
+
+func TestArgon2id_Hash(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2Params)
+
+	t.Run("valid test", func(t *testing.T) {
+		hash, err := hasher.Hash(testPassword)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+		assert.True(t, hasher.Supports(hash))
+	})
+
+	t.Run("long password", func(t *testing.T) {
+		hash, err := hasher.Hash(tooBigPassword)
+		require.NoError(t, err)
+		assert.NoError(t, hasher.Compare(hash, tooBigPassword))
+	})
+}
+
+func TestArgon2id_Compare(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2Params)
+
+	hash, err := hasher.Hash(testPassword)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		err := hasher.Compare(hash, testPassword)
+		assert.NoError(t, err)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		err := hasher.Compare(hash, "wrong_password")
+		assert.ErrorIs(t, err, ErrWrongPassword)
+	})
+
+	t.Run("other parameters", func(t *testing.T) {
+		other := NewArgon2idHasher(Argon2Params{Memory: 128, Iterations: 2, Parallelism: 2})
+		err := other.Compare(hash, testPassword)
+		assert.NoError(t, err)
+	})
+
+	t.Run("malformed hash", func(t *testing.T) {
+		err := hasher.Compare("$argon2id$v=19$m=64$salt$key", testPassword)
+		assert.ErrorIs(t, err, ErrUnknownHashFormat)
+	})
+}
+
+func TestArgon2id_NeedsRehash(t *testing.T) {
+	hasher := NewArgon2idHasher(testArgon2Params)
+
+	hash, err := hasher.Hash(testPassword)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		assert.False(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("parameters changed", func(t *testing.T) {
+		other := NewArgon2idHasher(Argon2Params{Memory: 128, Iterations: 1, Parallelism: 1})
+		assert.True(t, other.NeedsRehash(hash))
+	})
+}
@@ -1,17 +1,23 @@
 package password
 
 import (
+	"strings"
+
 	"golang.org/x/crypto/bcrypt"
 )
 
-type BCryptHasher struct{}
+type BCryptHasher struct {
+	cost int
+}
 
-func NewBCryptHasher() *BCryptHasher {
-	return &BCryptHasher{}
+func NewBCryptHasher(cost int) *BCryptHasher {
+	return &BCryptHasher{
+		cost: cost,
+	}
 }
 
 func (h *BCryptHasher) Hash(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
 	return string(hashedBytes), err
 }
 
@@ -22,3 +28,15 @@ func (h *BCryptHasher) Compare(hashed, plain string) error {
 	}
 	return nil
 }
+
+func (h *BCryptHasher) Supports(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") || strings.HasPrefix(hashed, "$2b$") || strings.HasPrefix(hashed, "$2y$")
+}
+
+func (h *BCryptHasher) NeedsRehash(hashed string) bool {
+	cost, err := bcrypt.Cost([]byte(hashed))
+	if err != nil {
+		return true
+	}
+	return cost != h.cost
+}
@@ -13,7 +13,7 @@ const testPassword = "secret"
 var tooBigPassword = string(make([]byte, 100))
 
 func TestBCrypt_Hash(t *testing.T) {
-	hasher := NewBCryptHasher()
+	hasher := NewBCryptHasher(bcrypt.MinCost)
 
 	t.Run("valid test", func(t *testing.T) {
 		hash, err := hasher.Hash(testPassword)
@@ -28,7 +28,7 @@ func TestBCrypt_Hash(t *testing.T) {
 }
 
 func TestBCrypt_Compare(t *testing.T) {
-	hasher := NewBCryptHasher()
+	hasher := NewBCryptHasher(bcrypt.MinCost)
 
 	t.Run("valid test", func(t *testing.T) {
 		preHash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.DefaultCost)
@@ -44,3 +44,20 @@ func TestBCrypt_Compare(t *testing.T) {
 		assert.ErrorIs(t, err, ErrWrongPassword)
 	})
 }
+
+func TestBCrypt_NeedsRehash(t *testing.T) {
+	hasher := NewBCryptHasher(bcrypt.MinCost)
+
+	t.Run("valid test", func(t *testing.T) {
+		hash, err := hasher.Hash(testPassword)
+		require.NoError(t, err)
+		assert.True(t, hasher.Supports(hash))
+		assert.False(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("cost changed", func(t *testing.T) {
+		hash, err := hasher.Hash(testPassword)
+		require.NoError(t, err)
+		assert.True(t, NewBCryptHasher(bcrypt.MinCost+1).NeedsRehash(hash))
+	})
+}
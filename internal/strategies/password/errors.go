@@ -3,7 +3,9 @@ package password
 import "errors"
 
 var (
-	ErrWrongPassword = errors.New("wrong password")
+	ErrWrongPassword     = errors.New("wrong password")
+	ErrWeakPassword      = errors.New("weak password")
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
 )
 
 type errWrongPassword struct {
@@ -27,3 +29,25 @@ func newErrWrongPassword(err error) error {
 		err: err,
 	}
 }
+
+type errWeakPassword struct {
+	err error
+}
+
+func (err *errWeakPassword) Error() string {
+	return err.err.Error()
+}
+
+func (err *errWeakPassword) Unwrap() error {
+	return err.err
+}
+
+func (err *errWeakPassword) IsErrWeakPassword() bool {
+	return true
+}
+
+func newErrWeakPassword(err error) error {
+	return &errWeakPassword{
+		err: err,
+	}
+}
@@ -0,0 +1,43 @@
+package password
+
+type strategy interface {
+	Hash(string) (string, error)
+	Compare(string, string) error
+	Supports(string) bool
+	NeedsRehash(string) bool
+}
+
+// MultiHasher hashes new passwords with the preferred strategy and verifies
+// existing hashes with whichever strategy recognises their prefix, so
+// several algorithms can coexist while users are migrated on login.
+type MultiHasher struct {
+	preferred  strategy
+	strategies []strategy
+}
+
+func NewMultiHasher(preferred strategy, others ...strategy) *MultiHasher {
+	return &MultiHasher{
+		preferred:  preferred,
+		strategies: append([]strategy{preferred}, others...),
+	}
+}
+
+func (h *MultiHasher) Hash(password string) (string, error) {
+	return h.preferred.Hash(password)
+}
+
+func (h *MultiHasher) Compare(hashed, plain string) error {
+	for _, s := range h.strategies {
+		if s.Supports(hashed) {
+			return s.Compare(hashed, plain)
+		}
+	}
+	return ErrUnknownHashFormat
+}
+
+func (h *MultiHasher) NeedsRehash(hashed string) bool {
+	if !h.preferred.Supports(hashed) {
+		return true
+	}
+	return h.preferred.NeedsRehash(hashed)
+}
@@ -0,0 +1,38 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestMultiHasher(t *testing.T) {
+	argon2id := NewArgon2idHasher(testArgon2Params)
+	bcryptHasher := NewBCryptHasher(bcrypt.MinCost)
+	hasher := NewMultiHasher(argon2id, bcryptHasher)
+
+	legacyHash, err := bcryptHasher.Hash(testPassword)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		hash, err := hasher.Hash(testPassword)
+		require.NoError(t, err)
+		assert.True(t, argon2id.Supports(hash))
+		assert.NoError(t, hasher.Compare(hash, testPassword))
+		assert.False(t, hasher.NeedsRehash(hash))
+	})
+
+	t.Run("legacy hash", func(t *testing.T) {
+		assert.NoError(t, hasher.Compare(legacyHash, testPassword))
+		assert.ErrorIs(t, hasher.Compare(legacyHash, "wrong_password"), ErrWrongPassword)
+		assert.True(t, hasher.NeedsRehash(legacyHash))
+	})
+
+	t.Run("unknown hash", func(t *testing.T) {
+		err := hasher.Compare("plain", testPassword)
+		assert.ErrorIs(t, err, ErrUnknownHashFormat)
+		assert.True(t, hasher.NeedsRehash("plain"))
+	})
+}
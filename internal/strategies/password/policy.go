@@ -0,0 +1,105 @@
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	lowerCharset  = 26
+	upperCharset  = 26
+	digitCharset  = 10
+	symbolCharset = 33
+	otherCharset  = 100
+)
+
+type Policy struct {
+	minLength  int
+	minEntropy float64
+	breached   map[string]struct{}
+}
+
+func NewPolicy(minLength int, minEntropy float64) *Policy {
+	return &Policy{
+		minLength:  minLength,
+		minEntropy: minEntropy,
+		breached:   make(map[string]struct{}),
+	}
+}
+
+// LoadBreachedList reads a newline separated list of known leaked passwords.
+// Empty lines and lines starting with # are skipped.
+func (p *Policy) LoadBreachedList(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		p.breached[line] = struct{}{}
+	}
+	return scanner.Err()
+}
+
+func (p *Policy) Check(password string) error {
+	if utf8.RuneCountInString(password) < p.minLength {
+		return newErrWeakPassword(fmt.Errorf("%w: must be at least %d characters long", ErrWeakPassword, p.minLength))
+	}
+	if _, ok := p.breached[password]; ok {
+		return newErrWeakPassword(fmt.Errorf("%w: found in a list of breached passwords", ErrWeakPassword))
+	}
+	if Entropy(password) < p.minEntropy {
+		return newErrWeakPassword(fmt.Errorf("%w: too predictable, use a longer password or more character classes", ErrWeakPassword))
+	}
+	return nil
+}
+
+// Entropy estimates password strength in bits as length * log2(charset),
+// where the charset is the union of the character classes used.
+func Entropy(password string) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range password {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+	charset := 0
+	for _, class := range []struct {
+		used bool
+		size int
+	}{
+		{lower, lowerCharset},
+		{upper, upperCharset},
+		{digit, digitCharset},
+		{symbol, symbolCharset},
+		{other, otherCharset},
+	} {
+		if class.used {
+			charset += class.size
+		}
+	}
+	if charset == 0 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(password)) * math.Log2(float64(charset))
+}
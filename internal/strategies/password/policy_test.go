@@ -0,0 +1,57 @@
+package password
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Check(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(path, []byte("# leaked\nqwerty123456\n\nCorrectHorse1\n"), 0o600)
+	require.NoError(t, err)
+
+	policy := NewPolicy(8, 40)
+	err = policy.LoadBreachedList(path)
+	require.NoError(t, err)
+
+	t.Run("valid test", func(t *testing.T) {
+		err := policy.Check("Tr0ub4dor&3x")
+		assert.NoError(t, err)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		err := policy.Check("Ab1!")
+		assert.ErrorIs(t, err, ErrWeakPassword)
+		assert.ErrorContains(t, err, "at least 8 characters")
+	})
+
+	t.Run("breached password", func(t *testing.T) {
+		err := policy.Check("CorrectHorse1")
+		assert.ErrorIs(t, err, ErrWeakPassword)
+		assert.ErrorContains(t, err, "breached")
+	})
+
+	t.Run("low entropy", func(t *testing.T) {
+		err := policy.Check("12345678")
+		assert.ErrorIs(t, err, ErrWeakPassword)
+		assert.ErrorContains(t, err, "predictable")
+	})
+
+	t.Run("no breached list", func(t *testing.T) {
+		err := NewPolicy(8, 0).LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
+
+func TestEntropy(t *testing.T) {
+	t.Run("valid test", func(t *testing.T) {
+		assert.Zero(t, Entropy(""))
+		assert.InDelta(t, 8*math.Log2(10), Entropy("12345678"), 0.001)
+		assert.InDelta(t, 4*math.Log2(62), Entropy("aB1c"), 0.001)
+	})
+}
@@ -0,0 +1,88 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 6238 appendix B secret "12345678901234567890" in base32.
+const testSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func testGenerator(unix int64) *Generator {
+	g := NewGenerator("loyalsys")
+	g.now = func() time.Time {
+		return time.Unix(unix, 0)
+	}
+	return g
+}
+
+func TestGenerator_Code(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, tt := range tests {
+		t.Run("rfc vector", func(t *testing.T) {
+			code, err := testGenerator(tt.unix).Code(testSecret)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestGenerator_Validate(t *testing.T) {
+	g := testGenerator(1111111109)
+
+	t.Run("valid test", func(t *testing.T) {
+		step, ok := g.Validate(testSecret, "081804")
+		assert.True(t, ok)
+		assert.Equal(t, int64(1111111109/period), step)
+	})
+
+	t.Run("previous step", func(t *testing.T) {
+		prev, err := testGenerator(1111111109 - period).Code(testSecret)
+		require.NoError(t, err)
+		step, ok := g.Validate(testSecret, prev)
+		assert.True(t, ok)
+		assert.Equal(t, int64(1111111109/period-1), step)
+	})
+
+	t.Run("expired code", func(t *testing.T) {
+		old, err := testGenerator(1111111109 - 3*period).Code(testSecret)
+		require.NoError(t, err)
+		_, ok := g.Validate(testSecret, old)
+		assert.False(t, ok)
+	})
+
+	t.Run("malformed code", func(t *testing.T) {
+		_, ok := g.Validate(testSecret, "12345")
+		assert.False(t, ok)
+	})
+}
+
+func TestGenerator_ProvisioningURI(t *testing.T) {
+	g := NewGenerator("loyalsys")
+
+	t.Run("valid test", func(t *testing.T) {
+		secret, err := g.GenerateSecret()
+		require.NoError(t, err)
+		assert.Len(t, secret, 32)
+
+		u, err := url.Parse(g.ProvisioningURI("user@example.com", secret))
+		require.NoError(t, err)
+		assert.Equal(t, "otpauth", u.Scheme)
+		assert.Equal(t, "totp", u.Host)
+		assert.Equal(t, "/loyalsys:user@example.com", u.Path)
+		assert.Equal(t, secret, u.Query().Get("secret"))
+		assert.Equal(t, "loyalsys", u.Query().Get("issuer"))
+	})
+}
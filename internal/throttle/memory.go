@@ -0,0 +1,62 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring"
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+type MemoryStore struct {
+	mu      sync.Mutex
+	records *expiring.Map[*models.ThrottleRecord]
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: expiring.NewMap[*models.ThrottleRecord](),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Reserve(_ context.Context, key string, window time.Duration, retry func(*models.ThrottleRecord) time.Duration) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	record, ok := s.records.Get(key, now)
+	if !ok {
+		record = &models.ThrottleRecord{}
+	}
+	rec := *record
+	if wait := retry(&rec); wait > 0 {
+		return wait, nil
+	}
+
+	record.Failures++
+	record.LastFailure = now
+	s.records.Set(key, record, now.Add(window), now)
+	return 0, nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records.Get(key, s.now())
+	if ok && record.Failures > 0 {
+		record.Failures--
+	}
+	return nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records.Delete(key)
+	return nil
+}
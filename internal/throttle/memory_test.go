@@ -0,0 +1,69 @@
+package throttle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/expiring/expiringtest"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	clock := expiringtest.NewClock()
+	store := NewMemoryStore()
+	store.now = clock.Now
+
+	var seen models.ThrottleRecord
+	record := func(rec *models.ThrottleRecord) time.Duration {
+		seen = *rec
+		return 0
+	}
+
+	t.Run("reserve", func(t *testing.T) {
+		for range 2 {
+			retry, err := store.Reserve(ctx, testKey, time.Minute, record)
+			require.NoError(t, err)
+			assert.Zero(t, retry)
+		}
+		assert.Equal(t, models.ThrottleRecord{Failures: 1, LastFailure: clock.Now()}, seen)
+
+		retry, err := store.Reserve(ctx, testKey, time.Minute, func(*models.ThrottleRecord) time.Duration {
+			return time.Second
+		})
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, retry)
+
+		_, err = store.Reserve(ctx, testKey, time.Minute, record)
+		require.NoError(t, err)
+		assert.Equal(t, 2, seen.Failures)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		require.NoError(t, store.Release(ctx, testKey))
+
+		_, err := store.Reserve(ctx, testKey, time.Minute, record)
+		require.NoError(t, err)
+		assert.Equal(t, 2, seen.Failures)
+	})
+
+	t.Run("expired record", func(t *testing.T) {
+		clock.Add(time.Minute)
+
+		_, err := store.Reserve(ctx, testKey, time.Minute, record)
+		require.NoError(t, err)
+		assert.Zero(t, seen.Failures)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		require.NoError(t, store.Reset(ctx, testKey))
+
+		_, err := store.Reserve(ctx, testKey, time.Minute, record)
+		require.NoError(t, err)
+		assert.Zero(t, seen.Failures)
+	})
+
+}
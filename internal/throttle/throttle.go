@@ -0,0 +1,105 @@
+package throttle
+
+import (
+	"context"
+	"time"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
+
+const (
+	defaultBaseDelay = time.Second
+	lockoutFactor    = 2
+)
+
+// store keeps the failure records. Reserve reads the record of the key,
+// asks retry whether the attempt has to wait and, if not, counts it as a
+// failure, all in one step, so that concurrent attempts can't all pass on
+// the same record.
+type store interface {
+	Reserve(ctx context.Context, key string, window time.Duration, retry func(*models.ThrottleRecord) time.Duration) (time.Duration, error)
+	Release(context.Context, string) error
+	Reset(context.Context, string) error
+}
+
+type Config struct {
+	Name      string
+	Attempts  int
+	BaseDelay time.Duration
+	Lockout   time.Duration
+}
+
+type Throttler struct {
+	store store
+	cfg   Config
+	now   func() time.Time
+}
+
+func NewThrottler(store store, cfg Config) *Throttler {
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = defaultBaseDelay
+	}
+	return &Throttler{
+		store: store,
+		cfg:   cfg,
+		now:   time.Now,
+	}
+}
+
+// Check reserves an attempt: unless the key has to wait, the attempt is
+// counted as a failure up front, before the credentials are checked. A
+// successful attempt is given back with Release or Reset.
+func (t *Throttler) Check(ctx context.Context, key string) (time.Duration, error) {
+	if t.cfg.Attempts <= 0 {
+		return 0, nil
+	}
+	return t.store.Reserve(ctx, t.key(key), t.cfg.Lockout, t.retry)
+}
+
+// Release gives back an attempt reserved by Check that didn't test the
+// credentials.
+func (t *Throttler) Release(ctx context.Context, key string) error {
+	if t.cfg.Attempts <= 0 {
+		return nil
+	}
+	return t.store.Release(ctx, t.key(key))
+}
+
+func (t *Throttler) Reset(ctx context.Context, key string) error {
+	if t.cfg.Attempts <= 0 {
+		return nil
+	}
+	return t.store.Reset(ctx, t.key(key))
+}
+
+func (t *Throttler) retry(rec *models.ThrottleRecord) time.Duration {
+	if rec.Failures <= t.cfg.Attempts {
+		return 0
+	}
+	elapsed := t.now().Sub(rec.LastFailure)
+	if elapsed >= t.cfg.Lockout {
+		return 0
+	}
+	delay := t.delay(rec.Failures)
+	if elapsed >= delay {
+		return 0
+	}
+	return delay - elapsed
+}
+
+func (t *Throttler) delay(failures int) time.Duration {
+	extra := failures - t.cfg.Attempts
+	if extra <= 0 {
+		return 0
+	}
+	if failures >= lockoutFactor*t.cfg.Attempts || extra > 30 {
+		return t.cfg.Lockout
+	}
+	return min(t.cfg.BaseDelay<<(extra-1), t.cfg.Lockout)
+}
+
+func (t *Throttler) key(key string) string {
+	return t.cfg.Name + ":" + key
+}
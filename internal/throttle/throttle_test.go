@@ -0,0 +1,167 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/expiring/expiringtest"
+	"github.com/rycln/loyalsys/internal/throttle/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testKey     = "user"
+	testLockout = time.Duration(15) * time.Minute
+)
+
+var errTest = errors.New("test error")
+
+func newTestThrottler(attempts int) (*Throttler, *expiringtest.Clock) {
+	clock := expiringtest.NewClock()
+	store := NewMemoryStore()
+	store.now = clock.Now
+	t := NewThrottler(store, Config{
+		Name:     "login",
+		Attempts: attempts,
+		Lockout:  testLockout,
+	})
+	t.now = clock.Now
+	return t, clock
+}
+
+func reserve(t *testing.T, th *Throttler, n int) {
+	t.Helper()
+	for range n {
+		retry, err := th.Check(context.Background(), testKey)
+		require.NoError(t, err)
+		require.Zero(t, retry)
+	}
+}
+
+func TestThrottler_Check(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("free attempts", func(t *testing.T) {
+		th, _ := newTestThrottler(3)
+		reserve(t, th, 4)
+
+		retry, err := th.Check(ctx, testKey)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, retry)
+	})
+
+	t.Run("progressive delay", func(t *testing.T) {
+		th, clock := newTestThrottler(3)
+		reserve(t, th, 4)
+
+		retry, err := th.Check(ctx, testKey)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Second, retry)
+
+		clock.Add(time.Second)
+		reserve(t, th, 1)
+		retry, err = th.Check(ctx, testKey)
+		assert.NoError(t, err)
+		assert.Equal(t, 2*time.Second, retry)
+
+		clock.Add(2 * time.Second)
+		reserve(t, th, 1)
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		th, clock := newTestThrottler(3)
+		reserve(t, th, 4)
+		clock.Add(time.Second)
+		reserve(t, th, 1)
+		clock.Add(2 * time.Second)
+		reserve(t, th, 1)
+
+		retry, err := th.Check(ctx, testKey)
+		assert.NoError(t, err)
+		assert.Equal(t, testLockout, retry)
+
+		clock.Add(testLockout)
+		reserve(t, th, 4)
+	})
+
+	t.Run("concurrent attempts", func(t *testing.T) {
+		th, _ := newTestThrottler(3)
+
+		var passed atomic.Int32
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				retry, err := th.Check(ctx, testKey)
+				if err == nil && retry == 0 {
+					passed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(4), passed.Load())
+	})
+
+	t.Run("release", func(t *testing.T) {
+		th, _ := newTestThrottler(1)
+		reserve(t, th, 2)
+		require.NoError(t, th.Release(ctx, testKey))
+
+		reserve(t, th, 1)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		th, _ := newTestThrottler(1)
+		reserve(t, th, 2)
+		require.NoError(t, th.Reset(ctx, testKey))
+
+		reserve(t, th, 2)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		th, _ := newTestThrottler(0)
+		reserve(t, th, 10)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mStore := mocks.NewMockstore(ctrl)
+		mStore.EXPECT().Reserve(gomock.Any(), "login:"+testKey, testLockout, gomock.Any()).Return(time.Duration(0), errTest)
+		th := NewThrottler(mStore, Config{Name: "login", Attempts: 3, Lockout: testLockout})
+
+		_, err := th.Check(ctx, testKey)
+		assert.Error(t, err)
+	})
+}
+
+func TestThrottler_Release(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mStore := mocks.NewMockstore(ctrl)
+	th := NewThrottler(mStore, Config{Name: "ip", Attempts: 3, Lockout: testLockout})
+
+	t.Run("valid test", func(t *testing.T) {
+		mStore.EXPECT().Release(gomock.Any(), "ip:"+testKey).Return(nil)
+
+		err := th.Release(context.Background(), testKey)
+		assert.NoError(t, err)
+	})
+
+	t.Run("some error", func(t *testing.T) {
+		mStore.EXPECT().Release(gomock.Any(), "ip:"+testKey).Return(errTest)
+
+		err := th.Release(context.Background(), testKey)
+		assert.Error(t, err)
+	})
+}
@@ -6,12 +6,16 @@ const (
 	defaultTickerPeriod = time.Duration(5) * time.Second
 	defaultTimeout      = time.Duration(5) * time.Second
 	defaultFanOutPool   = 10
+	defaultGracePeriod  = time.Duration(10) * time.Minute
+	defaultFlushTimeout = time.Duration(5) * time.Second
 )
 
 type SyncWorkerConfig struct {
 	tickerPeriod time.Duration
 	timeout      time.Duration
 	fanOutPool   int
+	gracePeriod  time.Duration
+	flushTimeout time.Duration
 }
 
 type SyncWorkerConfigBuilder struct {
@@ -24,6 +28,8 @@ func NewSyncWorkerConfigBuilder() *SyncWorkerConfigBuilder {
 			tickerPeriod: defaultTickerPeriod,
 			timeout:      defaultTimeout,
 			fanOutPool:   defaultFanOutPool,
+			gracePeriod:  defaultGracePeriod,
+			flushTimeout: defaultFlushTimeout,
 		},
 	}
 }
@@ -38,6 +44,21 @@ func (b *SyncWorkerConfigBuilder) WithTickerPeriod(period time.Duration) *SyncWo
 	return b
 }
 
+func (b *SyncWorkerConfigBuilder) WithFanOutPool(pool int) *SyncWorkerConfigBuilder {
+	b.cfg.fanOutPool = pool
+	return b
+}
+
+func (b *SyncWorkerConfigBuilder) WithGracePeriod(period time.Duration) *SyncWorkerConfigBuilder {
+	b.cfg.gracePeriod = period
+	return b
+}
+
+func (b *SyncWorkerConfigBuilder) WithFlushTimeout(timeout time.Duration) *SyncWorkerConfigBuilder {
+	b.cfg.flushTimeout = timeout
+	return b
+}
+
 func (b *SyncWorkerConfigBuilder) Build() *SyncWorkerConfig {
 	return b.cfg
 }
@@ -51,6 +51,44 @@ func (mr *MockgetAPIMockRecorder) GetOrderFromAccrual(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderFromAccrual", reflect.TypeOf((*MockgetAPI)(nil).GetOrderFromAccrual), arg0, arg1)
 }
 
+// MockbatchGetAPI is a mock of batchGetAPI interface.
+type MockbatchGetAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockbatchGetAPIMockRecorder
+}
+
+// MockbatchGetAPIMockRecorder is the mock recorder for MockbatchGetAPI.
+type MockbatchGetAPIMockRecorder struct {
+	mock *MockbatchGetAPI
+}
+
+// NewMockbatchGetAPI creates a new mock instance.
+func NewMockbatchGetAPI(ctrl *gomock.Controller) *MockbatchGetAPI {
+	mock := &MockbatchGetAPI{ctrl: ctrl}
+	mock.recorder = &MockbatchGetAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockbatchGetAPI) EXPECT() *MockbatchGetAPIMockRecorder {
+	return m.recorder
+}
+
+// GetOrdersFromAccrual mocks base method.
+func (m *MockbatchGetAPI) GetOrdersFromAccrual(arg0 context.Context, arg1 []string) ([]*models.OrderAccrual, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersFromAccrual", arg0, arg1)
+	ret0, _ := ret[0].([]*models.OrderAccrual)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersFromAccrual indicates an expected call of GetOrdersFromAccrual.
+func (mr *MockbatchGetAPIMockRecorder) GetOrdersFromAccrual(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersFromAccrual", reflect.TypeOf((*MockbatchGetAPI)(nil).GetOrdersFromAccrual), arg0, arg1)
+}
+
 // MockgetStorager is a mock of getStorager interface.
 type MockgetStorager struct {
 	ctrl     *gomock.Controller
@@ -89,6 +127,44 @@ func (mr *MockgetStoragerMockRecorder) GetInconclusiveOrderNums(arg0 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInconclusiveOrderNums", reflect.TypeOf((*MockgetStorager)(nil).GetInconclusiveOrderNums), arg0)
 }
 
+// MockstaleStorager is a mock of staleStorager interface.
+type MockstaleStorager struct {
+	ctrl     *gomock.Controller
+	recorder *MockstaleStoragerMockRecorder
+}
+
+// MockstaleStoragerMockRecorder is the mock recorder for MockstaleStorager.
+type MockstaleStoragerMockRecorder struct {
+	mock *MockstaleStorager
+}
+
+// NewMockstaleStorager creates a new mock instance.
+func NewMockstaleStorager(ctrl *gomock.Controller) *MockstaleStorager {
+	mock := &MockstaleStorager{ctrl: ctrl}
+	mock.recorder = &MockstaleStoragerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockstaleStorager) EXPECT() *MockstaleStoragerMockRecorder {
+	return m.recorder
+}
+
+// GetStaleOrderNums mocks base method.
+func (m *MockstaleStorager) GetStaleOrderNums(arg0 context.Context, arg1 time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStaleOrderNums", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStaleOrderNums indicates an expected call of GetStaleOrderNums.
+func (mr *MockstaleStoragerMockRecorder) GetStaleOrderNums(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStaleOrderNums", reflect.TypeOf((*MockstaleStorager)(nil).GetStaleOrderNums), arg0, arg1)
+}
+
 // MockerrRetryAfter is a mock of errRetryAfter interface.
 type MockerrRetryAfter struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+
+	"github.com/rycln/loyalsys/internal/logger"
+	"github.com/rycln/loyalsys/internal/models"
+	"go.uber.org/zap"
+)
+
+const (
+	accrualBatchSize = 100
+)
+
+// fetchBatches requests the orders accrualBatchSize at a time. Batches go
+// one after another, so the fan-out pool doesn't apply.
+func (worker *orderGetWorker) fetchBatches(ctx context.Context, orderNums []string, orderCh chan<- *models.OrderDB) error {
+	for start := 0; start < len(orderNums); start += accrualBatchSize {
+		batch := orderNums[start:min(start+accrualBatchSize, len(orderNums))]
+
+		ctxAPI, cancel := context.WithTimeout(ctx, worker.cfg.Load().timeout)
+		orders, err := worker.batchAPI.GetOrdersFromAccrual(ctxAPI, batch)
+		cancel()
+		if e, ok := err.(errRetryAfter); ok && e.IsErrRetryAfter() {
+			return err
+		}
+		if err != nil {
+			logger.Log.Debug("batch error", zap.Error(err))
+			continue
+		}
+
+		for _, order := range orders {
+			select {
+			case <-ctx.Done():
+				return nil
+			case orderCh <- &models.OrderDB{
+				Number:  order.Number,
+				Status:  order.Status,
+				Accrual: order.Accrual,
+			}:
+			}
+		}
+	}
+	return nil
+}
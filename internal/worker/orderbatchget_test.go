@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/fortytw2/leaktest"
+	"github.com/golang/mock/gomock"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/rycln/loyalsys/internal/worker/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_orderGetWorker_fetchBatches(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testOrderNums := make([]string, accrualBatchSize+1)
+	for i := range testOrderNums {
+		testOrderNums[i] = fmt.Sprint(i)
+	}
+
+	mAPI := mocks.NewMockbatchGetAPI(ctrl)
+	mStrg := mocks.NewMockgetStorager(ctrl)
+
+	testCfg := NewSyncWorkerConfigBuilder().
+		WithTimeout(testTimeout).
+		WithTickerPeriod(testTickerPeriod).
+		Build()
+
+	worker := newOrderBatchGetWorker(mAPI, mStrg, testCfg)
+
+	t.Run("valid test", func(t *testing.T) {
+		testCh := make(chan *models.OrderDB, 10)
+
+		mStrg.EXPECT().GetInconclusiveOrderNums(gomock.Any()).Return(testOrderNums, nil)
+		gomock.InOrder(
+			mAPI.EXPECT().GetOrdersFromAccrual(gomock.Any(), testOrderNums[:accrualBatchSize]).Return([]*models.OrderAccrual{
+				{Number: "1", Status: models.StatusProcessed, Accrual: 10},
+			}, nil),
+			mAPI.EXPECT().GetOrdersFromAccrual(gomock.Any(), testOrderNums[accrualBatchSize:]).Return([]*models.OrderAccrual{
+				{Number: "100", Status: models.StatusInvalid},
+			}, nil),
+		)
+
+		err := worker.getOrders(context.Background(), testCh)
+		require.NoError(t, err)
+		close(testCh)
+
+		var orders []*models.OrderDB
+		for order := range testCh {
+			orders = append(orders, order)
+		}
+		assert.Equal(t, []*models.OrderDB{
+			{Number: "1", Status: models.StatusProcessed, Accrual: 10},
+			{Number: "100", Status: models.StatusInvalid},
+		}, orders)
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		testCh := make(chan *models.OrderDB, 10)
+
+		mStrg.EXPECT().GetInconclusiveOrderNums(gomock.Any()).Return(testOrderNums, nil)
+		mAPI.EXPECT().GetOrdersFromAccrual(gomock.Any(), testOrderNums[:accrualBatchSize]).Return(nil, errTest)
+		mAPI.EXPECT().GetOrdersFromAccrual(gomock.Any(), testOrderNums[accrualBatchSize:]).Return([]*models.OrderAccrual{
+			{Number: "100", Status: models.StatusInvalid},
+		}, nil)
+
+		err := worker.getOrders(context.Background(), testCh)
+		assert.NoError(t, err)
+		assert.Len(t, testCh, 1)
+	})
+
+	t.Run("retry after error", func(t *testing.T) {
+		testCh := make(chan *models.OrderDB, 10)
+
+		mErr := mocks.NewMockerrRetryAfter(ctrl)
+		mErr.EXPECT().IsErrRetryAfter().Return(true)
+		mStrg.EXPECT().GetInconclusiveOrderNums(gomock.Any()).Return(testOrderNums, nil)
+		mAPI.EXPECT().GetOrdersFromAccrual(gomock.Any(), gomock.Any()).Return(nil, mErr)
+
+		err := worker.getOrders(context.Background(), testCh)
+		assert.Equal(t, mErr, err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rycln/loyalsys/internal/logger"
@@ -19,27 +20,79 @@ type getAPI interface {
 	GetOrderFromAccrual(context.Context, string) (*models.OrderAccrual, error)
 }
 
+type batchGetAPI interface {
+	GetOrdersFromAccrual(context.Context, []string) ([]*models.OrderAccrual, error)
+}
+
 type getStorager interface {
 	GetInconclusiveOrderNums(context.Context) ([]string, error)
 }
 
+type staleStorager interface {
+	GetStaleOrderNums(context.Context, time.Time) ([]string, error)
+}
+
 type errRetryAfter interface {
 	error
 	IsErrRetryAfter() bool
 	GetRetryAfterDuration() time.Duration
 }
 
+// orderGetWorker is the pull source: on every tick it asks the accrual
+// system about the inconclusive orders, either one order per request or in
+// batches.
 type orderGetWorker struct {
-	api     getAPI
-	storage getStorager
-	cfg     *SyncWorkerConfig
+	api      getAPI
+	batchAPI batchGetAPI
+	cfg      atomic.Pointer[SyncWorkerConfig]
+	reloadCh chan struct{}
+	list     func(context.Context) ([]string, error)
+	fetch    func(context.Context, []string, chan<- *models.OrderDB) error
 }
 
 func newOrderGetWorker(api getAPI, storage getStorager, cfg *SyncWorkerConfig) *orderGetWorker {
-	return &orderGetWorker{
-		api:     api,
-		storage: storage,
-		cfg:     cfg,
+	worker := &orderGetWorker{
+		api:      api,
+		list:     storage.GetInconclusiveOrderNums,
+		reloadCh: make(chan struct{}, 1),
+	}
+	worker.fetch = worker.fetchEach
+	worker.cfg.Store(cfg)
+	return worker
+}
+
+func newOrderBatchGetWorker(api batchGetAPI, storage getStorager, cfg *SyncWorkerConfig) *orderGetWorker {
+	worker := &orderGetWorker{
+		batchAPI: api,
+		list:     storage.GetInconclusiveOrderNums,
+		reloadCh: make(chan struct{}, 1),
+	}
+	worker.fetch = worker.fetchBatches
+	worker.cfg.Store(cfg)
+	return worker
+}
+
+// newOrderFallbackGetWorker polls only the orders the accrual system hasn't
+// reported on within the grace period, for when its callbacks get lost.
+func newOrderFallbackGetWorker(api getAPI, storage staleStorager, cfg *SyncWorkerConfig) *orderGetWorker {
+	worker := &orderGetWorker{
+		api:      api,
+		reloadCh: make(chan struct{}, 1),
+	}
+	worker.list = func(ctx context.Context) ([]string, error) {
+		return storage.GetStaleOrderNums(ctx, time.Now().Add(-worker.cfg.Load().gracePeriod))
+	}
+	worker.fetch = worker.fetchEach
+	worker.cfg.Store(cfg)
+	return worker
+}
+
+func (worker *orderGetWorker) reload(cfg *SyncWorkerConfig) {
+	worker.cfg.Store(cfg)
+
+	select {
+	case worker.reloadCh <- struct{}{}:
+	default:
 	}
 }
 
@@ -49,13 +102,15 @@ func (worker *orderGetWorker) run(ctx context.Context, wg *sync.WaitGroup, order
 	go func() {
 		defer wg.Done()
 
-		ticker := time.NewTicker(worker.cfg.tickerPeriod)
+		ticker := time.NewTicker(worker.cfg.Load().tickerPeriod)
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ctx.Done():
 				return
+			case <-worker.reloadCh:
+				ticker.Reset(worker.cfg.Load().tickerPeriod)
 			case <-ticker.C:
 				err := worker.getOrders(ctx, orderCh)
 				if err != nil {
@@ -80,10 +135,16 @@ func (worker *orderGetWorker) getOrders(ctx context.Context, orderCh chan<- *mod
 		return err
 	}
 
-	numsChan := orderNumbersGenerator(ctxGet, orderNums)
-	resultChans := worker.ordersFanOut(ctxGet, numsChan)
-	resultCh := ordersFanIn(ctxGet, resultChans)
-	errCh := ordersResultDispatcher(ctxGet, resultCh, orderCh)
+	return worker.fetch(ctxGet, orderNums, orderCh)
+}
+
+// fetchEach requests every order separately, fanning the requests out over
+// the pool.
+func (worker *orderGetWorker) fetchEach(ctx context.Context, orderNums []string, orderCh chan<- *models.OrderDB) error {
+	numsChan := orderNumbersGenerator(ctx, orderNums)
+	resultChans := worker.ordersFanOut(ctx, numsChan)
+	resultCh := ordersFanIn(ctx, resultChans)
+	errCh := ordersResultDispatcher(ctx, resultCh, orderCh)
 
 	for err := range errCh {
 		select {
@@ -104,10 +165,10 @@ func (worker *orderGetWorker) getOrders(ctx context.Context, orderCh chan<- *mod
 }
 
 func (worker *orderGetWorker) getOrderNums(ctx context.Context) ([]string, error) {
-	ctxDB, cancel := context.WithTimeout(ctx, worker.cfg.timeout)
+	ctxDB, cancel := context.WithTimeout(ctx, worker.cfg.Load().timeout)
 	defer cancel()
 
-	orderNums, err := worker.storage.GetInconclusiveOrderNums(ctxDB)
+	orderNums, err := worker.list(ctxDB)
 	if err != nil {
 		return nil, err
 	}
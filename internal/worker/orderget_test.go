@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -100,3 +101,87 @@ func Test_orderGetWorker_getOrders(t *testing.T) {
 		assert.Error(t, err, mErr)
 	})
 }
+
+func Test_orderGetWorker_getOrdersFallback(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCh := make(chan *models.OrderDB, 10)
+
+	mAPI := mocks.NewMockgetAPI(ctrl)
+	mStrg := mocks.NewMockstaleStorager(ctrl)
+	testCfg := NewSyncWorkerConfigBuilder().
+		WithTimeout(testTimeout).
+		WithTickerPeriod(testTickerPeriod).
+		WithGracePeriod(time.Hour).
+		Build()
+	worker := newOrderFallbackGetWorker(mAPI, mStrg, testCfg)
+
+	t.Run("valid test", func(t *testing.T) {
+		mStrg.EXPECT().GetStaleOrderNums(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) ([]string, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+			return []string{"123"}, nil
+		})
+		mAPI.EXPECT().GetOrderFromAccrual(gomock.Any(), "123").Return(&models.OrderAccrual{
+			Number: "123",
+			Status: models.StatusProcessed,
+		}, nil)
+
+		err := worker.getOrders(context.Background(), testCh)
+		assert.NoError(t, err)
+		assert.Equal(t, "123", (<-testCh).Number)
+	})
+
+	t.Run("no stale orders", func(t *testing.T) {
+		mStrg.EXPECT().GetStaleOrderNums(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		err := worker.getOrders(context.Background(), testCh)
+		assert.ErrorIs(t, err, errNoOrderNums)
+	})
+}
+
+func Test_orderGetWorker_reload(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mAPI := mocks.NewMockgetAPI(ctrl)
+	mStrg := mocks.NewMockgetStorager(ctrl)
+	testCfg := NewSyncWorkerConfigBuilder().
+		WithTimeout(testTimeout).
+		WithTickerPeriod(time.Hour).
+		Build()
+	worker := newOrderGetWorker(mAPI, mStrg, testCfg)
+
+	t.Run("valid test", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		called := make(chan struct{})
+		mStrg.EXPECT().GetInconclusiveOrderNums(gomock.Any()).DoAndReturn(func(context.Context) ([]string, error) {
+			cancel()
+			close(called)
+			return nil, nil
+		})
+
+		var wg sync.WaitGroup
+		worker.run(ctx, &wg, make(chan *models.OrderDB))
+
+		newCfg := NewSyncWorkerConfigBuilder().
+			WithTimeout(testTimeout).
+			WithTickerPeriod(time.Millisecond).
+			WithFanOutPool(1).
+			Build()
+		worker.reload(newCfg)
+
+		select {
+		case <-called:
+		case <-time.After(testTickerPeriod):
+			cancel()
+			t.Fatal("ticker was not reset on reload")
+		}
+		wg.Wait()
+		assert.Equal(t, newCfg, worker.cfg.Load())
+	})
+}
@@ -31,9 +31,10 @@ func orderNumbersGenerator(ctx context.Context, nums []string) <-chan string {
 }
 
 func (worker *orderGetWorker) ordersFanOut(ctx context.Context, inputNumCh <-chan string) []<-chan updateOrderResult {
-	channels := make([]<-chan updateOrderResult, worker.cfg.fanOutPool)
+	pool := worker.cfg.Load().fanOutPool
+	channels := make([]<-chan updateOrderResult, pool)
 
-	for i := 0; i < worker.cfg.fanOutPool; i++ {
+	for i := 0; i < pool; i++ {
 		resultCh := worker.getUpdatedOrderByNum(ctx, inputNumCh)
 		channels[i] = resultCh
 	}
@@ -50,7 +51,7 @@ func (worker *orderGetWorker) getUpdatedOrderByNum(ctx context.Context, inputNum
 		for num := range inputNumCh {
 			var orderDB *models.OrderDB
 
-			ctxAPI, cancel := context.WithTimeout(ctx, worker.cfg.timeout)
+			ctxAPI, cancel := context.WithTimeout(ctx, worker.cfg.Load().timeout)
 			defer cancel()
 
 			orderAccrual, err := worker.api.GetOrderFromAccrual(ctxAPI, num)
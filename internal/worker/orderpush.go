@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/rycln/loyalsys/internal/models"
+)
+
+var ErrSourceClosed = errors.New("accrual result source is closed")
+
+type errSourceClosed struct {
+	err error
+}
+
+func (err *errSourceClosed) Error() string {
+	return err.err.Error()
+}
+
+func (err *errSourceClosed) Unwrap() error {
+	return err.err
+}
+
+func (err *errSourceClosed) IsErrSourceClosed() bool {
+	return true
+}
+
+func newErrSourceClosed(err error) error {
+	return &errSourceClosed{
+		err: err,
+	}
+}
+
+// PushSource feeds the results the accrual system pushes to us into the
+// update pipeline, so the orders aren't polled.
+type PushSource struct {
+	resultCh  chan *models.OrderDB
+	closedCh  chan struct{}
+	closeOnce sync.Once
+}
+
+func NewPushSource() *PushSource {
+	return &PushSource{
+		resultCh: make(chan *models.OrderDB),
+		closedCh: make(chan struct{}),
+	}
+}
+
+// Push hands the results over to the running worker. It waits while the
+// updater's buffer is full, until ctx is done or the worker stops, which
+// is ErrSourceClosed. Results handed over before the worker stopped may
+// still be lost if the updater can't flush them in time.
+func (source *PushSource) Push(ctx context.Context, orders []*models.OrderAccrual) error {
+	for _, order := range orders {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-source.closedCh:
+			return newErrSourceClosed(ErrSourceClosed)
+		case source.resultCh <- &models.OrderDB{
+			Number:  order.Number,
+			Status:  order.Status,
+			Accrual: order.Accrual,
+		}:
+		}
+	}
+	return nil
+}
+
+func (source *PushSource) reload(*SyncWorkerConfig) {}
+
+func (source *PushSource) run(ctx context.Context, wg *sync.WaitGroup, orderCh chan<- *models.OrderDB) {
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer source.closeOnce.Do(func() {
+			close(source.closedCh)
+		})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case order := <-source.resultCh:
+				select {
+				case <-ctx.Done():
+					return
+				case orderCh <- order:
+				}
+			}
+		}
+	}()
+}
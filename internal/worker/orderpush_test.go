@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fortytw2/leaktest"
+	"github.com/rycln/loyalsys/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushSource_Push(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	testOrders := []*models.OrderAccrual{
+		{Number: "123", Status: models.StatusProcessing},
+		{Number: "456", Status: models.StatusProcessed, Accrual: 10},
+	}
+
+	t.Run("valid test", func(t *testing.T) {
+		source := NewPushSource()
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		orderCh := make(chan *models.OrderDB, 10)
+		source.run(ctx, &wg, orderCh)
+
+		err := source.Push(context.Background(), testOrders)
+		assert.NoError(t, err)
+		assert.Equal(t, &models.OrderDB{Number: "123", Status: models.StatusProcessing}, <-orderCh)
+		assert.Equal(t, &models.OrderDB{Number: "456", Status: models.StatusProcessed, Accrual: 10}, <-orderCh)
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		source := NewPushSource()
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		source.run(ctx, &wg, make(chan *models.OrderDB))
+		cancel()
+		wg.Wait()
+
+		err := source.Push(context.Background(), testOrders)
+		assert.ErrorIs(t, err, ErrSourceClosed)
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		source := NewPushSource()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := source.Push(ctx, testOrders)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -15,23 +15,78 @@ type syncAPI interface {
 	getAPI
 }
 
+type syncBatchAPI interface {
+	batchGetAPI
+}
+
 type syncStorager interface {
 	getStorager
 	updateStorager
 }
 
+type syncPushStorager interface {
+	syncStorager
+	staleStorager
+}
+
+// accrualSource delivers accrual results to the updater. Pull sources ask
+// the accrual system on every tick, the push source waits for the accrual
+// system to call us.
+type accrualSource interface {
+	run(context.Context, *sync.WaitGroup, chan<- *models.OrderDB)
+	reload(*SyncWorkerConfig)
+}
+
+// accrualSources runs several sources into the same updater.
+type accrualSources []accrualSource
+
+func (sources accrualSources) run(ctx context.Context, wg *sync.WaitGroup, orderCh chan<- *models.OrderDB) {
+	for _, source := range sources {
+		source.run(ctx, wg, orderCh)
+	}
+}
+
+func (sources accrualSources) reload(cfg *SyncWorkerConfig) {
+	for _, source := range sources {
+		source.reload(cfg)
+	}
+}
+
 type OrderSyncWorker struct {
-	getter  *orderGetWorker
+	source  accrualSource
 	updater *orderUpdateWorker
 }
 
+// NewOrderSyncWorker polls the accrual system one order per request.
 func NewOrderSyncWorker(api syncAPI, storage syncStorager, cfg *SyncWorkerConfig) *OrderSyncWorker {
 	return &OrderSyncWorker{
-		getter:  newOrderGetWorker(api, storage, cfg),
+		source:  newOrderGetWorker(api, storage, cfg),
 		updater: newOrderUpdateWorker(storage, cfg),
 	}
 }
 
+// NewOrderBatchSyncWorker polls the accrual system many orders per request.
+func NewOrderBatchSyncWorker(api syncBatchAPI, storage syncStorager, cfg *SyncWorkerConfig) *OrderSyncWorker {
+	return &OrderSyncWorker{
+		source:  newOrderBatchGetWorker(api, storage, cfg),
+		updater: newOrderUpdateWorker(storage, cfg),
+	}
+}
+
+// NewOrderPushSyncWorker stores the results pushed to source. Orders that
+// get no result within the grace period are polled one per request.
+func NewOrderPushSyncWorker(source *PushSource, api syncAPI, storage syncPushStorager, cfg *SyncWorkerConfig) *OrderSyncWorker {
+	return &OrderSyncWorker{
+		source:  accrualSources{source, newOrderFallbackGetWorker(api, storage, cfg)},
+		updater: newOrderUpdateWorker(storage, cfg),
+	}
+}
+
+func (worker *OrderSyncWorker) Reload(cfg *SyncWorkerConfig) {
+	worker.source.reload(cfg)
+	worker.updater.reload(cfg)
+}
+
 func (worker *OrderSyncWorker) Run(ctx context.Context) chan struct{} {
 	doneCh := make(chan struct{})
 
@@ -39,7 +94,7 @@ func (worker *OrderSyncWorker) Run(ctx context.Context) chan struct{} {
 
 	ordersCh := make(chan *models.OrderDB, ordersChanBufferSize)
 
-	worker.getter.run(ctx, &wg, ordersCh)
+	worker.source.run(ctx, &wg, ordersCh)
 	worker.updater.run(ctx, &wg, ordersCh)
 
 	go func() {
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rycln/loyalsys/internal/logger"
@@ -12,6 +13,8 @@ import (
 
 const (
 	ordersMaxBufSize = 1024
+	retryMinBackoff  = time.Duration(1) * time.Second
+	retryMaxBackoff  = time.Duration(1) * time.Minute
 )
 
 //go:generate mockgen -source=$GOFILE -destination=./mocks/mock_$GOFILE -package=mocks
@@ -20,15 +23,37 @@ type updateStorager interface {
 	UpdateOrdersBatch(context.Context, []*models.OrderDB) error
 }
 
+// orderUpdateWorker stores the accrual results in batches. The batch is
+// capped: while it is full and can't be stored, the worker stops reading
+// results, which holds the sources back. Failed writes are retried with
+// backoff, and what is left on shutdown is stored within the flush timeout.
 type orderUpdateWorker struct {
-	storage updateStorager
-	cfg     *SyncWorkerConfig
+	storage    updateStorager
+	cfg        atomic.Pointer[SyncWorkerConfig]
+	reloadCh   chan struct{}
+	maxBufSize int
+	minBackoff time.Duration
+	maxBackoff time.Duration
 }
 
 func newOrderUpdateWorker(storage updateStorager, cfg *SyncWorkerConfig) *orderUpdateWorker {
-	return &orderUpdateWorker{
-		storage: storage,
-		cfg:     cfg,
+	worker := &orderUpdateWorker{
+		storage:    storage,
+		reloadCh:   make(chan struct{}, 1),
+		maxBufSize: ordersMaxBufSize,
+		minBackoff: retryMinBackoff,
+		maxBackoff: retryMaxBackoff,
+	}
+	worker.cfg.Store(cfg)
+	return worker
+}
+
+func (worker *orderUpdateWorker) reload(cfg *SyncWorkerConfig) {
+	worker.cfg.Store(cfg)
+
+	select {
+	case worker.reloadCh <- struct{}{}:
+	default:
 	}
 }
 
@@ -38,44 +63,99 @@ func (worker *orderUpdateWorker) run(ctx context.Context, wg *sync.WaitGroup, or
 	go func() {
 		defer wg.Done()
 
-		ticker := time.NewTicker(worker.cfg.tickerPeriod)
+		ticker := time.NewTicker(worker.cfg.Load().tickerPeriod)
 		defer ticker.Stop()
 
-		var updatedOrdersBuf = make([]*models.OrderDB, 0, ordersMaxBufSize)
+		batch := newOrderBatch(worker.maxBufSize)
+		var backoff time.Duration
+		var retryCh <-chan time.Time
+
+		flush := func() {
+			err := worker.updateOrders(ctx, batch.orders)
+			if err != nil {
+				backoff = worker.nextBackoff(backoff)
+				retryCh = time.After(backoff)
+				logger.Log.Warn("Order update failed", zap.Error(err), zap.Int("orders", batch.len()), zap.Duration("retry", backoff))
+				return
+			}
+			batch.reset()
+			backoff = 0
+			retryCh = nil
+		}
 
 		for {
+			inputCh := orderCh
+			if batch.len() >= worker.maxBufSize {
+				inputCh = nil
+			}
+
 			select {
 			case <-ctx.Done():
+				worker.flushOnShutdown(ctx, batch, orderCh)
 				return
-			case order, ok := <-orderCh:
+			case <-worker.reloadCh:
+				ticker.Reset(worker.cfg.Load().tickerPeriod)
+			case order, ok := <-inputCh:
 				if !ok {
+					worker.flushOnShutdown(ctx, batch, nil)
 					return
 				}
-				if len(updatedOrdersBuf) == ordersMaxBufSize {
-					err := worker.updateOrders(ctx, updatedOrdersBuf)
-					if err != nil {
-						logger.Log.Debug("update orders error", zap.Error(err))
-						continue
-					}
-					updatedOrdersBuf = updatedOrdersBuf[:0]
+				batch.add(order)
+				if batch.len() >= worker.maxBufSize && retryCh == nil {
+					flush()
 				}
-				updatedOrdersBuf = append(updatedOrdersBuf, order)
 			case <-ticker.C:
-				if len(updatedOrdersBuf) > 0 {
-					err := worker.updateOrders(ctx, updatedOrdersBuf)
-					if err != nil {
-						logger.Log.Debug("update orders error", zap.Error(err))
-						continue
-					}
-					updatedOrdersBuf = updatedOrdersBuf[:0]
+				if batch.len() > 0 && retryCh == nil {
+					flush()
 				}
+			case <-retryCh:
+				flush()
 			}
 		}
 	}()
 }
 
+// flushOnShutdown stores the batch together with the results still queued
+// in orderCh, retrying until the flush timeout runs out.
+func (worker *orderUpdateWorker) flushOnShutdown(ctx context.Context, batch *orderBatch, orderCh <-chan *models.OrderDB) {
+	ctxFlush, cancel := context.WithTimeout(context.WithoutCancel(ctx), worker.cfg.Load().flushTimeout)
+	defer cancel()
+
+	for {
+		batch.drain(orderCh)
+		if batch.len() == 0 {
+			return
+		}
+
+		var backoff time.Duration
+		for {
+			err := worker.updateOrders(ctxFlush, batch.orders)
+			if err == nil {
+				break
+			}
+			backoff = worker.nextBackoff(backoff)
+			logger.Log.Warn("Order flush failed", zap.Error(err), zap.Int("orders", batch.len()), zap.Duration("retry", backoff))
+
+			select {
+			case <-ctxFlush.Done():
+				logger.Log.Error("Accrual results dropped on shutdown", zap.Int("orders", batch.len()))
+				return
+			case <-time.After(backoff):
+			}
+		}
+		batch.reset()
+	}
+}
+
+func (worker *orderUpdateWorker) nextBackoff(backoff time.Duration) time.Duration {
+	if backoff == 0 {
+		return worker.minBackoff
+	}
+	return min(2*backoff, worker.maxBackoff)
+}
+
 func (worker *orderUpdateWorker) updateOrders(ctx context.Context, updatedOrders []*models.OrderDB) error {
-	ctxDB, cancel := context.WithTimeout(ctx, worker.cfg.timeout)
+	ctxDB, cancel := context.WithTimeout(ctx, worker.cfg.Load().timeout)
 	defer cancel()
 
 	err := worker.storage.UpdateOrdersBatch(ctxDB, updatedOrders)
@@ -84,3 +164,53 @@ func (worker *orderUpdateWorker) updateOrders(ctx context.Context, updatedOrders
 	}
 	return nil
 }
+
+// orderBatch buffers the results to store, keeping only the last one of
+// every order.
+type orderBatch struct {
+	size   int
+	orders []*models.OrderDB
+	index  map[string]int
+}
+
+func newOrderBatch(size int) *orderBatch {
+	return &orderBatch{
+		size:   size,
+		orders: make([]*models.OrderDB, 0, size),
+		index:  make(map[string]int, size),
+	}
+}
+
+func (batch *orderBatch) add(order *models.OrderDB) {
+	if i, ok := batch.index[order.Number]; ok {
+		batch.orders[i] = order
+		return
+	}
+	batch.index[order.Number] = len(batch.orders)
+	batch.orders = append(batch.orders, order)
+}
+
+// drain moves the queued results into the batch without waiting for more,
+// until the batch is full.
+func (batch *orderBatch) drain(orderCh <-chan *models.OrderDB) {
+	for batch.len() < batch.size {
+		select {
+		case order, ok := <-orderCh:
+			if !ok {
+				return
+			}
+			batch.add(order)
+		default:
+			return
+		}
+	}
+}
+
+func (batch *orderBatch) len() int {
+	return len(batch.orders)
+}
+
+func (batch *orderBatch) reset() {
+	batch.orders = batch.orders[:0]
+	clear(batch.index)
+}
@@ -2,8 +2,11 @@ package worker
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/fortytw2/leaktest"
 	"github.com/golang/mock/gomock"
 	"github.com/rycln/loyalsys/internal/models"
 	"github.com/rycln/loyalsys/internal/worker/mocks"
@@ -48,3 +51,134 @@ func Test_orderUpdateWorker_updateOrders(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func Test_orderUpdateWorker_run(t *testing.T) {
+	defer leaktest.Check(t)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testCfg := NewSyncWorkerConfigBuilder().
+		WithTimeout(testTimeout).
+		WithTickerPeriod(time.Hour).
+		WithFlushTimeout(testTimeout).
+		Build()
+
+	newWorker := func(mStrg *mocks.MockupdateStorager) *orderUpdateWorker {
+		worker := newOrderUpdateWorker(mStrg, testCfg)
+		worker.maxBufSize = 2
+		worker.minBackoff = time.Millisecond
+		worker.maxBackoff = 10 * time.Millisecond
+		return worker
+	}
+
+	t.Run("flush on shutdown", func(t *testing.T) {
+		mStrg := mocks.NewMockupdateStorager(ctrl)
+		worker := newWorker(mStrg)
+
+		mStrg.EXPECT().UpdateOrdersBatch(gomock.Any(), []*models.OrderDB{
+			{Number: "123", Status: models.StatusProcessed, Accrual: 10},
+		}).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		orderCh := make(chan *models.OrderDB, 10)
+		orderCh <- &models.OrderDB{Number: "123", Status: models.StatusProcessing}
+		orderCh <- &models.OrderDB{Number: "123", Status: models.StatusProcessed, Accrual: 10}
+		cancel()
+		worker.run(ctx, &wg, orderCh)
+		wg.Wait()
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		mStrg := mocks.NewMockupdateStorager(ctrl)
+		worker := newWorker(mStrg)
+
+		stored := make(chan struct{})
+		gomock.InOrder(
+			mStrg.EXPECT().UpdateOrdersBatch(gomock.Any(), gomock.Len(2)).Return(errTest).Times(2),
+			mStrg.EXPECT().UpdateOrdersBatch(gomock.Any(), gomock.Len(2)).DoAndReturn(func(context.Context, []*models.OrderDB) error {
+				close(stored)
+				return nil
+			}),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		orderCh := make(chan *models.OrderDB)
+		worker.run(ctx, &wg, orderCh)
+
+		orderCh <- &models.OrderDB{Number: "123", Status: models.StatusProcessed}
+		orderCh <- &models.OrderDB{Number: "456", Status: models.StatusInvalid}
+
+		select {
+		case <-stored:
+		case <-time.After(testTimeout):
+			t.Error("failed update wasn't retried")
+		}
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("backpressure", func(t *testing.T) {
+		mStrg := mocks.NewMockupdateStorager(ctrl)
+		worker := newWorker(mStrg)
+		worker.minBackoff = time.Hour
+
+		mStrg.EXPECT().UpdateOrdersBatch(gomock.Any(), gomock.Len(2)).Return(errTest)
+		mStrg.EXPECT().UpdateOrdersBatch(gomock.Any(), gomock.Len(2)).Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		orderCh := make(chan *models.OrderDB)
+		worker.run(ctx, &wg, orderCh)
+
+		orderCh <- &models.OrderDB{Number: "123", Status: models.StatusProcessed}
+		orderCh <- &models.OrderDB{Number: "456", Status: models.StatusInvalid}
+
+		select {
+		case orderCh <- &models.OrderDB{Number: "789", Status: models.StatusProcessing}:
+			t.Error("full buffer accepted an order")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		cancel()
+		wg.Wait()
+	})
+
+	t.Run("flush timeout", func(t *testing.T) {
+		mStrg := mocks.NewMockupdateStorager(ctrl)
+		worker := newWorker(mStrg)
+		worker.reload(NewSyncWorkerConfigBuilder().
+			WithTimeout(testTimeout).
+			WithTickerPeriod(time.Hour).
+			WithFlushTimeout(20 * time.Millisecond).
+			Build())
+
+		mStrg.EXPECT().UpdateOrdersBatch(gomock.Any(), gomock.Any()).Return(errTest).MinTimes(1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		orderCh := make(chan *models.OrderDB, 1)
+		orderCh <- &models.OrderDB{Number: "123", Status: models.StatusProcessed}
+		cancel()
+		worker.run(ctx, &wg, orderCh)
+		wg.Wait()
+	})
+}
+
+func Test_orderBatch_add(t *testing.T) {
+	batch := newOrderBatch(10)
+	batch.add(&models.OrderDB{Number: "123", Status: models.StatusProcessing})
+	batch.add(&models.OrderDB{Number: "456", Status: models.StatusProcessing})
+	batch.add(&models.OrderDB{Number: "123", Status: models.StatusProcessed, Accrual: 10})
+
+	assert.Equal(t, []*models.OrderDB{
+		{Number: "123", Status: models.StatusProcessed, Accrual: 10},
+		{Number: "456", Status: models.StatusProcessing},
+	}, batch.orders)
+
+	batch.reset()
+	batch.add(&models.OrderDB{Number: "123", Status: models.StatusInvalid})
+	assert.Equal(t, 1, batch.len())
+}